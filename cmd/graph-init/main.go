@@ -33,13 +33,19 @@ func main() {
 
 	// Create graph builder
 	graphConfig := &graph.GraphConfig{
-		URI:      config.Graph.Neo4j.URI,
-		Username: config.Graph.Neo4j.Username,
-		Password: config.Graph.Neo4j.Password,
-		Database: config.Graph.Neo4j.Database,
+		URI:                   config.Graph.Neo4j.URI,
+		Username:              config.Graph.Neo4j.Username,
+		Password:              config.Graph.Neo4j.Password,
+		Database:              config.Graph.Neo4j.Database,
+		MaxConnectionPoolSize: config.Graph.Neo4j.MaxConnectionPoolSize,
+		ConnectionTimeout:     time.Duration(config.Graph.Neo4j.ConnectionTimeout) * time.Second,
+		MaxConcurrentSessions: config.Graph.MaxGraphWorkers,
+		ConnectAttempts:       config.Graph.Neo4j.ConnectAttempts,
+		ConnectRetryDelay:     time.Duration(config.Graph.Neo4j.ConnectRetryDelay) * time.Second,
 	}
 
-	fmt.Printf("📡 Connecting to Neo4j at %s...\n", graphConfig.URI)
+	// Often run right after docker compose up, so wait for Neo4j to come up
+	fmt.Printf("📡 Connecting to Neo4j at %s (up to %d attempts)...\n", graphConfig.URI, graphConfig.ConnectAttempts)
 
 	builder, err := graph.NewGraphBuilder(graphConfig)
 	if err != nil {
@@ -78,5 +84,5 @@ func main() {
 	fmt.Println("\n🚀 Ready to use:")
 	fmt.Println("   archivist process lib/ --enable-graph")
 	fmt.Println("   archivist explore \"your query\"")
-	fmt.Println("   archivist graph --show-citations")
+	fmt.Println("   archivist graph show-citations \"<paper title>\"")
 }
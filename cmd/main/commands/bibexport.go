@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"archivist/internal/analyzer"
+	"archivist/internal/app"
+	"archivist/internal/citation"
+	"archivist/internal/parser"
+	"archivist/internal/storage"
+	"archivist/internal/ui"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var bibexportOut string
+
+// NewBibexportCommand creates the bibexport command
+func NewBibexportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bibexport",
+		Short: "Export the library as a BibTeX bibliography",
+		Long: `Write a BibTeX entry for every processed paper, using the title, authors,
+year and venue extracted from its source PDF. Cite keys are built from the
+first author's surname, the year and the first word of the title
+(e.g. vaswani2017attention). Papers with the same DOI or title are listed once.
+
+Extracting the metadata costs one API call per paper.
+
+Examples:
+  rph bibexport                      # Writes library.bib
+  rph bibexport --out refs/mine.bib`,
+		Args: cobra.NoArgs,
+		Run:  runBibexport,
+	}
+
+	cmd.Flags().StringVarP(&bibexportOut, "out", "o", "library.bib", "output .bib file")
+
+	return cmd
+}
+
+func runBibexport(cmd *cobra.Command, args []string) {
+	// Refuse to create missing directories so typos in --out are caught
+	outDir := filepath.Dir(bibexportOut)
+	if info, err := os.Stat(outDir); err != nil || !info.IsDir() {
+		ui.PrintError(fmt.Sprintf("Output directory does not exist: %s", outDir))
+		os.Exit(1)
+	}
+
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
+		os.Exit(1)
+	}
+
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to open metadata store: %v", err))
+		os.Exit(1)
+	}
+	defer metadataStore.Close()
+
+	records := metadataStore.GetRecordsByStatus(storage.StatusCompleted)
+	if len(records) == 0 {
+		ui.PrintWarning("No processed papers to export")
+		return
+	}
+
+	paperAnalyzer, err := analyzer.NewAnalyzer(config)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to create analyzer: %v", err))
+		os.Exit(1)
+	}
+	defer paperAnalyzer.Close()
+	pdfParser := parser.NewPDFParser(paperAnalyzer)
+
+	entries := make([]citation.BibEntry, 0, len(records))
+	for i, record := range records {
+		fmt.Printf("[%d/%d] %s\n", i+1, len(records), record.FilePath)
+		entries = append(entries, bibEntryFor(pdfParser, record))
+	}
+
+	bib, written := citation.FormatBibTeX(entries)
+	if err := os.WriteFile(bibexportOut, []byte(bib), 0644); err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to write %s: %v", bibexportOut, err))
+		os.Exit(1)
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Exported %d paper(s) to %s", written, bibexportOut))
+	if skipped := len(records) - written; skipped > 0 {
+		ui.PrintInfo(fmt.Sprintf("Skipped %d duplicate or untitled paper(s)", skipped))
+	}
+}
+
+// bibEntryFor extracts the bibliographic metadata of a processed paper from
+// its source PDF. If that fails the entry falls back to the recorded title.
+func bibEntryFor(pdfParser *parser.PDFParser, record storage.ProcessingRecord) citation.BibEntry {
+	fallback := citation.BibEntry{Title: record.PaperTitle, DOI: record.DOI, ArxivID: record.ArxivID}
+
+	if !fileExists(record.FilePath) {
+		ui.PrintWarning("  Source PDF is missing, exporting the title only")
+		return fallback
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	metadata, err := pdfParser.ExtractMetadata(ctx, record.FilePath)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("  Metadata extraction failed, exporting the title only: %v", err))
+		return fallback
+	}
+
+	entry := citation.BibEntry{
+		Title:   metadata.Title,
+		Authors: metadata.Authors,
+		Year:    metadata.Year,
+		Venue:   metadata.Venue,
+		DOI:     metadata.DOI,
+		ArxivID: metadata.ArxivID,
+	}
+	if entry.Title == "" {
+		entry.Title = record.PaperTitle
+	}
+	if entry.DOI == "" {
+		entry.DOI = record.DOI
+	}
+	if entry.ArxivID == "" {
+		entry.ArxivID = record.ArxivID
+	}
+	return entry
+}
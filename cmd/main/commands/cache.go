@@ -6,14 +6,18 @@ import (
 	"archivist/internal/ui"
 	"archivist/pkg/fileutil"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
+var cacheClearYes bool
+
 // NewCacheCommand creates the cache command with subcommands
 func NewCacheCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -24,6 +28,7 @@ func NewCacheCommand() *cobra.Command {
 
 	cmd.AddCommand(
 		newCacheClearCommand(),
+		newCacheInvalidateCommand(),
 		newCacheStatsCommand(),
 		newCacheListCommand(),
 	)
@@ -32,11 +37,37 @@ func NewCacheCommand() *cobra.Command {
 }
 
 func newCacheClearCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Clear all cached analysis results",
+		Long: `Remove every cached analysis result from Redis, so all papers are analyzed
+fresh on their next run. To evict single papers use 'rph cache invalidate'.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				return fmt.Errorf("clear removes every entry and takes no arguments; use 'rph cache invalidate <file.pdf>...' for specific papers")
+			}
+			return nil
+		},
+		Run: runCacheClear,
+	}
+
+	cmd.Flags().BoolVarP(&cacheClearYes, "yes", "y", false, "skip the confirmation prompt")
+
+	return cmd
+}
+
+func newCacheInvalidateCommand() *cobra.Command {
 	return &cobra.Command{
-		Use:   "clear [file1.pdf] [file2.pdf] ...",
-		Short: "Clear cached analysis results",
-		Long:  "Remove all cached analysis results from Redis, or specific papers if file paths are provided",
-		Run:   runCacheClear,
+		Use:   "invalidate <file.pdf>...",
+		Short: "Evict the cached analysis of specific papers",
+		Long: `Remove the cached analysis of each PDF (looked up by its content hash), e.g.
+after the model produced a bad report, so it is analyzed fresh on the next run.
+
+Examples:
+  rph cache invalidate lib/attention.pdf
+  rph cache invalidate lib/*.pdf`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  runCacheInvalidate,
 	}
 }
 
@@ -58,9 +89,10 @@ func newCacheListCommand() *cobra.Command {
 	}
 }
 
-func runCacheClear(cmd *cobra.Command, args []string) {
-	ui.ShowBanner()
-
+// openRedisCache loads the config and connects to the Redis analysis cache,
+// exiting if Redis is unreachable. It returns nil, after saying why, when the
+// Redis cache isn't enabled.
+func openRedisCache() *cache.RedisCache {
 	config, err := app.LoadConfig(ConfigPath)
 	if err != nil {
 		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
@@ -70,17 +102,16 @@ func runCacheClear(cmd *cobra.Command, args []string) {
 	if !config.Cache.Enabled {
 		ui.PrintWarning("Cache is not enabled in config")
 		ui.PrintInfo("To enable cache, set cache.enabled: true in config/config.yaml")
-		return
+		return nil
 	}
 
 	if config.Cache.Type != "redis" {
-		ui.PrintError("Only Redis cache is supported for clearing")
-		return
+		ui.PrintError("Only the Redis cache can be cleared or invalidated")
+		return nil
 	}
 
 	ui.PrintStage("Connecting to Redis", fmt.Sprintf("Connecting to %s", config.Cache.Redis.Addr))
 
-	ctx := context.Background()
 	ttl := time.Duration(config.Cache.TTL) * time.Hour
 	redisCache, err := cache.NewRedisCache(
 		config.Cache.Redis.Addr,
@@ -97,50 +128,22 @@ func runCacheClear(cmd *cobra.Command, args []string) {
 		ui.ColorSubtle.Println("  redis-server")
 		os.Exit(1)
 	}
-	defer redisCache.Close()
 
 	ui.PrintSuccess("Connected to Redis")
 	fmt.Println()
+	return redisCache
+}
 
-	// If specific files are provided, clear only those
-	if len(args) > 0 {
-		ui.PrintStage("Clearing Specific Papers", fmt.Sprintf("Removing cache for %d file(s)", len(args)))
-
-		successCount := 0
-		failCount := 0
-
-		for _, filePath := range args {
-			hash, err := fileutil.ComputeFileHash(filePath)
-			if err != nil {
-				ui.PrintError(fmt.Sprintf("❌ Failed to hash %s: %v", filePath, err))
-				failCount++
-				continue
-			}
-
-			err = redisCache.Delete(ctx, hash)
-			if err != nil {
-				ui.PrintError(fmt.Sprintf("❌ Failed to clear cache for %s: %v", filePath, err))
-				failCount++
-			} else {
-				ui.PrintSuccess(fmt.Sprintf("✅ Cleared cache for: %s", filepath.Base(filePath)))
-				successCount++
-			}
-		}
+func runCacheClear(cmd *cobra.Command, args []string) {
+	ui.ShowBanner()
 
-		fmt.Println()
-		if successCount > 0 {
-			ui.PrintSuccess(fmt.Sprintf("Successfully cleared %d cached entries", successCount))
-		}
-		if failCount > 0 {
-			ui.PrintWarning(fmt.Sprintf("%d entries failed to clear", failCount))
-		}
-		fmt.Println()
-		ui.ColorInfo.Println("💡 These papers will be analyzed fresh on next processing")
-		fmt.Println()
+	redisCache := openRedisCache()
+	if redisCache == nil {
 		return
 	}
+	defer redisCache.Close()
 
-	// Clear all cache entries
+	ctx := context.Background()
 	count, err := redisCache.GetStats(ctx)
 	if err != nil {
 		ui.PrintError(fmt.Sprintf("Failed to get stats: %v", err))
@@ -155,14 +158,17 @@ func runCacheClear(cmd *cobra.Command, args []string) {
 	ui.PrintWarning(fmt.Sprintf("Found %d cached entries", count))
 	fmt.Println()
 	ui.ColorWarning.Println("⚠️  This will permanently delete ALL cached analysis results!")
-	fmt.Print("\nAre you sure? (yes/no): ")
-
-	var confirm string
-	fmt.Scanln(&confirm)
+	fmt.Println()
 
-	if confirm != "yes" {
-		ui.PrintInfo("Cache clear cancelled")
-		return
+	if !cacheClearYes {
+		prompt := promptui.Prompt{
+			Label:     "Clear the analysis cache",
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			ui.PrintInfo("Cache clear cancelled")
+			return
+		}
 	}
 
 	fmt.Println()
@@ -180,6 +186,57 @@ func runCacheClear(cmd *cobra.Command, args []string) {
 	fmt.Println()
 }
 
+func runCacheInvalidate(cmd *cobra.Command, args []string) {
+	ui.ShowBanner()
+
+	redisCache := openRedisCache()
+	if redisCache == nil {
+		return
+	}
+	defer redisCache.Close()
+
+	ui.PrintStage("Invalidating Papers", fmt.Sprintf("Removing cache for %d file(s)", len(args)))
+
+	ctx := context.Background()
+	removed, notCached, failCount := 0, 0, 0
+
+	for _, filePath := range args {
+		hash, err := fileutil.ComputeFileHash(filePath)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("❌ Failed to hash %s: %v", filePath, err))
+			failCount++
+			continue
+		}
+
+		err = redisCache.Invalidate(ctx, hash)
+		switch {
+		case errors.Is(err, cache.ErrNotCached):
+			ui.ColorSubtle.Printf("   Not cached: %s\n", filepath.Base(filePath))
+			notCached++
+		case err != nil:
+			ui.PrintError(fmt.Sprintf("❌ Failed to clear cache for %s: %v", filePath, err))
+			failCount++
+		default:
+			ui.PrintSuccess(fmt.Sprintf("✅ Cleared cache for: %s", filepath.Base(filePath)))
+			removed++
+		}
+	}
+
+	fmt.Println()
+	ui.PrintSuccess(fmt.Sprintf("Removed cached analyses for %d of %d file(s)", removed, len(args)))
+	if notCached > 0 {
+		ui.PrintInfo(fmt.Sprintf("%d file(s) had nothing cached", notCached))
+	}
+	if failCount > 0 {
+		ui.PrintWarning(fmt.Sprintf("%d file(s) failed to clear", failCount))
+	}
+	if removed > 0 {
+		fmt.Println()
+		ui.ColorInfo.Println("💡 These papers will be analyzed fresh on next processing")
+	}
+	fmt.Println()
+}
+
 func runCacheStats(cmd *cobra.Command, args []string) {
 	ui.ShowBanner()
 
@@ -334,7 +391,7 @@ func runCacheList(cmd *cobra.Command, args []string) {
 
 	ui.ColorBold.Println("═══════════════════════════════════════════════════════════════")
 	fmt.Println()
-	ui.PrintInfo("To clear specific papers, run: rph cache clear <file1.pdf> <file2.pdf>")
+	ui.PrintInfo("To clear specific papers, run: rph cache invalidate <file1.pdf> <file2.pdf>")
 	ui.PrintInfo("To clear all cache, run: rph cache clear")
 	fmt.Println()
 }
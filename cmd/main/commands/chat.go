@@ -32,7 +32,8 @@ The chat uses RAG (Retrieval Augmented Generation) to provide context-aware answ
 Examples:
   archivist chat paper.pdf                    # Chat with a single paper
   archivist chat --papers lib/*.pdf           # Chat with multiple papers
-  archivist chat                              # Interactive paper selection`,
+  archivist chat                              # Interactive paper selection
+  archivist chat export <sessionID>           # Compile a saved session to PDF`,
 	RunE: runChat,
 }
 
@@ -41,6 +42,7 @@ func NewChatCommand() *cobra.Command {
 	chatCmd.Flags().StringSliceVar(&chatPapers, "papers", []string{}, "Papers to chat about (comma-separated)")
 	chatCmd.Flags().BoolVarP(&chatInteractive, "interactive", "i", true, "Interactive mode")
 	chatCmd.Flags().StringVarP(&chatExport, "export", "e", "", "Export chat to LaTeX file")
+	chatCmd.AddCommand(newChatExportCommand())
 	return chatCmd
 }
 
@@ -93,36 +95,34 @@ func runChat(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize RAG components
-	embedClient, err := rag.NewEmbeddingClient(config.Gemini.APIKey)
+	embedClient, err := rag.NewEmbeddingClient(config.Gemini.APIKey, config.RAG.EmbeddingModel, config.RAG.EmbeddingDim)
 	if err != nil {
 		return fmt.Errorf("failed to create embedding client: %w", err)
 	}
 	defer embedClient.Close()
 
 	// Use FAISS vector store for RAG
-	vectorStore, err := rag.NewFAISSVectorStore(config.FAISS.IndexDir)
+	vectorStore, err := rag.NewFAISSVectorStore(config.FAISS.IndexDir, config.RAG.EmbeddingDim)
 	if err != nil {
 		return fmt.Errorf("failed to create FAISS vector store: %w", err)
 	}
 
-	retrievalConfig := rag.DefaultRetrievalConfig()
-	retrievalConfig.TopK = 5
+	retrievalConfig := rag.DefaultRetrievalConfig(config.RAG)
 	retriever := rag.NewRetriever(vectorStore, embedClient, retrievalConfig)
 
-	// Gemini client for chat
-	geminiClient, err := analyzer.NewGeminiClient(
-		config.Gemini.APIKey,
-		config.Gemini.Model,
-		config.Gemini.Temperature,
-		config.Gemini.MaxTokens,
-	)
+	// LLM client for chat
+	llmClient, err := analyzer.NewLLMClient(config, config.Gemini.Model, config.Gemini.Temperature)
 	if err != nil {
-		return fmt.Errorf("failed to create Gemini client: %w", err)
+		return fmt.Errorf("failed to create LLM client: %w", err)
 	}
-	defer geminiClient.Close()
+	defer llmClient.Close()
 
 	// Chat engine
-	chatEngine := chat.NewChatEngine(retriever, geminiClient, redisClient)
+	chatPrompt, err := app.ParseChatPrompt(config.Chat.SystemPrompt)
+	if err != nil {
+		return fmt.Errorf("invalid chat.system_prompt: %w", err)
+	}
+	chatEngine := chat.NewChatEngine(retriever, llmClient, redisClient, chatPrompt)
 
 	// Extract paper titles from paths
 	paperTitles := make([]string, len(paperPaths))
@@ -133,7 +133,7 @@ func runChat(cmd *cobra.Command, args []string) error {
 	// Check if papers are indexed
 	fmt.Println("\n📚 Checking paper indices...")
 	indexer := rag.NewIndexer(
-		rag.NewChunker(rag.DefaultChunkSize, rag.DefaultChunkOverlap),
+		rag.NewChunker(config.RAG.ChunkSize, config.RAG.ChunkOverlap),
 		embedClient,
 		vectorStore,
 	)
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/chat"
+	"archivist/internal/compiler"
+	"archivist/internal/generator"
+	"archivist/internal/ui"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/cobra"
+)
+
+// newChatExportCommand creates the 'chat export' subcommand
+func newChatExportCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export <sessionID>",
+		Short: "Export a chat session to a PDF report",
+		Long: `Compile a saved chat session (kept in Redis for 24 hours) into a standalone
+PDF in the report directory. The .tex source is written to the tex directory.
+
+Examples:
+  rph chat export session_1712345678901234567`,
+		Args: cobra.ExactArgs(1),
+		Run:  runChatExport,
+	}
+}
+
+func runChatExport(cmd *cobra.Command, args []string) {
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     config.Cache.Redis.Addr,
+		Password: config.Cache.Redis.Password,
+		DB:       config.Cache.Redis.DB,
+	})
+	defer redisClient.Close()
+
+	// Only Redis is needed to read a saved session
+	chatEngine := chat.NewChatEngine(nil, nil, redisClient, nil)
+	session, err := chatEngine.GetSession(ctx, args[0])
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load chat session: %v", err))
+		os.Exit(1)
+	}
+
+	if len(session.Messages) == 0 {
+		ui.PrintWarning("Chat session has no messages, nothing to export")
+		return
+	}
+
+	name := fmt.Sprintf("chat_%s_%s", time.Now().Format("2006-01-02"), session.ID)
+	latexGen, err := generator.NewLatexGenerator(config.TexOutputDir, config.Latex.Template)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load LaTeX template: %v", err))
+		os.Exit(1)
+	}
+	texPath, err := latexGen.GenerateLatexFile(name, chatEngine.ExportSessionToDocument(session), "")
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to write LaTeX file: %v", err))
+		os.Exit(1)
+	}
+
+	ui.PrintInfo(fmt.Sprintf("Compiling %s...", texPath))
+	latexCompiler := compiler.NewLatexCompiler(
+		config.Latex.Compiler,
+		config.Latex.Engine == "latexmk",
+		config.Latex.CleanAux,
+		config.ReportOutputDir,
+	)
+
+	reportPath, err := latexCompiler.Compile(texPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("PDF compilation failed: %v", err))
+		os.Exit(1)
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Chat exported to %s", reportPath))
+}
@@ -172,7 +172,7 @@ func printCitationsText(citations []analyzer.CitedPaper) {
 }
 
 func printCitationsMarkdown(citations []analyzer.CitedPaper) {
-	fmt.Println("# Citations\n")
+	fmt.Print("# Citations\n\n")
 	fmt.Println("| # | Title | Authors | Year | Venue | Cited | Foundational | Context |")
 	fmt.Println("|---|-------|---------|------|-------|-------|--------------|---------|")
 
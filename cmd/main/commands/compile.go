@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/compiler"
+	"archivist/internal/storage"
+	"archivist/internal/ui"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var compileWorkers int
+
+// NewCompileCommand creates the compile command
+func NewCompileCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compile [file.tex...]",
+		Short: "Rebuild PDF reports from existing .tex files",
+		Long: `Recompile .tex files into PDF reports without calling the model, e.g. after
+changing latex.template or installing a missing LaTeX package. With no
+arguments every .tex file in tex_output_dir is compiled.
+
+A file that fails to compile is reported and the rest carry on. Papers with a
+metadata record have their report path and status updated.
+
+Examples:
+  rph compile                                   # Recompile every report
+  rph compile tex_files/Attention_Is_All.tex    # Recompile one report
+  rph compile --workers 4                       # Run 4 compilations at once`,
+		Run: runCompile,
+
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{"tex"}, cobra.ShellCompDirectiveFilterFileExt
+		},
+	}
+
+	cmd.Flags().IntVarP(&compileWorkers, "workers", "w", 1, "number of files to compile at once")
+
+	return cmd
+}
+
+// compileResult is the outcome of compiling one .tex file
+type compileResult struct {
+	texPath    string
+	reportPath string
+	duration   time.Duration
+	err        error
+}
+
+func runCompile(cmd *cobra.Command, args []string) {
+	ui.ShowBanner()
+
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
+		os.Exit(1)
+	}
+
+	if compileWorkers < 1 {
+		ui.PrintError(fmt.Sprintf("--workers must be at least 1, got %d", compileWorkers))
+		os.Exit(1)
+	}
+
+	texFiles, err := texFilesToCompile(config, args)
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+	if len(texFiles) == 0 {
+		ui.PrintWarning(fmt.Sprintf("No .tex files found in %s", config.TexOutputDir))
+		return
+	}
+
+	if err := compiler.CheckDependencies(config.Latex.Engine == "latexmk", config.Latex.Compiler); err != nil {
+		ui.PrintError(fmt.Sprintf("Dependency check failed: %v", err))
+		os.Exit(1)
+	}
+
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Failed to open metadata store, report paths won't be updated: %v", err))
+		metadataStore = nil
+	} else {
+		defer metadataStore.Close()
+	}
+
+	// Stop starting new compilations on Ctrl-C / SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ui.PrintStage("Compiling Reports", fmt.Sprintf("%d .tex file(s)", len(texFiles)))
+
+	var compiled, failed, interrupted int
+	i := 0
+	for result := range compileAll(ctx, config, texFiles) {
+		i++
+		switch {
+		case ctx.Err() != nil && result.err != nil:
+			interrupted++
+			ui.PrintWarning(fmt.Sprintf("[%d/%d] %s - interrupted", i, len(texFiles), result.texPath))
+			continue
+		case result.err != nil:
+			failed++
+			ui.PrintError(fmt.Sprintf("[%d/%d] %s - %v", i, len(texFiles), result.texPath, result.err))
+		default:
+			compiled++
+			ui.PrintSuccess(fmt.Sprintf("[%d/%d] %s -> %s (%.1fs)", i, len(texFiles), result.texPath, result.reportPath, result.duration.Seconds()))
+		}
+		if metadataStore != nil {
+			recordCompileResult(metadataStore, result)
+		}
+	}
+
+	fmt.Println()
+	ui.PrintInfo(fmt.Sprintf("Compiled: %d | Failed: %d", compiled, failed))
+	if interrupted > 0 || len(texFiles) > i {
+		ui.PrintWarning(fmt.Sprintf("Interrupted: %d | Not started: %d", interrupted, len(texFiles)-i))
+	}
+	if failed > 0 || ctx.Err() != nil {
+		os.Exit(1)
+	}
+}
+
+// texFilesToCompile returns the files given on the command line, looked up in
+// tex_output_dir if they aren't found as given, or every .tex file there
+func texFilesToCompile(config *app.Config, args []string) ([]string, error) {
+	if len(args) == 0 {
+		files, err := filepath.Glob(filepath.Join(config.TexOutputDir, "*.tex"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list .tex files: %w", err)
+		}
+		return files, nil
+	}
+
+	var files []string
+	for _, arg := range args {
+		path := arg
+		if !fileExists(path) {
+			path = filepath.Join(config.TexOutputDir, arg)
+		}
+		if !fileExists(path) {
+			return nil, fmt.Errorf("file not found: %s", arg)
+		}
+		if filepath.Ext(path) != ".tex" {
+			return nil, fmt.Errorf("not a .tex file: %s", arg)
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// compileAll compiles texFiles with compileWorkers compilations running at
+// once, sending each result as it finishes. Files not started before ctx is
+// cancelled are left out. The channel is closed once all are done.
+func compileAll(ctx context.Context, config *app.Config, texFiles []string) <-chan compileResult {
+	jobs := make(chan string)
+	results := make(chan compileResult)
+
+	go func() {
+		defer close(jobs)
+		for _, texPath := range texFiles {
+			select {
+			case <-ctx.Done():
+				return
+			case jobs <- texPath:
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for n := 0; n < compileWorkers; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			latexCompiler := compiler.NewLatexCompiler(
+				config.Latex.Compiler,
+				config.Latex.Engine == "latexmk",
+				config.Latex.CleanAux,
+				config.ReportOutputDir,
+			)
+			latexCompiler.SetBibEngine(config.Latex.BibEngine)
+
+			for texPath := range jobs {
+				results <- compileTexFile(ctx, config, latexCompiler, texPath)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+// compileTexFile compiles one file within timeout_per_paper
+func compileTexFile(ctx context.Context, config *app.Config, latexCompiler *compiler.LatexCompiler, texPath string) compileResult {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(config.Processing.TimeoutPerPaper)*time.Second)
+	defer cancel()
+
+	log.Printf("Compiling: %s", texPath)
+	start := time.Now()
+	reportPath, err := latexCompiler.CompileContext(ctx, texPath)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("compilation exceeded timeout_per_paper of %d seconds", config.Processing.TimeoutPerPaper)
+	}
+	return compileResult{texPath: texPath, reportPath: reportPath, duration: time.Since(start), err: err}
+}
+
+// recordCompileResult updates the metadata record of the paper whose report
+// was compiled from result.texPath, if there is one
+func recordCompileResult(store storage.Store, result compileResult) {
+	record, ok := findRecordByTexPath(store, result.texPath)
+	if !ok {
+		return
+	}
+
+	var err error
+	if result.err != nil {
+		err = store.MarkFailed(record.FileHash, fmt.Sprintf("PDF compilation failed: %v", result.err))
+	} else {
+		err = store.MarkCompleted(record.FileHash, record.PaperTitle, record.TexFilePath, result.reportPath)
+	}
+	if err != nil {
+		ui.PrintWarning(fmt.Sprintf("Failed to update metadata for %s: %v", result.texPath, err))
+	}
+}
+
+// findRecordByTexPath returns the record whose .tex file is texPath
+func findRecordByTexPath(store storage.Store, texPath string) (storage.ProcessingRecord, bool) {
+	want := absPath(texPath)
+	for _, record := range store.GetAllRecords() {
+		if record.TexFilePath != "" && absPath(record.TexFilePath) == want {
+			return record, true
+		}
+	}
+	return storage.ProcessingRecord{}, false
+}
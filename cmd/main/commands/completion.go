@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"archivist/internal/storage"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NewCompletionCommand creates the completion command
+func NewCompletionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion [bash|zsh|fish]",
+		Short: "Generate shell completion scripts",
+		Long: `Generate a completion script for your shell and print it to stdout.
+
+Besides commands and flags, 'status' and 'delete' complete the paths and
+titles of papers that have already been processed.
+
+Bash:
+  # Current session
+  source <(rph completion bash)
+
+  # Every session (Linux)
+  rph completion bash > /etc/bash_completion.d/rph
+
+Zsh:
+  # Enable completion once if it isn't already
+  echo "autoload -U compinit; compinit" >> ~/.zshrc
+
+  rph completion zsh > "${fpath[1]}/_rph"
+
+Fish:
+  rph completion fish > ~/.config/fish/completions/rph.fish
+
+Start a new shell after installing the script for it to take effect.`,
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs:             []string{"bash", "zsh", "fish"},
+		DisableFlagsInUseLine: true,
+		Run:                   runCompletion,
+	}
+}
+
+func runCompletion(cmd *cobra.Command, args []string) {
+	rootCmd := cmd.Root()
+
+	var err error
+	switch args[0] {
+	case "bash":
+		err = rootCmd.GenBashCompletion(os.Stdout)
+	case "zsh":
+		err = rootCmd.GenZshCompletion(os.Stdout)
+	case "fish":
+		err = rootCmd.GenFishCompletion(os.Stdout, true)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate %s completion: %v\n", args[0], err)
+		os.Exit(1)
+	}
+}
+
+// completeProcessedPapers suggests the source paths of processed papers and,
+// if includeTitles is set, their titles as well
+func completeProcessedPapers(includeTitles bool) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		metadataStore, err := storage.Open(completionMetadataBackend(), storage.DefaultMetadataDir)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		defer metadataStore.Close()
+
+		var suggestions []string
+		for _, record := range metadataStore.GetAllRecords() {
+			if strings.HasPrefix(record.FilePath, toComplete) {
+				suggestions = append(suggestions, record.FilePath)
+			}
+			if includeTitles && record.PaperTitle != "" &&
+				strings.HasPrefix(strings.ToLower(record.PaperTitle), strings.ToLower(toComplete)) {
+				suggestions = append(suggestions, record.PaperTitle)
+			}
+		}
+
+		return suggestions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completionMetadataBackend reads the metadata backend from the config file.
+// app.LoadConfig isn't used here because it may print to stdout, which the
+// shell would treat as completion candidates.
+func completionMetadataBackend() string {
+	v := viper.New()
+	v.SetConfigFile(ConfigPath)
+	v.SetDefault("metadata.backend", "json")
+	_ = v.ReadInConfig()
+	return v.GetString("metadata.backend")
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/ui"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var configInitForce bool
+
+// NewConfigCommand creates the config command with subcommands
+func NewConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the configuration file",
+		Long:  "Create and manage config.yaml. Use 'configure' for an interactive wizard.",
+	}
+
+	cmd.AddCommand(newConfigInitCommand())
+
+	return cmd
+}
+
+// newConfigInitCommand creates the 'config init' subcommand
+func newConfigInitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Write a commented default config.yaml",
+		Long: `Write a config file with every setting filled in with its default value and
+a comment explaining it. The file goes to the --config path and is never
+overwritten unless --force is given.
+
+The defaults keep the Redis cache and knowledge graph disabled, so only LaTeX
+and a GEMINI_API_KEY are needed to start processing papers.
+
+Examples:
+  rph config init
+  rph config init --config ~/archivist/config.yaml
+  rph config init --force`,
+		Args: cobra.NoArgs,
+		Run:  runConfigInit,
+	}
+
+	cmd.Flags().BoolVarP(&configInitForce, "force", "f", false, "overwrite an existing config file")
+
+	return cmd
+}
+
+func runConfigInit(cmd *cobra.Command, args []string) {
+	if _, err := os.Stat(ConfigPath); err == nil && !configInitForce {
+		ui.PrintError(fmt.Sprintf("%s already exists (use --force to overwrite it)", ConfigPath))
+		os.Exit(1)
+	}
+
+	data, err := app.MarshalConfigYAML(app.DefaultConfig())
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to generate config: %v", err))
+		os.Exit(1)
+	}
+
+	if dir := filepath.Dir(ConfigPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to create %s: %v", dir, err))
+			os.Exit(1)
+		}
+	}
+
+	if err := os.WriteFile(ConfigPath, data, 0644); err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to write config: %v", err))
+		os.Exit(1)
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Wrote default configuration to %s", ConfigPath))
+	ui.PrintInfo("Set GEMINI_API_KEY in your environment or .env, then run: rph check")
+}
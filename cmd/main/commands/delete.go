@@ -0,0 +1,221 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/cache"
+	"archivist/internal/graph"
+	"archivist/internal/storage"
+	"archivist/internal/ui"
+	"archivist/internal/worker"
+	"archivist/pkg/fileutil"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteDryRun bool
+	deleteYes    bool
+)
+
+// NewDeleteCommand creates the delete command
+func NewDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete [file.pdf | title]",
+		Short: "Remove a processed paper and all its artifacts",
+		Long: `Remove everything generated for a paper: the .tex file, the report PDF,
+its extracted figures, its metadata record, its cached analysis (Redis or
+in-memory snapshot), its chat vectors and (if the graph is enabled) its node
+in the knowledge graph. The source PDF is left untouched.
+
+The paper can be given as a path to the source PDF or as its title.
+
+Examples:
+  rph delete lib/attention.pdf
+  rph delete "Attention Is All You Need" --dry-run
+  rph delete lib/attention.pdf --yes`,
+		Args: cobra.ExactArgs(1),
+		Run:  runDelete,
+
+		ValidArgsFunction: completeProcessedPapers(true),
+	}
+
+	cmd.Flags().BoolVar(&deleteDryRun, "dry-run", false, "list what would be removed without deleting anything")
+	cmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "skip the confirmation prompt")
+
+	return cmd
+}
+
+func runDelete(cmd *cobra.Command, args []string) {
+	ui.ShowBanner()
+
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
+		os.Exit(1)
+	}
+
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to open metadata store: %v", err))
+		os.Exit(1)
+	}
+	defer metadataStore.Close()
+
+	record, err := findRecord(metadataStore, args[0])
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+
+	title := record.PaperTitle
+	if title == "" {
+		title = record.FilePath
+	}
+
+	ui.PrintInfo(fmt.Sprintf("Paper: %s", title))
+	fmt.Println()
+	ui.ColorBold.Println("The following will be removed:")
+	figuresDir := worker.FiguresDir(config, record.FileHash)
+	for _, path := range []string{record.TexFilePath, record.ReportPath} {
+		if path != "" && fileExists(path) {
+			fmt.Printf("  • %s\n", path)
+		}
+	}
+	if info, err := os.Stat(figuresDir); err == nil && info.IsDir() {
+		fmt.Printf("  • %s%c (extracted figures)\n", figuresDir, os.PathSeparator)
+	}
+	fmt.Printf("  • metadata record %s\n", shortHash(record.FileHash))
+	if config.Cache.Enabled {
+		fmt.Printf("  • %s cache entry %s\n", config.Cache.Type, shortHash(record.FileHash))
+	}
+	if record.PaperTitle != "" {
+		fmt.Printf("  • chat vectors for \"%s\"\n", record.PaperTitle)
+	}
+	if config.Graph.Enabled && record.PaperTitle != "" {
+		fmt.Printf("  • graph node \"%s\"\n", record.PaperTitle)
+	}
+	fmt.Println()
+
+	if deleteDryRun {
+		ui.PrintInfo("Dry run: nothing was deleted")
+		return
+	}
+
+	if !deleteYes {
+		prompt := promptui.Prompt{
+			Label:     "Delete these artifacts",
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			ui.PrintInfo("Aborted")
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	failed := false
+
+	for _, path := range []string{record.TexFilePath, record.ReportPath} {
+		if path == "" {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			ui.PrintWarning(fmt.Sprintf("Failed to remove %s: %v", path, err))
+			failed = true
+		}
+	}
+	if err := os.RemoveAll(figuresDir); err != nil {
+		ui.PrintWarning(fmt.Sprintf("Failed to remove %s: %v", figuresDir, err))
+		failed = true
+	}
+
+	if config.Cache.Enabled {
+		analysisCache, err := worker.NewAnalysisCache(config)
+		if err != nil {
+			ui.PrintWarning(fmt.Sprintf("Failed to open the analysis cache, entry not evicted: %v", err))
+			failed = true
+		} else {
+			if err := analysisCache.Delete(ctx, record.FileHash); err != nil && !errors.Is(err, cache.ErrNotCached) {
+				ui.PrintWarning(fmt.Sprintf("Failed to evict cache entry: %v", err))
+				failed = true
+			}
+			analysisCache.Close()
+		}
+	}
+
+	if record.PaperTitle != "" {
+		if _, err := worker.RemovePaperVectors(config.FAISS.IndexDir, config.RAG.EmbeddingDim, record.PaperTitle); err != nil {
+			ui.PrintWarning(fmt.Sprintf("Failed to remove chat vectors: %v", err))
+			failed = true
+		}
+	}
+
+	if config.Graph.Enabled && record.PaperTitle != "" {
+		builder, err := graph.NewGraphBuilder(neo4jConfig(config))
+		if err != nil {
+			ui.PrintWarning(fmt.Sprintf("Failed to connect to Neo4j, graph node not deleted: %v", err))
+			failed = true
+		} else {
+			if err := builder.DeletePaper(ctx, record.PaperTitle); err != nil {
+				ui.PrintWarning(fmt.Sprintf("Failed to delete graph node: %v", err))
+				failed = true
+			}
+			builder.Close(ctx)
+		}
+	}
+
+	// Drop the metadata record last so a partial failure can be retried
+	if failed {
+		ui.PrintError("Some artifacts could not be removed; metadata record kept so you can retry")
+		os.Exit(1)
+	}
+	if err := metadataStore.DeleteRecord(record.FileHash); err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to delete metadata record: %v", err))
+		os.Exit(1)
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Deleted %s", title))
+}
+
+// findRecord resolves a source PDF path or paper title to its processing record
+func findRecord(store storage.Store, target string) (storage.ProcessingRecord, error) {
+	if fileExists(target) {
+		hash, err := fileutil.ComputeFileHash(target)
+		if err != nil {
+			return storage.ProcessingRecord{}, fmt.Errorf("failed to hash %s: %w", target, err)
+		}
+		if record, ok := store.GetRecord(hash); ok {
+			return record, nil
+		}
+	}
+
+	var matches []storage.ProcessingRecord
+	for _, record := range store.GetAllRecords() {
+		if record.FilePath == target || strings.EqualFold(record.PaperTitle, target) {
+			matches = append(matches, record)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return storage.ProcessingRecord{}, fmt.Errorf("no processed paper matches %q", target)
+	case 1:
+		return matches[0], nil
+	default:
+		var paths []string
+		for _, match := range matches {
+			paths = append(paths, match.FilePath)
+		}
+		return storage.ProcessingRecord{}, fmt.Errorf("%q matches %d papers, pass the PDF path instead: %s",
+			target, len(matches), strings.Join(paths, ", "))
+	}
+}
@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/storage"
+	"archivist/internal/ui"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat string
+	exportOut    string
+)
+
+// NewExportCommand creates the export command
+func NewExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export processing records",
+		Long: `Export all processing records from the metadata store to a CSV or JSON file.
+
+Examples:
+  rph export                                  # Writes processing_records.json
+  rph export --format csv --out records.csv   # CSV snapshot`,
+		Args: cobra.NoArgs,
+		Run:  runExport,
+	}
+
+	cmd.Flags().StringVar(&exportFormat, "format", "json", "output format (json, csv)")
+	cmd.Flags().StringVarP(&exportOut, "out", "o", "", "output file path (default: processing_records.<format>)")
+
+	return cmd
+}
+
+func runExport(cmd *cobra.Command, args []string) {
+	if exportFormat != "json" && exportFormat != "csv" {
+		ui.PrintError(fmt.Sprintf("Unsupported format: %s (must be 'json' or 'csv')", exportFormat))
+		os.Exit(1)
+	}
+
+	outPath := exportOut
+	if outPath == "" {
+		outPath = "processing_records." + exportFormat
+	}
+
+	// Refuse to create missing directories so typos in --out are caught
+	outDir := filepath.Dir(outPath)
+	if info, err := os.Stat(outDir); err != nil || !info.IsDir() {
+		ui.PrintError(fmt.Sprintf("Output directory does not exist: %s", outDir))
+		os.Exit(1)
+	}
+
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
+		os.Exit(1)
+	}
+
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to open metadata store: %v", err))
+		os.Exit(1)
+	}
+	defer metadataStore.Close()
+
+	records := metadataStore.GetAllRecords()
+
+	if err := writeRecordsFile(outPath, exportFormat, records); err != nil {
+		ui.PrintError(fmt.Sprintf("Export failed: %v", err))
+		os.Exit(1)
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Exported %d record(s) to %s", len(records), outPath))
+}
+
+// writeRecordsFile writes records to path in the given format ("json" or "csv")
+func writeRecordsFile(path, format string, records []storage.ProcessingRecord) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if format == "csv" {
+		err = storage.WriteRecordsCSV(f, records)
+	} else {
+		err = storage.WriteRecordsJSON(f, records)
+	}
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write file: %w", closeErr)
+	}
+
+	return err
+}
@@ -4,12 +4,14 @@ import (
 	"archivist/internal/app"
 	"archivist/internal/ui"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -32,6 +34,12 @@ func NewGraphCommand() *cobra.Command {
 		newGraphAddCommand(),
 		newGraphStatsCommand(),
 		newGraphStatusCommand(),
+		newGraphExportCommand(),
+		newGraphShowCitationsCommand(),
+		newGraphClearCommand(),
+		newGraphRecommendCommand(),
+		newGraphTrendsCommand(),
+		newGraphQueryCommand(),
 	)
 
 	// Global flags for graph commands
@@ -187,8 +195,9 @@ func runGraphStats(cmd *cobra.Command, args []string) {
 
 	resp, err := http.Get(graphServiceURL + "/api/graph/stats")
 	if err != nil {
-		ui.PrintError(fmt.Sprintf("Failed to connect to graph service: %v", err))
-		os.Exit(1)
+		ui.PrintWarning(fmt.Sprintf("Graph service unavailable (%v), reading Neo4j directly", err))
+		runGraphStatsDirect()
+		return
 	}
 	defer resp.Body.Close()
 
@@ -226,6 +235,34 @@ func runGraphStats(cmd *cobra.Command, args []string) {
 	fmt.Println()
 }
 
+// runGraphStatsDirect prints the counts GraphBuilder.GetStats reads from Neo4j,
+// for when the graph service isn't running
+func runGraphStatsDirect() {
+	builder := openGraphOrExit()
+	defer closeGraph(builder)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stats, err := builder.GetStats(ctx)
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	ui.ColorBold.Println("═══════════════════════════════════════════════════════════════")
+	ui.ColorBold.Println("            KNOWLEDGE GRAPH STATISTICS                         ")
+	ui.ColorBold.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	ui.ColorInfo.Printf("  📄 Papers:        %d\n", stats.PaperCount)
+	ui.ColorInfo.Printf("  💡 Concepts:      %d\n", stats.ConceptCount)
+	ui.ColorInfo.Printf("  🔗 Citations:     %d\n", stats.CitationCount)
+	ui.ColorInfo.Printf("  🔀 Similarities:  %d\n", stats.SimilarityCount)
+	fmt.Println()
+}
+
 func runGraphStatus(cmd *cobra.Command, args []string) {
 	ui.PrintInfo(fmt.Sprintf("Checking graph service: %s", graphServiceURL))
 
@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/graph"
+	"archivist/internal/ui"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	showCitationsDepth int
+	graphClearYes      bool
+)
+
+// newGraphShowCitationsCommand creates the 'graph show-citations' subcommand
+func newGraphShowCitationsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-citations <title>",
+		Short: "List the papers a paper cites and the papers citing it",
+		Long: `Look up a paper in Neo4j by its exact title and list the papers it cites
+(following citation chains up to --depth hops) and the papers that cite it.
+
+Examples:
+  rph graph show-citations "Attention Is All You Need"
+  rph graph show-citations "Attention Is All You Need" --depth 3`,
+		Args: cobra.ExactArgs(1),
+		Run:  runGraphShowCitations,
+	}
+
+	cmd.Flags().IntVar(&showCitationsDepth, "depth", 1, "citation hops to follow from the paper (1-5)")
+
+	return cmd
+}
+
+// newGraphClearCommand creates the 'graph clear' subcommand
+func newGraphClearCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear",
+		Short: "Delete every node and relationship in the knowledge graph",
+		Long: `Delete every node and relationship in Neo4j. Processed reports and metadata
+are kept, so papers can be added back with 'rph graph add' or reprocessing.`,
+		Args: cobra.NoArgs,
+		Run:  runGraphClear,
+	}
+
+	cmd.Flags().BoolVarP(&graphClearYes, "yes", "y", false, "skip the confirmation prompt")
+
+	return cmd
+}
+
+// neo4jConfig returns the connection settings for the Neo4j instance in config
+func neo4jConfig(config *app.Config) *graph.GraphConfig {
+	return &graph.GraphConfig{
+		URI:                   config.Graph.Neo4j.URI,
+		Username:              config.Graph.Neo4j.Username,
+		Password:              config.Graph.Neo4j.Password,
+		Database:              config.Graph.Neo4j.Database,
+		MaxConnectionPoolSize: config.Graph.Neo4j.MaxConnectionPoolSize,
+		ConnectionTimeout:     time.Duration(config.Graph.Neo4j.ConnectionTimeout) * time.Second,
+		MaxConcurrentSessions: config.Graph.MaxGraphWorkers,
+		ConnectAttempts:       config.Graph.Neo4j.ConnectAttempts,
+		ConnectRetryDelay:     time.Duration(config.Graph.Neo4j.ConnectRetryDelay) * time.Second,
+	}
+}
+
+// connectGraph opens a GraphBuilder on the Neo4j instance from config
+func connectGraph(config *app.Config) (*graph.GraphBuilder, error) {
+	ui.PrintInfo(fmt.Sprintf("Connecting to Neo4j at %s...", config.Graph.Neo4j.URI))
+	return graph.NewGraphBuilder(neo4jConfig(config))
+}
+
+// closeGraph closes builder, giving the driver a few seconds to shut down
+func closeGraph(builder *graph.GraphBuilder) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	builder.Close(ctx)
+}
+
+// openGraphOrExit loads the config and connects to Neo4j, exiting on failure
+func openGraphOrExit() *graph.GraphBuilder {
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
+		os.Exit(1)
+	}
+
+	builder, err := connectGraph(config)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to connect to Neo4j: %v", err))
+		os.Exit(1)
+	}
+	return builder
+}
+
+func runGraphShowCitations(cmd *cobra.Command, args []string) {
+	title := args[0]
+
+	builder := openGraphOrExit()
+	defer closeGraph(builder)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	exists, err := builder.PaperExists(ctx, title)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to look up paper: %v", err))
+		os.Exit(1)
+	}
+	if !exists {
+		ui.PrintError(fmt.Sprintf("Paper not found in the graph: %s", title))
+		ui.PrintInfo("Titles must match exactly; see 'rph list --processed' for processed papers")
+		os.Exit(1)
+	}
+
+	chains, err := builder.GetCitationChain(ctx, title, showCitationsDepth)
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+	citing, err := builder.GetCitingPapers(ctx, title)
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	ui.ColorBold.Printf("📄 %s\n\n", title)
+
+	ui.ColorBold.Printf("Cites (%d path(s)):\n", len(chains))
+	if len(chains) == 0 {
+		ui.ColorSubtle.Println("   (no citations in the graph)")
+	}
+	for _, chain := range chains {
+		// The first node is the paper itself
+		fmt.Printf("   → %s\n", strings.Join(chain.Nodes[1:], " → "))
+	}
+	fmt.Println()
+
+	ui.ColorBold.Printf("Cited by (%d):\n", len(citing))
+	if len(citing) == 0 {
+		ui.ColorSubtle.Println("   (no citing papers in the graph)")
+	}
+	for _, paper := range citing {
+		fmt.Printf("   ← %s\n", paper)
+	}
+	fmt.Println()
+}
+
+func runGraphClear(cmd *cobra.Command, args []string) {
+	builder := openGraphOrExit()
+	defer closeGraph(builder)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	stats, err := builder.GetStats(ctx)
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+
+	ui.PrintWarning(fmt.Sprintf("This deletes %d papers, %d concepts and %d citations from Neo4j",
+		stats.PaperCount, stats.ConceptCount, stats.CitationCount))
+
+	if !graphClearYes {
+		prompt := promptui.Prompt{
+			Label:     "Clear the knowledge graph",
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			ui.PrintInfo("Aborted")
+			return
+		}
+	}
+
+	if err := builder.ClearGraph(ctx); err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+	ui.PrintSuccess("Knowledge graph cleared")
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/ui"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphExportFormat string
+	graphExportOut    string
+)
+
+// newGraphExportCommand creates the 'graph export' subcommand
+func newGraphExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the knowledge graph for external visualization",
+		Long: `Export Paper, Concept, and Author nodes and their relationships from Neo4j
+as GraphML (for Gephi, yEd, Cytoscape) or DOT (for Graphviz).
+
+Examples:
+  rph graph export --format graphml --out graph.xml
+  rph graph export --format dot --out graph.dot
+  dot -Tsvg graph.dot -o graph.svg`,
+		Args: cobra.NoArgs,
+		Run:  runGraphExport,
+	}
+
+	cmd.Flags().StringVar(&graphExportFormat, "format", "graphml", "export format: graphml or dot")
+	cmd.Flags().StringVarP(&graphExportOut, "out", "o", "", "output file path (default: graph.xml or graph.dot)")
+
+	return cmd
+}
+
+func runGraphExport(cmd *cobra.Command, args []string) {
+	var defaultOut string
+	switch graphExportFormat {
+	case "graphml":
+		defaultOut = "graph.xml"
+	case "dot":
+		defaultOut = "graph.dot"
+	default:
+		ui.PrintError(fmt.Sprintf("Unsupported format: %s (use graphml or dot)", graphExportFormat))
+		os.Exit(1)
+	}
+
+	outPath := graphExportOut
+	if outPath == "" {
+		outPath = defaultOut
+	}
+
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
+		os.Exit(1)
+	}
+
+	builder, err := connectGraph(config)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to connect to Neo4j: %v", err))
+		os.Exit(1)
+	}
+	defer closeGraph(builder)
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to create output file: %v", err))
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	if graphExportFormat == "dot" {
+		err = builder.ExportDOT(ctx, f)
+	} else {
+		err = builder.ExportGraphML(ctx, f)
+	}
+	if err != nil {
+		f.Close()
+		os.Remove(outPath)
+		ui.PrintError(fmt.Sprintf("Export failed: %v", err))
+		os.Exit(1)
+	}
+
+	if err := f.Close(); err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to write output file: %v", err))
+		os.Exit(1)
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Graph exported to %s (%s)", outPath, graphExportFormat))
+}
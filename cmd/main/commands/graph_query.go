@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"archivist/internal/graph"
+	"archivist/internal/ui"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphQueryParams []string
+	graphQueryJSON   bool
+)
+
+// maxQueryCellWidth is where long values are cut off in the result table
+const maxQueryCellWidth = 40
+
+// newGraphQueryCommand creates the 'graph query' subcommand
+func newGraphQueryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query <cypher>",
+		Short: "Run a read-only Cypher query and print the result as a table",
+		Long: `Run an ad-hoc Cypher query against the knowledge graph without opening the
+Neo4j browser. Only read queries are allowed: queries using CREATE, MERGE,
+DELETE, SET or another write clause are refused, and the query runs in a
+read transaction. Nodes and relationships are shown as their properties.
+
+Parameters are passed with --param name=value; values that parse as JSON
+(numbers, booleans, lists) keep their type, anything else is a string.
+
+Examples:
+  rph graph query "MATCH (p:Paper) RETURN p.title AS title, p.year AS year ORDER BY year DESC LIMIT 10"
+  rph graph query 'MATCH (p:Paper)-[:CITES]->(c:Paper) WHERE p.title = $title RETURN c.title' --param title="Attention Is All You Need"
+  rph graph query "MATCH (a:Author) RETURN a.name, a.paper_count" --json`,
+		Args: cobra.ExactArgs(1),
+		Run:  runGraphQuery,
+	}
+
+	cmd.Flags().StringArrayVarP(&graphQueryParams, "param", "p", nil, "query parameter as name=value (repeatable)")
+	cmd.Flags().BoolVar(&graphQueryJSON, "json", false, "output rows as JSON")
+
+	return cmd
+}
+
+func runGraphQuery(cmd *cobra.Command, args []string) {
+	params, err := parseQueryParams(graphQueryParams)
+	if err != nil {
+		exitWithError(graphQueryJSON, err.Error())
+	}
+
+	builder := openGraphOrExit()
+	defer closeGraph(builder)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	rows, err := builder.RunReadQuery(ctx, args[0], params)
+	if err != nil {
+		if errors.Is(err, graph.ErrWriteQuery) {
+			exitWithError(graphQueryJSON, fmt.Sprintf("%v; use the Neo4j browser to change the graph", err))
+		}
+		exitWithError(graphQueryJSON, err.Error())
+	}
+
+	if graphQueryJSON {
+		if rows == nil {
+			rows = []map[string]interface{}{}
+		}
+		printJSON(rows)
+		return
+	}
+
+	if len(rows) == 0 {
+		ui.PrintInfo("No rows returned")
+		return
+	}
+
+	fmt.Println()
+	fmt.Print(formatQueryTable(rows))
+	fmt.Printf("\n%d row(s)\n", len(rows))
+}
+
+// parseQueryParams turns name=value pairs into query parameters
+func parseQueryParams(pairs []string) (map[string]interface{}, error) {
+	params := make(map[string]interface{}, len(pairs))
+	for _, pair := range pairs {
+		name, raw, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --param %q (expected name=value)", pair)
+		}
+
+		var value interface{}
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			value = raw
+		}
+		if f, isFloat := value.(float64); isFloat && f == float64(int64(f)) {
+			value = int64(f) // Cypher compares integers and floats differently
+		}
+		params[name] = value
+	}
+	return params, nil
+}
+
+// formatQueryTable renders rows as an aligned text table with one column per
+// key, in alphabetical order
+func formatQueryTable(rows []map[string]interface{}) string {
+	columnSet := make(map[string]bool)
+	for _, row := range rows {
+		for key := range row {
+			columnSet[key] = true
+		}
+	}
+	columns := make([]string, 0, len(columnSet))
+	for key := range columnSet {
+		columns = append(columns, key)
+	}
+	sort.Strings(columns)
+
+	cells := make([][]string, len(rows))
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		widths[i] = utf8.RuneCountInString(column)
+	}
+	for r, row := range rows {
+		cells[r] = make([]string, len(columns))
+		for i, column := range columns {
+			cell := formatQueryCell(row[column])
+			cells[r][i] = cell
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	var sb strings.Builder
+	writeRow := func(values []string) {
+		for i, value := range values {
+			if i > 0 {
+				sb.WriteString(" │ ")
+			}
+			sb.WriteString(value)
+			if i < len(values)-1 {
+				sb.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(value)))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	writeRow(columns)
+	separators := make([]string, len(columns))
+	for i, width := range widths {
+		separators[i] = strings.Repeat("─", width)
+	}
+	sb.WriteString(strings.Join(separators, "─┼─") + "\n")
+	for _, row := range cells {
+		writeRow(row)
+	}
+
+	return sb.String()
+}
+
+// formatQueryCell renders a value for the result table on a single line,
+// cut off at maxQueryCellWidth
+func formatQueryCell(value interface{}) string {
+	var cell string
+	switch v := value.(type) {
+	case nil:
+		cell = ""
+	case string:
+		cell = v
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			cell = fmt.Sprint(v)
+		} else {
+			cell = string(data)
+		}
+	default:
+		cell = fmt.Sprint(v)
+	}
+
+	cell = strings.Join(strings.Fields(cell), " ")
+	if utf8.RuneCountInString(cell) > maxQueryCellWidth {
+		cell = string([]rune(cell)[:maxQueryCellWidth-1]) + "…"
+	}
+	return cell
+}
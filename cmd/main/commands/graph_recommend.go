@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/graph"
+	"archivist/internal/ui"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	recommendLimit int
+	recommendJSON  bool
+)
+
+// newGraphRecommendCommand creates the 'graph recommend' subcommand
+func newGraphRecommendCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recommend <title> [title...]",
+		Short: "Suggest papers from your library to read next",
+		Long: `Suggest papers in the knowledge graph to read after the given ones. Papers
+are scored by shared concepts and methods, citation proximity and similarity
+to the papers you name, and each suggestion says why it was picked.
+
+Examples:
+  rph graph recommend "Attention Is All You Need"
+  rph graph recommend "BERT" "GPT-2" --limit 5`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  runGraphRecommend,
+	}
+
+	cmd.Flags().IntVarP(&recommendLimit, "limit", "n", 10, "maximum number of suggestions")
+	cmd.Flags().BoolVar(&recommendJSON, "json", false, "output machine-readable JSON")
+
+	return cmd
+}
+
+func runGraphRecommend(cmd *cobra.Command, args []string) {
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		exitWithError(recommendJSON, fmt.Sprintf("Failed to load config: %v", err))
+	}
+
+	if !recommendJSON {
+		ui.PrintInfo(fmt.Sprintf("Connecting to Neo4j at %s...", config.Graph.Neo4j.URI))
+	}
+	builder, err := graph.NewEnhancedNeo4jBuilder(neo4jConfig(config))
+	if err != nil {
+		exitWithError(recommendJSON, fmt.Sprintf("Failed to connect to Neo4j: %v", err))
+	}
+	defer closeGraph(builder.GraphBuilder)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	for _, title := range args {
+		exists, err := builder.PaperExists(ctx, title)
+		if err != nil {
+			exitWithError(recommendJSON, fmt.Sprintf("Failed to look up paper: %v", err))
+		}
+		if !exists {
+			exitWithError(recommendJSON, fmt.Sprintf("Paper not found in the graph: %s", title))
+		}
+	}
+
+	recommendations, err := builder.RecommendPapers(ctx, args, recommendLimit)
+	if err != nil {
+		exitWithError(recommendJSON, err.Error())
+	}
+
+	if recommendJSON {
+		printJSON(recommendations)
+		return
+	}
+
+	fmt.Println()
+	if len(recommendations) == 0 {
+		ui.PrintWarning("No related papers found in the graph")
+		ui.PrintInfo("Process more papers with graph building enabled to get suggestions")
+		return
+	}
+
+	ui.ColorBold.Printf("📚 Read next (%d):\n\n", len(recommendations))
+	for i, rec := range recommendations {
+		ui.ColorBold.Printf("%2d. %s", i+1, rec.Title)
+		ui.ColorSubtle.Printf("  (%.0f%%)\n", rec.Score*100)
+		fmt.Printf("    %s\n", rec.Reason)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/graph"
+	"archivist/internal/ui"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var trendsJSON bool
+
+// maxTrendBarWidth is the length of the bar for the peak year
+const maxTrendBarWidth = 40
+
+// newGraphTrendsCommand creates the 'graph trends' subcommand
+func newGraphTrendsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trends <topic>",
+		Short: "Show whether a concept or method is rising or fading in your library",
+		Long: `Count papers per year that use or mention a concept or method in the knowledge
+graph, and classify the topic as growing, stable or declining.
+
+Examples:
+  rph graph trends transformer
+  rph graph trends "contrastive learning" --json`,
+		Args: cobra.ExactArgs(1),
+		Run:  runGraphTrends,
+	}
+
+	cmd.Flags().BoolVar(&trendsJSON, "json", false, "output machine-readable JSON")
+
+	return cmd
+}
+
+func runGraphTrends(cmd *cobra.Command, args []string) {
+	topic := args[0]
+
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		exitWithError(trendsJSON, fmt.Sprintf("Failed to load config: %v", err))
+	}
+
+	if !trendsJSON {
+		ui.PrintInfo(fmt.Sprintf("Connecting to Neo4j at %s...", config.Graph.Neo4j.URI))
+	}
+	builder, err := graph.NewEnhancedNeo4jBuilder(neo4jConfig(config))
+	if err != nil {
+		exitWithError(trendsJSON, fmt.Sprintf("Failed to connect to Neo4j: %v", err))
+	}
+	defer closeGraph(builder.GraphBuilder)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	trend, err := builder.GetTrendAnalysis(ctx, topic)
+	if err != nil {
+		exitWithError(trendsJSON, err.Error())
+	}
+
+	if trendsJSON {
+		printJSON(trend)
+		return
+	}
+
+	years := make([]int, 0, len(trend.Timeline))
+	for year := range trend.Timeline {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	peak := trend.Timeline[trend.PeakYear]
+
+	fmt.Println()
+	ui.ColorBold.Printf("📈 %s\n\n", trend.Topic)
+	for year := years[0]; year <= years[len(years)-1]; year++ {
+		count := trend.Timeline[year]
+		bar := strings.Repeat("█", count*maxTrendBarWidth/peak)
+		fmt.Printf("  %d  %-*s %d\n", year, maxTrendBarWidth, bar, count)
+	}
+	fmt.Println()
+
+	summary := fmt.Sprintf("%s (%+.0f%% of the yearly average per year), peak in %d",
+		strings.ToUpper(trend.PredictedTrend[:1])+trend.PredictedTrend[1:], trend.GrowthRate*100, trend.PeakYear)
+	switch trend.PredictedTrend {
+	case graph.TrendGrowing:
+		ui.PrintSuccess(summary)
+	case graph.TrendDeclining:
+		ui.PrintWarning(summary)
+	default:
+		ui.PrintInfo(summary)
+	}
+	fmt.Println()
+}
@@ -1,13 +1,15 @@
 package commands
 
 import (
+	"archivist/internal/analyzer"
 	"archivist/internal/app"
 	"archivist/internal/cache"
+	"archivist/internal/parser"
 	"archivist/internal/rag"
 	"archivist/pkg/fileutil"
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"path/filepath"
 	"strings"
 	"time"
@@ -26,8 +28,11 @@ Run this if:
 - You processed papers before the chat feature was added
 - You want to rebuild the vector index
 
+Papers whose analysis hasn't changed since they were indexed are skipped,
+so repeated runs only pay for new or changed papers.
+
 Example:
-  archivist index                    # Index all processed papers
+  archivist index                    # Index new and changed papers
   archivist index --force            # Reindex even if already indexed`,
 	RunE: runIndex,
 }
@@ -45,6 +50,13 @@ func runIndex(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	return indexLibrary(config, forceReindex, false)
+}
+
+// indexLibrary embeds the cached analysis of every library paper into the
+// FAISS index. Papers whose LaTeX is unchanged since they were indexed are
+// skipped unless force is set; rebuild also empties the index first.
+func indexLibrary(config *app.Config, force, rebuild bool) error {
 	ctx := context.Background()
 
 	fmt.Println("\n🔌 Connecting to Redis cache...")
@@ -75,10 +87,11 @@ func runIndex(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create cache: %w", err)
 	}
 	defer redisCache.Close()
+	redisCache.SetRefreshOnHit(config.Cache.RefreshOnHit)
 
 	// Initialize embedding client
 	fmt.Println("🧮 Initializing Gemini embeddings...")
-	embedClient, err := rag.NewEmbeddingClient(config.Gemini.APIKey)
+	embedClient, err := rag.NewEmbeddingClient(config.Gemini.APIKey, config.RAG.EmbeddingModel, config.RAG.EmbeddingDim)
 	if err != nil {
 		return fmt.Errorf("failed to create embedding client: %w", err)
 	}
@@ -86,14 +99,38 @@ func runIndex(cmd *cobra.Command, args []string) error {
 
 	// Initialize FAISS vector store
 	fmt.Println("📊 Initializing FAISS vector store...")
-	vectorStore, err := rag.NewFAISSVectorStore(config.FAISS.IndexDir)
+	vectorStore, err := rag.NewFAISSVectorStore(config.FAISS.IndexDir, config.RAG.EmbeddingDim)
+	if rebuild && errors.Is(err, rag.ErrDimensionMismatch) {
+		// The index is being rebuilt anyway, so discard the one built for another model
+		fmt.Printf("⚠️  %v\n", err)
+		if err := rag.ResetFAISSIndex(config.FAISS.IndexDir); err != nil {
+			return err
+		}
+		vectorStore, err = rag.NewFAISSVectorStore(config.FAISS.IndexDir, config.RAG.EmbeddingDim)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create FAISS vector store: %w", err)
 	}
 
+	if rebuild {
+		fmt.Println("🗑️  Clearing existing vector index...")
+		if err := vectorStore.Clear(); err != nil {
+			return fmt.Errorf("failed to clear vector index: %w", err)
+		}
+	}
+
 	// Create indexer
-	chunker := rag.NewChunker(rag.DefaultChunkSize, rag.DefaultChunkOverlap)
+	chunker := rag.NewChunker(config.RAG.ChunkSize, config.RAG.ChunkOverlap)
 	indexer := rag.NewIndexer(chunker, embedClient, vectorStore)
+	if config.RAG.IndexMetadata {
+		paperAnalyzer, err := analyzer.NewAnalyzer(config)
+		if err != nil {
+			fmt.Printf("⚠️  Indexing without paper metadata: %v\n", err)
+		} else {
+			defer paperAnalyzer.Close()
+			indexer.SetMetadataExtractor(parser.NewPDFParser(paperAnalyzer))
+		}
+	}
 
 	fmt.Println("✅ Indexer ready")
 
@@ -120,18 +157,6 @@ func runIndex(cmd *cobra.Command, args []string) error {
 
 		fmt.Printf("[%d/%d] Processing: %s\n", i+1, len(pdfFiles), paperTitle)
 
-		// Check if already indexed
-		if !forceReindex {
-			isIndexed, numChunks, err := indexer.CheckIfIndexed(ctx, paperTitle)
-			if err != nil {
-				log.Printf("  ⚠️  Error checking index status: %v", err)
-			} else if isIndexed {
-				fmt.Printf("  ⏭️  Already indexed (%d chunks) - skipping\n", numChunks)
-				skipped++
-				continue
-			}
-		}
-
 		// Get cached LaTeX content
 		fileHash, err := fileutil.ComputeFileHash(pdfPath)
 		if err != nil {
@@ -148,11 +173,17 @@ func runIndex(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		// Index the paper
-		if forceReindex {
+		// Index the paper, skipping it if its content hasn't changed
+		if force {
 			err = indexer.ReindexPaper(ctx, paperTitle, cached.LatexContent, pdfPath)
 		} else {
-			err = indexer.IndexPaper(ctx, paperTitle, cached.LatexContent, pdfPath)
+			var changed bool
+			changed, err = indexer.IndexPaperIfChanged(ctx, paperTitle, cached.LatexContent, pdfPath)
+			if err == nil && !changed {
+				fmt.Println("  ⏭️  Already indexed and unchanged - skipping")
+				skipped++
+				continue
+			}
 		}
 
 		if err != nil {
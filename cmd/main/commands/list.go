@@ -2,16 +2,25 @@ package commands
 
 import (
 	"archivist/internal/app"
+	"archivist/internal/storage"
 	"archivist/internal/ui"
 	"archivist/pkg/fileutil"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
 
-var showReports bool
+var (
+	showReports   bool
+	showProcessed bool
+	listStatus    string
+	listJSON      bool
+	listFailed    bool
+)
 
 // NewListCommand creates the list command
 func NewListCommand() *cobra.Command {
@@ -23,17 +32,48 @@ func NewListCommand() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&showReports, "reports", "r", false, "show generated reports instead of input files")
+	cmd.Flags().BoolVarP(&showProcessed, "processed", "P", false, "show processing history from the metadata store")
+	cmd.Flags().StringVar(&listStatus, "status", "", "only show processed papers with this status (completed, failed, processing); implies --processed")
+	cmd.Flags().BoolVar(&listFailed, "failed", false, "show papers that failed for good, with their failure category, from .metadata/failed.jsonl")
+	cmd.Flags().BoolVar(&listJSON, "json", false, "print JSON instead: processing records with --processed/--status, otherwise an array of file paths")
 
 	return cmd
 }
 
 func runList(cmd *cobra.Command, args []string) {
-	ui.ShowBanner()
+	if !listJSON {
+		ui.ShowBanner()
+	}
 
 	config, err := app.LoadConfig(ConfigPath)
 	if err != nil {
-		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
-		os.Exit(1)
+		exitWithError(listJSON, fmt.Sprintf("Failed to load config: %v", err))
+	}
+
+	if listFailed {
+		listFailures(config)
+		return
+	}
+
+	if showProcessed || listStatus != "" {
+		listProcessedRecords(config)
+		return
+	}
+
+	if listJSON {
+		dir := config.InputDir
+		if showReports {
+			dir = config.ReportOutputDir
+		}
+		files, err := fileutil.GetPDFFiles(dir)
+		if err != nil {
+			exitWithError(true, fmt.Sprintf("Failed to get PDF files: %v", err))
+		}
+		if files == nil {
+			files = []string{}
+		}
+		printJSON(files)
+		return
 	}
 
 	if showReports {
@@ -86,3 +126,152 @@ func runList(cmd *cobra.Command, args []string) {
 		}
 	}
 }
+
+// listProcessedRecords prints the records in the metadata store, filtered by --status if set
+func listProcessedRecords(config *app.Config) {
+	var status storage.ProcessingStatus
+	if listStatus != "" {
+		var err error
+		if status, err = storage.ParseStatus(listStatus); err != nil {
+			exitWithError(listJSON, err.Error())
+		}
+	}
+
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		exitWithError(listJSON, fmt.Sprintf("Failed to open metadata store: %v", err))
+	}
+	defer metadataStore.Close()
+
+	// Records come back newest first, so the output is stable between runs
+	var records []storage.ProcessingRecord
+	if status != "" {
+		records = metadataStore.GetRecordsByStatus(status)
+	} else {
+		records = metadataStore.GetAllRecords()
+	}
+
+	if listJSON {
+		if records == nil {
+			records = []storage.ProcessingRecord{}
+		}
+		printJSON(records)
+		return
+	}
+
+	heading := "PROCESSED PAPERS"
+	if status != "" {
+		heading = strings.ToUpper(string(status)) + " PAPERS"
+	}
+
+	ui.ColorBold.Println("═══════════════════════════════════════════════════════════════")
+	ui.ColorBold.Printf("              %s (%d)                        \n", heading, len(records))
+	ui.ColorBold.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	if len(records) == 0 {
+		if status != "" {
+			ui.PrintWarning(fmt.Sprintf("No papers with status %s", status))
+		} else {
+			ui.PrintWarning("No papers have been processed yet")
+		}
+		return
+	}
+
+	for i, record := range records {
+		title := record.PaperTitle
+		if title == "" {
+			title = filepath.Base(record.FilePath)
+		}
+		ui.ColorTitle.Printf("%d. %s\n", i+1, title)
+		ui.ColorSubtle.Printf("   Status: %s (%s)\n", record.Status, record.ProcessedAt.Format("2006-01-02 15:04"))
+		ui.ColorSubtle.Printf("   Source: %s\n", record.FilePath)
+		if record.ReportPath != "" {
+			ui.ColorSubtle.Printf("   Report: %s\n", record.ReportPath)
+		}
+		if record.Error != "" {
+			ui.ColorError.Printf("   Error:  %s\n", record.Error)
+		}
+		fmt.Println()
+	}
+}
+
+// listFailures prints the latest dead-letter entry of every paper that
+// hasn't been processed successfully since, newest first
+func listFailures(config *app.Config) {
+	failures, err := unresolvedFailures(config)
+	if err != nil {
+		exitWithError(listJSON, err.Error())
+	}
+
+	if listJSON {
+		if failures == nil {
+			failures = []storage.FailureEntry{}
+		}
+		printJSON(failures)
+		return
+	}
+
+	ui.ColorBold.Println("═══════════════════════════════════════════════════════════════")
+	ui.ColorBold.Printf("              FAILED PAPERS (%d)                        \n", len(failures))
+	ui.ColorBold.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	if len(failures) == 0 {
+		ui.PrintSuccess("No unresolved failures")
+		return
+	}
+
+	for i, failure := range failures {
+		ui.ColorTitle.Printf("%d. %s\n", i+1, filepath.Base(failure.FilePath))
+		ui.ColorSubtle.Printf("   Category: %s (attempt %d, %s)\n", failure.Category, failure.Attempts, failure.FailedAt.Local().Format("2006-01-02 15:04"))
+		ui.ColorSubtle.Printf("   Source:   %s\n", failure.FilePath)
+		ui.ColorError.Printf("   Error:    %s\n", failure.Error)
+		fmt.Println()
+	}
+	ui.PrintInfo("Retry them with: rph reprocess-failed [--category <category>]")
+}
+
+// unresolvedFailures returns the latest dead-letter entry of each paper whose
+// metadata record hasn't since been marked completed
+func unresolvedFailures(config *app.Config) ([]storage.FailureEntry, error) {
+	entries, err := storage.NewFailureLog(storage.DefaultMetadataDir).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open metadata store: %w", err)
+	}
+	defer metadataStore.Close()
+
+	var failures []storage.FailureEntry
+	for _, failure := range storage.LatestFailures(entries) {
+		if failure.FileHash != "" && metadataStore.IsProcessed(failure.FileHash) {
+			continue
+		}
+		failures = append(failures, failure)
+	}
+	return failures, nil
+}
+
+// printJSON writes v to stdout as indented JSON
+func printJSON(v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		exitWithError(true, fmt.Sprintf("Failed to encode JSON: %v", err))
+	}
+	fmt.Println(string(data))
+}
+
+// exitWithError reports msg and exits with status 1. With jsonOutput the
+// message goes to stderr as plain text so stdout only ever holds JSON.
+func exitWithError(jsonOutput bool, msg string) {
+	if jsonOutput {
+		fmt.Fprintln(os.Stderr, "Error: "+msg)
+	} else {
+		ui.PrintError(msg)
+	}
+	os.Exit(1)
+}
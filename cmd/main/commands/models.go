@@ -33,18 +33,24 @@ func runModels(cmd *cobra.Command, args []string) {
 	ui.PrintStage("Querying Gemini API", "Finding available models")
 
 	// Create analyzer to query models
-	analyzer, err := analyzer.NewAnalyzer(config)
+	paperAnalyzer, err := analyzer.NewAnalyzer(config)
 	if err != nil {
 		ui.PrintError(fmt.Sprintf("Failed to create analyzer: %v", err))
 		os.Exit(1)
 	}
-	defer analyzer.Close()
+	defer paperAnalyzer.Close()
+
+	geminiClient, ok := paperAnalyzer.GetClient().(*analyzer.GeminiClient)
+	if !ok {
+		ui.PrintError(fmt.Sprintf("Model listing is only supported for the gemini provider (current: %s)", config.LLM.Provider))
+		os.Exit(1)
+	}
 
 	ctx := context.Background()
 
 	// List all available models
 	ui.PrintInfo("Fetching list of available models...")
-	models, err := analyzer.GetClient().ListAvailableModels(ctx)
+	models, err := geminiClient.ListAvailableModels(ctx)
 	if err != nil {
 		ui.PrintError(fmt.Sprintf("Failed to list models: %v", err))
 		os.Exit(1)
@@ -62,7 +68,7 @@ func runModels(cmd *cobra.Command, args []string) {
 	// Find best thinking model
 	fmt.Println()
 	ui.PrintInfo("Finding best thinking model...")
-	thinkingModel, err := analyzer.GetClient().FindThinkingModel(ctx)
+	thinkingModel, err := geminiClient.FindThinkingModel(ctx)
 	if err != nil {
 		ui.PrintWarning(fmt.Sprintf("Could not find thinking model: %v", err))
 	} else {
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/storage"
+	"archivist/internal/ui"
+	"archivist/pkg/fileutil"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// NewOpenCommand creates the open command
+func NewOpenCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "open [file.pdf | title]",
+		Short: "Open a paper's report PDF in the default viewer",
+		Long: `Open the report generated for a processed paper with the system's default
+PDF viewer. The paper can be given as a path to the source PDF or as its title.
+
+Examples:
+  rph open lib/attention.pdf
+  rph open "Attention Is All You Need"`,
+		Args: cobra.ExactArgs(1),
+		Run:  runOpen,
+
+		ValidArgsFunction: completeProcessedPapers(true),
+	}
+
+	return cmd
+}
+
+func runOpen(cmd *cobra.Command, args []string) {
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
+		os.Exit(1)
+	}
+
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to open metadata store: %v", err))
+		os.Exit(1)
+	}
+	defer metadataStore.Close()
+
+	record, err := findRecord(metadataStore, args[0])
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+
+	reportPath, err := reportToOpen(record)
+	if err != nil {
+		ui.PrintError(err.Error())
+		os.Exit(1)
+	}
+
+	if err := fileutil.OpenFile(reportPath); err != nil {
+		ui.PrintError(err.Error())
+		ui.PrintInfo("Please open the file manually:")
+		ui.ColorBold.Printf("  %s\n", reportPath)
+		os.Exit(1)
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Opened %s", reportPath))
+}
+
+// reportToOpen returns the report of a processed paper, or why there is none
+func reportToOpen(record storage.ProcessingRecord) (string, error) {
+	if record.Status != storage.StatusCompleted {
+		return "", fmt.Errorf("%s has not been processed yet (status: %s); run: rph process %s",
+			record.FilePath, record.Status, record.FilePath)
+	}
+	if record.ReportPath == "" {
+		return "", fmt.Errorf("no report was recorded for %s; run: rph process --force %s",
+			record.FilePath, record.FilePath)
+	}
+	if !fileExists(record.ReportPath) {
+		return "", fmt.Errorf("report %s is missing; regenerate it with: rph compile %s",
+			record.ReportPath, record.TexFilePath)
+	}
+	return record.ReportPath, nil
+}
@@ -3,39 +3,58 @@ package commands
 import (
 	"archivist/internal/app"
 	"archivist/internal/compiler"
+	"archivist/internal/storage"
 	"archivist/internal/tui"
 	"archivist/internal/ui"
 	"archivist/internal/wizard"
+	"archivist/pkg/fileutil"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
+var statusJSON bool
+
 // NewStatusCommand creates the status command
 func NewStatusCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "status [file]",
 		Short: "Show processing status",
-		Long:  "Check if a paper has been processed by looking for its report",
-		Args:  cobra.ExactArgs(1),
-		Run:   runStatus,
+		Long: `Check if a paper has been processed by looking for its report.
+
+Exits with status 1 if the paper has not been processed.`,
+		Args: cobra.ExactArgs(1),
+		Run:  runStatus,
+
+		ValidArgsFunction: completeProcessedPapers(false),
 	}
+
+	cmd.Flags().BoolVar(&statusJSON, "json", false, "print the processing record as JSON")
+
+	return cmd
 }
 
 func runStatus(cmd *cobra.Command, args []string) {
-	ui.ShowBanner()
+	if !statusJSON {
+		ui.ShowBanner()
+	}
 
 	config, err := app.LoadConfig(ConfigPath)
 	if err != nil {
-		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
-		os.Exit(1)
+		exitWithError(statusJSON, fmt.Sprintf("Failed to load config: %v", err))
 	}
 
 	filePath := args[0]
-	basename := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+
+	if statusJSON {
+		printStatusJSON(config, filePath)
+		return
+	}
 
 	ui.ColorBold.Println("═══════════════════════════════════════════════════════════════")
 	ui.ColorBold.Println("                      FILE STATUS                              ")
@@ -45,45 +64,152 @@ func runStatus(cmd *cobra.Command, args []string) {
 	ui.ColorTitle.Printf("📄 Input:     %s\n", filePath)
 	fmt.Println()
 
-	// Check if report exists in reports folder
-	// Try to find matching report (approximate match since title may be modified)
-	reports, _ := filepath.Glob(filepath.Join(config.ReportOutputDir, "*.pdf"))
-	foundReport := false
-	var reportPath string
-
-	for _, report := range reports {
-		reportBase := filepath.Base(report)
-		if strings.Contains(strings.ToLower(reportBase), strings.ToLower(basename)) {
-			foundReport = true
-			reportPath = report
-			break
-		}
+	// Prefer the recorded processing history when available
+	if record, ok := lookupRecord(config, filePath); ok {
+		printRecordStatus(record)
+		fmt.Println()
+		return
 	}
 
-	if foundReport {
+	if reportPath, texPath, ok := findReport(config, filePath); ok {
 		ui.ColorSuccess.Println("✅ Status:    Processed")
 		ui.ColorInfo.Printf("📊 Report:    %s\n", reportPath)
-
-		// Check for tex file
-		texPath := filepath.Join(config.TexOutputDir, strings.TrimSuffix(filepath.Base(reportPath), ".pdf")+".tex")
-		if _, err := os.Stat(texPath); err == nil {
+		if texPath != "" {
 			ui.ColorInfo.Printf("📝 LaTeX:     %s\n", texPath)
 		}
-	} else {
-		ui.ColorWarning.Println("⏳ Status:    Not processed")
+		fmt.Println()
+		return
 	}
 
+	ui.ColorWarning.Println("⏳ Status:    Not processed")
 	fmt.Println()
+	os.Exit(1)
 }
 
+// printStatusJSON prints the processing record for filePath. Papers without
+// a record but with a matching report get a completed record built from it.
+func printStatusJSON(config *app.Config, filePath string) {
+	record, ok := lookupRecord(config, filePath)
+	if !ok {
+		reportPath, texPath, found := findReport(config, filePath)
+		if !found {
+			exitWithError(true, fmt.Sprintf("%s has not been processed", filePath))
+		}
+		record = storage.ProcessingRecord{
+			FilePath:    filePath,
+			Status:      storage.StatusCompleted,
+			ReportPath:  reportPath,
+			TexFilePath: texPath,
+		}
+	}
+
+	printJSON(record)
+}
+
+// findReport looks for a generated report whose name contains the PDF's
+// basename (approximate, since the title may have been modified) and the
+// LaTeX file it was compiled from, if that still exists
+func findReport(config *app.Config, filePath string) (reportPath, texPath string, ok bool) {
+	basename := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+
+	reports, _ := filepath.Glob(filepath.Join(config.ReportOutputDir, "*.pdf"))
+	markdownReports, _ := filepath.Glob(filepath.Join(config.ReportOutputDir, "*.md"))
+	for _, report := range append(reports, markdownReports...) {
+		if strings.Contains(strings.ToLower(filepath.Base(report)), strings.ToLower(basename)) {
+			reportPath = report
+			break
+		}
+	}
+	if reportPath == "" {
+		return "", "", false
+	}
+
+	if filepath.Ext(reportPath) != ".pdf" {
+		return reportPath, "", true
+	}
+
+	candidate := filepath.Join(config.TexOutputDir, strings.TrimSuffix(filepath.Base(reportPath), ".pdf")+".tex")
+	if _, err := os.Stat(candidate); err == nil {
+		texPath = candidate
+	}
+
+	return reportPath, texPath, true
+}
+
+// lookupRecord finds the metadata record for a PDF by its content hash
+func lookupRecord(config *app.Config, filePath string) (storage.ProcessingRecord, bool) {
+	hash, err := fileutil.ComputeFileHash(filePath)
+	if err != nil {
+		return storage.ProcessingRecord{}, false
+	}
+
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		return storage.ProcessingRecord{}, false
+	}
+	defer metadataStore.Close()
+
+	return metadataStore.GetRecord(hash)
+}
+
+// printRecordStatus prints the details of a processing record
+func printRecordStatus(record storage.ProcessingRecord) {
+	switch record.Status {
+	case storage.StatusCompleted:
+		ui.ColorSuccess.Println("✅ Status:    Processed")
+	case storage.StatusFailed:
+		ui.ColorError.Println("❌ Status:    Failed")
+	case storage.StatusProcessing:
+		ui.ColorWarning.Println("🔄 Status:    Processing (or interrupted)")
+	default:
+		ui.ColorWarning.Printf("⏳ Status:    %s\n", record.Status)
+	}
+
+	if record.PaperTitle != "" {
+		ui.ColorInfo.Printf("📖 Title:     %s\n", record.PaperTitle)
+	}
+	if record.ReportPath != "" {
+		ui.ColorInfo.Printf("📊 Report:    %s\n", record.ReportPath)
+	}
+	if record.TexFilePath != "" {
+		ui.ColorInfo.Printf("📝 LaTeX:     %s\n", record.TexFilePath)
+	}
+	if record.Mode != "" {
+		ui.ColorInfo.Printf("⚙️  Mode:      %s\n", record.Mode)
+	}
+	if record.ModelUsed != "" {
+		ui.ColorInfo.Printf("🤖 Model:     %s\n", record.ModelUsed)
+	}
+	if record.Duration > 0 {
+		ui.ColorInfo.Printf("⏱️  Duration:  %s\n", record.Duration.Round(100*time.Millisecond))
+	}
+	ui.ColorSubtle.Printf("🕒 Updated:   %s\n", record.ProcessedAt.Format("2006-01-02 15:04:05"))
+	if record.Error != "" {
+		ui.ColorError.Printf("⚠️  Error:     %s\n", record.Error)
+	}
+}
+
+var (
+	cleanAll bool
+	cleanYes bool
+)
+
 // NewCleanCommand creates the clean command
 func NewCleanCommand() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "clean",
 		Short: "Clean temporary files",
-		Long:  "Remove auxiliary LaTeX files",
-		Run:   runClean,
+		Long: `Remove auxiliary LaTeX files.
+
+With --all, also remove .tex files and reports (.pdf/.md) that no completed
+processing record points to, or whose source PDF no longer exists.`,
+		Run: runClean,
 	}
+
+	cmd.Flags().BoolVar(&cleanAll, "all", false, "also remove orphaned .tex files and reports")
+	cmd.Flags().BoolVarP(&cleanYes, "yes", "y", false, "skip the confirmation prompt")
+
+	return cmd
 }
 
 func runClean(cmd *cobra.Command, args []string) {
@@ -97,19 +223,179 @@ func runClean(cmd *cobra.Command, args []string) {
 
 	ui.PrintStage("Cleaning", "Removing auxiliary LaTeX files")
 
-	extensions := []string{"*.aux", "*.log", "*.out", "*.toc", "*.fdb_latexmk", "*.fls", "*.synctex.gz"}
+	extensions := []string{"*.aux", "*.log", "*.out", "*.toc", "*.fdb_latexmk", "*.fls", "*.synctex.gz", "*.bbl", "*.blg", "*.bcf", "*.run.xml"}
 	totalCleaned := 0
+	var bytesReclaimed int64
 
 	for _, ext := range extensions {
 		matches, _ := filepath.Glob(filepath.Join(config.TexOutputDir, ext))
 		for _, file := range matches {
-			if err := os.Remove(file); err == nil {
+			if size, ok := removeFile(file); ok {
 				totalCleaned++
+				bytesReclaimed += size
 			}
 		}
 	}
 
 	ui.PrintSuccess(fmt.Sprintf("Cleaned %d auxiliary files", totalCleaned))
+
+	if cleanAll {
+		removed, size := cleanOrphanedOutputs(config)
+		totalCleaned += removed
+		bytesReclaimed += size
+	}
+
+	ui.PrintInfo(fmt.Sprintf("Reclaimed %s across %d files", formatBytes(bytesReclaimed), totalCleaned))
+}
+
+// cleanOrphanedOutputs removes .tex files and reports with no completed
+// record behind them, after confirmation. It returns how many files and
+// bytes were removed.
+func cleanOrphanedOutputs(config *app.Config) (int, int64) {
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to open metadata store: %v", err))
+		os.Exit(1)
+	}
+	records := metadataStore.GetAllRecords()
+	metadataStore.Close()
+
+	ui.PrintStage("Cleaning", "Looking for orphaned .tex files and reports")
+
+	orphans := findOrphanedOutputs(config, records)
+	if len(orphans) == 0 {
+		ui.PrintSuccess("No orphaned .tex files or reports")
+		return 0, 0
+	}
+
+	var total int64
+	fmt.Println()
+	ui.ColorBold.Println("The following have no completed record or source PDF:")
+	for _, path := range orphans {
+		size := fileSize(path)
+		total += size
+		fmt.Printf("  • %s (%s)\n", path, formatBytes(size))
+	}
+	fmt.Println()
+
+	if !cleanYes {
+		prompt := promptui.Prompt{
+			Label:     fmt.Sprintf("Delete %d files (%s)", len(orphans), formatBytes(total)),
+			IsConfirm: true,
+		}
+		if _, err := prompt.Run(); err != nil {
+			ui.PrintInfo("Orphaned files kept")
+			return 0, 0
+		}
+	}
+
+	removed := 0
+	var reclaimed int64
+	for _, path := range orphans {
+		size, ok := removeFile(path)
+		if !ok {
+			ui.PrintWarning(fmt.Sprintf("Failed to remove %s", path))
+			continue
+		}
+		removed++
+		reclaimed += size
+	}
+
+	ui.PrintSuccess(fmt.Sprintf("Removed %d orphaned files", removed))
+	return removed, reclaimed
+}
+
+// findOrphanedOutputs lists .tex files in the tex output dir and .pdf/.md
+// reports in the report dir that belong to no completed record whose source PDF still
+// exists. Source PDFs themselves are never listed, even if the report dir is
+// also the input dir.
+func findOrphanedOutputs(config *app.Config, records []storage.ProcessingRecord) []string {
+	keep := make(map[string]bool)
+	var keepBasenames []string
+
+	for _, record := range records {
+		keep[absPath(record.FilePath)] = true
+		if record.Status != storage.StatusCompleted || !fileExists(record.FilePath) {
+			continue
+		}
+		for _, path := range []string{record.TexFilePath, record.ReportPath} {
+			if path != "" {
+				keep[absPath(path)] = true
+			}
+		}
+		// Older records don't store output paths; match outputs by name like status does
+		if record.TexFilePath == "" || record.ReportPath == "" {
+			base := strings.TrimSuffix(filepath.Base(record.FilePath), filepath.Ext(record.FilePath))
+			keepBasenames = append(keepBasenames, strings.ToLower(base))
+		}
+	}
+
+	var candidates []string
+	texFiles, _ := filepath.Glob(filepath.Join(config.TexOutputDir, "*.tex"))
+	candidates = append(candidates, texFiles...)
+	if absPath(config.ReportOutputDir) != absPath(config.InputDir) {
+		reports, _ := filepath.Glob(filepath.Join(config.ReportOutputDir, "*.pdf"))
+		markdownReports, _ := filepath.Glob(filepath.Join(config.ReportOutputDir, "*.md"))
+		candidates = append(candidates, reports...)
+		candidates = append(candidates, markdownReports...)
+	}
+
+	var orphans []string
+candidates:
+	for _, path := range candidates {
+		if keep[absPath(path)] {
+			continue
+		}
+		name := strings.ToLower(filepath.Base(path))
+		for _, base := range keepBasenames {
+			if strings.Contains(name, base) {
+				continue candidates
+			}
+		}
+		orphans = append(orphans, path)
+	}
+
+	return orphans
+}
+
+// absPath returns path made absolute and cleaned, for comparing paths
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// fileSize returns the size of path in bytes, or 0 if it can't be read
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// removeFile deletes path and reports its size
+func removeFile(path string) (int64, bool) {
+	size := fileSize(path)
+	if err := os.Remove(path); err != nil {
+		return 0, false
+	}
+	return size, true
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. "1.5 MB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
 }
 
 // NewCheckCommand creates the check command
@@ -4,14 +4,18 @@ import (
 	"archivist/internal/app"
 	"archivist/internal/compiler"
 	"archivist/internal/profiler"
+	"archivist/internal/storage"
 	"archivist/internal/ui"
 	"archivist/internal/worker"
 	"archivist/pkg/fileutil"
 	"context"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"time"
 
+	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
@@ -23,8 +27,16 @@ var (
 	selectPapers bool
 	inputDir     string
 	outputDir    string
+	texOutputDir string
+	nameFilter   string
+	filterRegex  bool
+	outputFormat string
+	resumeBatch  string
 )
 
+// resumeLatest is what a bare --resume means: the most recent unfinished batch
+const resumeLatest = "latest"
+
 // NewProcessCommand creates the process command
 func NewProcessCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -35,13 +47,19 @@ func NewProcessCommand() *cobra.Command {
 		Run:   runProcess,
 	}
 
-	cmd.Flags().BoolVarP(&force, "force", "f", false, "reprocess even if already processed")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "reprocess even if already processed (or a duplicate, with processing.dedup_by_metadata)")
 	cmd.Flags().IntVarP(&parallel, "parallel", "p", 0, "number of parallel workers (default: config value)")
 	cmd.Flags().StringVarP(&mode, "mode", "m", "", "processing mode: 'fast' (default: interactive)")
 	cmd.Flags().BoolVarP(&interactive, "interactive", "i", true, "enable interactive mode selection")
 	cmd.Flags().BoolVarP(&selectPapers, "select", "s", false, "interactively select papers to process from library")
 	cmd.Flags().StringVar(&inputDir, "input-dir", "", "input directory for PDF papers (overrides config)")
-	cmd.Flags().StringVar(&outputDir, "output-dir", "", "output directory for PDF reports (overrides config)")
+	cmd.Flags().StringVarP(&outputDir, "output-dir", "o", "", "output directory for reports (overrides report_output_dir for this run)")
+	cmd.Flags().StringVar(&texOutputDir, "tex-output-dir", "", "output directory for .tex files (overrides tex_output_dir for this run)")
+	cmd.Flags().StringVar(&nameFilter, "filter", "", "only process PDFs whose filename matches this glob (e.g. '2023_iclr_*')")
+	cmd.Flags().BoolVar(&filterRegex, "regex", false, "treat --filter as a regular expression instead of a glob")
+	cmd.Flags().StringVar(&outputFormat, "format", string(worker.FormatLatex), "report format: 'latex' (compiled to PDF) or 'markdown' (no LaTeX needed)")
+	cmd.Flags().StringVar(&resumeBatch, "resume", "", "resume an interrupted batch by ID, skipping files it completed (no ID: the latest one)")
+	cmd.Flags().Lookup("resume").NoOptDefVal = resumeLatest
 
 	return cmd
 }
@@ -84,15 +102,33 @@ func runProcess(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	format := worker.OutputFormat(outputFormat)
+	if format != worker.FormatLatex && format != worker.FormatMarkdown {
+		ui.PrintError(fmt.Sprintf("Invalid --format %q (must be 'latex' or 'markdown')", outputFormat))
+		os.Exit(1)
+	}
+
 	// Override directories if flags are provided
 	if inputDir != "" {
 		config.InputDir = inputDir
 		ui.PrintInfo(fmt.Sprintf("Using custom input directory: %s", inputDir))
 	}
 	if outputDir != "" {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to create output directory: %v", err))
+			os.Exit(1)
+		}
 		config.ReportOutputDir = outputDir
 		ui.PrintInfo(fmt.Sprintf("Using custom output directory: %s", outputDir))
 	}
+	if texOutputDir != "" {
+		if err := os.MkdirAll(texOutputDir, 0755); err != nil {
+			ui.PrintError(fmt.Sprintf("Failed to create LaTeX output directory: %v", err))
+			os.Exit(1)
+		}
+		config.TexOutputDir = texOutputDir
+		ui.PrintInfo(fmt.Sprintf("Using custom LaTeX output directory: %s", texOutputDir))
+	}
 
 	// Initialize logger
 	logCleanup, err := app.InitLogger(config)
@@ -101,6 +137,9 @@ func runProcess(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 	defer logCleanup()
+	if outputDir != "" || texOutputDir != "" {
+		log.Printf("Output directories for this run: reports=%s tex=%s", config.ReportOutputDir, config.TexOutputDir)
+	}
 
 	// Select processing mode
 	var selectedMode ui.ProcessingMode
@@ -130,20 +169,40 @@ func runProcess(cmd *cobra.Command, args []string) {
 		ui.PrintInfo(fmt.Sprintf("Using %d parallel workers", config.Processing.MaxWorkers))
 	}
 
-	// Check dependencies
-	ui.PrintStage("Checking Dependencies", "Verifying LaTeX installation")
-	if err := compiler.CheckDependencies(config.Latex.Engine == "latexmk", config.Latex.Compiler); err != nil {
-		ui.PrintError(fmt.Sprintf("Dependency check failed: %v", err))
-		fmt.Println("\nPlease install the required LaTeX tools:")
-		fmt.Println("  sudo apt install texlive-latex-extra latexmk")
-		os.Exit(1)
+	// Check dependencies; Markdown reports skip the LaTeX toolchain entirely
+	if format == worker.FormatMarkdown {
+		ui.PrintInfo("Writing Markdown reports (LaTeX compilation skipped)")
+	} else {
+		ui.PrintStage("Checking Dependencies", "Verifying LaTeX installation")
+		if err := compiler.CheckDependencies(config.Latex.Engine == "latexmk", config.Latex.Compiler); err != nil {
+			ui.PrintError(fmt.Sprintf("Dependency check failed: %v", err))
+			fmt.Println("\nPlease install the required LaTeX tools:")
+			fmt.Println("  sudo apt install texlive-latex-extra latexmk")
+			fmt.Println("Or use --format markdown to skip LaTeX")
+			os.Exit(1)
+		}
+		ui.PrintSuccess("All dependencies installed")
 	}
-	ui.PrintSuccess("All dependencies installed")
 
 	// Get files to process
 	var files []string
+	var checkpoint *storage.BatchCheckpoint
+
+	if resumeBatch != "" {
+		// The interrupted batch's own file list; completed files are skipped later
+		if len(args) > 0 || selectPapers {
+			ui.PrintWarning("Ignoring file/directory argument and --select when resuming a batch")
+		}
 
-	if selectPapers {
+		checkpoint, err = loadCheckpoint(resumeBatch)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Cannot resume: %v", err))
+			os.Exit(1)
+		}
+		files = existingFiles(checkpoint.Files)
+		ui.PrintInfo(fmt.Sprintf("Resuming batch %s: %d of %d file(s) already completed",
+			checkpoint.ID, len(checkpoint.Completed), len(checkpoint.Files)))
+	} else if selectPapers {
 		// Interactive paper selection mode
 		if len(args) > 0 {
 			ui.PrintWarning("Ignoring file/directory argument when using --select flag")
@@ -220,6 +279,30 @@ func runProcess(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	if nameFilter != "" {
+		discovered := len(files)
+		files, err = fileutil.FilterByName(files, nameFilter, filterRegex)
+		if err != nil {
+			ui.PrintError(fmt.Sprintf("Invalid --filter: %v", err))
+			os.Exit(1)
+		}
+
+		kind := "glob"
+		if filterRegex {
+			kind = "regex"
+		}
+		ui.PrintInfo(fmt.Sprintf("Filter %s %q matched %d of %d PDF file(s)", kind, nameFilter, len(files), discovered))
+
+		if len(files) == 0 {
+			ui.PrintWarning(fmt.Sprintf("No PDF files match filter %q; nothing to process", nameFilter))
+			return
+		}
+	}
+
+	if interactive {
+		files = offerStaleRecovery(config, files)
+	}
+
 	ui.PrintInfo(fmt.Sprintf("Found %d PDF file(s)", len(files)))
 
 	// Confirm processing
@@ -245,7 +328,14 @@ func runProcess(cmd *cobra.Command, args []string) {
 	fmt.Println()
 	ui.PrintStage("Processing Papers", "Starting batch processing")
 	ctx := context.Background()
-	if err := worker.ProcessBatch(ctx, files, config, force, enableRAG, enableGraphBuilding); err != nil {
+	opts := worker.BatchOptions{
+		Force:               force,
+		EnableRAG:           enableRAG,
+		EnableGraphBuilding: enableGraphBuilding,
+		Format:              format,
+		Resume:              checkpoint,
+	}
+	if err := worker.ProcessBatchWithOptions(ctx, files, config, opts); err != nil {
 		ui.PrintError(fmt.Sprintf("Processing failed: %v", err))
 		fmt.Println()
 		ui.PrintInfo("Press Enter to continue...")
@@ -258,6 +348,81 @@ func runProcess(cmd *cobra.Command, args []string) {
 	ui.PrintSuccess("All processing complete!")
 }
 
+// loadCheckpoint loads the checkpoint --resume asked for
+func loadCheckpoint(id string) (*storage.BatchCheckpoint, error) {
+	if id != resumeLatest {
+		return storage.LoadBatchCheckpoint(storage.DefaultMetadataDir, id)
+	}
+
+	checkpoint, err := storage.LatestBatchCheckpoint(storage.DefaultMetadataDir)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint == nil {
+		return nil, fmt.Errorf("no interrupted batch found in %s", storage.DefaultMetadataDir)
+	}
+	return checkpoint, nil
+}
+
+// existingFiles drops files that were moved or deleted since the batch started
+func existingFiles(files []string) []string {
+	var existing []string
+	for _, file := range files {
+		if _, err := os.Stat(file); err != nil {
+			ui.PrintWarning(fmt.Sprintf("Skipping %s: %v", file, err))
+			continue
+		}
+		existing = append(existing, file)
+	}
+	return existing
+}
+
+// offerStaleRecovery lists papers an earlier run left in the processing state
+// (usually because it crashed) and adds them to files if the user agrees
+func offerStaleRecovery(config *app.Config, files []string) []string {
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		return files
+	}
+	defer metadataStore.Close()
+
+	// A live run can't take longer than the analysis timeout plus compilation
+	staleAfter := 2 * time.Duration(config.Processing.TimeoutPerPaper) * time.Second
+
+	queued := make(map[string]bool, len(files))
+	for _, file := range files {
+		queued[file] = true
+	}
+
+	var interrupted []storage.ProcessingRecord
+	for _, record := range metadataStore.GetStaleProcessing(staleAfter) {
+		if !queued[record.FilePath] && fileExists(record.FilePath) {
+			interrupted = append(interrupted, record)
+		}
+	}
+	if len(interrupted) == 0 {
+		return files
+	}
+
+	ui.PrintWarning(fmt.Sprintf("%d paper(s) were interrupted during an earlier run:", len(interrupted)))
+	for _, record := range interrupted {
+		fmt.Printf("  • %s (started %s)\n", record.FilePath, record.ProcessedAt.Format("2006-01-02 15:04"))
+	}
+
+	prompt := promptui.Prompt{
+		Label:     "Re-run them now",
+		IsConfirm: true,
+	}
+	if _, err := prompt.Run(); err != nil {
+		return files
+	}
+
+	for _, record := range interrupted {
+		files = append(files, record.FilePath)
+	}
+	return files
+}
+
 func applyModeConfig(config *app.Config, mode ui.ProcessingMode) {
 	modes := ui.GetModeConfigs()
 	modeConfig := modes[mode]
@@ -268,6 +433,7 @@ func applyModeConfig(config *app.Config, mode ui.ProcessingMode) {
 	config.Gemini.Agentic.MultiStageAnalysis = modeConfig.MultiStageAnalysis
 	config.Gemini.Agentic.Stages.LatexGeneration.Validation = modeConfig.ValidationEnabled
 	config.Gemini.Model = modeConfig.Model
+	config.Processing.Mode = string(mode)
 
 	// Use fast model for methodology analysis (only one mode now)
 	config.Gemini.Agentic.Stages.MethodologyAnalysis.Model = "models/gemini-2.0-flash-exp"
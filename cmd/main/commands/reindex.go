@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var reindexForce bool
+
+// NewReindexCommand creates the reindex command
+func NewReindexCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reindex",
+		Short: "Re-embed papers whose analysis changed",
+		Long: `Bring the chat vector index up to date with the cached analyses. Only new
+papers and papers whose LaTeX changed since they were indexed are embedded
+again, which saves embedding API cost on repeated runs.
+
+Use --force to throw the index away and rebuild it from scratch.
+
+Examples:
+  rph reindex            # Re-embed new and changed papers
+  rph reindex --force    # Rebuild the whole index`,
+		Args: cobra.NoArgs,
+		RunE: runReindex,
+	}
+
+	cmd.Flags().BoolVarP(&reindexForce, "force", "f", false, "clear the index and re-embed every paper")
+
+	return cmd
+}
+
+func runReindex(cmd *cobra.Command, args []string) error {
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return indexLibrary(config, reindexForce, reindexForce)
+}
@@ -0,0 +1,150 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/compiler"
+	"archivist/internal/storage"
+	"archivist/internal/ui"
+	"archivist/internal/worker"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	reprocessMax      int
+	reprocessCategory string
+	reprocessMode     string
+)
+
+// NewReprocessFailedCommand creates the reprocess-failed command
+func NewReprocessFailedCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reprocess-failed",
+		Short: "Retry papers that failed to process",
+		Long: `Retry only the papers whose last processing attempt failed (e.g. after
+hitting an API quota). Papers whose source file no longer exists are skipped.
+Runs without prompting, so it can be scheduled on an unattended machine.
+
+Examples:
+  rph reprocess-failed                         # Retry every failed paper
+  rph reprocess-failed --max 10                # Retry at most 10 papers
+  rph reprocess-failed --category rate_limit   # Retry only papers that hit the quota
+  rph reprocess-failed --mode fast             # Choose the processing mode`,
+		Args: cobra.NoArgs,
+		Run:  runReprocessFailed,
+	}
+
+	cmd.Flags().IntVar(&reprocessMax, "max", 0, "maximum number of papers to retry (0 = all)")
+	cmd.Flags().StringVar(&reprocessCategory, "category", "", "only retry papers whose last failure has this category (see 'rph list --failed')")
+	cmd.Flags().StringVarP(&reprocessMode, "mode", "m", string(ui.ModeFast), "processing mode: 'fast'")
+
+	return cmd
+}
+
+func runReprocessFailed(cmd *cobra.Command, args []string) {
+	ui.ShowBanner()
+
+	selectedMode := ui.ProcessingMode(reprocessMode)
+	if _, ok := ui.GetModeConfigs()[selectedMode]; !ok {
+		ui.PrintError(fmt.Sprintf("Unknown processing mode: %s", reprocessMode))
+		os.Exit(1)
+	}
+
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
+		os.Exit(1)
+	}
+
+	logCleanup, err := app.InitLogger(config)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to initialize logger: %v", err))
+		os.Exit(1)
+	}
+	defer logCleanup()
+
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to open metadata store: %v", err))
+		os.Exit(1)
+	}
+	defer metadataStore.Close()
+
+	// Oldest failures first so --max works through the backlog in order
+	failed := metadataStore.GetRecordsByStatus(storage.StatusFailed)
+	storage.SortRecords(failed, storage.SortOldestFirst)
+
+	if reprocessCategory != "" {
+		failed = filterByFailureCategory(config, failed, reprocessCategory)
+	}
+
+	if len(failed) == 0 {
+		ui.PrintSuccess("No failed papers to reprocess")
+		return
+	}
+
+	var files []string
+	for _, record := range failed {
+		if !fileExists(record.FilePath) {
+			ui.PrintWarning(fmt.Sprintf("Skipping (source file missing): %s", record.FilePath))
+			continue
+		}
+		files = append(files, record.FilePath)
+		if reprocessMax > 0 && len(files) >= reprocessMax {
+			break
+		}
+	}
+
+	if len(files) == 0 {
+		ui.PrintWarning("None of the failed papers have a source file on disk")
+		return
+	}
+
+	ui.PrintInfo(fmt.Sprintf("Retrying %d of %d failed paper(s)", len(files), len(failed)))
+
+	applyModeConfig(config, selectedMode)
+
+	if err := compiler.CheckDependencies(config.Latex.Engine == "latexmk", config.Latex.Compiler); err != nil {
+		ui.PrintError(fmt.Sprintf("Dependency check failed: %v", err))
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	ui.PrintStage("Reprocessing Papers", "Retrying failed papers")
+	opts := worker.BatchOptions{Force: true, NonInteractive: true}
+	if err := worker.ProcessBatchWithOptions(context.Background(), files, config, opts); err != nil {
+		ui.PrintError(fmt.Sprintf("Reprocessing failed: %v", err))
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	ui.PrintSuccess("All failed papers reprocessed successfully!")
+}
+
+// filterByFailureCategory keeps the failed records whose latest dead-letter
+// entry has the given category
+func filterByFailureCategory(config *app.Config, failed []storage.ProcessingRecord, category string) []storage.ProcessingRecord {
+	failures, err := unresolvedFailures(config)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to read failure log: %v", err))
+		os.Exit(1)
+	}
+
+	matching := make(map[string]bool)
+	for _, failure := range failures {
+		if failure.Category == category {
+			matching[failure.FileHash] = true
+		}
+	}
+
+	var filtered []storage.ProcessingRecord
+	for _, record := range failed {
+		if matching[record.FileHash] {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered
+}
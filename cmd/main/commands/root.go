@@ -1,7 +1,14 @@
 package commands
 
 import (
+	"archivist/internal/ui"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var (
@@ -9,8 +16,28 @@ var (
 	ConfigPath    string
 	EnableProfile bool
 	ProfileDir    string
+	LogLevel      string
+	NoColor       bool
 )
 
+// defaultConfigPath is where --config points unless given
+const defaultConfigPath = "config/config.yaml"
+
+// configOptional lists the top-level commands that work without a config
+// file: they create one, don't read one, or fall back to defaults
+var configOptional = map[string]bool{
+	"setup":      true,
+	"config":     true,
+	"configure":  true,
+	"version":    true,
+	"completion": true,
+	"help":       true,
+	"export":     true,
+	"search":     true,
+	"similar":    true,
+	"citations":  true,
+}
+
 // NewRootCommand creates the root command
 func NewRootCommand() *cobra.Command {
 	rootCmd := &cobra.Command{
@@ -19,12 +46,28 @@ func NewRootCommand() *cobra.Command {
 		Long: `Research Paper Helper analyzes AI/ML research papers using Gemini AI
 and generates comprehensive, student-friendly LaTeX reports with detailed
 explanations of methodologies, breakthroughs, and results.`,
+		Version: Version,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			// Takes precedence over logging.level in the config file and RPH_LOGGING_LEVEL
+			if cmd.Flags().Changed("log-level") {
+				viper.Set("logging.level", LogLevel)
+			}
+			if NoColor {
+				ui.DisableColor()
+			}
+			if needsConfig(cmd) {
+				checkConfigExists()
+			}
+		},
 	}
+	rootCmd.SetVersionTemplate(versionInfo())
 
 	// Global flags
-	rootCmd.PersistentFlags().StringVarP(&ConfigPath, "config", "c", "config/config.yaml", "config file path")
+	rootCmd.PersistentFlags().StringVarP(&ConfigPath, "config", "c", defaultConfigPath, "config file path")
 	rootCmd.PersistentFlags().BoolVar(&EnableProfile, "profile", false, "enable CPU and memory profiling")
 	rootCmd.PersistentFlags().StringVar(&ProfileDir, "profile-dir", "./profiles", "directory for profile output")
+	rootCmd.PersistentFlags().StringVar(&LogLevel, "log-level", "", "log level: debug, info, warn or error (overrides logging.level)")
+	rootCmd.PersistentFlags().BoolVar(&NoColor, "no-color", false, "disable colored output (also set by NO_COLOR or when output is not a terminal)")
 
 	// Add subcommands
 	rootCmd.AddCommand(
@@ -38,13 +81,56 @@ explanations of methodologies, breakthroughs, and results.`,
 		NewModelsCommand(),
 		NewCacheCommand(),
 		NewConfigureCommand(),
+		NewConfigCommand(),
 		NewChatCommand(),
 		NewIndexCommand(),
 		NewSearchCommand(),
 		NewSimilarCommand(),
 		NewCitationsCommand(),
 		NewGraphCommand(),
+		NewExportCommand(),
+		NewBibexportCommand(),
+		NewReprocessFailedCommand(),
+		NewCompileCommand(),
+		NewWatchCommand(),
+		NewDeleteCommand(),
+		NewOpenCommand(),
+		NewStatsCommand(),
+		NewReindexCommand(),
+		NewCompletionCommand(),
+		NewVersionCommand(),
 	)
 
 	return rootCmd
 }
+
+// needsConfig reports whether cmd reads the config file, judged by the
+// top-level command it belongs to
+func needsConfig(cmd *cobra.Command) bool {
+	for cmd.HasParent() && cmd.Parent().HasParent() {
+		cmd = cmd.Parent()
+	}
+	return cmd.HasParent() && !configOptional[cmd.Name()] && !strings.HasPrefix(cmd.Name(), "__")
+}
+
+// checkConfigExists exits with directions for creating a config file if
+// --config points at nothing, instead of every command failing to read it
+func checkConfigExists() {
+	if _, err := os.Stat(ConfigPath); !errors.Is(err, os.ErrNotExist) {
+		return
+	}
+
+	ui.PrintError(fmt.Sprintf("Config file not found: %s", ConfigPath))
+	fmt.Println()
+	fmt.Println("Create one with the default settings:")
+	if ConfigPath == defaultConfigPath {
+		fmt.Println("  rph config init")
+	} else {
+		fmt.Printf("  rph config init --config %s\n", ConfigPath)
+	}
+	fmt.Println("or answer a few questions instead:")
+	fmt.Println("  rph configure")
+	fmt.Println()
+	fmt.Printf("Use --config <path> to point at an existing file (default: %s).\n", defaultConfigPath)
+	os.Exit(1)
+}
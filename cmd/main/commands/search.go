@@ -1,8 +1,8 @@
 package commands
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -40,7 +40,7 @@ Start it with: cd services/search-engine && python run.py`,
 	cmd.Flags().IntVarP(&searchMaxResults, "max-results", "n", 20, "Maximum number of results")
 	cmd.Flags().StringSliceVarP(&searchSources, "sources", "s", []string{}, "Filter by sources (arxiv, openreview, acl)")
 	cmd.Flags().BoolVarP(&searchDownload, "download", "d", false, "Download selected papers to lib/")
-	cmd.Flags().StringVar(&searchServiceURL, "service-url", "http://localhost:8000", "Search service URL")
+	cmd.Flags().StringVar(&searchServiceURL, "service-url", "", "Search service URL (default: search.base_url from config)")
 
 	return cmd
 }
@@ -48,12 +48,24 @@ Start it with: cd services/search-engine && python run.py`,
 func runSearch(cmd *cobra.Command, args []string) error {
 	query := strings.Join(args, " ")
 
+	// Load config for lib directory and the search service URL
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		color.Yellow("Warning: Could not load config, using default lib directory")
+		config = &app.Config{InputDir: "./lib"}
+	}
+
+	serviceURL := searchServiceURL
+	if serviceURL == "" {
+		serviceURL = config.Search.BaseURL
+	}
+
 	// Create search client
-	client := search.NewClient(searchServiceURL)
+	client := search.NewClient(serviceURL)
 
 	// Check if service is running
 	if !client.IsServiceRunning() {
-		return fmt.Errorf(`search service is not running
+		return fmt.Errorf(`search service is not running at %s
 
 Please start the search microservice:
   cd services/search-engine
@@ -62,14 +74,7 @@ Please start the search microservice:
   pip install -r requirements.txt
   python run.py
 
-Then try your search again.`)
-	}
-
-	// Load config for lib directory
-	config, err := app.LoadConfig(ConfigPath)
-	if err != nil {
-		color.Yellow("Warning: Could not load config, using default lib directory")
-		config = &app.Config{InputDir: "./lib"}
+Then try your search again.`, client.BaseURL())
 	}
 
 	// Print search info
@@ -109,7 +114,7 @@ Then try your search again.`)
 
 	// Offer to download if flag is set
 	if searchDownload {
-		return handleDownload(client, results.Results, config.InputDir)
+		return handleDownload(results.Results, config.InputDir)
 	}
 
 	return nil
@@ -168,7 +173,7 @@ func printSearchResult(index int, result *search.SearchResult) {
 	fmt.Println()
 }
 
-func handleDownload(client *search.Client, results []search.SearchResult, libDir string) error {
+func handleDownload(results []search.SearchResult, libDir string) error {
 	if len(results) == 0 {
 		return nil
 	}
@@ -259,75 +264,42 @@ func handleDownload(client *search.Client, results []search.SearchResult, libDir
 		return nil
 	}
 
-	// Download selected papers
-	color.Cyan("\n📥 Downloading %d papers to %s...\n\n", len(selectedIndices), libDir)
-
-	successCount := 0
-	for idx := range selectedIndices {
-		result := results[idx]
-
-		color.White("Downloading [%d/%d]: %s\n", successCount+1, len(selectedIndices), result.Title)
-
-		// Generate filename from paper ID
-		filename := sanitizeFilename(result.Title)
-		if filename == "" {
-			filename = result.ID
-		}
-		filename = filename + ".pdf"
-
-		// Download to temporary location first
-		downloadResp, err := client.DownloadPaper(result.PDFURL, filename)
-		if err != nil {
-			color.Red("  ✗ Failed: %v\n", err)
-			continue
+	// Download selected papers, keeping the order they were listed in
+	selected := make([]search.SearchResult, 0, len(selectedIndices))
+	for i, result := range results {
+		if selectedIndices[i] {
+			selected = append(selected, result)
 		}
+	}
 
-		// Move from temp location to lib directory
-		// The Python service downloads to /tmp/archivist_downloads
-		tempPath := filepath.Join("/tmp/archivist_downloads", downloadResp.Filename)
-		finalPath := filepath.Join(libDir, downloadResp.Filename)
+	color.Cyan("\n📥 Downloading %d papers to %s...\n\n", len(selected), libDir)
 
-		if err := os.Rename(tempPath, finalPath); err != nil {
-			// If rename fails, try copying
-			if err := copyFile(tempPath, finalPath); err != nil {
-				color.Red("  ✗ Failed to move file: %v\n", err)
-				continue
+	successCount := 0
+	events := search.DownloadResults(context.Background(), selected, libDir, search.DefaultDownloadConcurrency)
+	for event := range events {
+		switch event.Kind {
+		case search.DownloadStarted:
+			if event.Attempt == 1 {
+				color.White("  ↓ %s\n", event.Result.Title)
 			}
-			os.Remove(tempPath)
+		case search.DownloadRetrying:
+			color.Yellow("  ↻ Retrying %s (attempt %d failed: %v)\n", event.Result.Title, event.Attempt, event.Err)
+		case search.DownloadCompleted:
+			color.Green("  ✓ Downloaded: %s (%.2f MB)\n", filepath.Base(event.Path), float64(event.BytesDone)/(1024*1024))
+			successCount++
+		case search.DownloadSkipped:
+			color.Yellow("  • Already in library: %s\n", filepath.Base(event.Path))
+			successCount++
+		case search.DownloadFailed:
+			color.Red("  ✗ Failed: %s: %v\n", event.Result.Title, event.Err)
 		}
-
-		color.Green("  ✓ Downloaded: %s (%.2f MB)\n", downloadResp.Filename, float64(downloadResp.SizeBytes)/(1024*1024))
-		successCount++
 	}
 
-	color.Green("\n✓ Successfully downloaded %d/%d papers\n", successCount, len(selectedIndices))
+	color.Green("\n✓ Successfully downloaded %d/%d papers\n", successCount, len(selected))
 
 	return nil
 }
 
-func sanitizeFilename(filename string) string {
-	invalid := []string{"/", "\\", ":", "*", "?", "\"", "<", ">", "|", "\n", "\r", "\t"}
-	for _, char := range invalid {
-		filename = strings.ReplaceAll(filename, char, "_")
-	}
-	filename = strings.TrimSpace(filename)
-	filename = strings.Trim(filename, ".")
-
-	if len(filename) > 200 {
-		filename = filename[:200]
-	}
-
-	return filename
-}
-
-func copyFile(src, dst string) error {
-	input, err := os.ReadFile(src)
-	if err != nil {
-		return err
-	}
-	return os.WriteFile(dst, input, 0644)
-}
-
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -80,7 +80,7 @@ func runSetup(skipServices, autoYes bool) error {
 	cmdExec.Stdin = os.Stdin
 
 	fmt.Println("\n🚀 Starting bootstrap process...")
-	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+	fmt.Print("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n\n")
 
 	startTime := time.Now()
 
@@ -8,7 +8,6 @@ import (
 
 	"archivist/internal/analyzer"
 	"archivist/internal/app"
-	"archivist/internal/search"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -39,7 +38,7 @@ Start it with: cd services/search-engine && python run.py`,
 	}
 
 	cmd.Flags().IntVarP(&similarMaxResults, "max-results", "n", 10, "Maximum number of similar papers to find")
-	cmd.Flags().StringVar(&similarServiceURL, "service-url", "http://localhost:8000", "Search service URL")
+	cmd.Flags().StringVar(&similarServiceURL, "service-url", "", "Search service URL (default: search.base_url from config)")
 	cmd.Flags().BoolVarP(&similarDownload, "download", "d", false, "Download similar papers to lib/")
 
 	return cmd
@@ -82,8 +81,13 @@ func runSimilar(cmd *cobra.Command, args []string) error {
 	}
 	defer analyzerInstance.Close()
 
+	serviceURL := similarServiceURL
+	if serviceURL == "" {
+		serviceURL = config.Search.BaseURL
+	}
+
 	// Create similar paper finder
-	finder := analyzer.NewSimilarPaperFinder(analyzerInstance, similarServiceURL)
+	finder := analyzer.NewSimilarPaperFinder(analyzerInstance, serviceURL)
 
 	// Print header
 	color.Cyan("\n🔍 Finding Similar Papers\n")
@@ -124,8 +128,7 @@ func runSimilar(cmd *cobra.Command, args []string) error {
 
 	// Offer to download if flag is set
 	if similarDownload {
-		searchClient := search.NewClient(similarServiceURL)
-		return handleDownload(searchClient, results.Results, config.InputDir)
+		return handleDownload(results.Results, config.InputDir)
 	}
 
 	return nil
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/storage"
+	"archivist/internal/ui"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewStatsCommand creates the stats command
+func NewStatsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Summarize the processing history",
+		Long:  "Show aggregate metrics for every paper in the metadata store: success rate, run times, token usage and mode breakdown",
+		Args:  cobra.NoArgs,
+		Run:   runStats,
+	}
+}
+
+func runStats(cmd *cobra.Command, args []string) {
+	ui.ShowBanner()
+
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
+		os.Exit(1)
+	}
+
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to open metadata store: %v", err))
+		os.Exit(1)
+	}
+	defer metadataStore.Close()
+
+	stats := storage.ComputeStats(metadataStore.GetAllRecords())
+
+	ui.ColorBold.Println("═══════════════════════════════════════════════════════════════")
+	ui.ColorBold.Println("                    LIBRARY STATISTICS                         ")
+	ui.ColorBold.Println("═══════════════════════════════════════════════════════════════")
+	fmt.Println()
+
+	if stats.Total == 0 {
+		ui.PrintWarning("No papers have been processed yet")
+		return
+	}
+
+	ui.ColorInfo.Printf("📚 Total papers:   %d\n", stats.Total)
+	ui.ColorSuccess.Printf("✅ Completed:      %d (%.1f%%)\n", stats.Completed, percent(stats.Completed, stats.Total))
+	if stats.Failed > 0 {
+		ui.ColorError.Printf("❌ Failed:         %d (%.1f%%)\n", stats.Failed, percent(stats.Failed, stats.Total))
+	} else {
+		ui.ColorSubtle.Println("❌ Failed:         0")
+	}
+	if stats.Processing > 0 {
+		ui.ColorWarning.Printf("🔄 In progress:    %d (or interrupted)\n", stats.Processing)
+	}
+	fmt.Println()
+
+	if stats.TimedRuns > 0 {
+		ui.ColorBold.Println("⏱️  Processing time")
+		fmt.Printf("   Average:  %s\n", stats.AverageRuntime.Round(100*time.Millisecond))
+		fmt.Printf("   Median:   %s\n", stats.MedianRuntime.Round(100*time.Millisecond))
+		ui.ColorSubtle.Printf("   (from %d timed run(s))\n", stats.TimedRuns)
+		fmt.Println()
+	}
+
+	if stats.PromptTokens > 0 || stats.OutputTokens > 0 {
+		ui.ColorBold.Println("🔢 Tokens")
+		fmt.Printf("   Prompt:   %d\n", stats.PromptTokens)
+		fmt.Printf("   Output:   %d\n", stats.OutputTokens)
+		fmt.Printf("   Total:    %d\n", stats.PromptTokens+stats.OutputTokens)
+		fmt.Println()
+	}
+
+	if !stats.Oldest.IsZero() {
+		ui.ColorBold.Println("🕒 History")
+		fmt.Printf("   Oldest:   %s\n", stats.Oldest.Local().Format("2006-01-02 15:04"))
+		fmt.Printf("   Newest:   %s\n", stats.Newest.Local().Format("2006-01-02 15:04"))
+		fmt.Println()
+	}
+
+	if failures, err := unresolvedFailures(config); err == nil && len(failures) > 0 {
+		counts := storage.CountByCategory(failures)
+		categories := make([]string, 0, len(counts))
+		for category := range counts {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+
+		ui.ColorBold.Println("📕 Unresolved failures by category")
+		for _, category := range categories {
+			fmt.Printf("   %-12s %d\n", category+":", counts[category])
+		}
+		ui.ColorSubtle.Println("   (details: rph list --failed)")
+		fmt.Println()
+	}
+
+	if len(stats.ByMode) > 0 {
+		modes := make([]string, 0, len(stats.ByMode))
+		for mode := range stats.ByMode {
+			modes = append(modes, mode)
+		}
+		sort.Strings(modes)
+
+		ui.ColorBold.Println("⚙️  Completed by mode")
+		for _, mode := range modes {
+			fmt.Printf("   %-10s %d\n", mode+":", stats.ByMode[mode])
+		}
+		fmt.Println()
+	}
+}
+
+// percent returns part as a percentage of total
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
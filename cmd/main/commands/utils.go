@@ -12,3 +12,11 @@ func fileExists(path string) bool {
 	}
 	return err == nil && !info.IsDir()
 }
+
+// shortHash abbreviates a file hash for display
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/spf13/cobra"
+)
+
+// Build information, injected at build time with
+//
+//	go build -ldflags "-X archivist/cmd/main/commands.Version=v1.2.0 \
+//	  -X archivist/cmd/main/commands.Commit=$(git rev-parse --short HEAD) \
+//	  -X archivist/cmd/main/commands.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildDate = "unknown"
+)
+
+// NewVersionCommand creates the version command
+func NewVersionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Show build version information",
+		Long:  "Print the version, git commit, build date and Go version of this build. Include this when reporting issues.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Print(versionInfo())
+		},
+	}
+}
+
+// versionInfo formats the build information, one field per line
+func versionInfo() string {
+	return fmt.Sprintf("rph %s\n  commit:     %s\n  built:      %s\n  go version: %s\n  platform:   %s/%s\n",
+		Version, Commit, BuildDate, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
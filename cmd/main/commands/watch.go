@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/compiler"
+	"archivist/internal/storage"
+	"archivist/internal/ui"
+	"archivist/internal/worker"
+	"archivist/pkg/fileutil"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+)
+
+var watchDebounce time.Duration
+
+// NewWatchCommand creates the watch command
+func NewWatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch the library and auto-process new PDFs",
+		Long: `Monitor the input directory and automatically process PDFs as they appear.
+
+Events are debounced so files that are written and then renamed (as browsers
+do when downloading) are only processed once they are complete. Papers that
+have already been processed are skipped. Press Ctrl+C to stop.
+
+Examples:
+  rph watch                     # Watch the configured input directory
+  rph watch --debounce 5s       # Wait longer for slow downloads`,
+		Args: cobra.NoArgs,
+		Run:  runWatch,
+	}
+
+	cmd.Flags().DurationVar(&watchDebounce, "debounce", 2*time.Second, "quiet period before a new file is processed")
+
+	return cmd
+}
+
+func runWatch(cmd *cobra.Command, args []string) {
+	ui.ShowBanner()
+
+	config, err := app.LoadConfig(ConfigPath)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to load config: %v", err))
+		os.Exit(1)
+	}
+
+	logCleanup, err := app.InitLogger(config)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to initialize logger: %v", err))
+		os.Exit(1)
+	}
+	defer logCleanup()
+
+	applyModeConfig(config, ui.ModeFast)
+
+	if err := compiler.CheckDependencies(config.Latex.Engine == "latexmk", config.Latex.Compiler); err != nil {
+		ui.PrintError(fmt.Sprintf("Dependency check failed: %v", err))
+		os.Exit(1)
+	}
+
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to open metadata store: %v", err))
+		os.Exit(1)
+	}
+	defer metadataStore.Close()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to create file watcher: %v", err))
+		os.Exit(1)
+	}
+	defer watcher.Close()
+
+	if err := addWatchDirs(watcher, config.InputDir); err != nil {
+		ui.PrintError(fmt.Sprintf("Failed to watch %s: %v", config.InputDir, err))
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ui.PrintSuccess(fmt.Sprintf("Watching %s for new PDFs (Ctrl+C to stop)", config.InputDir))
+
+	// Timers are only touched from this goroutine; they signal via ready
+	pending := make(map[string]*time.Timer)
+	ready := make(chan string, 16)
+
+	for {
+		select {
+		case <-ctx.Done():
+			for _, timer := range pending {
+				timer.Stop()
+			}
+			fmt.Println()
+			ui.PrintInfo("Stopped watching")
+			return
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+				continue
+			}
+
+			if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+				if event.Has(fsnotify.Create) {
+					if err := addWatchDirs(watcher, event.Name); err != nil {
+						log.Printf("⚠️  Failed to watch new directory %s: %v", event.Name, err)
+					}
+				}
+				continue
+			}
+
+			if strings.ToLower(filepath.Ext(event.Name)) != ".pdf" {
+				continue
+			}
+
+			path := event.Name
+			if timer, ok := pending[path]; ok {
+				timer.Stop()
+			}
+			pending[path] = time.AfterFunc(watchDebounce, func() { ready <- path })
+
+		case path := <-ready:
+			delete(pending, path)
+			processWatchedFile(ctx, path, config, metadataStore)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("⚠️  Watcher error: %v", err)
+		}
+	}
+}
+
+// addWatchDirs watches dir and all of its subdirectories
+func addWatchDirs(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+// processWatchedFile processes a newly settled PDF unless it's incomplete or already processed
+func processWatchedFile(ctx context.Context, path string, config *app.Config, metadataStore storage.Store) {
+	complete, err := fileutil.IsCompletePDF(path)
+	if err != nil {
+		// Usually the file was renamed or removed before the debounce fired
+		log.Printf("⚠️  Skipping %s: %v", path, err)
+		return
+	}
+	if !complete {
+		ui.PrintWarning(fmt.Sprintf("Skipping incomplete or invalid PDF: %s", filepath.Base(path)))
+		return
+	}
+
+	hash, err := fileutil.ComputeFileHash(path)
+	if err == nil && metadataStore.IsProcessed(hash) {
+		ui.PrintInfo(fmt.Sprintf("Already processed, skipping: %s", filepath.Base(path)))
+		return
+	}
+
+	fmt.Println()
+	ui.PrintStage("New Paper Detected", filepath.Base(path))
+	opts := worker.BatchOptions{NonInteractive: true}
+	if err := worker.ProcessBatchWithOptions(ctx, []string{path}, config, opts); err != nil {
+		ui.PrintError(fmt.Sprintf("Processing failed: %v", err))
+	}
+
+	ui.PrintInfo(fmt.Sprintf("Watching %s for new PDFs (Ctrl+C to stop)", config.InputDir))
+}
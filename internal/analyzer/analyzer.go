@@ -11,26 +11,37 @@ import (
 )
 
 type Analyzer struct {
-	client *GeminiClient
-	config *app.Config
+	client  LLMClient
+	config  *app.Config
+	backoff retryBackoff
+	limiter *RateLimiter // Shared with the other workers; nil means unlimited
+
+	stageUsage TokenUsage // Usage from short-lived per-stage clients
 }
 
 // NewAnalyzer creates a new analyzer
 func NewAnalyzer(config *app.Config) (*Analyzer, error) {
-	client, err := NewGeminiClient(
-		config.Gemini.APIKey,
-		config.Gemini.Model,
-		config.Gemini.Temperature,
-		config.Gemini.MaxTokens,
-	)
+	client, err := NewLLMClient(config, config.Gemini.Model, config.Gemini.Temperature)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
+		return nil, fmt.Errorf("failed to create LLM client: %w", err)
 	}
 
+	return NewAnalyzerWithClient(config, client), nil
+}
+
+// NewAnalyzerWithClient creates an analyzer backed by an existing LLM client
+func NewAnalyzerWithClient(config *app.Config, client LLMClient) *Analyzer {
 	return &Analyzer{
-		client: client,
-		config: config,
-	}, nil
+		client:  client,
+		config:  config,
+		backoff: newRetryBackoff(config.Gemini.Agentic.Retry.BackoffMultiplier, config.Gemini.Agentic.Retry.InitialDelayMs),
+	}
+}
+
+// SetRateLimiter makes every API call of this analyzer wait for limiter first.
+// Pass the same limiter to all analyzers of a batch to share one budget.
+func (a *Analyzer) SetRateLimiter(limiter *RateLimiter) {
+	a.limiter = limiter
 }
 
 // Close closes the analyzer
@@ -38,8 +49,54 @@ func (a *Analyzer) Close() error {
 	return a.client.Close()
 }
 
-// GetClient returns the underlying Gemini client
-func (a *Analyzer) GetClient() *GeminiClient {
+// retryAttempts returns the configured retry attempts, or fallback if unset
+func (a *Analyzer) retryAttempts(fallback int) int {
+	if a.config.Gemini.Agentic.Retry.MaxAttempts > 0 {
+		return a.config.Gemini.Agentic.Retry.MaxAttempts
+	}
+	return fallback
+}
+
+// generateTextRetry calls GenerateText on client with the analyzer's backoff
+func (a *Analyzer) generateTextRetry(ctx context.Context, client LLMClient, prompt string, maxAttempts int) (string, error) {
+	return a.backoff.run(ctx, maxAttempts, func() (string, error) {
+		if err := a.limiter.Wait(ctx); err != nil {
+			return "", err
+		}
+		return client.GenerateText(ctx, prompt)
+	})
+}
+
+// analyzePDFRetry calls AnalyzePDFWithVision on client with the analyzer's backoff
+func (a *Analyzer) analyzePDFRetry(ctx context.Context, client LLMClient, pdfPath, prompt string, maxAttempts int) (string, error) {
+	return a.backoff.run(ctx, maxAttempts, func() (string, error) {
+		if err := a.limiter.Wait(ctx); err != nil {
+			return "", err
+		}
+		return client.AnalyzePDFWithVision(ctx, pdfPath, prompt)
+	})
+}
+
+// AnalyzePDFWithVision sends pdfPath with prompt once, waiting for the rate limiter.
+// Together with AnalyzePDFWithVisionRetry it lets an Analyzer back a parser.PDFParser.
+func (a *Analyzer) AnalyzePDFWithVision(ctx context.Context, pdfPath, prompt string) (string, error) {
+	return a.analyzePDFRetry(ctx, a.client, pdfPath, prompt, 1)
+}
+
+// AnalyzePDFWithVisionRetry is AnalyzePDFWithVision with up to maxAttempts tries
+func (a *Analyzer) AnalyzePDFWithVisionRetry(ctx context.Context, pdfPath, prompt string, maxAttempts int) (string, error) {
+	return a.analyzePDFRetry(ctx, a.client, pdfPath, prompt, maxAttempts)
+}
+
+// TokenUsage returns the LLM tokens consumed by this analyzer so far
+func (a *Analyzer) TokenUsage() TokenUsage {
+	usage := clientUsage(a.client)
+	usage.Add(a.stageUsage)
+	return usage
+}
+
+// GetClient returns the underlying LLM client
+func (a *Analyzer) GetClient() LLMClient {
 	return a.client
 }
 
@@ -57,11 +114,11 @@ func (a *Analyzer) AnalyzePaper(ctx context.Context, pdfPath string) (string, er
 // simplAnalysis performs a single-stage analysis
 func (a *Analyzer) simplAnalysis(ctx context.Context, pdfPath string) (string, error) {
 	log.Println("     📝 Using simple analysis workflow (single API call)")
-	log.Printf("     → Calling Gemini API (%s)...", a.config.Gemini.Model)
+	log.Printf("     → Calling %s API (%s)...", llmProviderName(a.config), llmModelName(a.config, a.config.Gemini.Model))
 	startTime := time.Now()
 
-	// Use retry logic with up to 5 attempts
-	latexContent, err := a.client.AnalyzePDFWithVisionRetry(ctx, pdfPath, AnalysisPrompt, 5)
+	// Retry rate-limit and server errors using the configured backoff
+	latexContent, err := a.analyzePDFRetry(ctx, a.client, pdfPath, AnalysisPrompt, a.retryAttempts(5))
 	if err != nil {
 		return "", fmt.Errorf("analysis failed: %w", err)
 	}
@@ -81,20 +138,16 @@ func (a *Analyzer) agenticAnalysis(ctx context.Context, pdfPath string) (string,
 	log.Println("     🔬 Stage 1: Initial deep analysis")
 	stage1Start := time.Now()
 	stage1Config := a.config.Gemini.Agentic.Stages.MethodologyAnalysis
-	stage1Client, err := NewGeminiClient(
-		a.config.Gemini.APIKey,
-		stage1Config.Model,
-		stage1Config.Temperature,
-		a.config.Gemini.MaxTokens,
-	)
+	stage1Client, err := NewLLMClient(a.config, stage1Config.Model, stage1Config.Temperature)
 	if err != nil {
 		return "", fmt.Errorf("failed to create stage 1 client: %w", err)
 	}
 	defer stage1Client.Close()
 
-	log.Printf("     → Calling Gemini API (%s) for paper analysis...", stage1Config.Model)
-	// Use retry logic with up to 5 attempts
-	latexContent, err = stage1Client.AnalyzePDFWithVisionRetry(ctx, pdfPath, AnalysisPrompt, 5)
+	log.Printf("     → Calling %s API (%s) for paper analysis...", llmProviderName(a.config), llmModelName(a.config, stage1Config.Model))
+	// Retry rate-limit and server errors using the configured backoff
+	latexContent, err = a.analyzePDFRetry(ctx, stage1Client, pdfPath, AnalysisPrompt, a.retryAttempts(5))
+	a.stageUsage.Add(clientUsage(stage1Client))
 	if err != nil {
 		return "", fmt.Errorf("stage 1 analysis failed: %w", err)
 	}
@@ -123,8 +176,8 @@ If it's already excellent, output: APPROVED
 
 Output:`, latexContent)
 
-			// Use retry logic with up to 3 attempts for reflection
-			reflection, err := a.client.GenerateTextRetry(ctx, reflectionPrompt, 3)
+			// Retry transient errors (configured attempts, default 3)
+			reflection, err := a.generateTextRetry(ctx, a.client, reflectionPrompt, a.retryAttempts(3))
 			if err != nil {
 				log.Printf("       ⚠️  Reflection iteration %d failed: %v (continuing with current version)", i+1, err)
 				break
@@ -148,7 +201,7 @@ Output:`, latexContent)
 	}
 
 	// Stage 3: Syntax validation after self-reflection
-	log.Println("     🔍 Stage 3: Syntax validation (Gemini API)")
+	log.Printf("     🔍 Stage 3: Syntax validation (%s API)", llmProviderName(a.config))
 	stage3Start := time.Now()
 	validatedContent, err := a.validateLatexSyntax(ctx, latexContent)
 	if err != nil {
@@ -161,14 +214,14 @@ Output:`, latexContent)
 	return latexContent, nil
 }
 
-// validateLatexSyntax performs Gemini-based syntax validation only
+// validateLatexSyntax performs LLM-based syntax validation only
 func (a *Analyzer) validateLatexSyntax(ctx context.Context, latexContent string) (string, error) {
-	log.Println("     → Calling Gemini API for syntax-only validation...")
+	log.Printf("     → Calling %s API for syntax-only validation...", llmProviderName(a.config))
 
 	validationPrompt := fmt.Sprintf(SyntaxValidationPrompt, latexContent)
 
-	// Use retry logic with up to 3 attempts for validation
-	result, err := a.client.GenerateTextRetry(ctx, validationPrompt, 3)
+	// Retry transient errors (configured attempts, default 3)
+	result, err := a.generateTextRetry(ctx, a.client, validationPrompt, a.retryAttempts(3))
 	if err != nil {
 		return latexContent, fmt.Errorf("syntax validation API call failed: %w", err)
 	}
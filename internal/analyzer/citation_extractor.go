@@ -67,7 +67,7 @@ Be thorough. Extract ALL cited papers, even if only mentioned once.`
 
 	startTime := time.Now()
 	// Use retry logic with up to 5 attempts
-	result, err := ce.analyzer.client.AnalyzePDFWithVisionRetry(ctx, pdfPath, prompt, 5)
+	result, err := ce.analyzer.analyzePDFRetry(ctx, ce.analyzer.client, pdfPath, prompt, 5)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract citations: %w", err)
 	}
@@ -210,7 +210,7 @@ Output format:
 Be accurate - count all citations, including in-text citations and reference mentions.`, strings.Join(titles, "\n"))
 
 	// Use retry logic with up to 3 attempts
-	result, err := ce.analyzer.client.AnalyzePDFWithVisionRetry(ctx, pdfPath, countPrompt, 3)
+	result, err := ce.analyzer.analyzePDFRetry(ctx, ce.analyzer.client, pdfPath, countPrompt, 3)
 	if err != nil {
 		log.Printf("Warning: Could not enrich with citation counts: %v", err)
 		return citations
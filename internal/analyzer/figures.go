@@ -0,0 +1,199 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"image/png"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Figure is an image extracted from a paper
+type Figure struct {
+	Path    string // PNG file in the figures directory
+	Page    int
+	Width   int
+	Height  int
+	Caption string // Set for figures chosen by ExtractKeyFigures
+}
+
+// figureRef is a figure the model considers important
+type figureRef struct {
+	Page    int
+	Caption string
+}
+
+// pdfImagesCommand is poppler's image extractor (poppler-utils)
+const pdfImagesCommand = "pdfimages"
+
+// Figure selection limits
+const (
+	maxKeyFigures    = 3
+	minFigureSide    = 200 // Pixels; smaller images are usually logos or icons
+	figureFilePrefix = "img"
+)
+
+// figureFileRegex matches pdfimages -p output names: img-<page>-<index>.png
+var figureFileRegex = regexp.MustCompile(`^` + figureFilePrefix + `-(\d+)-(\d+)\.png$`)
+
+// figureLineRegex matches a FIGURE line of the KeyFiguresPrompt response
+var figureLineRegex = regexp.MustCompile(`(?i)^FIGURE:\s*\[?(\d+)\]?\s*\|\s*(.+)$`)
+
+// ExtractKeyFigures pulls the embedded images out of pdfPath into outDir and
+// asks the model which ones matter. Only the chosen figures are kept, in the
+// order the model ranked them.
+func (a *Analyzer) ExtractKeyFigures(ctx context.Context, pdfPath, outDir string) ([]Figure, error) {
+	log.Println("     🖼️  Extracting figures...")
+	startTime := time.Now()
+
+	figures, err := ExtractFigures(ctx, pdfPath, outDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(figures) == 0 {
+		log.Println("     ✓ No figures large enough to embed")
+		return nil, nil
+	}
+
+	prompt := fmt.Sprintf(KeyFiguresPrompt, maxKeyFigures)
+	response, err := a.analyzePDFRetry(ctx, a.client, pdfPath, prompt, a.retryAttempts(3))
+	if err != nil {
+		return nil, fmt.Errorf("failed to identify key figures: %w", err)
+	}
+
+	selected := selectKeyFigures(figures, parseFigureRefs(response))
+
+	// Drop the images that won't be referenced
+	keep := make(map[string]bool, len(selected))
+	for _, figure := range selected {
+		keep[figure.Path] = true
+	}
+	for _, figure := range figures {
+		if !keep[figure.Path] {
+			os.Remove(figure.Path)
+		}
+	}
+
+	log.Printf("     ✓ Selected %d of %d figures (%.2fs)", len(selected), len(figures), time.Since(startTime).Seconds())
+	return selected, nil
+}
+
+// ExtractFigures writes every embedded image of pdfPath to outDir as PNG and
+// returns those at least minFigureSide pixels on each side, by page. Smaller
+// images are deleted.
+func ExtractFigures(ctx context.Context, pdfPath, outDir string) ([]Figure, error) {
+	if _, err := exec.LookPath(pdfImagesCommand); err != nil {
+		return nil, fmt.Errorf("%s not found (install poppler-utils): %w", pdfImagesCommand, err)
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create figures directory: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, pdfImagesCommand, "-png", "-p", pdfPath, filepath.Join(outDir, figureFilePrefix))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("%s failed: %w: %s", pdfImagesCommand, err, strings.TrimSpace(string(output)))
+	}
+
+	return collectFigures(outDir)
+}
+
+// collectFigures reads the images pdfimages wrote to dir
+func collectFigures(dir string) ([]Figure, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read figures directory: %w", err)
+	}
+
+	var figures []Figure
+	for _, entry := range entries {
+		match := figureFileRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+
+		width, height, err := pngSize(path)
+		if err != nil || width < minFigureSide || height < minFigureSide {
+			os.Remove(path)
+			continue
+		}
+
+		page, _ := strconv.Atoi(match[1])
+		figures = append(figures, Figure{Path: path, Page: page, Width: width, Height: height})
+	}
+
+	sort.SliceStable(figures, func(i, j int) bool { return figures[i].Page < figures[j].Page })
+	return figures, nil
+}
+
+// pngSize returns the dimensions of a PNG without decoding the pixels
+func pngSize(path string) (int, int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	config, err := png.DecodeConfig(f)
+	if err != nil {
+		return 0, 0, err
+	}
+	return config.Width, config.Height, nil
+}
+
+// parseFigureRefs reads the FIGURE lines of a KeyFiguresPrompt response
+func parseFigureRefs(response string) []figureRef {
+	var refs []figureRef
+	for _, line := range strings.Split(response, "\n") {
+		match := figureLineRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		page, err := strconv.Atoi(match[1])
+		if err != nil || page < 1 {
+			continue
+		}
+		caption := strings.Trim(strings.TrimSpace(match[2]), "[]")
+		refs = append(refs, figureRef{Page: page, Caption: strings.TrimSpace(caption)})
+		if len(refs) == maxKeyFigures {
+			break
+		}
+	}
+	return refs
+}
+
+// selectKeyFigures picks, for each reference, the largest unused image on
+// its page. References to pages without images are skipped.
+func selectKeyFigures(figures []Figure, refs []figureRef) []Figure {
+	used := make(map[string]bool)
+	var selected []Figure
+
+	for _, ref := range refs {
+		best := -1
+		for i, figure := range figures {
+			if figure.Page != ref.Page || used[figure.Path] {
+				continue
+			}
+			if best == -1 || figure.Width*figure.Height > figures[best].Width*figures[best].Height {
+				best = i
+			}
+		}
+		if best == -1 {
+			continue
+		}
+
+		figure := figures[best]
+		figure.Caption = ref.Caption
+		used[figure.Path] = true
+		selected = append(selected, figure)
+	}
+
+	return selected
+}
@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePNG(t *testing.T, path string, width, height int) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, png.Encode(f, image.NewGray(image.Rect(0, 0, width, height))))
+}
+
+func TestCollectFiguresDropsSmallImages(t *testing.T) {
+	dir := t.TempDir()
+	writePNG(t, filepath.Join(dir, "img-003-001.png"), 800, 600)
+	writePNG(t, filepath.Join(dir, "img-001-000.png"), 64, 64) // Logo
+	writePNG(t, filepath.Join(dir, "img-001-002.png"), 400, 300)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("x"), 0644))
+
+	figures, err := collectFigures(dir)
+	require.NoError(t, err)
+
+	require.Len(t, figures, 2)
+	assert.Equal(t, Figure{Path: filepath.Join(dir, "img-001-002.png"), Page: 1, Width: 400, Height: 300}, figures[0])
+	assert.Equal(t, 3, figures[1].Page)
+	assert.NoFileExists(t, filepath.Join(dir, "img-001-000.png"))
+	assert.FileExists(t, filepath.Join(dir, "notes.txt"))
+}
+
+func TestParseFigureRefs(t *testing.T) {
+	response := `Here are the figures:
+FIGURE: 3 | Overview of the Transformer architecture
+figure: [5] | [BLEU scores against prior models]
+FIGURE: page two | Not a number
+FIGURE: 7 | Attention heads
+FIGURE: 9 | One too many`
+
+	refs := parseFigureRefs(response)
+
+	assert.Equal(t, []figureRef{
+		{Page: 3, Caption: "Overview of the Transformer architecture"},
+		{Page: 5, Caption: "BLEU scores against prior models"},
+		{Page: 7, Caption: "Attention heads"},
+	}, refs)
+}
+
+func TestSelectKeyFigures(t *testing.T) {
+	figures := []Figure{
+		{Path: "small-p3", Page: 3, Width: 300, Height: 300},
+		{Path: "large-p3", Page: 3, Width: 900, Height: 600},
+		{Path: "p5", Page: 5, Width: 500, Height: 400},
+	}
+	refs := []figureRef{
+		{Page: 3, Caption: "Architecture"},
+		{Page: 4, Caption: "No image on this page"},
+		{Page: 3, Caption: "Second figure on page 3"},
+		{Page: 5, Caption: "Results"},
+	}
+
+	selected := selectKeyFigures(figures, refs)
+
+	require.Len(t, selected, 3)
+	assert.Equal(t, "large-p3", selected[0].Path)
+	assert.Equal(t, "Architecture", selected[0].Caption)
+	assert.Equal(t, "small-p3", selected[1].Path)
+	assert.Equal(t, "p5", selected[2].Path)
+	assert.Empty(t, figures[0].Caption, "selection must not modify the input")
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -16,6 +18,10 @@ type GeminiClient struct {
 	model       string
 	temperature float64
 	maxTokens   int
+	backoff     retryBackoff
+
+	usageMu sync.Mutex
+	usage   TokenUsage // Accumulated across all successful calls
 }
 
 // NewGeminiClient creates a new Gemini API client
@@ -32,9 +38,38 @@ func NewGeminiClient(apiKey, model string, temperature float64, maxTokens int) (
 		model:       model,
 		temperature: temperature,
 		maxTokens:   maxTokens,
+		backoff:     newRetryBackoff(0, 0),
 	}, nil
 }
 
+// SetRetryBackoff configures the exponential backoff used by the *Retry methods.
+// Non-positive values keep the defaults.
+func (gc *GeminiClient) SetRetryBackoff(multiplier int, initialDelayMs int) {
+	gc.backoff = newRetryBackoff(multiplier, initialDelayMs)
+}
+
+// Usage returns the tokens consumed by this client so far
+func (gc *GeminiClient) Usage() TokenUsage {
+	gc.usageMu.Lock()
+	defer gc.usageMu.Unlock()
+	return gc.usage
+}
+
+// recordUsage adds a response's usage metadata to the running total
+func (gc *GeminiClient) recordUsage(resp *genai.GenerateContentResponse) {
+	if resp == nil || resp.UsageMetadata == nil {
+		return
+	}
+
+	gc.usageMu.Lock()
+	defer gc.usageMu.Unlock()
+	gc.usage.Add(TokenUsage{
+		PromptTokens: int(resp.UsageMetadata.PromptTokenCount),
+		OutputTokens: int(resp.UsageMetadata.CandidatesTokenCount),
+		TotalTokens:  int(resp.UsageMetadata.TotalTokenCount),
+	})
+}
+
 // Close closes the Gemini client
 func (gc *GeminiClient) Close() error {
 	return gc.client.Close()
@@ -52,6 +87,7 @@ func (gc *GeminiClient) GenerateText(ctx context.Context, prompt string) (string
 	if err != nil {
 		return "", fmt.Errorf("failed to generate content: %w", err)
 	}
+	gc.recordUsage(resp)
 
 	if len(resp.Candidates) == 0 {
 		return "", fmt.Errorf("no candidates returned")
@@ -72,6 +108,47 @@ func (gc *GeminiClient) GenerateText(ctx context.Context, prompt string) (string
 	return result, nil
 }
 
+// GenerateTextStream generates text from a prompt, calling onChunk with each
+// piece of the response as it arrives. It returns the full response.
+func (gc *GeminiClient) GenerateTextStream(ctx context.Context, prompt string, onChunk func(string)) (string, error) {
+	model := gc.client.GenerativeModel(gc.model)
+	model.SetTemperature(float32(gc.temperature))
+	model.SetMaxOutputTokens(int32(gc.maxTokens))
+
+	iter := model.GenerateContentStream(ctx, genai.Text(prompt))
+
+	var result strings.Builder
+	var last *genai.GenerateContentResponse
+	for {
+		resp, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to stream content: %w", err)
+		}
+		last = resp
+
+		if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+			continue
+		}
+		for _, part := range resp.Candidates[0].Content.Parts {
+			if txt, ok := part.(genai.Text); ok && txt != "" {
+				result.WriteString(string(txt))
+				onChunk(string(txt))
+			}
+		}
+	}
+	// Usage metadata on the final chunk covers the whole response
+	gc.recordUsage(last)
+
+	if result.Len() == 0 {
+		return "", fmt.Errorf("no content in response")
+	}
+
+	return result.String(), nil
+}
+
 // AnalyzePDFWithVision analyzes a PDF using multimodal capabilities
 func (gc *GeminiClient) AnalyzePDFWithVision(ctx context.Context, pdfPath, prompt string) (string, error) {
 	model := gc.client.GenerativeModel(gc.model)
@@ -98,6 +175,7 @@ func (gc *GeminiClient) AnalyzePDFWithVision(ctx context.Context, pdfPath, promp
 	if err != nil {
 		return "", fmt.Errorf("failed to analyze PDF: %w", err)
 	}
+	gc.recordUsage(resp)
 
 	if len(resp.Candidates) == 0 {
 		return "", fmt.Errorf("no candidates returned")
@@ -139,84 +217,18 @@ func (gc *GeminiClient) GenerateWithRetry(ctx context.Context, prompt string, ma
 	return "", fmt.Errorf("failed after %d attempts: %w", maxAttempts, lastErr)
 }
 
-// GenerateTextRetry generates text with retry logic and exponential backoff
+// GenerateTextRetry generates text, retrying rate-limit and server errors with exponential backoff
 func (gc *GeminiClient) GenerateTextRetry(ctx context.Context, prompt string, maxAttempts int) (string, error) {
-	var lastErr error
-
-	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		result, err := gc.GenerateText(ctx, prompt)
-		if err == nil {
-			return result, nil
-		}
-
-		lastErr = err
-
-		// Check if it's a quota error
-		errStr := err.Error()
-		isQuotaError := strings.Contains(errStr, "quota") ||
-			strings.Contains(errStr, "QuotaFailure") ||
-			strings.Contains(errStr, "rate limit") ||
-			strings.Contains(errStr, "RESOURCE_EXHAUSTED")
-
-		if attempt < maxAttempts {
-			// Exponential backoff: 2, 4, 8, 16, 32, 64 seconds
-			delaySeconds := 1 << uint(attempt) // 2^attempt
-
-			// For quota errors, use longer delays (start at 48 seconds as shown in error)
-			if isQuotaError && delaySeconds < 48 {
-				delaySeconds = 48
-			}
-
-			delay := time.Duration(delaySeconds) * time.Second
-
-			fmt.Printf("⚠️  API call failed (attempt %d/%d): %v\n", attempt, maxAttempts, err)
-			fmt.Printf("   Retrying in %v...\n", delay)
-
-			time.Sleep(delay)
-		}
-	}
-
-	return "", fmt.Errorf("failed after %d attempts: %w", maxAttempts, lastErr)
+	return gc.backoff.run(ctx, maxAttempts, func() (string, error) {
+		return gc.GenerateText(ctx, prompt)
+	})
 }
 
-// AnalyzePDFWithVisionRetry analyzes a PDF using multimodal capabilities with retry logic
+// AnalyzePDFWithVisionRetry analyzes a PDF, retrying rate-limit and server errors with exponential backoff
 func (gc *GeminiClient) AnalyzePDFWithVisionRetry(ctx context.Context, pdfPath, prompt string, maxAttempts int) (string, error) {
-	var lastErr error
-
-	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		result, err := gc.AnalyzePDFWithVision(ctx, pdfPath, prompt)
-		if err == nil {
-			return result, nil
-		}
-
-		lastErr = err
-
-		// Check if it's a quota error
-		errStr := err.Error()
-		isQuotaError := strings.Contains(errStr, "quota") ||
-			strings.Contains(errStr, "QuotaFailure") ||
-			strings.Contains(errStr, "rate limit") ||
-			strings.Contains(errStr, "RESOURCE_EXHAUSTED")
-
-		if attempt < maxAttempts {
-			// Exponential backoff: 2, 4, 8, 16, 32, 64 seconds
-			delaySeconds := 1 << uint(attempt) // 2^attempt
-
-			// For quota errors, use longer delays (start at 48 seconds as shown in error)
-			if isQuotaError && delaySeconds < 48 {
-				delaySeconds = 48
-			}
-
-			delay := time.Duration(delaySeconds) * time.Second
-
-			fmt.Printf("⚠️  API call failed (attempt %d/%d): %v\n", attempt, maxAttempts, err)
-			fmt.Printf("   Retrying in %v...\n", delay)
-
-			time.Sleep(delay)
-		}
-	}
-
-	return "", fmt.Errorf("failed after %d attempts: %w", maxAttempts, lastErr)
+	return gc.backoff.run(ctx, maxAttempts, func() (string, error) {
+		return gc.AnalyzePDFWithVision(ctx, pdfPath, prompt)
+	})
 }
 
 // ListAvailableModels lists all available Gemini models
@@ -0,0 +1,89 @@
+package analyzer
+
+import (
+	"archivist/internal/app"
+	"context"
+	"fmt"
+)
+
+// LLMClient is the model backend used for paper analysis and chat.
+// GeminiClient and OpenAIClient both implement it.
+type LLMClient interface {
+	GenerateText(ctx context.Context, prompt string) (string, error)
+	AnalyzePDFWithVision(ctx context.Context, pdfPath, prompt string) (string, error)
+	Close() error
+}
+
+// StreamingLLMClient is implemented by clients that can deliver a response
+// incrementally. onChunk is called with each new piece of text in order.
+type StreamingLLMClient interface {
+	LLMClient
+	GenerateTextStream(ctx context.Context, prompt string, onChunk func(string)) (string, error)
+}
+
+// usageReporter is implemented by clients that track token usage
+type usageReporter interface {
+	Usage() TokenUsage
+}
+
+// TokenUsage counts tokens consumed by one or more API calls
+type TokenUsage struct {
+	PromptTokens int
+	OutputTokens int
+	TotalTokens  int
+}
+
+// Add accumulates other into u
+func (u *TokenUsage) Add(other TokenUsage) {
+	u.PromptTokens += other.PromptTokens
+	u.OutputTokens += other.OutputTokens
+	u.TotalTokens += other.TotalTokens
+}
+
+// NewLLMClient creates a client for the provider selected by llm.provider.
+// geminiModel and temperature apply to Gemini; OpenAI uses llm.openai.model.
+func NewLLMClient(config *app.Config, geminiModel string, temperature float64) (LLMClient, error) {
+	switch config.LLM.Provider {
+	case app.ProviderOpenAI:
+		return NewOpenAIClient(
+			config.LLM.OpenAI.APIKey,
+			config.LLM.OpenAI.BaseURL,
+			config.LLM.OpenAI.Model,
+			temperature,
+			config.Gemini.MaxTokens,
+		), nil
+	case app.ProviderGemini, "":
+		client, err := NewGeminiClient(config.Gemini.APIKey, geminiModel, temperature, config.Gemini.MaxTokens)
+		if err != nil {
+			return nil, err
+		}
+		client.SetRetryBackoff(config.Gemini.Agentic.Retry.BackoffMultiplier, config.Gemini.Agentic.Retry.InitialDelayMs)
+		return client, nil
+	default:
+		return nil, fmt.Errorf("unknown llm provider: %s", config.LLM.Provider)
+	}
+}
+
+// llmModelName returns the model NewLLMClient uses when asked for geminiModel
+func llmModelName(config *app.Config, geminiModel string) string {
+	if config.LLM.Provider == app.ProviderOpenAI {
+		return config.LLM.OpenAI.Model
+	}
+	return geminiModel
+}
+
+// llmProviderName returns the display name of the configured provider
+func llmProviderName(config *app.Config) string {
+	if config.LLM.Provider == app.ProviderOpenAI {
+		return "OpenAI"
+	}
+	return "Gemini"
+}
+
+// clientUsage returns a client's token usage, or zero if it doesn't track usage
+func clientUsage(client LLMClient) TokenUsage {
+	if reporter, ok := client.(usageReporter); ok {
+		return reporter.Usage()
+	}
+	return TokenUsage{}
+}
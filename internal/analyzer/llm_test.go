@@ -0,0 +1,20 @@
+package analyzer
+
+import (
+	"archivist/internal/app"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLLMModelName(t *testing.T) {
+	config := &app.Config{}
+	config.LLM.OpenAI.Model = "gpt-4o-mini"
+
+	assert.Equal(t, "models/gemini-2.0-flash", llmModelName(config, "models/gemini-2.0-flash"))
+	assert.Equal(t, "Gemini", llmProviderName(config))
+
+	config.LLM.Provider = app.ProviderOpenAI
+	assert.Equal(t, "gpt-4o-mini", llmModelName(config, "models/gemini-2.0-flash"))
+	assert.Equal(t, "OpenAI", llmProviderName(config))
+}
@@ -0,0 +1,54 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// AnalyzePaperMarkdown asks the model for a Markdown report instead of a
+// LaTeX document. It is a single call; the agentic stages only apply to LaTeX.
+func (a *Analyzer) AnalyzePaperMarkdown(ctx context.Context, pdfPath string) (string, error) {
+	log.Println("     📝 Generating Markdown report (single API call)")
+	startTime := time.Now()
+
+	// Retry rate-limit and server errors using the configured backoff
+	content, err := a.analyzePDFRetry(ctx, a.client, pdfPath, MarkdownAnalysisPrompt, a.retryAttempts(5))
+	if err != nil {
+		return "", fmt.Errorf("analysis failed: %w", err)
+	}
+
+	content = cleanMarkdownOutput(content)
+	if content == "" {
+		return "", fmt.Errorf("analysis failed: model returned an empty report")
+	}
+
+	log.Printf("     ✓ Analysis complete (%.2fs, %d chars generated)", time.Since(startTime).Seconds(), len(content))
+	return content, nil
+}
+
+// cleanMarkdownOutput removes a code fence wrapping the whole report and any
+// preamble the model wrote before the first heading
+func cleanMarkdownOutput(content string) string {
+	content = strings.TrimSpace(content)
+
+	if strings.HasPrefix(content, "```") {
+		if newline := strings.Index(content, "\n"); newline != -1 {
+			content = content[newline+1:]
+		} else {
+			content = ""
+		}
+		content = strings.TrimSuffix(strings.TrimSpace(content), "```")
+	}
+
+	if strings.HasPrefix(content, "# ") {
+		return strings.TrimSpace(content)
+	}
+	if start := strings.Index(content, "\n# "); start != -1 {
+		content = content[start+1:]
+	}
+
+	return strings.TrimSpace(content)
+}
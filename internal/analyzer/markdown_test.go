@@ -0,0 +1,42 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanMarkdownOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "plain report",
+			content: "# ResNet\n\n## Summary\nText\n",
+			want:    "# ResNet\n\n## Summary\nText",
+		},
+		{
+			name:    "wrapped in a code fence",
+			content: "```markdown\n# ResNet\n\n## Summary\n```python\nx = 1\n```\n```",
+			want:    "# ResNet\n\n## Summary\n```python\nx = 1\n```",
+		},
+		{
+			name:    "commentary before the title",
+			content: "Here is the report you asked for:\n\n# ResNet\nText",
+			want:    "# ResNet\nText",
+		},
+		{
+			name:    "no title heading",
+			content: "## Summary\nText",
+			want:    "## Summary\nText",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, cleanMarkdownOutput(tt.content))
+		})
+	}
+}
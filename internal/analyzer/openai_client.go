@@ -0,0 +1,172 @@
+package analyzer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultOpenAIBaseURL is used when llm.openai.base_url is not set
+const DefaultOpenAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIClient talks to the OpenAI Chat Completions API (or a compatible server)
+type OpenAIClient struct {
+	httpClient  *http.Client
+	apiKey      string
+	baseURL     string
+	model       string
+	temperature float64
+	maxTokens   int
+
+	usageMu sync.Mutex
+	usage   TokenUsage
+}
+
+// OpenAIError is a non-2xx response from the OpenAI API
+type OpenAIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *OpenAIError) Error() string {
+	return fmt.Sprintf("openai: status %d: %s", e.StatusCode, e.Message)
+}
+
+// NewOpenAIClient creates a new OpenAI API client
+func NewOpenAIClient(apiKey, baseURL, model string, temperature float64, maxTokens int) *OpenAIClient {
+	if baseURL == "" {
+		baseURL = DefaultOpenAIBaseURL
+	}
+
+	return &OpenAIClient{
+		httpClient:  &http.Client{},
+		apiKey:      apiKey,
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		model:       model,
+		temperature: temperature,
+		maxTokens:   maxTokens,
+	}
+}
+
+// Close is a no-op; the HTTP client holds no resources that need releasing
+func (oc *OpenAIClient) Close() error {
+	return nil
+}
+
+// Usage returns the tokens consumed by this client so far
+func (oc *OpenAIClient) Usage() TokenUsage {
+	oc.usageMu.Lock()
+	defer oc.usageMu.Unlock()
+	return oc.usage
+}
+
+// GenerateText generates text from a prompt
+func (oc *OpenAIClient) GenerateText(ctx context.Context, prompt string) (string, error) {
+	return oc.complete(ctx, []interface{}{
+		map[string]interface{}{"type": "text", "text": prompt},
+	})
+}
+
+// AnalyzePDFWithVision sends the PDF as a file input alongside the prompt
+func (oc *OpenAIClient) AnalyzePDFWithVision(ctx context.Context, pdfPath, prompt string) (string, error) {
+	pdfData, err := os.ReadFile(pdfPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read PDF: %w", err)
+	}
+
+	return oc.complete(ctx, []interface{}{
+		map[string]interface{}{
+			"type": "file",
+			"file": map[string]string{
+				"filename":  filepath.Base(pdfPath),
+				"file_data": "data:application/pdf;base64," + base64.StdEncoding.EncodeToString(pdfData),
+			},
+		},
+		map[string]interface{}{"type": "text", "text": prompt},
+	})
+}
+
+// chatCompletionResponse is the subset of the Chat Completions response we use
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// complete sends a single user message made of the given content parts
+func (oc *OpenAIClient) complete(ctx context.Context, content []interface{}) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model":                 oc.model,
+		"temperature":           oc.temperature,
+		"max_completion_tokens": oc.maxTokens,
+		"messages": []interface{}{
+			map[string]interface{}{"role": "user", "content": content},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oc.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+oc.apiKey)
+
+	resp, err := oc.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to call OpenAI API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var parsed chatCompletionResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		msg := strings.TrimSpace(string(respBody))
+		if parsed.Error != nil && parsed.Error.Message != "" {
+			msg = parsed.Error.Message
+		}
+		return "", &OpenAIError{StatusCode: resp.StatusCode, Message: msg}
+	}
+
+	oc.usageMu.Lock()
+	oc.usage.Add(TokenUsage{
+		PromptTokens: parsed.Usage.PromptTokens,
+		OutputTokens: parsed.Usage.CompletionTokens,
+		TotalTokens:  parsed.Usage.TotalTokens,
+	})
+	oc.usageMu.Unlock()
+
+	if len(parsed.Choices) == 0 {
+		return "", fmt.Errorf("no choices returned")
+	}
+
+	return parsed.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,55 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAIClient_GenerateText(t *testing.T) {
+	var gotAuth string
+	var gotBody map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat/completions", r.URL.Path)
+		gotAuth = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+
+		w.Write([]byte(`{
+			"choices": [{"message": {"content": "hello"}}],
+			"usage": {"prompt_tokens": 12, "completion_tokens": 3, "total_tokens": 15}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewOpenAIClient("test-key", server.URL+"/", "gpt-4o", 0.2, 100)
+	text, err := client.GenerateText(context.Background(), "hi")
+	require.NoError(t, err)
+
+	assert.Equal(t, "hello", text)
+	assert.Equal(t, "Bearer test-key", gotAuth)
+	assert.Equal(t, "gpt-4o", gotBody["model"])
+	assert.Equal(t, TokenUsage{PromptTokens: 12, OutputTokens: 3, TotalTokens: 15}, client.Usage())
+}
+
+func TestOpenAIClient_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error": {"message": "Rate limit reached"}}`))
+	}))
+	defer server.Close()
+
+	client := NewOpenAIClient("test-key", server.URL, "gpt-4o", 0.2, 100)
+	_, err := client.GenerateText(context.Background(), "hi")
+
+	var openAIErr *OpenAIError
+	require.ErrorAs(t, err, &openAIErr)
+	assert.Equal(t, http.StatusTooManyRequests, openAIErr.StatusCode)
+	assert.Equal(t, "Rate limit reached", openAIErr.Message)
+	assert.True(t, isRetryableError(err))
+}
@@ -151,3 +151,54 @@ If there are NO errors, output exactly: VALID
 
 Document to check:
 %s`
+
+// MarkdownAnalysisPrompt asks for the same report as AnalysisPrompt, written
+// as Markdown for readers who don't have a LaTeX toolchain
+const MarkdownAnalysisPrompt = `You are an expert AI/ML researcher and technical writer tasked with analyzing research papers for CS students.
+
+Please analyze this research paper PDF and write a comprehensive, student-friendly report in GitHub-flavored Markdown that explains the paper in detail.
+
+Output ONLY the Markdown document, starting with a level-1 heading that contains the paper title:
+
+# [Paper Title]: Technical Report Student Guide
+
+## Executive Summary
+3-4 sentence overview: What is this paper about? Why does it matter?
+
+## Problem Statement
+What specific problem does this paper address? Why is it important? What are the limitations of existing approaches?
+
+## Methods Overview
+List the primary techniques/architectures used.
+
+## Detailed Methodology
+### Prerequisites
+> Specific concepts needed (NOT vague like "linear algebra") and prior work that should be understood first.
+
+### Architecture and Approach
+Break down the methodology step-by-step. Explain mathematical formulations clearly using $inline$ and $$display$$ math. Define all notation explicitly.
+
+### Implementation Details
+
+## Key Insights
+> The most important takeaways, as blockquotes.
+
+## Results and Evaluation
+Use Markdown tables for quantitative results.
+
+## Limitations and Future Work
+
+## Summary for Students
+
+Rules:
+- Do NOT wrap the output in a code block
+- Do NOT use LaTeX commands outside math
+- Do NOT add any commentary before or after the report`
+
+// KeyFiguresPrompt asks which figures best explain the paper, so only those
+// are embedded in the report
+const KeyFiguresPrompt = `Identify the figures in this research paper that are most helpful for a student trying to understand it (architecture diagrams, method overviews, key result plots). Ignore logos, author photos and decorative images.
+
+Output at most %d lines, most important first, EXACTLY in this format (no additional text):
+
+FIGURE: [page number where the figure appears] | [one-sentence caption explaining what the figure shows]`
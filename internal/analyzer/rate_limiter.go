@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter spaces out API calls so that every analyzer sharing it stays
+// under a requests-per-minute budget, however many workers are running. It
+// is a token bucket holding a single token, so calls are evenly spread
+// rather than sent in bursts. A nil *RateLimiter does not limit.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // Time between calls
+	next     time.Time     // When the next call may start
+}
+
+// NewRateLimiter creates a limiter allowing requestsPerMinute calls per
+// minute, or returns nil (no limit) when requestsPerMinute is not positive
+func NewRateLimiter(requestsPerMinute int) *RateLimiter {
+	if requestsPerMinute <= 0 {
+		return nil
+	}
+	return &RateLimiter{interval: time.Minute / time.Duration(requestsPerMinute)}
+}
+
+// Wait blocks until the caller may make a call, or until ctx is done
+func (rl *RateLimiter) Wait(ctx context.Context) error {
+	if rl == nil {
+		return ctx.Err()
+	}
+
+	rl.mu.Lock()
+	now := time.Now()
+	slot := rl.next
+	if slot.Before(now) {
+		slot = now
+	}
+	rl.next = slot.Add(rl.interval)
+	rl.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Hand the slot back if nobody has queued behind it
+		rl.mu.Lock()
+		if rl.next.Equal(slot.Add(rl.interval)) {
+			rl.next = slot
+		}
+		rl.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,54 @@
+package analyzer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRateLimiterDisabled(t *testing.T) {
+	assert.Nil(t, NewRateLimiter(0))
+	assert.Nil(t, NewRateLimiter(-5))
+	assert.Equal(t, 4*time.Second, NewRateLimiter(15).interval)
+
+	var rl *RateLimiter
+	assert.NoError(t, rl.Wait(context.Background()))
+}
+
+func TestRateLimiterSpacesConcurrentCalls(t *testing.T) {
+	rl := &RateLimiter{interval: 20 * time.Millisecond}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, rl.Wait(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	// The first call goes straight through; the other four wait one interval each
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func TestRateLimiterRespectsCancellation(t *testing.T) {
+	rl := &RateLimiter{interval: time.Hour}
+	require.NoError(t, rl.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := rl.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	// The cancelled caller gave its slot back instead of pushing later callers out
+	assert.WithinDuration(t, time.Now().Add(time.Hour), rl.next, time.Minute)
+}
@@ -0,0 +1,159 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// Default backoff used when retry settings are missing from the config
+const (
+	defaultBackoffMultiplier = 2
+	defaultInitialDelay      = time.Second
+	maxRetryDelay            = 2 * time.Minute
+)
+
+// Substrings of Gemini errors that are worth retrying (rate limits and server-side failures)
+var retryableErrorMarkers = []string{
+	"RESOURCE_EXHAUSTED",
+	"quota",
+	"QuotaFailure",
+	"rate limit",
+	"Error 429",
+	"UNAVAILABLE",
+	"INTERNAL",
+	"Error 500",
+	"Error 502",
+	"Error 503",
+	"Error 504",
+}
+
+// Substrings of errors that will never succeed on retry
+var fatalErrorMarkers = []string{
+	"API key not valid",
+	"API_KEY_INVALID",
+	"INVALID_ARGUMENT",
+	"PERMISSION_DENIED",
+	"UNAUTHENTICATED",
+	"NOT_FOUND",
+}
+
+// isRetryableError reports whether a Gemini error is transient (429, 5xx or a
+// network failure). Bad requests, auth failures and unknown errors fail fast.
+func isRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return isRetryableStatus(apiErr.Code)
+	}
+
+	var openAIErr *OpenAIError
+	if errors.As(err, &openAIErr) {
+		return isRetryableStatus(openAIErr.StatusCode)
+	}
+
+	msg := err.Error()
+	for _, marker := range fatalErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return false
+		}
+	}
+	for _, marker := range retryableErrorMarkers {
+		if strings.Contains(msg, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRetryableStatus reports whether an HTTP status is a rate limit or server error
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// retryBackoff is an exponential backoff schedule for transient API errors
+type retryBackoff struct {
+	multiplier   int
+	initialDelay time.Duration
+}
+
+// newRetryBackoff builds a backoff schedule, using defaults for non-positive values
+func newRetryBackoff(multiplier int, initialDelayMs int) retryBackoff {
+	rb := retryBackoff{multiplier: defaultBackoffMultiplier, initialDelay: defaultInitialDelay}
+	if multiplier > 0 {
+		rb.multiplier = multiplier
+	}
+	if initialDelayMs > 0 {
+		rb.initialDelay = time.Duration(initialDelayMs) * time.Millisecond
+	}
+	return rb
+}
+
+// retryDelay returns the wait before the given retry attempt (1-based):
+// initialDelay * multiplier^(attempt-1), capped at maxRetryDelay
+func retryDelay(attempt int, multiplier int, initialDelay time.Duration) time.Duration {
+	delay := initialDelay
+	for i := 1; i < attempt; i++ {
+		delay *= time.Duration(multiplier)
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+	return delay
+}
+
+// run calls fn until it succeeds, returns a non-retryable error, or runs out of attempts.
+// It never sleeps past the context deadline.
+func (rb retryBackoff) run(ctx context.Context, maxAttempts int, fn func() (string, error)) (string, error) {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		result, err := fn()
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+
+		if !isRetryableError(err) {
+			return "", err
+		}
+		if attempt == maxAttempts {
+			break
+		}
+
+		delay := retryDelay(attempt, rb.multiplier, rb.initialDelay)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return "", fmt.Errorf("giving up after %d attempts (not enough time left before deadline): %w", attempt, err)
+		}
+
+		log.Printf("⚠️  API call failed (attempt %d/%d): %v", attempt, maxAttempts, err)
+		log.Printf("   Retrying in %v...", delay)
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("retry aborted: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+
+	return "", fmt.Errorf("failed after %d attempts: %w", maxAttempts, lastErr)
+}
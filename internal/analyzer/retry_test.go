@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/googleapi"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"rate limit status", &googleapi.Error{Code: 429}, true},
+		{"server error status", &googleapi.Error{Code: 503}, true},
+		{"bad request status", &googleapi.Error{Code: 400}, false},
+		{"wrapped rate limit", fmt.Errorf("failed to analyze PDF: %w", &googleapi.Error{Code: 429}), true},
+		{"resource exhausted message", errors.New("rpc error: code = RESOURCE_EXHAUSTED desc = quota exceeded"), true},
+		{"invalid api key", errors.New("googleapi: Error 400: API key not valid. Please pass a valid API key."), false},
+		{"invalid argument", errors.New("INVALID_ARGUMENT: request contains an invalid argument"), false},
+		{"context cancelled", context.Canceled, false},
+		{"unknown error", errors.New("failed to read PDF: no such file"), false},
+		{"openai rate limit", &OpenAIError{StatusCode: 429, Message: "Rate limit reached"}, true},
+		{"openai bad key", &OpenAIError{StatusCode: 401, Message: "Incorrect API key provided"}, false},
+		{"connection refused", fmt.Errorf("failed to analyze PDF: %w", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isRetryableError(tt.err))
+		})
+	}
+}
+
+func TestNewRetryBackoff_Defaults(t *testing.T) {
+	rb := newRetryBackoff(0, 0)
+	assert.Equal(t, defaultBackoffMultiplier, rb.multiplier)
+	assert.Equal(t, defaultInitialDelay, rb.initialDelay)
+
+	rb = newRetryBackoff(3, 250)
+	assert.Equal(t, 3, rb.multiplier)
+	assert.Equal(t, 250*time.Millisecond, rb.initialDelay)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Second, retryDelay(1, 2, time.Second))
+	assert.Equal(t, 2*time.Second, retryDelay(2, 2, time.Second))
+	assert.Equal(t, 8*time.Second, retryDelay(4, 2, time.Second))
+	assert.Equal(t, maxRetryDelay, retryDelay(30, 2, time.Second))
+}
+
+func TestWithRetry(t *testing.T) {
+	rb := retryBackoff{multiplier: 2, initialDelay: time.Millisecond}
+
+	t.Run("retries transient errors", func(t *testing.T) {
+		calls := 0
+		result, err := rb.run(context.Background(), 3, func() (string, error) {
+			calls++
+			if calls < 3 {
+				return "", &googleapi.Error{Code: 429}
+			}
+			return "ok", nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "ok", result)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("fails fast on fatal errors", func(t *testing.T) {
+		calls := 0
+		_, err := rb.run(context.Background(), 5, func() (string, error) {
+			calls++
+			return "", &googleapi.Error{Code: 400}
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		calls := 0
+		_, err := rb.run(context.Background(), 2, func() (string, error) {
+			calls++
+			return "", &googleapi.Error{Code: 503}
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("respects context deadline", func(t *testing.T) {
+		slow := retryBackoff{multiplier: 2, initialDelay: time.Hour}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		calls := 0
+		_, err := slow.run(ctx, 5, func() (string, error) {
+			calls++
+			return "", &googleapi.Error{Code: 429}
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+}
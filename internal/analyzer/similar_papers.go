@@ -2,11 +2,26 @@ package analyzer
 
 import (
 	"archivist/internal/search"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net"
+	"net/http"
+	"os"
 	"strings"
 	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+// Reasons essence extraction fails, so callers can suggest what to do next
+var (
+	ErrPDFUnreadable  = errors.New("PDF could not be read")
+	ErrLLMUnreachable = errors.New("LLM service is unreachable")
+	ErrLLMRequest     = errors.New("LLM API request failed")
 )
 
 // PaperEssence represents the key characteristics of a paper
@@ -29,7 +44,7 @@ type SimilarPaperFinder struct {
 // NewSimilarPaperFinder creates a new similar paper finder
 func NewSimilarPaperFinder(analyzer *Analyzer, searchServiceURL string) *SimilarPaperFinder {
 	if searchServiceURL == "" {
-		searchServiceURL = "http://localhost:8000"
+		searchServiceURL = search.DefaultBaseURL
 	}
 
 	return &SimilarPaperFinder{
@@ -56,11 +71,15 @@ NOVEL_CONTRIBUTIONS: [Comma-separated list of what makes this paper unique]
 
 Be specific and technical. Use actual terminology from the paper.`
 
+	if err := checkPDFReadable(pdfPath); err != nil {
+		return nil, err
+	}
+
 	startTime := time.Now()
-	// Use retry logic with exponential backoff (max 5 attempts)
-	result, err := spf.analyzer.client.AnalyzePDFWithVisionRetry(ctx, pdfPath, prompt, 5)
+	// Retry rate-limit, server and network errors using the configured backoff
+	result, err := spf.analyzer.analyzePDFRetry(ctx, spf.analyzer.client, pdfPath, prompt, spf.analyzer.retryAttempts(5))
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract essence: %w", err)
+		return nil, classifyEssenceError(err)
 	}
 
 	log.Printf("✓ Essence extracted (%.2fs)", time.Since(startTime).Seconds())
@@ -102,6 +121,50 @@ Be specific and technical. Use actual terminology from the paper.`
 	return essence, nil
 }
 
+// checkPDFReadable fails with ErrPDFUnreadable unless path opens and starts like a PDF
+func checkPDFReadable(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPDFUnreadable, err)
+	}
+	defer f.Close()
+
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(f, header); err != nil || !bytes.Equal(header, []byte("%PDF-")) {
+		return fmt.Errorf("%w: %s is not a valid PDF file", ErrPDFUnreadable, path)
+	}
+	return nil
+}
+
+// classifyEssenceError wraps an LLM failure with ErrLLMUnreachable (network or
+// server errors) or ErrLLMRequest (quota, auth, bad request, and the rest)
+func classifyEssenceError(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	reason := ErrLLMRequest
+	var netErr net.Error
+	var apiErr *googleapi.Error
+	var openAIErr *OpenAIError
+	switch {
+	case errors.As(err, &netErr), errors.Is(err, context.DeadlineExceeded):
+		reason = ErrLLMUnreachable
+	case errors.As(err, &apiErr):
+		if apiErr.Code >= http.StatusInternalServerError {
+			reason = ErrLLMUnreachable
+		}
+	case errors.As(err, &openAIErr):
+		if openAIErr.StatusCode >= http.StatusInternalServerError {
+			reason = ErrLLMUnreachable
+		}
+	case strings.Contains(err.Error(), "UNAVAILABLE"):
+		reason = ErrLLMUnreachable
+	}
+
+	return fmt.Errorf("%w: %w", reason, err)
+}
+
 // FindSimilarPapers searches for papers similar to the given essence
 func (spf *SimilarPaperFinder) FindSimilarPapers(ctx context.Context, essence *PaperEssence, maxResults int) (*search.SearchResponse, error) {
 	if !spf.searchClient.IsServiceRunning() {
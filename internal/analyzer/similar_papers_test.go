@@ -0,0 +1,59 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+)
+
+func TestCheckPDFReadable(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "paper.pdf")
+	require.NoError(t, os.WriteFile(valid, []byte("%PDF-1.7\n..."), 0644))
+	assert.NoError(t, checkPDFReadable(valid))
+
+	notPDF := filepath.Join(dir, "notes.pdf")
+	require.NoError(t, os.WriteFile(notPDF, []byte("<html>"), 0644))
+	assert.ErrorIs(t, checkPDFReadable(notPDF), ErrPDFUnreadable)
+
+	empty := filepath.Join(dir, "empty.pdf")
+	require.NoError(t, os.WriteFile(empty, nil, 0644))
+	assert.ErrorIs(t, checkPDFReadable(empty), ErrPDFUnreadable)
+
+	assert.ErrorIs(t, checkPDFReadable(filepath.Join(dir, "missing.pdf")), ErrPDFUnreadable)
+}
+
+func TestClassifyEssenceError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"connection refused", fmt.Errorf("failed to analyze PDF: %w", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}), ErrLLMUnreachable},
+		{"server error", fmt.Errorf("failed after 3 attempts: %w", &googleapi.Error{Code: 503}), ErrLLMUnreachable},
+		{"openai server error", &OpenAIError{StatusCode: 502, Message: "Bad gateway"}, ErrLLMUnreachable},
+		{"timeout", context.DeadlineExceeded, ErrLLMUnreachable},
+		{"quota", &googleapi.Error{Code: 429}, ErrLLMRequest},
+		{"bad key", &OpenAIError{StatusCode: 401, Message: "Incorrect API key provided"}, ErrLLMRequest},
+		{"unknown", errors.New("empty response"), ErrLLMRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyEssenceError(tt.err)
+			assert.ErrorIs(t, err, tt.want)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	assert.Equal(t, context.Canceled, classifyEssenceError(context.Canceled))
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// ChatPromptData is what a chat prompt template (chat.system_prompt) can reference
+type ChatPromptData struct {
+	Papers   []string // Titles of the papers the session is about; empty when chatting with the whole library
+	Context  string   // Chunks retrieved for the question, with their sources
+	History  string   // Summary of earlier turns and the recent ones, or "" on the first question
+	Question string   // The user's current question
+}
+
+// DefaultChatPrompt is the student-friendly prompt used when chat.system_prompt is empty
+const DefaultChatPrompt = `You are a helpful AI research assistant for CS students studying AI/ML papers.
+
+{{if .Papers}}You are discussing the following papers:
+{{range .Papers}}- {{.}}
+{{end}}
+{{end}}RELEVANT CONTEXT FROM PAPERS:
+---
+{{.Context}}---
+
+{{.History}}CURRENT QUESTION:
+{{.Question}}
+
+INSTRUCTIONS:
+- Answer the question using the provided context from the papers.
+- Be clear, concise, and student-friendly.
+- Cite specific sections when referencing information (e.g., 'According to Section 3.2...').
+- If the context doesn't contain enough information, say so.
+- Use technical terms but explain them when first introduced.
+- If comparing multiple papers, clearly distinguish between them.
+
+ANSWER:`
+
+// Rendered when validating a chat prompt to make sure the question and the
+// retrieved context actually end up in it
+const (
+	chatPromptCheckQuestion = "archivist-prompt-check-question"
+	chatPromptCheckContext  = "archivist-prompt-check-context"
+)
+
+// ParseChatPrompt parses a chat prompt template, or DefaultChatPrompt if text
+// is empty. The template must render with sample data and include
+// {{.Question}} and {{.Context}}, so mistakes surface when the config loads.
+func ParseChatPrompt(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		text = DefaultChatPrompt
+	}
+
+	tmpl, err := template.New("chat.system_prompt").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse chat prompt: %w", err)
+	}
+
+	var out bytes.Buffer
+	sample := ChatPromptData{
+		Papers:   []string{"Title"},
+		Context:  chatPromptCheckContext,
+		History:  "User: question\nAssistant: answer\n",
+		Question: chatPromptCheckQuestion,
+	}
+	if err := tmpl.Execute(&out, sample); err != nil {
+		return nil, fmt.Errorf("failed to render chat prompt: %w", err)
+	}
+	if !strings.Contains(out.String(), chatPromptCheckQuestion) {
+		return nil, fmt.Errorf("chat prompt does not include {{.Question}}")
+	}
+	if !strings.Contains(out.String(), chatPromptCheckContext) {
+		return nil, fmt.Errorf("chat prompt does not include {{.Context}}")
+	}
+
+	return tmpl, nil
+}
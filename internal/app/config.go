@@ -2,11 +2,16 @@ package app
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"reflect"
 	"runtime"
 	"strings"
 
+	"archivist/internal/generator"
+
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
 )
@@ -17,19 +22,35 @@ type Config struct {
 	ReportOutputDir  string           `mapstructure:"report_output_dir"`
 	Processing       ProcessingConfig `mapstructure:"processing"`
 	Gemini           GeminiConfig     `mapstructure:"gemini"`
+	LLM              LLMConfig        `mapstructure:"llm"`
 	Latex            LatexConfig      `mapstructure:"latex"`
 	Cache            CacheConfig      `mapstructure:"cache"`
 	FAISS            FAISSConfig      `mapstructure:"faiss"`
+	RAG              RAGConfig        `mapstructure:"rag"`
+	Chat             ChatConfig       `mapstructure:"chat"`
 	Graph            GraphConfig      `mapstructure:"graph"`
 	Visualization    VisualizationConfig `mapstructure:"visualization"`
 	HashAlgorithm    string           `mapstructure:"hash_algorithm"`
 	Logging          LoggingConfig    `mapstructure:"logging"`
+	Metadata         MetadataConfig   `mapstructure:"metadata"`
+	Microservices    MicroservicesConfig `mapstructure:"microservices"`
+	Search           SearchServiceConfig `mapstructure:"search"`
+	Metrics          MetricsConfig       `mapstructure:"metrics"`
+}
+
+// MetadataConfig selects where processing records are stored
+type MetadataConfig struct {
+	Backend string `mapstructure:"backend"` // "json" (hashes.json) or "sqlite"
 }
 
 type ProcessingConfig struct {
 	MaxWorkers       int `mapstructure:"max_workers"`
 	BatchSize        int `mapstructure:"batch_size"`
 	TimeoutPerPaper  int `mapstructure:"timeout_per_paper"`
+	ExtractFigures   bool `mapstructure:"extract_figures"` // Embed the paper's key figures in LaTeX reports (needs pdfimages)
+	MaxInflightBytes int64 `mapstructure:"max_inflight_bytes"` // Total size of PDFs processed at once; 0 = unlimited
+	DedupByMetadata  bool `mapstructure:"dedup_by_metadata"` // Skip papers whose title, authors and year match a processed one (one extra API call per paper)
+	Mode             string // Processing mode selected for this run (set by the CLI/TUI, not config.yaml)
 }
 
 type GeminiConfig struct {
@@ -37,9 +58,27 @@ type GeminiConfig struct {
 	MaxTokens   int           `mapstructure:"max_tokens"`
 	Temperature float64       `mapstructure:"temperature"`
 	Agentic     AgenticConfig `mapstructure:"agentic"`
+	RateLimit   int           `mapstructure:"rate_limit"` // Requests per minute across all workers; 0 = unlimited
 	APIKey      string        // Loaded from .env
 }
 
+// LLMConfig selects the model backend used for analysis and chat
+type LLMConfig struct {
+	Provider string       `mapstructure:"provider"` // "gemini" or "openai"
+	OpenAI   OpenAIConfig `mapstructure:"openai"`
+}
+
+type OpenAIConfig struct {
+	Model   string `mapstructure:"model"`
+	BaseURL string `mapstructure:"base_url"`
+	APIKey  string // Loaded from .env (OPENAI_API_KEY)
+}
+
+const (
+	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai"
+)
+
 type AgenticConfig struct {
 	Enabled            bool          `mapstructure:"enabled"`
 	MaxIterations      int           `mapstructure:"max_iterations"`
@@ -72,6 +111,8 @@ type LatexConfig struct {
 	Compiler  string `mapstructure:"compiler"`
 	Engine    string `mapstructure:"engine"`
 	CleanAux  bool   `mapstructure:"clean_aux"`
+	BibEngine string `mapstructure:"bibengine"` // "bibtex" or "biber"
+	Template  string `mapstructure:"template"`  // Optional text/template file wrapping generated reports
 }
 
 type LoggingConfig struct {
@@ -84,7 +125,13 @@ type CacheConfig struct {
 	Enabled  bool   `mapstructure:"enabled"`
 	Type     string `mapstructure:"type"`      // "redis" or "memory"
 	Redis    RedisConfig `mapstructure:"redis"`
+	Memory   MemoryCacheConfig `mapstructure:"memory"`
 	TTL      int    `mapstructure:"ttl"`       // TTL in hours
+	RefreshOnHit bool `mapstructure:"refresh_on_hit"` // Reset a Redis entry's TTL whenever it is read
+}
+
+type MemoryCacheConfig struct {
+	SnapshotFile string `mapstructure:"snapshot_file"` // Optional JSON snapshot to persist across runs
 }
 
 type RedisConfig struct {
@@ -97,6 +144,38 @@ type FAISSConfig struct {
 	IndexDir string `mapstructure:"index_dir"`
 }
 
+// DefaultFAISSIndexDir is where the vector index lives unless faiss.index_dir says otherwise
+const DefaultFAISSIndexDir = ".metadata/vector_index"
+
+// DefaultEmbeddingModel and DefaultEmbeddingDim are used unless
+// rag.embedding_model and rag.embedding_dim say otherwise
+const (
+	DefaultEmbeddingModel = "models/text-embedding-004"
+	DefaultEmbeddingDim   = 768
+)
+
+// searchWeightTolerance is how far the hybrid search weights may sum away from 1
+const searchWeightTolerance = 0.05
+
+// RAGConfig tunes how papers are chunked for the vector store and how much
+// context is retrieved for chat
+type RAGConfig struct {
+	ChunkSize      int     `mapstructure:"chunk_size"`      // Target characters per chunk
+	ChunkOverlap   int     `mapstructure:"chunk_overlap"`   // Characters shared between neighbouring chunks
+	TopK           int     `mapstructure:"top_k"`           // Chunks retrieved per question
+	ScoreThreshold float64 `mapstructure:"score_threshold"` // Minimum similarity score in [0, 1]
+	Rerank         bool    `mapstructure:"rerank"`          // Rerank retrieved chunks with BM25 before answering
+	RecencyWeight  float64 `mapstructure:"recency_weight"`  // Score decay per year of a paper's age, in [0, 1); 0 disables
+	IndexMetadata  bool    `mapstructure:"index_metadata"`  // Also embed each paper's abstract, keywords, methods, datasets and metrics
+	EmbeddingModel string  `mapstructure:"embedding_model"` // Gemini embedding model used for the vector index
+	EmbeddingDim   int     `mapstructure:"embedding_dim"`   // Vector size embedding_model produces; must match the index
+}
+
+// ChatConfig customizes how the chat engine prompts the model
+type ChatConfig struct {
+	SystemPrompt string `mapstructure:"system_prompt"` // text/template for the whole prompt; "" uses DefaultChatPrompt
+}
+
 type GraphConfig struct {
 	Enabled            bool                      `mapstructure:"enabled"`
 	Neo4j              Neo4jConfig               `mapstructure:"neo4j"`
@@ -105,15 +184,61 @@ type GraphConfig struct {
 	CitationExtraction CitationExtractionConfig  `mapstructure:"citation_extraction"`
 	Search             SearchConfig              `mapstructure:"search"`
 	Optimization       OptimizationConfig        `mapstructure:"optimization"`
+	Kafka              KafkaConfig               `mapstructure:"kafka"`
+}
+
+// MicroservicesConfig tells the batch processor where to poll the graph and
+// RAG services for progress after papers are published to Kafka
+type MicroservicesConfig struct {
+	GraphURL     string `mapstructure:"graph_url"`     // Base URL of the graph service
+	RAGURL       string `mapstructure:"rag_url"`       // Base URL of the Python RAG service
+	PollInterval int    `mapstructure:"poll_interval"` // Seconds between status checks
+	MaxWait      int    `mapstructure:"max_wait"`      // Seconds before giving up on the services
+}
+
+// SearchServiceConfig locates the paper search service used by search, similar and the TUI
+type SearchServiceConfig struct {
+	BaseURL  string `mapstructure:"base_url"`  // Base URL of the search service
+	CacheTTL int    `mapstructure:"cache_ttl"` // Seconds results are reused for the same query; 0 disables
+}
+
+// MetricsConfig controls the Prometheus endpoint served while a batch runs
+type MetricsConfig struct {
+	Enabled bool `mapstructure:"enabled"` // Serve /metrics during process runs
+	Port    int  `mapstructure:"port"`    // Port the metrics endpoint listens on
+}
+
+// DefaultSearchBaseURL is where the search service listens unless search.base_url says otherwise
+const DefaultSearchBaseURL = "http://localhost:8000"
+
+// KafkaConfig configures the producer that feeds the graph/RAG microservices
+type KafkaConfig struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Brokers []string `mapstructure:"brokers"`
+	Topic   string   `mapstructure:"topic"`
 }
 
+const (
+	DefaultKafkaBroker = "localhost:9094" // External listener from docker-compose-graph.yml
+	DefaultKafkaTopic  = "paper.processed"
+)
+
 type Neo4jConfig struct {
-	URI      string `mapstructure:"uri"`
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
-	Database string `mapstructure:"database"`
+	URI                   string `mapstructure:"uri"`
+	Username              string `mapstructure:"username"`
+	Password              string `mapstructure:"password"`
+	Database              string `mapstructure:"database"`
+	MaxConnectionPoolSize int    `mapstructure:"max_connection_pool_size"` // Driver connection pool size; 0 = driver default (100)
+	ConnectionTimeout     int    `mapstructure:"connection_timeout"`       // Seconds to wait for a pooled connection; 0 = driver default (60)
+	ConnectAttempts       int    `mapstructure:"connect_attempts"`         // Tries to reach Neo4j before giving up, e.g. while it starts in Docker
+	ConnectRetryDelay     int    `mapstructure:"connect_retry_delay"`      // Seconds before the first retry; doubles after each failed try
 }
 
+const (
+	DefaultNeo4jConnectAttempts   = 5
+	DefaultNeo4jConnectRetryDelay = 2 // Seconds
+)
+
 type CitationExtractionConfig struct {
 	Enabled              bool     `mapstructure:"enabled"`
 	PrioritizeInText     bool     `mapstructure:"prioritize_in_text"`
@@ -151,17 +276,79 @@ type WebVisualizationConfig struct {
 	Port    int  `mapstructure:"port"`
 }
 
-// LoadConfig loads configuration from config.yaml and .env
+// EnvPrefix prefixes environment variables that override config.yaml. The
+// rest of the name is the key path in upper case with dots replaced by
+// underscores, e.g. RPH_PROCESSING_MAX_WORKERS or RPH_GRAPH_NEO4J_URI.
+const EnvPrefix = "RPH"
+
+// ErrConfigNotFound is returned by LoadConfig when the config file doesn't exist
+var ErrConfigNotFound = errors.New("config file not found")
+
+// LoadConfig loads configuration from config.yaml and .env. Values are taken
+// from RPH_* environment variables first, then the config file, then defaults.
 func LoadConfig(configPath string) (*Config, error) {
+	if _, err := os.Stat(configPath); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s (create one with 'rph config init')", ErrConfigNotFound, configPath)
+	}
+
 	// Load .env file
 	if err := godotenv.Load(); err != nil {
-		fmt.Println("Warning: .env file not found, using environment variables")
+		// Stderr keeps stdout clean for machine-readable output such as --json
+		fmt.Fprintln(os.Stderr, "Warning: .env file not found, using environment variables")
 	}
 
 	// Setup Viper
 	viper.SetConfigFile(configPath)
 	viper.SetConfigType("yaml")
 
+	// Defaults for sections older config files may not have
+	viper.SetDefault("graph.kafka.enabled", true)
+	viper.SetDefault("graph.kafka.brokers", []string{DefaultKafkaBroker})
+	viper.SetDefault("graph.kafka.topic", DefaultKafkaTopic)
+	viper.SetDefault("graph.neo4j.max_connection_pool_size", 0)
+	viper.SetDefault("graph.neo4j.connection_timeout", 0)
+	viper.SetDefault("graph.neo4j.connect_attempts", DefaultNeo4jConnectAttempts)
+	viper.SetDefault("graph.neo4j.connect_retry_delay", DefaultNeo4jConnectRetryDelay)
+	viper.SetDefault("cache.refresh_on_hit", false)
+	viper.SetDefault("gemini.rate_limit", 0)
+	viper.SetDefault("processing.max_inflight_bytes", 0)
+	viper.SetDefault("processing.dedup_by_metadata", false)
+	viper.SetDefault("latex.bibengine", "bibtex")
+	viper.SetDefault("latex.template", "")
+	viper.SetDefault("llm.provider", ProviderGemini)
+	viper.SetDefault("llm.openai.model", "gpt-4o")
+	viper.SetDefault("llm.openai.base_url", "https://api.openai.com/v1")
+	viper.SetDefault("faiss.index_dir", DefaultFAISSIndexDir)
+	viper.SetDefault("metadata.backend", "json")
+	viper.SetDefault("microservices.graph_url", "http://localhost:8081")
+	viper.SetDefault("microservices.rag_url", "http://localhost:8082")
+	viper.SetDefault("microservices.poll_interval", 3)
+	viper.SetDefault("microservices.max_wait", 300)
+	viper.SetDefault("search.base_url", DefaultSearchBaseURL)
+	viper.SetDefault("search.cache_ttl", 600)
+	viper.SetDefault("metrics.enabled", false)
+	viper.SetDefault("metrics.port", 9091)
+	viper.SetDefault("rag.chunk_size", 2000)
+	viper.SetDefault("rag.chunk_overlap", 200)
+	viper.SetDefault("rag.top_k", 5)
+	viper.SetDefault("rag.score_threshold", 0.3)
+	viper.SetDefault("rag.recency_weight", 0)
+	viper.SetDefault("rag.index_metadata", true)
+	viper.SetDefault("rag.embedding_model", DefaultEmbeddingModel)
+	viper.SetDefault("rag.embedding_dim", DefaultEmbeddingDim)
+	viper.SetDefault("chat.system_prompt", "")
+
+	// Environment overrides. AutomaticEnv only covers keys viper already knows
+	// from the file or defaults, so every field is bound explicitly as well.
+	viper.SetEnvPrefix(EnvPrefix)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+	for _, key := range configKeys(reflect.TypeOf(Config{}), "") {
+		if err := viper.BindEnv(key); err != nil {
+			return nil, fmt.Errorf("failed to bind environment variable for %s: %w", key, err)
+		}
+	}
+
 	// Read config file
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
@@ -175,11 +362,11 @@ func LoadConfig(configPath string) (*Config, error) {
 	// Load user preferences and override config directories
 	prefs, err := LoadPreferences()
 	if err == nil && prefs.ConfiguredOnce {
-		// User has configured preferences, use them
-		if prefs.InputDirectory != "" {
+		// User has configured preferences, use them unless the environment says otherwise
+		if prefs.InputDirectory != "" && !envOverridden("input_dir") {
 			config.InputDir = prefs.InputDirectory
 		}
-		if prefs.OutputDirectory != "" {
+		if prefs.OutputDirectory != "" && !envOverridden("report_output_dir") {
 			config.ReportOutputDir = prefs.OutputDirectory
 		}
 	} else if err == nil && !prefs.ConfiguredOnce {
@@ -193,9 +380,13 @@ func LoadConfig(configPath string) (*Config, error) {
 		SavePreferences(defaultPrefs)
 	}
 
-	// Load API key from environment or prompt for it
+	// Load API keys from environment; prompt for Gemini's if it is the active provider
 	config.Gemini.APIKey = os.Getenv("GEMINI_API_KEY")
-	if config.Gemini.APIKey == "" {
+	config.LLM.OpenAI.APIKey = os.Getenv("OPENAI_API_KEY")
+	if config.LLM.Provider == ProviderOpenAI && config.LLM.OpenAI.APIKey == "" {
+		return nil, fmt.Errorf("OPENAI_API_KEY must be set when llm.provider is 'openai'")
+	}
+	if config.Gemini.APIKey == "" && config.LLM.Provider != ProviderOpenAI {
 		fmt.Println()
 		fmt.Println("═══════════════════════════════════════════════════════════════")
 		fmt.Println("                    API KEY NOT FOUND                          ")
@@ -240,6 +431,43 @@ func LoadConfig(configPath string) (*Config, error) {
 	return &config, nil
 }
 
+// configKeys lists the dotted key of every config.yaml setting in t, using
+// the mapstructure tags. Untagged fields (API keys, the processing mode) are
+// runtime-only and have no key.
+func configKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := field.Tag.Get("mapstructure")
+		if name == "" {
+			continue
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			keys = append(keys, configKeys(field.Type, key)...)
+		} else {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// envVarName returns the environment variable that overrides key
+func envVarName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// envOverridden reports whether key is set through the environment
+func envOverridden(key string) bool {
+	_, ok := os.LookupEnv(envVarName(key))
+	return ok
+}
+
 // validateConfig validates the configuration values
 func validateConfig(config *Config) error {
 	// Validate MaxWorkers
@@ -257,18 +485,114 @@ func validateConfig(config *Config) error {
 			config.Processing.TimeoutPerPaper)
 	}
 
+	// Validate in-flight byte budget
+	if config.Processing.MaxInflightBytes < 0 {
+		return fmt.Errorf("processing.max_inflight_bytes must be >= 0 (0 = unlimited), got %d",
+			config.Processing.MaxInflightBytes)
+	}
+
 	// Validate Temperature
 	if config.Gemini.Temperature < 0 || config.Gemini.Temperature > 2 {
 		return fmt.Errorf("temperature must be in range [0, 2], got %.2f",
 			config.Gemini.Temperature)
 	}
 
+	// Validate rate limit
+	if config.Gemini.RateLimit < 0 {
+		return fmt.Errorf("gemini.rate_limit must be >= 0 requests per minute (0 = unlimited), got %d",
+			config.Gemini.RateLimit)
+	}
+
 	// Validate Model format
 	if !strings.HasPrefix(config.Gemini.Model, "models/") {
 		return fmt.Errorf("invalid model format: %s (must start with 'models/')",
 			config.Gemini.Model)
 	}
 
+	// Validate LLM provider
+	if config.LLM.Provider != ProviderGemini && config.LLM.Provider != ProviderOpenAI {
+		return fmt.Errorf("invalid llm provider: %s (must be '%s' or '%s')",
+			config.LLM.Provider, ProviderGemini, ProviderOpenAI)
+	}
+
+	// Validate metrics port
+	if config.Metrics.Enabled && (config.Metrics.Port < 1 || config.Metrics.Port > 65535) {
+		return fmt.Errorf("metrics.port must be between 1 and 65535, got %d", config.Metrics.Port)
+	}
+
+	// Validate log level
+	if _, err := ParseLogLevel(config.Logging.Level); err != nil {
+		return fmt.Errorf("logging.level: %w", err)
+	}
+
+	// Validate metadata backend
+	if config.Metadata.Backend != "json" && config.Metadata.Backend != "sqlite" {
+		return fmt.Errorf("invalid metadata backend: %s (must be 'json' or 'sqlite')",
+			config.Metadata.Backend)
+	}
+
+	// Validate RAG settings
+	if config.RAG.ChunkSize <= 0 {
+		return fmt.Errorf("rag chunk_size must be > 0, got %d", config.RAG.ChunkSize)
+	}
+	if config.RAG.ChunkOverlap < 0 || config.RAG.ChunkOverlap >= config.RAG.ChunkSize {
+		return fmt.Errorf("rag chunk_overlap must be in range [0, chunk_size), got %d (chunk_size %d)",
+			config.RAG.ChunkOverlap, config.RAG.ChunkSize)
+	}
+	if config.RAG.TopK <= 0 {
+		return fmt.Errorf("rag top_k must be > 0, got %d", config.RAG.TopK)
+	}
+	if config.RAG.ScoreThreshold < 0 || config.RAG.ScoreThreshold > 1 {
+		return fmt.Errorf("rag score_threshold must be in range [0, 1], got %.2f",
+			config.RAG.ScoreThreshold)
+	}
+	if config.RAG.RecencyWeight < 0 || config.RAG.RecencyWeight >= 1 {
+		return fmt.Errorf("rag recency_weight must be in range [0, 1), got %.2f",
+			config.RAG.RecencyWeight)
+	}
+	if config.RAG.EmbeddingModel == "" {
+		return fmt.Errorf("rag embedding_model must be set (e.g. %q)", DefaultEmbeddingModel)
+	}
+	if config.RAG.EmbeddingDim <= 0 {
+		return fmt.Errorf("rag embedding_dim must be > 0, got %d", config.RAG.EmbeddingDim)
+	}
+
+	// Validate the chat prompt so a bad one fails now, not on the first question
+	if _, err := ParseChatPrompt(config.Chat.SystemPrompt); err != nil {
+		return fmt.Errorf("invalid chat.system_prompt: %w", err)
+	}
+
+	// Validate microservice monitor timings
+	if config.Microservices.PollInterval <= 0 {
+		return fmt.Errorf("microservices poll_interval must be > 0 seconds, got %d",
+			config.Microservices.PollInterval)
+	}
+	if config.Microservices.MaxWait <= 0 {
+		return fmt.Errorf("microservices max_wait must be > 0 seconds, got %d",
+			config.Microservices.MaxWait)
+	}
+
+	// Validate the search service location
+	if u, err := url.Parse(config.Search.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("search base_url must be an http(s) URL, got %q", config.Search.BaseURL)
+	}
+	if config.Search.CacheTTL < 0 {
+		return fmt.Errorf("search cache_ttl must be >= 0 seconds, got %d", config.Search.CacheTTL)
+	}
+
+	// Validate the vector index location used by index, chat and RAG indexing
+	if strings.TrimSpace(config.FAISS.IndexDir) == "" {
+		return fmt.Errorf("faiss index_dir must be set for chat and RAG indexing (e.g. %q)",
+			DefaultFAISSIndexDir)
+	}
+
+	// Validate knowledge graph settings
+	if config.Graph.Enabled {
+		if err := validateGraphConfig(config.Graph); err != nil {
+			return err
+		}
+	}
+
 	// Validate MaxTokens
 	if config.Gemini.MaxTokens <= 0 {
 		return fmt.Errorf("max_tokens must be > 0, got %d", config.Gemini.MaxTokens)
@@ -300,6 +624,19 @@ func validateConfig(config *Config) error {
 			config.Latex.Compiler, validCompilers)
 	}
 
+	// Validate bibliography backend
+	if config.Latex.BibEngine != "" && config.Latex.BibEngine != "bibtex" && config.Latex.BibEngine != "biber" {
+		return fmt.Errorf("invalid latex bibengine: %s (must be 'bibtex' or 'biber')",
+			config.Latex.BibEngine)
+	}
+
+	// Validate the report template so a bad one fails now, not after analysis
+	if config.Latex.Template != "" {
+		if _, err := generator.LoadReportTemplate(config.Latex.Template); err != nil {
+			return fmt.Errorf("invalid latex.template: %w", err)
+		}
+	}
+
 	// Validate Hash Algorithm
 	validHashAlgos := []string{"sha256", "sha512", "md5"}
 	isValidHash := false
@@ -317,6 +654,52 @@ func validateConfig(config *Config) error {
 	return nil
 }
 
+// validateGraphConfig checks the settings needed once the knowledge graph is enabled
+func validateGraphConfig(graph GraphConfig) error {
+	uri := strings.TrimSpace(graph.Neo4j.URI)
+	if uri == "" {
+		return fmt.Errorf("graph.neo4j.uri must be set when the graph is enabled " +
+			"(e.g. \"bolt://localhost:7687\"), or set graph.enabled to false")
+	}
+	validSchemes := []string{"bolt://", "bolt+s://", "bolt+ssc://", "neo4j://", "neo4j+s://", "neo4j+ssc://"}
+	isValidScheme := false
+	for _, scheme := range validSchemes {
+		if strings.HasPrefix(uri, scheme) {
+			isValidScheme = true
+			break
+		}
+	}
+	if !isValidScheme {
+		return fmt.Errorf("invalid graph.neo4j.uri: %s (must start with bolt:// or neo4j://)", uri)
+	}
+	if graph.Neo4j.MaxConnectionPoolSize < 0 {
+		return fmt.Errorf("graph.neo4j.max_connection_pool_size must be >= 0, got %d", graph.Neo4j.MaxConnectionPoolSize)
+	}
+	if graph.Neo4j.ConnectionTimeout < 0 {
+		return fmt.Errorf("graph.neo4j.connection_timeout must be >= 0, got %d", graph.Neo4j.ConnectionTimeout)
+	}
+	if graph.Neo4j.ConnectAttempts < 1 {
+		return fmt.Errorf("graph.neo4j.connect_attempts must be >= 1, got %d", graph.Neo4j.ConnectAttempts)
+	}
+	if graph.Neo4j.ConnectRetryDelay < 0 {
+		return fmt.Errorf("graph.neo4j.connect_retry_delay must be >= 0, got %d", graph.Neo4j.ConnectRetryDelay)
+	}
+
+	weights := graph.Search
+	if weights.VectorWeight < 0 || weights.GraphWeight < 0 || weights.KeywordWeight < 0 {
+		return fmt.Errorf("graph search weights must be >= 0, got vector_weight=%.2f graph_weight=%.2f keyword_weight=%.2f",
+			weights.VectorWeight, weights.GraphWeight, weights.KeywordWeight)
+	}
+	sum := weights.VectorWeight + weights.GraphWeight + weights.KeywordWeight
+	if sum < 1-searchWeightTolerance || sum > 1+searchWeightTolerance {
+		return fmt.Errorf("graph search weights must sum to 1.0, got %.2f "+
+			"(vector_weight=%.2f + graph_weight=%.2f + keyword_weight=%.2f)",
+			sum, weights.VectorWeight, weights.GraphWeight, weights.KeywordWeight)
+	}
+
+	return nil
+}
+
 func ensureDirectories(config *Config) error {
 	dirs := []string{
 		config.InputDir,
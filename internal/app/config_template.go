@@ -0,0 +1,310 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"runtime"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxDefaultWorkers caps the default worker count; analysis is bound by the
+// LLM API rather than local CPU, so more workers mostly hit rate limits
+const maxDefaultWorkers = 8
+
+// DefaultConfig returns a fully populated configuration that passes
+// validation. Optional services (cache, knowledge graph) are disabled so a
+// fresh install only needs LaTeX and an API key.
+func DefaultConfig() *Config {
+	workers := runtime.NumCPU()
+	if workers > maxDefaultWorkers {
+		workers = maxDefaultWorkers
+	}
+
+	return &Config{
+		InputDir:        "./lib",
+		TexOutputDir:    "./tex_files",
+		ReportOutputDir: "./reports",
+		Processing: ProcessingConfig{
+			MaxWorkers:      workers,
+			BatchSize:       10,
+			TimeoutPerPaper: 600,
+		},
+		Gemini: GeminiConfig{
+			Model:       "models/gemini-2.0-flash-exp",
+			MaxTokens:   8000,
+			Temperature: 0.3,
+			Agentic: AgenticConfig{
+				Enabled:       true,
+				MaxIterations: 1,
+				Stages: StagesConfig{
+					MetadataExtraction:  StageConfig{Model: "models/gemini-2.0-flash-exp", Temperature: 1},
+					MethodologyAnalysis: StageConfig{Model: "models/gemini-2.0-flash-exp", Temperature: 1, ThinkingBudget: 10000},
+					LatexGeneration:     StageConfig{Model: "models/gemini-2.0-flash-exp", Temperature: 1, Validation: true},
+				},
+				Retry: RetryConfig{MaxAttempts: 3, BackoffMultiplier: 2, InitialDelayMs: 1000},
+			},
+		},
+		LLM: LLMConfig{
+			Provider: ProviderGemini,
+			OpenAI:   OpenAIConfig{Model: "gpt-4o", BaseURL: "https://api.openai.com/v1"},
+		},
+		Latex: LatexConfig{
+			Compiler:  "pdflatex",
+			Engine:    "latexmk",
+			CleanAux:  true,
+			BibEngine: "bibtex",
+		},
+		Cache: CacheConfig{
+			Enabled:      false,
+			Type:         "redis",
+			TTL:          720,
+			RefreshOnHit: false,
+			Redis:        RedisConfig{Addr: "localhost:6379"},
+			Memory:       MemoryCacheConfig{SnapshotFile: ".metadata/analysis_cache.json"},
+		},
+		FAISS: FAISSConfig{IndexDir: DefaultFAISSIndexDir},
+		RAG: RAGConfig{
+			ChunkSize:      2000,
+			ChunkOverlap:   200,
+			TopK:           5,
+			ScoreThreshold: 0.3,
+			IndexMetadata:  true,
+			EmbeddingModel: DefaultEmbeddingModel,
+			EmbeddingDim:   DefaultEmbeddingDim,
+		},
+		Graph: GraphConfig{
+			Enabled: false,
+			Neo4j: Neo4jConfig{
+				URI:                   "bolt://localhost:7687",
+				Username:              "neo4j",
+				Password:              "password",
+				Database:              "archivist",
+				MaxConnectionPoolSize: 100,
+				ConnectionTimeout:     60,
+				ConnectAttempts:       DefaultNeo4jConnectAttempts,
+				ConnectRetryDelay:     DefaultNeo4jConnectRetryDelay,
+			},
+			AsyncBuilding:   true,
+			MaxGraphWorkers: 2,
+			CitationExtraction: CitationExtractionConfig{
+				Enabled:             true,
+				PrioritizeInText:    true,
+				ConfidenceThreshold: 0.7,
+				ImportanceFilter:    []string{"high", "medium"},
+			},
+			Search: SearchConfig{
+				DefaultTopK:    10,
+				VectorWeight:   0.5,
+				GraphWeight:    0.3,
+				KeywordWeight:  0.2,
+				TraversalDepth: 2,
+			},
+			Optimization: OptimizationConfig{
+				MaxPapersInMemory:      50,
+				CacheGraphLayout:       true,
+				PrecomputeSimilarities: true,
+			},
+			Kafka: KafkaConfig{
+				Enabled: true,
+				Brokers: []string{DefaultKafkaBroker},
+				Topic:   DefaultKafkaTopic,
+			},
+		},
+		Visualization: VisualizationConfig{
+			Terminal: TerminalVisualizationConfig{Enabled: true, MaxNodesDisplayed: 15, LayoutAlgorithm: "force_directed"},
+			Web:      WebVisualizationConfig{Port: 8080},
+		},
+		HashAlgorithm: "sha256",
+		Logging: LoggingConfig{
+			Level:   "info",
+			File:    "./logs/processing.log",
+			Console: true,
+		},
+		Metadata: MetadataConfig{Backend: "json"},
+		Microservices: MicroservicesConfig{
+			GraphURL:     "http://localhost:8081",
+			RAGURL:       "http://localhost:8082",
+			PollInterval: 3,
+			MaxWait:      300,
+		},
+		Search: SearchServiceConfig{
+			BaseURL:  DefaultSearchBaseURL,
+			CacheTTL: 600,
+		},
+		Metrics: MetricsConfig{
+			Enabled: false,
+			Port:    9091,
+		},
+	}
+}
+
+// configHeader opens a generated config file
+const configHeader = `Archivist configuration
+Every setting can be overridden with an RPH_* environment variable named after
+its key path, e.g. RPH_PROCESSING_MAX_WORKERS=4 or RPH_GRAPH_NEO4J_URI=bolt://neo4j:7687
+Precedence: environment > this file > built-in defaults`
+
+// configComments explains config.yaml keys, indexed by their dotted path.
+// Sections get the comment above them, values get it at the end of the line.
+var configComments = map[string]string{
+	"input_dir":         "Folder scanned for research paper PDFs",
+	"tex_output_dir":    "Where generated .tex files are written",
+	"report_output_dir": "Where compiled PDF reports are written",
+
+	"processing":                    "Batch processing",
+	"processing.max_workers":        "Papers analyzed in parallel (at most the number of CPUs)",
+	"processing.batch_size":         "Papers queued per batch",
+	"processing.timeout_per_paper":  "Seconds a paper may take (analysis, LaTeX and compilation) before it is marked failed",
+	"processing.extract_figures":    "Embed the paper's key figures in LaTeX reports (needs pdfimages from poppler-utils; slower)",
+	"processing.max_inflight_bytes": "Total bytes of PDFs analyzed at once, to bound memory (0 = unlimited; a larger PDF still runs alone)",
+	"processing.dedup_by_metadata":  "Skip a paper whose title, authors and year match one already processed (one extra API call per paper)",
+
+	"gemini":                              "Gemini model settings (API key is read from GEMINI_API_KEY)",
+	"gemini.model":                        "Must start with 'models/'",
+	"gemini.max_tokens":                   "Maximum tokens per response",
+	"gemini.temperature":                  "0 = deterministic, 2 = most creative",
+	"gemini.rate_limit":                   "API requests per minute shared by all workers (0 = unlimited)",
+	"gemini.agentic":                      "Agentic workflow: validation and optional self-reflection passes",
+	"gemini.agentic.enabled":              "Validate generated LaTeX before compiling",
+	"gemini.agentic.max_iterations":       "Refinement passes per stage",
+	"gemini.agentic.self_reflection":      "Let the model critique its own analysis (slower)",
+	"gemini.agentic.multi_stage_analysis": "Split analysis into separate metadata/methodology/LaTeX stages (slower)",
+	"gemini.agentic.stages":               "Per-stage model overrides",
+	"gemini.agentic.retry":                "Retry and error recovery",
+
+	"llm":          "Model backend used for analysis and chat",
+	"llm.provider": "'gemini' or 'openai' (openai needs OPENAI_API_KEY)",
+
+	"latex":           "Report compilation",
+	"latex.compiler":  "pdflatex, xelatex or lualatex",
+	"latex.engine":    "'latexmk' runs the compiler as many times as needed",
+	"latex.clean_aux": "Remove .aux/.log files after compiling",
+	"latex.bibengine": "'bibtex' or 'biber' (used when the report has a bibliography)",
+	"latex.template":  "Optional template file wrapping each report; use {{.Title}}, {{.Preamble}} and {{.Body}}",
+
+	"cache":                      "Cache analysis results so re-processing is instant",
+	"cache.enabled":              "Requires Redis when type is 'redis'",
+	"cache.type":                 "'redis' or 'memory'",
+	"cache.ttl":                  "Hours before a cached analysis expires",
+	"cache.refresh_on_hit":       "Restart the TTL whenever a cached analysis is used (Redis only)",
+	"cache.memory.snapshot_file": "Persist the memory cache across runs ('' to disable)",
+
+	"faiss":           "Local vector index used by chat",
+	"faiss.index_dir": "Directory holding the index files",
+
+	"rag":                 "Chunking and retrieval for chat",
+	"rag.chunk_size":      "Characters per chunk; smaller = more precise, larger = more context",
+	"rag.chunk_overlap":   "Must be less than chunk_size",
+	"rag.top_k":           "Chunks retrieved per question",
+	"rag.score_threshold": "Minimum similarity score (0-1)",
+	"rag.rerank":          "Rerank retrieved chunks with BM25 (fetches 3x top_k candidates)",
+	"rag.recency_weight":  "Favor newer papers: scores shrink by this fraction per year of age (0 disables)",
+	"rag.index_metadata":  "Also index each paper's abstract, keywords, methods, datasets and metrics (one extra API call per paper)",
+	"rag.embedding_model": "Gemini embedding model for the vector index",
+	"rag.embedding_dim":   "Vector size of embedding_model; changing it requires 'rph reindex --force'",
+
+	"chat":               "Chat prompt",
+	"chat.system_prompt": "Go template for the chat prompt ('' = built-in student-friendly prompt); see README",
+
+	"graph":                                "Knowledge graph (needs Neo4j and Kafka, see scripts/setup_graph.sh)",
+	"graph.enabled":                        "Build a citation graph while processing",
+	"graph.neo4j.max_connection_pool_size": "Driver connection pool size (0 = driver default)",
+	"graph.neo4j.connection_timeout":       "Seconds to wait for a free pooled connection (0 = driver default)",
+	"graph.neo4j.connect_attempts":         "Tries to reach Neo4j before giving up (covers it still starting after docker compose up)",
+	"graph.neo4j.connect_retry_delay":      "Seconds before the first retry; doubles after each failed try",
+	"graph.max_graph_workers":              "Separate from paper workers; also caps open Neo4j sessions during batch ingestion",
+	"graph.kafka":                          "Kafka producer feeding the graph/RAG microservices",
+	"graph.kafka.brokers":                  "External listener (see docker-compose-graph.yml)",
+
+	"visualization": "Graph visualization",
+
+	"hash_algorithm": "Used to detect already-processed papers",
+
+	"logging":         "Logging",
+	"logging.level":   "debug, info, warn or error",
+	"logging.console": "Also log to the terminal",
+
+	"metadata":         "Processing history (used by list, status, export, reprocess-failed)",
+	"metadata.backend": "'json' (.metadata/hashes.json) or 'sqlite' (.metadata/metadata.db)",
+
+	"microservices":               "Graph/RAG microservices polled for progress after a batch is published to Kafka",
+	"microservices.poll_interval": "Seconds between status checks",
+	"microservices.max_wait":      "Seconds to wait before leaving them to finish in the background",
+
+	"search":           "Paper search service used by search, similar and the TUI",
+	"search.cache_ttl": "Seconds results are reused for the same query (0 disables)",
+
+	"metrics":      "Prometheus metrics served at http://localhost:<port>/metrics while 'rph process' runs",
+	"metrics.port": "9090 is left free for Prometheus itself",
+}
+
+// MarshalConfigYAML renders config as YAML using the same keys LoadConfig
+// reads, annotated with configComments
+func MarshalConfigYAML(config *Config) ([]byte, error) {
+	root, err := configNode(reflect.ValueOf(*config), "")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&yaml.Node{Kind: yaml.DocumentNode, HeadComment: configHeader, Content: []*yaml.Node{root}}); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// configNode converts a config value into a YAML node. Struct fields are keyed
+// by their mapstructure tag; fields without one (API keys, the CLI-selected
+// processing mode) are runtime-only and left out.
+func configNode(value reflect.Value, path string) (*yaml.Node, error) {
+	if value.Kind() != reflect.Struct {
+		var node yaml.Node
+		if err := node.Encode(value.Interface()); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", path, err)
+		}
+		if node.Kind == yaml.SequenceNode {
+			node.Style = yaml.FlowStyle
+		}
+		return &node, nil
+	}
+
+	mapping := &yaml.Node{Kind: yaml.MappingNode}
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		key := valueType.Field(i).Tag.Get("mapstructure")
+		if key == "" {
+			continue
+		}
+
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+
+		child, err := configNode(value.Field(i), fieldPath)
+		if err != nil {
+			return nil, err
+		}
+
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: key}
+		if comment, ok := configComments[fieldPath]; ok {
+			if child.Kind == yaml.MappingNode {
+				keyNode.HeadComment = comment
+			} else {
+				child.LineComment = comment
+			}
+		}
+
+		mapping.Content = append(mapping.Content, keyNode, child)
+	}
+
+	return mapping, nil
+}
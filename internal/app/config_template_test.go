@@ -0,0 +1,42 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDefaultConfigIsValid(t *testing.T) {
+	config := DefaultConfig()
+
+	require.NoError(t, validateConfig(config))
+	assert.False(t, config.Cache.Enabled)
+	assert.False(t, config.Graph.Enabled)
+	assert.Positive(t, config.Processing.MaxWorkers)
+}
+
+func TestMarshalConfigYAML(t *testing.T) {
+	data, err := MarshalConfigYAML(DefaultConfig())
+	require.NoError(t, err)
+
+	var raw map[string]interface{}
+	require.NoError(t, yaml.Unmarshal(data, &raw))
+
+	rag, ok := raw["rag"].(map[string]interface{})
+	require.True(t, ok, "rag section uses its mapstructure key")
+	assert.Equal(t, 2000, rag["chunk_size"])
+	assert.Equal(t, "models/gemini-2.0-flash-exp", raw["gemini"].(map[string]interface{})["model"])
+
+	// Runtime-only fields never end up in the file
+	assert.NotContains(t, string(data), "apikey")
+	assert.NotContains(t, string(data), "mode:")
+
+	assert.Contains(t, string(data), "# Precedence: environment > this file > built-in defaults\n")
+
+	// Sections are commented above, values at the end of the line
+	assert.Contains(t, string(data), "# Chunking and retrieval for chat\nrag:")
+	assert.Contains(t, string(data), "  top_k: 5 # Chunks retrieved per question\n")
+	assert.Contains(t, string(data), "brokers: ['localhost:9094']")
+}
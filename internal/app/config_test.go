@@ -0,0 +1,226 @@
+package app
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfigGraphAndFAISS(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{
+			name:   "defaults with graph enabled",
+			modify: func(c *Config) { c.Graph.Enabled = true },
+		},
+		{
+			name:    "missing FAISS index dir",
+			modify:  func(c *Config) { c.FAISS.IndexDir = " " },
+			wantErr: "faiss index_dir must be set",
+		},
+		{
+			name: "graph enabled without Neo4j URI",
+			modify: func(c *Config) {
+				c.Graph.Enabled = true
+				c.Graph.Neo4j.URI = ""
+			},
+			wantErr: "graph.neo4j.uri must be set",
+		},
+		{
+			name: "graph disabled ignores Neo4j URI",
+			modify: func(c *Config) {
+				c.Graph.Enabled = false
+				c.Graph.Neo4j.URI = ""
+			},
+		},
+		{
+			name: "Neo4j URI with wrong scheme",
+			modify: func(c *Config) {
+				c.Graph.Enabled = true
+				c.Graph.Neo4j.URI = "http://localhost:7474"
+			},
+			wantErr: "invalid graph.neo4j.uri",
+		},
+		{
+			name: "negative Neo4j connection pool size",
+			modify: func(c *Config) {
+				c.Graph.Enabled = true
+				c.Graph.Neo4j.MaxConnectionPoolSize = -1
+			},
+			wantErr: "graph.neo4j.max_connection_pool_size must be >= 0",
+		},
+		{
+			name: "negative Neo4j connection timeout",
+			modify: func(c *Config) {
+				c.Graph.Enabled = true
+				c.Graph.Neo4j.ConnectionTimeout = -5
+			},
+			wantErr: "graph.neo4j.connection_timeout must be >= 0",
+		},
+		{
+			name: "zero Neo4j connect attempts",
+			modify: func(c *Config) {
+				c.Graph.Enabled = true
+				c.Graph.Neo4j.ConnectAttempts = 0
+			},
+			wantErr: "graph.neo4j.connect_attempts must be >= 1",
+		},
+		{
+			name: "negative search weight",
+			modify: func(c *Config) {
+				c.Graph.Enabled = true
+				c.Graph.Search.KeywordWeight = -0.2
+				c.Graph.Search.VectorWeight = 0.9
+			},
+			wantErr: "must be >= 0",
+		},
+		{
+			name: "search weights too large",
+			modify: func(c *Config) {
+				c.Graph.Enabled = true
+				c.Graph.Search.VectorWeight = 1
+			},
+			wantErr: "must sum to 1.0, got 1.50",
+		},
+		{
+			name: "search weights roughly one",
+			modify: func(c *Config) {
+				c.Graph.Enabled = true
+				c.Graph.Search.VectorWeight = 0.33
+				c.Graph.Search.GraphWeight = 0.33
+				c.Graph.Search.KeywordWeight = 0.33
+			},
+		},
+		{
+			name:   "remote search service",
+			modify: func(c *Config) { c.Search.BaseURL = "https://search.example.org:8443" },
+		},
+		{
+			name:    "search service URL without scheme",
+			modify:  func(c *Config) { c.Search.BaseURL = "localhost:8000" },
+			wantErr: "search base_url must be an http(s) URL",
+		},
+		{
+			name:    "negative search cache TTL",
+			modify:  func(c *Config) { c.Search.CacheTTL = -1 },
+			wantErr: "search cache_ttl must be >= 0",
+		},
+		{
+			name:    "recency weight of one",
+			modify:  func(c *Config) { c.RAG.RecencyWeight = 1 },
+			wantErr: "rag recency_weight must be in range [0, 1)",
+		},
+		{
+			name:   "recency weight enabled",
+			modify: func(c *Config) { c.RAG.RecencyWeight = 0.1 },
+		},
+		{
+			name:    "zero embedding dimension",
+			modify:  func(c *Config) { c.RAG.EmbeddingDim = 0 },
+			wantErr: "rag embedding_dim must be > 0",
+		},
+		{
+			name:    "negative Gemini rate limit",
+			modify:  func(c *Config) { c.Gemini.RateLimit = -1 },
+			wantErr: "gemini.rate_limit must be >= 0",
+		},
+		{
+			name:    "negative in-flight byte budget",
+			modify:  func(c *Config) { c.Processing.MaxInflightBytes = -1 },
+			wantErr: "processing.max_inflight_bytes must be >= 0",
+		},
+		{
+			name:    "missing latex template",
+			modify:  func(c *Config) { c.Latex.Template = "/nonexistent/report.tex.tmpl" },
+			wantErr: "invalid latex.template",
+		},
+		{
+			name: "metrics enabled with invalid port",
+			modify: func(c *Config) {
+				c.Metrics.Enabled = true
+				c.Metrics.Port = 0
+			},
+			wantErr: "metrics.port must be between 1 and 65535",
+		},
+		{
+			name:   "metrics disabled ignores port",
+			modify: func(c *Config) { c.Metrics.Port = 0 },
+		},
+		{
+			name:    "chat prompt without question",
+			modify:  func(c *Config) { c.Chat.SystemPrompt = "You are a reviewer.\n{{.Context}}" },
+			wantErr: "chat prompt does not include {{.Question}}",
+		},
+		{
+			name:    "chat prompt with unknown field",
+			modify:  func(c *Config) { c.Chat.SystemPrompt = "{{.Context}}{{.Question}}{{.Paper}}" },
+			wantErr: "failed to render chat prompt",
+		},
+		{
+			name: "custom chat prompt",
+			modify: func(c *Config) {
+				c.Chat.SystemPrompt = "You are a peer reviewer.\n{{.Context}}\n{{.History}}Q: {{.Question}}"
+			},
+		},
+		{
+			name:    "unknown log level",
+			modify:  func(c *Config) { c.Logging.Level = "verbose" },
+			wantErr: "invalid log level",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(config)
+
+			err := validateConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigKeys(t *testing.T) {
+	keys := configKeys(reflect.TypeOf(Config{}), "")
+
+	assert.Contains(t, keys, "input_dir")
+	assert.Contains(t, keys, "processing.max_workers")
+	assert.Contains(t, keys, "graph.neo4j.uri")
+	assert.Contains(t, keys, "gemini.agentic.stages.latex_generation.validation")
+	assert.Contains(t, keys, "graph.kafka.brokers")
+
+	// Sections and runtime-only fields have no key of their own
+	assert.NotContains(t, keys, "graph")
+	assert.NotContains(t, keys, "processing.mode")
+	for _, key := range keys {
+		assert.NotContains(t, key, "apikey")
+	}
+}
+
+func TestEnvVarName(t *testing.T) {
+	assert.Equal(t, "RPH_PROCESSING_MAX_WORKERS", envVarName("processing.max_workers"))
+	assert.Equal(t, "RPH_GRAPH_NEO4J_URI", envVarName("graph.neo4j.uri"))
+}
+
+func TestEnvOverridden(t *testing.T) {
+	t.Setenv("RPH_INPUT_DIR", "/papers")
+
+	assert.True(t, envOverridden("input_dir"))
+	assert.False(t, envOverridden("report_output_dir"))
+}
+
+func TestLoadConfigMissingFile(t *testing.T) {
+	_, err := LoadConfig(filepath.Join(t.TempDir(), "config.yaml"))
+
+	assert.ErrorIs(t, err, ErrConfigNotFound)
+	assert.ErrorContains(t, err, "rph config init")
+}
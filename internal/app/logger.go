@@ -1,16 +1,71 @@
 package app
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 )
 
+// LogLevel orders log output from most to least verbose
+type LogLevel int32
+
+const (
+	LevelDebug LogLevel = iota // Per-stage timings and other detail
+	LevelInfo                  // Routine progress, e.g. each processing step
+	LevelWarn                  // Problems that don't stop the run
+	LevelError                 // Failures
+)
+
+// logLevelNames maps logging.level / --log-level values to levels
+var logLevelNames = map[string]LogLevel{
+	"debug":   LevelDebug,
+	"info":    LevelInfo,
+	"warn":    LevelWarn,
+	"warning": LevelWarn,
+	"error":   LevelError,
+}
+
+// ParseLogLevel parses a level name; an empty name means info
+func ParseLogLevel(name string) (LogLevel, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return LevelInfo, nil
+	}
+	level, ok := logLevelNames[name]
+	if !ok {
+		return LevelInfo, fmt.Errorf("invalid log level: %q (must be debug, info, warn or error)", name)
+	}
+	return level, nil
+}
+
+// currentLogLevel is the level set by the last InitLogger call
+var currentLogLevel atomic.Int32
+
+func init() {
+	currentLogLevel.Store(int32(LevelInfo))
+}
+
+// Debugf logs like log.Printf, but only when the log level is debug
+func Debugf(format string, args ...interface{}) {
+	if LogLevel(currentLogLevel.Load()) <= LevelDebug {
+		log.Output(2, fmt.Sprintf(format, args...))
+	}
+}
+
 // InitLogger initializes logging based on config
 // Returns a cleanup function that should be deferred to close log files
 func InitLogger(config *Config) (func(), error) {
+	level, err := ParseLogLevel(config.Logging.Level)
+	if err != nil {
+		return nil, err
+	}
+	currentLogLevel.Store(int32(level))
+
 	var writers []io.Writer
 	var logFile *os.File
 
@@ -38,7 +93,7 @@ func InitLogger(config *Config) (func(), error) {
 
 	if len(writers) > 0 {
 		multiWriter := io.MultiWriter(writers...)
-		log.SetOutput(multiWriter)
+		log.SetOutput(&levelFilter{w: multiWriter, level: level})
 	}
 
 	log.SetFlags(log.Ldate | log.Ltime | log.Lshortfile)
@@ -52,3 +107,42 @@ func InitLogger(config *Config) (func(), error) {
 
 	return cleanup, nil
 }
+
+// levelFilter drops log lines below level. The standard logger has no levels,
+// so a line's level is read from the markers warnings and errors are logged
+// with; see lineLevel.
+type levelFilter struct {
+	w     io.Writer
+	level LogLevel
+}
+
+func (f *levelFilter) Write(p []byte) (int, error) {
+	if lineLevel(p) < f.level {
+		return len(p), nil
+	}
+	return f.w.Write(p)
+}
+
+// Markers that make a log line a warning or an error
+var (
+	warnMarkers  = [][]byte{[]byte("⚠️"), []byte("warning")}
+	errorMarkers = [][]byte{[]byte("❌"), []byte("error"), []byte("failed")}
+)
+
+// lineLevel guesses the level of a log line: warnings and errors by their
+// markers, anything else is info. Debugf lines are filtered before they get
+// here, so they pass as info.
+func lineLevel(line []byte) LogLevel {
+	lower := bytes.ToLower(line)
+	for _, marker := range warnMarkers {
+		if bytes.Contains(lower, marker) {
+			return LevelWarn
+		}
+	}
+	for _, marker := range errorMarkers {
+		if bytes.Contains(lower, marker) {
+			return LevelError
+		}
+	}
+	return LevelInfo
+}
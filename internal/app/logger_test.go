@@ -0,0 +1,71 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    LogLevel
+		wantErr bool
+	}{
+		{name: "", want: LevelInfo},
+		{name: "debug", want: LevelDebug},
+		{name: "INFO", want: LevelInfo},
+		{name: " warn ", want: LevelWarn},
+		{name: "warning", want: LevelWarn},
+		{name: "error", want: LevelError},
+		{name: "verbose", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, err := ParseLogLevel(tt.name)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "invalid log level")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, level)
+		})
+	}
+}
+
+func TestLevelFilter(t *testing.T) {
+	lines := []string{
+		"[Worker 1] Processing: paper.pdf\n",
+		"  ⚠️  Failed to build graph: timeout\n",
+		"Warning: cache unavailable\n",
+		"[Worker 1] ❌ Analysis failed: quota exceeded\n",
+	}
+
+	tests := []struct {
+		level LogLevel
+		want  []string
+	}{
+		{level: LevelDebug, want: lines},
+		{level: LevelInfo, want: lines},
+		{level: LevelWarn, want: lines[1:]},
+		{level: LevelError, want: lines[3:]},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		filter := &levelFilter{w: &out, level: tt.level}
+		for _, line := range lines {
+			n, err := filter.Write([]byte(line))
+			assert.NoError(t, err)
+			assert.Equal(t, len(line), n)
+		}
+
+		var want bytes.Buffer
+		for _, line := range tt.want {
+			want.WriteString(line)
+		}
+		assert.Equal(t, want.String(), out.String(), "level %d", tt.level)
+	}
+}
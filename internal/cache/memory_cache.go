@@ -0,0 +1,210 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AnalysisCache is the surface shared by all analysis cache backends
+type AnalysisCache interface {
+	Get(ctx context.Context, contentHash string) (*CachedAnalysis, error)
+	Set(ctx context.Context, contentHash string, analysis *CachedAnalysis) error
+	// Delete evicts every cached analysis of the file with contentHash,
+	// including per-format variants, returning ErrNotCached if there were none
+	Delete(ctx context.Context, contentHash string) error
+	GetStats(ctx context.Context) (int64, error)
+	Close() error
+}
+
+// MemoryCache is an in-process analysis cache for users without Redis.
+// If a snapshot path is set, entries are persisted to disk as JSON so they
+// survive across runs.
+type MemoryCache struct {
+	mu           sync.RWMutex
+	entries      map[string]*CachedAnalysis
+	ttl          time.Duration
+	snapshotPath string
+}
+
+// NewMemoryCache creates a new in-memory cache, loading the snapshot file if one exists
+func NewMemoryCache(ttl time.Duration, snapshotPath string) (*MemoryCache, error) {
+	mc := &MemoryCache{
+		entries:      make(map[string]*CachedAnalysis),
+		ttl:          ttl,
+		snapshotPath: snapshotPath,
+	}
+
+	if snapshotPath != "" {
+		if err := mc.loadSnapshot(); err != nil {
+			return nil, err
+		}
+	}
+
+	return mc, nil
+}
+
+// Close flushes the snapshot to disk
+func (mc *MemoryCache) Close() error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	return mc.saveSnapshot()
+}
+
+// Get retrieves a cached analysis result by content hash
+func (mc *MemoryCache) Get(ctx context.Context, contentHash string) (*CachedAnalysis, error) {
+	mc.mu.RLock()
+	cached, ok := mc.entries[contentHash]
+	mc.mu.RUnlock()
+
+	if !ok {
+		return nil, nil
+	}
+
+	if mc.isExpired(cached) {
+		mc.mu.Lock()
+		// A concurrent Set may have replaced the entry since the read lock was released
+		if current, ok := mc.entries[contentHash]; ok && mc.isExpired(current) {
+			delete(mc.entries, contentHash)
+		}
+		mc.mu.Unlock()
+		return nil, nil
+	}
+
+	log.Printf("  🎯 Cache HIT for hash: %s (cached %.1f hours ago)",
+		shortHash(contentHash), time.Since(cached.CachedAt).Hours())
+
+	// Return a copy so callers can't mutate the cached entry
+	entry := *cached
+	return &entry, nil
+}
+
+// Set stores an analysis result in the cache
+func (mc *MemoryCache) Set(ctx context.Context, contentHash string, analysis *CachedAnalysis) error {
+	entry := *analysis
+	entry.CachedAt = time.Now()
+	entry.ContentHash = contentHash
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	mc.entries[contentHash] = &entry
+
+	if err := mc.saveSnapshot(); err != nil {
+		return err
+	}
+
+	log.Printf("  💾 Cached analysis for hash: %s (TTL: %.0f hours)", shortHash(contentHash), mc.ttl.Hours())
+	return nil
+}
+
+// Delete evicts the entry for contentHash and its "<hash>:<format>" variants
+// and persists the snapshot
+func (mc *MemoryCache) Delete(ctx context.Context, contentHash string) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	deleted := 0
+	for key := range mc.entries {
+		if key == contentHash || strings.HasPrefix(key, contentHash+":") {
+			delete(mc.entries, key)
+			deleted++
+		}
+	}
+	if deleted == 0 {
+		return ErrNotCached
+	}
+
+	if err := mc.saveSnapshot(); err != nil {
+		return err
+	}
+
+	log.Printf("  🗑️  Deleted %d cache entries for hash: %s", deleted, shortHash(contentHash))
+	return nil
+}
+
+// GetStats returns the number of live (unexpired) entries
+func (mc *MemoryCache) GetStats(ctx context.Context) (int64, error) {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+
+	var count int64
+	for _, cached := range mc.entries {
+		if !mc.isExpired(cached) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// isExpired reports whether an entry has outlived the cache TTL
+func (mc *MemoryCache) isExpired(cached *CachedAnalysis) bool {
+	return mc.ttl > 0 && time.Since(cached.CachedAt) > mc.ttl
+}
+
+// loadSnapshot reads previously cached entries from disk, dropping expired ones
+func (mc *MemoryCache) loadSnapshot() error {
+	data, err := os.ReadFile(mc.snapshotPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read cache snapshot: %w", err)
+	}
+
+	var entries map[string]*CachedAnalysis
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("failed to parse cache snapshot: %w", err)
+	}
+
+	for hash, cached := range entries {
+		if cached != nil && !mc.isExpired(cached) {
+			mc.entries[hash] = cached
+		}
+	}
+
+	return nil
+}
+
+// saveSnapshot writes all entries to disk. The caller must hold the lock.
+func (mc *MemoryCache) saveSnapshot() error {
+	if mc.snapshotPath == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(mc.snapshotPath), 0755); err != nil {
+		return fmt.Errorf("failed to create cache snapshot directory: %w", err)
+	}
+
+	data, err := json.Marshal(mc.entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache snapshot: %w", err)
+	}
+
+	tmpPath := mc.snapshotPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache snapshot: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, mc.snapshotPath); err != nil {
+		return fmt.Errorf("failed to replace cache snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// shortHash truncates a hash for log output
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache_SetGet(t *testing.T) {
+	ctx := context.Background()
+	mc, err := NewMemoryCache(time.Hour, "")
+	require.NoError(t, err)
+
+	miss, err := mc.Get(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Nil(t, miss)
+
+	require.NoError(t, mc.Set(ctx, "abc123", &CachedAnalysis{PaperTitle: "Paper", LatexContent: "\\section{A}"}))
+
+	hit, err := mc.Get(ctx, "abc123")
+	require.NoError(t, err)
+	require.NotNil(t, hit)
+	assert.Equal(t, "Paper", hit.PaperTitle)
+	assert.Equal(t, "abc123", hit.ContentHash)
+
+	count, err := mc.GetStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestMemoryCache_TTLExpiry(t *testing.T) {
+	ctx := context.Background()
+	mc, err := NewMemoryCache(time.Millisecond, "")
+	require.NoError(t, err)
+
+	require.NoError(t, mc.Set(ctx, "abc123", &CachedAnalysis{PaperTitle: "Paper"}))
+	time.Sleep(5 * time.Millisecond)
+
+	expired, err := mc.Get(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Nil(t, expired, "Expired entries should be treated as a miss")
+}
+
+func TestMemoryCache_SnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	snapshot := filepath.Join(t.TempDir(), "analysis_cache.json")
+
+	mc, err := NewMemoryCache(time.Hour, snapshot)
+	require.NoError(t, err)
+	require.NoError(t, mc.Set(ctx, "abc123", &CachedAnalysis{PaperTitle: "Paper"}))
+	require.NoError(t, mc.Close())
+
+	reopened, err := NewMemoryCache(time.Hour, snapshot)
+	require.NoError(t, err)
+
+	hit, err := reopened.Get(ctx, "abc123")
+	require.NoError(t, err)
+	require.NotNil(t, hit)
+	assert.Equal(t, "Paper", hit.PaperTitle)
+}
+
+func TestMemoryCache_DeletePersists(t *testing.T) {
+	ctx := context.Background()
+	snapshot := filepath.Join(t.TempDir(), "analysis_cache.json")
+
+	mc, err := NewMemoryCache(time.Hour, snapshot)
+	require.NoError(t, err)
+	require.NoError(t, mc.Set(ctx, "abc123", &CachedAnalysis{PaperTitle: "Paper"}))
+	require.NoError(t, mc.Set(ctx, "abc123:markdown", &CachedAnalysis{PaperTitle: "Paper"}))
+	require.NoError(t, mc.Set(ctx, "def456", &CachedAnalysis{PaperTitle: "Other"}))
+
+	require.NoError(t, mc.Delete(ctx, "abc123"))
+	assert.ErrorIs(t, mc.Delete(ctx, "abc123"), ErrNotCached)
+
+	// The eviction must survive a restart, or the next run would still skip the paper
+	reopened, err := NewMemoryCache(time.Hour, snapshot)
+	require.NoError(t, err)
+
+	for _, key := range []string{"abc123", "abc123:markdown"} {
+		miss, err := reopened.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Nil(t, miss, key)
+	}
+	hit, err := reopened.Get(ctx, "def456")
+	require.NoError(t, err)
+	assert.NotNil(t, hit)
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -21,9 +22,10 @@ type CachedAnalysis struct {
 
 // RedisCache handles Redis-based caching for paper analysis
 type RedisCache struct {
-	client *redis.Client
-	ttl    time.Duration
-	prefix string
+	client       *redis.Client
+	ttl          time.Duration
+	prefix       string
+	refreshOnHit bool // Reset an entry's TTL when Get returns it
 }
 
 // NewRedisCache creates a new Redis cache instance
@@ -51,6 +53,12 @@ func NewRedisCache(addr, password string, db int, ttl time.Duration) (*RedisCach
 	}, nil
 }
 
+// SetRefreshOnHit makes Get restart an entry's TTL on every hit, so analyses
+// that keep being used don't expire
+func (rc *RedisCache) SetRefreshOnHit(refresh bool) {
+	rc.refreshOnHit = refresh
+}
+
 // Close closes the Redis connection
 func (rc *RedisCache) Close() error {
 	return rc.client.Close()
@@ -74,6 +82,13 @@ func (rc *RedisCache) Get(ctx context.Context, contentHash string) (*CachedAnaly
 		return nil, fmt.Errorf("failed to unmarshal cached data: %w", err)
 	}
 
+	// A TTL of zero means entries never expire, and EXPIRE 0 would delete the key
+	if rc.refreshOnHit && rc.ttl > 0 {
+		if err := rc.client.Expire(ctx, key, rc.ttl).Err(); err != nil {
+			log.Printf("  ⚠️  Failed to refresh cache TTL: %v", err)
+		}
+	}
+
 	log.Printf("  🎯 Cache HIT for hash: %s (cached %.1f hours ago)",
 		contentHash[:12], time.Since(cached.CachedAt).Hours())
 
@@ -102,8 +117,8 @@ func (rc *RedisCache) Set(ctx context.Context, contentHash string, analysis *Cac
 	return nil
 }
 
-// Clear removes all cached entries with the archivist prefix
-func (rc *RedisCache) Clear(ctx context.Context) (int64, error) {
+// Clear removes all cached entries with the archivist prefix and returns how many were removed
+func (rc *RedisCache) Clear(ctx context.Context) (int, error) {
 	iter := rc.client.Scan(ctx, 0, rc.prefix+"*", 0).Iterator()
 
 	var keys []string
@@ -124,7 +139,7 @@ func (rc *RedisCache) Clear(ctx context.Context) (int64, error) {
 		return 0, fmt.Errorf("failed to delete keys: %w", err)
 	}
 
-	return deleted, nil
+	return int(deleted), nil
 }
 
 // GetStats returns cache statistics
@@ -155,20 +170,37 @@ func (rc *RedisCache) Exists(ctx context.Context, contentHash string) (bool, err
 	return result > 0, nil
 }
 
-// Delete removes a specific cache entry by content hash
+// Delete evicts every cached analysis of the file with contentHash (see Invalidate)
 func (rc *RedisCache) Delete(ctx context.Context, contentHash string) error {
-	key := rc.prefix + contentHash
+	return rc.Invalidate(ctx, contentHash)
+}
+
+// ErrNotCached is returned by Delete and Invalidate when nothing is cached for a hash
+var ErrNotCached = errors.New("no cached analysis")
+
+// Invalidate evicts every cached analysis of the file with contentHash,
+// including per-format variants keyed "<hash>:<format>", so the next run
+// sends the paper to the model again
+func (rc *RedisCache) Invalidate(ctx context.Context, contentHash string) error {
+	keys := []string{rc.prefix + contentHash}
 
-	deleted, err := rc.client.Del(ctx, key).Result()
+	iter := rc.client.Scan(ctx, 0, rc.prefix+contentHash+":*", 0).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to scan keys: %w", err)
+	}
+
+	deleted, err := rc.client.Del(ctx, keys...).Result()
 	if err != nil {
 		return fmt.Errorf("failed to delete cache entry: %w", err)
 	}
-
 	if deleted == 0 {
-		return fmt.Errorf("cache entry not found")
+		return ErrNotCached
 	}
 
-	log.Printf("  🗑️  Deleted cache entry for hash: %s", contentHash[:12])
+	log.Printf("  🗑️  Invalidated %d cache entries for hash: %s", deleted, shortHash(contentHash))
 	return nil
 }
 
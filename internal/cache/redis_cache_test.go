@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRedisCache connects to the Redis at ARCHIVIST_TEST_REDIS_ADDR
+// (default localhost:6379, database 15), skipping the test if there is none.
+// Keys get a per-test prefix and are removed afterwards.
+func newTestRedisCache(t *testing.T, ttl time.Duration) *RedisCache {
+	t.Helper()
+
+	addr := os.Getenv("ARCHIVIST_TEST_REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	rc, err := NewRedisCache(addr, "", 15, ttl)
+	if err != nil {
+		t.Skipf("Redis not available at %s: %v", addr, err)
+	}
+
+	rc.prefix = fmt.Sprintf("archivist:test:%d:", time.Now().UnixNano())
+	t.Cleanup(func() {
+		rc.Clear(context.Background())
+		rc.Close()
+	})
+	return rc
+}
+
+func TestRedisCache_RefreshOnHit(t *testing.T) {
+	ctx := context.Background()
+	const ttl = 2 * time.Second
+
+	for _, refresh := range []bool{true, false} {
+		t.Run(fmt.Sprintf("refresh=%v", refresh), func(t *testing.T) {
+			rc := newTestRedisCache(t, ttl)
+			rc.SetRefreshOnHit(refresh)
+
+			require.NoError(t, rc.Set(ctx, "abc123def456", &CachedAnalysis{PaperTitle: "Paper"}))
+			time.Sleep(time.Second)
+
+			hit, err := rc.Get(ctx, "abc123def456")
+			require.NoError(t, err)
+			require.NotNil(t, hit)
+
+			remaining, err := rc.client.PTTL(ctx, rc.prefix+"abc123def456").Result()
+			require.NoError(t, err)
+			if refresh {
+				assert.Greater(t, remaining, 1500*time.Millisecond, "TTL restarts on a hit")
+			} else {
+				assert.LessOrEqual(t, remaining, time.Second, "TTL keeps counting down")
+			}
+		})
+	}
+}
@@ -2,11 +2,15 @@ package chat
 
 import (
 	"archivist/internal/analyzer"
+	"archivist/internal/app"
 	"archivist/internal/rag"
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -34,21 +38,32 @@ type ChatSession struct {
 	Messages      []Message `json:"messages"`
 	CreatedAt     time.Time `json:"created_at"`
 	LastUpdated   time.Time `json:"last_updated"`
+
+	// Summary condenses the first SummarizedCount messages once the history
+	// outgrows the prompt budget; see updateSummary
+	Summary         string `json:"summary,omitempty"`
+	SummarizedCount int    `json:"summarized_count,omitempty"`
 }
 
 // ChatEngine handles RAG-powered chat interactions
 type ChatEngine struct {
 	retriever    *rag.Retriever
-	geminiClient *analyzer.GeminiClient
+	llmClient    analyzer.LLMClient
 	redisClient  *redis.Client
+	prompt       *template.Template
 }
 
-// NewChatEngine creates a new chat engine
-func NewChatEngine(retriever *rag.Retriever, geminiClient *analyzer.GeminiClient, redisClient *redis.Client) *ChatEngine {
+// NewChatEngine creates a new chat engine. prompt is the template from
+// app.ParseChatPrompt; nil uses app.DefaultChatPrompt.
+func NewChatEngine(retriever *rag.Retriever, llmClient analyzer.LLMClient, redisClient *redis.Client, prompt *template.Template) *ChatEngine {
+	if prompt == nil {
+		prompt = template.Must(app.ParseChatPrompt(""))
+	}
 	return &ChatEngine{
 		retriever:    retriever,
-		geminiClient: geminiClient,
+		llmClient:    llmClient,
 		redisClient:  redisClient,
+		prompt:       prompt,
 	}
 }
 
@@ -76,6 +91,17 @@ func (ce *ChatEngine) StartSession(ctx context.Context, paperTitles []string) (*
 
 // Chat processes a user message and generates a response
 func (ce *ChatEngine) Chat(ctx context.Context, session *ChatSession, userMessage string) (*Message, error) {
+	return ce.respond(ctx, session, userMessage, nil)
+}
+
+// ChatStream is like Chat but calls onChunk with each piece of the response as
+// it is generated. Clients without streaming support deliver it in one chunk.
+func (ce *ChatEngine) ChatStream(ctx context.Context, session *ChatSession, userMessage string, onChunk func(string)) (*Message, error) {
+	return ce.respond(ctx, session, userMessage, onChunk)
+}
+
+// respond retrieves context, generates the assistant's reply and saves the session
+func (ce *ChatEngine) respond(ctx context.Context, session *ChatSession, userMessage string, onChunk func(string)) (*Message, error) {
 	if userMessage == "" {
 		return nil, fmt.Errorf("empty message")
 	}
@@ -112,12 +138,24 @@ func (ce *ChatEngine) Chat(ctx context.Context, session *ChatSession, userMessag
 
 	log.Printf("  ✓ Retrieved %d relevant chunks", len(retrievedContext.Chunks))
 
-	// Build prompt with context and conversation history
-	prompt := ce.buildPrompt(session, userMessage, retrievedContext)
+	// Build prompt with context and conversation history, summarizing older turns if it grew too long
+	ce.updateSummary(ctx, session)
+	prompt, err := ce.buildPrompt(session, userMessage, retrievedContext)
+	if err != nil {
+		return nil, err
+	}
 
-	// Generate response using Gemini
+	// Generate response, streaming it if the caller and client support it
 	log.Println("  🤖 Generating response...")
-	response, err := ce.geminiClient.GenerateText(ctx, prompt)
+	var response string
+	if streamer, ok := ce.llmClient.(analyzer.StreamingLLMClient); ok && onChunk != nil {
+		response, err = streamer.GenerateTextStream(ctx, prompt, onChunk)
+	} else {
+		response, err = ce.llmClient.GenerateText(ctx, prompt)
+		if err == nil && onChunk != nil {
+			onChunk(response)
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate response: %w", err)
 	}
@@ -244,6 +282,30 @@ func (ce *ChatEngine) ExportSessionToLatex(session *ChatSession) string {
 	return latex
 }
 
+// ExportSessionToDocument wraps ExportSessionToLatex in a standalone
+// article that can be compiled to PDF on its own
+func (ce *ChatEngine) ExportSessionToDocument(session *ChatSession) string {
+	title := "Chat Session"
+	if len(session.PaperTitles) == 1 {
+		title = "Chat Session: " + escapeLatex(session.PaperTitles[0])
+	}
+
+	var b strings.Builder
+	b.WriteString("\\documentclass[11pt]{article}\n")
+	b.WriteString("\\usepackage[utf8]{inputenc}\n")
+	b.WriteString("\\usepackage[T1]{fontenc}\n")
+	b.WriteString("\\usepackage{lmodern}\n")
+	b.WriteString("\\usepackage[margin=1in]{geometry}\n")
+	b.WriteString("\\usepackage{hyperref}\n\n")
+	b.WriteString(fmt.Sprintf("\\title{%s}\n", title))
+	b.WriteString(fmt.Sprintf("\\date{%s}\n\n", session.LastUpdated.Format("January 2, 2006")))
+	b.WriteString("\\begin{document}\n\\maketitle\n\n")
+	b.WriteString(ce.ExportSessionToLatex(session))
+	b.WriteString("\\end{document}\n")
+
+	return b.String()
+}
+
 // saveSession saves a session to Redis
 func (ce *ChatEngine) saveSession(ctx context.Context, session *ChatSession) error {
 	key := ChatHistoryPrefix + session.ID
@@ -262,59 +324,39 @@ func (ce *ChatEngine) saveSession(ctx context.Context, session *ChatSession) err
 }
 
 // buildPrompt builds the RAG prompt with context and history
-func (ce *ChatEngine) buildPrompt(session *ChatSession, userMessage string, context *rag.RetrievedContext) string {
-	prompt := "You are a helpful AI research assistant for CS students studying AI/ML papers.\n\n"
-
-	// Add paper context
-	if len(session.PaperTitles) > 0 {
-		prompt += "You are discussing the following papers:\n"
-		for _, title := range session.PaperTitles {
-			prompt += fmt.Sprintf("- %s\n", title)
-		}
-		prompt += "\n"
+func (ce *ChatEngine) buildPrompt(session *ChatSession, userMessage string, context *rag.RetrievedContext) (string, error) {
+	data := app.ChatPromptData{
+		Papers:   session.PaperTitles,
+		Context:  context.Context,
+		Question: userMessage,
 	}
 
-	// Add retrieved context
-	prompt += "RELEVANT CONTEXT FROM PAPERS:\n"
-	prompt += "---\n"
-	prompt += context.Context
-	prompt += "---\n\n"
-
-	// Add conversation history (last 3 exchanges to keep context manageable)
-	if len(session.Messages) > 1 {
-		prompt += "CONVERSATION HISTORY:\n"
-		startIdx := len(session.Messages) - 6 // Last 3 Q&A pairs
-		if startIdx < 0 {
-			startIdx = 0
-		}
+	// The summary of older turns, then the recent ones verbatim
+	var history strings.Builder
+	if session.Summary != "" {
+		history.WriteString("CONVERSATION SO FAR (summary):\n")
+		history.WriteString(session.Summary + "\n\n")
+	}
 
-		for i := startIdx; i < len(session.Messages)-1; i++ {
-			msg := session.Messages[i]
+	messages := session.history()
+	if start := historyStart(session); start < len(messages) {
+		history.WriteString("CONVERSATION HISTORY:\n")
+		for _, msg := range messages[start:] {
 			if msg.Role == "user" {
-				prompt += fmt.Sprintf("User: %s\n", msg.Content)
+				history.WriteString(fmt.Sprintf("User: %s\n", msg.Content))
 			} else {
-				prompt += fmt.Sprintf("Assistant: %s\n", msg.Content)
+				history.WriteString(fmt.Sprintf("Assistant: %s\n", msg.Content))
 			}
 		}
-		prompt += "\n"
+		history.WriteString("\n")
 	}
+	data.History = history.String()
 
-	// Add current question
-	prompt += "CURRENT QUESTION:\n"
-	prompt += userMessage + "\n\n"
-
-	// Add instructions
-	prompt += "INSTRUCTIONS:\n"
-	prompt += "- Answer the question using the provided context from the papers.\n"
-	prompt += "- Be clear, concise, and student-friendly.\n"
-	prompt += "- Cite specific sections when referencing information (e.g., 'According to Section 3.2...').\n"
-	prompt += "- If the context doesn't contain enough information, say so.\n"
-	prompt += "- Use technical terms but explain them when first introduced.\n"
-	prompt += "- If comparing multiple papers, clearly distinguish between them.\n\n"
-
-	prompt += "ANSWER:"
-
-	return prompt
+	var prompt bytes.Buffer
+	if err := ce.prompt.Execute(&prompt, data); err != nil {
+		return "", fmt.Errorf("failed to render chat prompt: %w", err)
+	}
+	return prompt.String(), nil
 }
 
 // extractCitations extracts citation information from retrieved context
@@ -323,10 +365,7 @@ func (ce *ChatEngine) extractCitations(context *rag.RetrievedContext) []string {
 	seen := make(map[string]bool)
 
 	for _, chunk := range context.Chunks {
-		citation := chunk.Document.Source
-		if chunk.Document.Section != "" {
-			citation += fmt.Sprintf(" (Section: %s)", chunk.Document.Section)
-		}
+		citation := rag.FormatCitation(chunk.Document)
 
 		if !seen[citation] {
 			seen[citation] = true
@@ -346,39 +385,30 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen] + "..."
 }
 
-func escapeLatex(text string) string {
-	replacements := map[string]string{
-		"\\": "\\textbackslash{}",
-		"&":  "\\&",
-		"%":  "\\%",
-		"$":  "\\$",
-		"#":  "\\#",
-		"_":  "\\_",
-		"{":  "\\{",
-		"}":  "\\}",
-		"~":  "\\textasciitilde{}",
-		"^":  "\\textasciicircum{}",
-	}
-
-	for old, new := range replacements {
-		text = replaceAll(text, old, new)
-	}
-
-	return text
+// latexEscapes maps LaTeX special characters to their escaped form
+var latexEscapes = map[rune]string{
+	'\\': "\\textbackslash{}",
+	'&':  "\\&",
+	'%':  "\\%",
+	'$':  "\\$",
+	'#':  "\\#",
+	'_':  "\\_",
+	'{':  "\\{",
+	'}':  "\\}",
+	'~':  "\\textasciitilde{}",
+	'^':  "\\textasciicircum{}",
 }
 
-func replaceAll(s, old, new string) string {
-	result := ""
-	for i := 0; i < len(s); i++ {
-		found := false
-		if i+len(old) <= len(s) && s[i:i+len(old)] == old {
-			result += new
-			i += len(old) - 1
-			found = true
-		}
-		if !found {
-			result += string(s[i])
+// escapeLatex escapes LaTeX special characters in a single pass, so the
+// braces and backslashes produced by one replacement are never re-escaped
+func escapeLatex(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if escaped, ok := latexEscapes[r]; ok {
+			b.WriteString(escaped)
+		} else {
+			b.WriteRune(r)
 		}
 	}
-	return result
+	return b.String()
 }
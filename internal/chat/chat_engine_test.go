@@ -0,0 +1,104 @@
+package chat
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/rag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeLatex(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain", "attention is all you need", "attention is all you need"},
+		{"ampersand and percent", "Q&A at 95%", "Q\\&A at 95\\%"},
+		{"braces", "map[string]{}", "map[string]\\{\\}"},
+		{"backslash", "C:\\path", "C:\\textbackslash{}path"},
+		{"code snippet", "for (i = 0; i < n; i++) { x_i = a^2 + $b }",
+			"for (i = 0; i < n; i++) \\{ x\\_i = a\\textasciicircum{}2 + \\$b \\}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, escapeLatex(tt.input))
+		})
+	}
+}
+
+func TestExportSessionToDocument(t *testing.T) {
+	ce := &ChatEngine{}
+	session := &ChatSession{
+		ID:          "session_1",
+		PaperTitles: []string{"Attention_Is_All_You_Need"},
+		Messages: []Message{
+			{Role: "user", Content: "What is self-attention?"},
+			{Role: "assistant", Content: "It relates positions of a sequence.", Citations: []string{"Section 3.2"}},
+		},
+		LastUpdated: time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC),
+	}
+
+	doc := ce.ExportSessionToDocument(session)
+
+	assert.True(t, strings.HasPrefix(doc, "\\documentclass"))
+	assert.Contains(t, doc, "\\title{Chat Session: Attention\\_Is\\_All\\_You\\_Need}")
+	assert.Contains(t, doc, "\\date{March 14, 2025}")
+	assert.Contains(t, doc, ce.ExportSessionToLatex(session))
+	assert.True(t, strings.HasSuffix(doc, "\\end{document}\n"))
+}
+
+func TestExtractCitationsIncludesPages(t *testing.T) {
+	ce := &ChatEngine{}
+	context := &rag.RetrievedContext{
+		Chunks: []rag.SearchResult{
+			{Document: rag.VectorDocument{Source: "BERT", Section: "3.1", Metadata: map[string]string{rag.PageKey: "4"}}},
+			{Document: rag.VectorDocument{Source: "BERT", Section: "3.1", Metadata: map[string]string{rag.PageKey: "4"}}},
+			{Document: rag.VectorDocument{Source: "BERT", Metadata: map[string]string{rag.PageKey: "9"}}},
+			{Document: rag.VectorDocument{Source: "ResNet", Section: "Method"}},
+		},
+	}
+
+	assert.Equal(t, []string{"BERT, p.4 (Section 3.1)", "BERT, p.9", "ResNet (Section Method)"},
+		ce.extractCitations(context))
+}
+
+func TestBuildPromptDefault(t *testing.T) {
+	ce := NewChatEngine(nil, nil, nil, nil)
+	session := &ChatSession{
+		PaperTitles: []string{"BERT"},
+		Messages: []Message{
+			{Role: "user", Content: "What is masked language modeling?"},
+			{Role: "assistant", Content: "Predicting hidden tokens."},
+			{Role: "user", Content: "Why mask only 15%?"},
+		},
+	}
+
+	prompt, err := ce.buildPrompt(session, "Why mask only 15%?", &rag.RetrievedContext{Context: "[BERT, Section 3.1]\nWe mask 15% of tokens.\n"})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(prompt, "You are a helpful AI research assistant for CS students"))
+	assert.Contains(t, prompt, "You are discussing the following papers:\n- BERT\n\nRELEVANT CONTEXT FROM PAPERS:\n---\n[BERT, Section 3.1]\nWe mask 15% of tokens.\n---\n\n")
+	assert.Contains(t, prompt, "CONVERSATION HISTORY:\nUser: What is masked language modeling?\nAssistant: Predicting hidden tokens.\n\nCURRENT QUESTION:\nWhy mask only 15%?\n\nINSTRUCTIONS:\n")
+	assert.True(t, strings.HasSuffix(prompt, "ANSWER:"))
+}
+
+func TestBuildPromptCustomTemplate(t *testing.T) {
+	tmpl, err := app.ParseChatPrompt("You are a peer reviewer for {{range .Papers}}{{.}} {{end}}\n{{.Context}}{{.History}}Q: {{.Question}}")
+	require.NoError(t, err)
+	ce := NewChatEngine(nil, nil, nil, tmpl)
+	session := &ChatSession{
+		PaperTitles: []string{"BERT"},
+		Messages:    []Message{{Role: "user", Content: "Is the ablation convincing?"}},
+	}
+
+	prompt, err := ce.buildPrompt(session, "Is the ablation convincing?", &rag.RetrievedContext{Context: "ctx\n"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "You are a peer reviewer for BERT \nctx\nQ: Is the ablation convincing?", prompt)
+}
@@ -0,0 +1,99 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+const (
+	// historyTokenBudget is roughly how many tokens of earlier conversation a
+	// prompt may carry verbatim before older turns are summarized
+	historyTokenBudget = 3000
+	// recentHistoryMessages is how many of the latest messages (3 Q&A pairs)
+	// stay verbatim when older ones are summarized
+	recentHistoryMessages = 6
+	// charsPerToken is the rough size of a token in English text
+	charsPerToken = 4
+)
+
+// estimateTokens approximates the tokens taken by the messages' content
+func estimateTokens(messages []Message) int {
+	chars := 0
+	for _, msg := range messages {
+		chars += len(msg.Content)
+	}
+	return chars / charsPerToken
+}
+
+// history returns the messages before the current question
+func (s *ChatSession) history() []Message {
+	if len(s.Messages) == 0 {
+		return nil
+	}
+	return s.Messages[:len(s.Messages)-1]
+}
+
+// historyStart returns the index of the first message the prompt quotes
+// verbatim. Messages covered by the summary are skipped, and if the rest is
+// still over budget (say the summary couldn't be made) only the latest
+// recentHistoryMessages are kept.
+func historyStart(session *ChatSession) int {
+	history := session.history()
+	start := min(session.SummarizedCount, len(history))
+	if estimateTokens(history[start:]) > historyTokenBudget {
+		start = max(start, len(history)-recentHistoryMessages)
+	}
+	return start
+}
+
+// updateSummary folds older turns into session.Summary once the unsummarized
+// history exceeds historyTokenBudget, keeping the latest recentHistoryMessages
+// out of it. The summary is stored on the session, so each turn is summarized
+// once. If the model call fails the session is left as it was.
+func (ce *ChatEngine) updateSummary(ctx context.Context, session *ChatSession) {
+	history := session.history()
+	start := min(session.SummarizedCount, len(history))
+	pending := history[start:]
+	if len(pending) <= recentHistoryMessages || estimateTokens(pending) <= historyTokenBudget {
+		return
+	}
+
+	cut := len(history) - recentHistoryMessages
+	log.Printf("  📝 Summarizing %d earlier messages to fit the prompt...", cut-start)
+	summary, err := ce.llmClient.GenerateText(ctx, buildSummaryPrompt(session.Summary, history[start:cut]))
+	if err != nil {
+		log.Printf("  ⚠️  Failed to summarize conversation, keeping only recent messages: %v", err)
+		return
+	}
+
+	session.Summary = strings.TrimSpace(summary)
+	session.SummarizedCount = cut
+}
+
+// buildSummaryPrompt asks for previous (the summary so far, if any) extended
+// with messages
+func buildSummaryPrompt(previous string, messages []Message) string {
+	var b strings.Builder
+	b.WriteString("Summarize this conversation between a student and a research assistant about AI/ML papers.\n")
+	b.WriteString("Keep the questions asked, the key facts and conclusions in the answers, and any papers or sections referred to.\n")
+	b.WriteString("Write at most 200 words of plain prose, no preamble.\n\n")
+
+	if previous != "" {
+		b.WriteString("SUMMARY OF THE EARLIER CONVERSATION:\n")
+		b.WriteString(previous + "\n\n")
+	}
+
+	b.WriteString("MESSAGES:\n")
+	for _, msg := range messages {
+		if msg.Role == "user" {
+			b.WriteString(fmt.Sprintf("User: %s\n", msg.Content))
+		} else {
+			b.WriteString(fmt.Sprintf("Assistant: %s\n", msg.Content))
+		}
+	}
+
+	b.WriteString("\nSUMMARY:")
+	return b.String()
+}
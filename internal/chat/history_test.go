@@ -0,0 +1,113 @@
+package chat
+
+import (
+	"archivist/internal/rag"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLLM returns reply for every prompt and records the prompts it got
+type fakeLLM struct {
+	reply   string
+	err     error
+	prompts []string
+}
+
+func (f *fakeLLM) GenerateText(ctx context.Context, prompt string) (string, error) {
+	f.prompts = append(f.prompts, prompt)
+	return f.reply, f.err
+}
+
+func (f *fakeLLM) AnalyzePDFWithVision(ctx context.Context, pdfPath, prompt string) (string, error) {
+	return "", errors.New("not supported")
+}
+
+func (f *fakeLLM) Close() error { return nil }
+
+// longSession builds a session of n alternating messages of size chars each,
+// followed by the current question
+func longSession(n, size int) *ChatSession {
+	session := &ChatSession{ID: "session_1"}
+	for i := 0; i < n; i++ {
+		role := "user"
+		if i%2 == 1 {
+			role = "assistant"
+		}
+		content := fmt.Sprintf("message %d ", i) + strings.Repeat("x", size)
+		session.Messages = append(session.Messages, Message{Role: role, Content: content})
+	}
+	session.Messages = append(session.Messages, Message{Role: "user", Content: "current question"})
+	return session
+}
+
+func TestUpdateSummaryUnderBudget(t *testing.T) {
+	llm := &fakeLLM{reply: "summary"}
+	ce := NewChatEngine(nil, llm, nil, nil)
+	session := longSession(10, 100)
+
+	ce.updateSummary(context.Background(), session)
+
+	assert.Empty(t, llm.prompts)
+	assert.Empty(t, session.Summary)
+	assert.Equal(t, 0, historyStart(session))
+}
+
+func TestUpdateSummaryCachesSummary(t *testing.T) {
+	llm := &fakeLLM{reply: "  The user asked about attention.  "}
+	ce := NewChatEngine(nil, llm, nil, nil)
+	session := longSession(10, 2000)
+
+	ce.updateSummary(context.Background(), session)
+
+	assert.Len(t, llm.prompts, 1)
+	assert.Contains(t, llm.prompts[0], "message 3 ")
+	assert.NotContains(t, llm.prompts[0], "message 4 ")
+	assert.Equal(t, "The user asked about attention.", session.Summary)
+	assert.Equal(t, 4, session.SummarizedCount)
+
+	// The recent turns now fit, so the next call reuses the summary
+	ce.updateSummary(context.Background(), session)
+	assert.Len(t, llm.prompts, 1)
+
+	prompt, err := ce.buildPrompt(session, "current question", &rag.RetrievedContext{})
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "CONVERSATION SO FAR (summary):\nThe user asked about attention.")
+	assert.NotContains(t, prompt, "message 3 ")
+	assert.Contains(t, prompt, "message 4 ")
+	assert.Contains(t, prompt, "message 9 ")
+}
+
+func TestUpdateSummaryExtendsPreviousSummary(t *testing.T) {
+	llm := &fakeLLM{reply: "newer summary"}
+	ce := NewChatEngine(nil, llm, nil, nil)
+	session := longSession(14, 2000)
+	session.Summary = "older summary"
+	session.SummarizedCount = 4
+
+	ce.updateSummary(context.Background(), session)
+
+	assert.Len(t, llm.prompts, 1)
+	assert.Contains(t, llm.prompts[0], "older summary")
+	assert.NotContains(t, llm.prompts[0], "message 3 ")
+	assert.Contains(t, llm.prompts[0], "message 4 ")
+	assert.Equal(t, "newer summary", session.Summary)
+	assert.Equal(t, 8, session.SummarizedCount)
+}
+
+func TestUpdateSummaryFailureKeepsRecentMessages(t *testing.T) {
+	llm := &fakeLLM{err: errors.New("quota exceeded")}
+	ce := NewChatEngine(nil, llm, nil, nil)
+	session := longSession(10, 2000)
+
+	ce.updateSummary(context.Background(), session)
+
+	assert.Empty(t, session.Summary)
+	assert.Equal(t, 0, session.SummarizedCount)
+	assert.Equal(t, 4, historyStart(session))
+}
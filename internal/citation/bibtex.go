@@ -0,0 +1,191 @@
+package citation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// BibEntry is one paper to write to a BibTeX file
+type BibEntry struct {
+	Title   string
+	Authors []string
+	Year    string
+	Venue   string // Conference or journal; empty for preprints and unknown venues
+	DOI     string
+	ArxivID string
+}
+
+// bibYearRegex matches a plausible publication year
+var bibYearRegex = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+
+// conferenceWords mark a venue as a conference rather than a journal
+var conferenceWords = map[string]bool{
+	"conference": true, "proceedings": true, "workshop": true, "symposium": true,
+	"neurips": true, "nips": true, "icml": true, "iclr": true, "cvpr": true, "iccv": true, "eccv": true,
+	"acl": true, "emnlp": true, "naacl": true, "aaai": true, "ijcai": true, "kdd": true, "sigir": true,
+}
+
+// titleStopWords are skipped when picking the title word of a cite key
+var titleStopWords = map[string]bool{"a": true, "an": true, "the": true, "on": true, "of": true}
+
+// bibEscaper escapes the characters BibTeX and LaTeX treat specially
+var bibEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+// FormatBibTeX renders entries as a BibTeX file and returns it with the number
+// of entries written. Entries with the same DOI or normalized title as an
+// earlier one are dropped, and cite keys that would clash get a letter suffix
+// (vaswani2017attention, vaswani2017attentionb).
+func FormatBibTeX(entries []BibEntry) (string, int) {
+	var sb strings.Builder
+	written := 0
+	seenTitles := make(map[string]bool)
+	seenDOIs := make(map[string]bool)
+	usedKeys := make(map[string]bool)
+
+	for _, entry := range entries {
+		titleKey := normalizeBibTitle(entry.Title)
+		doiKey := strings.ToLower(entry.DOI)
+		if titleKey == "" || seenTitles[titleKey] || (doiKey != "" && seenDOIs[doiKey]) {
+			continue
+		}
+		seenTitles[titleKey] = true
+		if doiKey != "" {
+			seenDOIs[doiKey] = true
+		}
+
+		key := entry.CiteKey()
+		for suffix := 'b'; usedKeys[key]; suffix++ {
+			key = entry.CiteKey() + string(suffix)
+		}
+		usedKeys[key] = true
+
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		writeBibEntry(&sb, key, entry)
+		written++
+	}
+
+	return sb.String(), written
+}
+
+// writeBibEntry writes a single entry. Venues that look like conferences
+// become @inproceedings, other venues @article and no venue @misc.
+func writeBibEntry(sb *strings.Builder, key string, entry BibEntry) {
+	entryType, venueField := "misc", ""
+	if entry.Venue != "" {
+		entryType, venueField = "article", "journal"
+		if isConference(entry.Venue) {
+			entryType, venueField = "inproceedings", "booktitle"
+		}
+	}
+
+	fmt.Fprintf(sb, "@%s{%s,\n", entryType, key)
+	// Double braces keep BibTeX styles from lowercasing the title
+	fmt.Fprintf(sb, "  title = {{%s}},\n", bibEscaper.Replace(entry.Title))
+	if len(entry.Authors) > 0 {
+		authors := make([]string, len(entry.Authors))
+		for i, author := range entry.Authors {
+			authors[i] = bibEscaper.Replace(author)
+		}
+		fmt.Fprintf(sb, "  author = {%s},\n", strings.Join(authors, " and "))
+	}
+	if venueField != "" {
+		fmt.Fprintf(sb, "  %s = {%s},\n", venueField, bibEscaper.Replace(entry.Venue))
+	}
+	if year := bibYearRegex.FindString(entry.Year); year != "" {
+		fmt.Fprintf(sb, "  year = {%s},\n", year)
+	}
+	if entry.DOI != "" {
+		fmt.Fprintf(sb, "  doi = {%s},\n", bibEscaper.Replace(entry.DOI))
+	}
+	if entry.ArxivID != "" {
+		fmt.Fprintf(sb, "  eprint = {%s},\n  archivePrefix = {arXiv},\n", entry.ArxivID)
+	}
+	sb.WriteString("}\n")
+}
+
+// CiteKey returns the key the entry is cited by: the first author's surname,
+// the year and the first significant word of the title, e.g. vaswani2017attention
+func (e BibEntry) CiteKey() string {
+	var surname string
+	if len(e.Authors) > 0 {
+		surname = authorSurname(e.Authors[0])
+	}
+
+	var titleWord string
+	for _, word := range strings.Fields(e.Title) {
+		word = keyWord(word)
+		if word != "" && !titleStopWords[word] {
+			titleWord = word
+			break
+		}
+	}
+
+	key := keyWord(surname) + bibYearRegex.FindString(e.Year) + titleWord
+	if key == "" {
+		return "paper"
+	}
+	return key
+}
+
+// authorSurname returns the family name of "First Last" or "Last, First"
+func authorSurname(author string) string {
+	if last, _, found := strings.Cut(author, ","); found {
+		return strings.TrimSpace(last)
+	}
+	fields := strings.Fields(author)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
+
+// keyWord lowercases s and keeps only ASCII letters and digits
+func keyWord(s string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// normalizeBibTitle reduces a title to lowercase letters and digits so
+// differently punctuated copies of a paper compare equal
+func normalizeBibTitle(title string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// isConference reports whether venue names a conference or workshop
+func isConference(venue string) bool {
+	words := strings.FieldsFunc(strings.ToLower(venue), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		if conferenceWords[word] {
+			return true
+		}
+	}
+	return false
+}
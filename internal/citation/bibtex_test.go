@@ -0,0 +1,65 @@
+package citation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCiteKey(t *testing.T) {
+	tests := []struct {
+		entry BibEntry
+		want  string
+	}{
+		{BibEntry{Title: "Attention Is All You Need", Authors: []string{"Ashish Vaswani", "Noam Shazeer"}, Year: "2017"}, "vaswani2017attention"},
+		{BibEntry{Title: "The Lottery Ticket Hypothesis", Authors: []string{"Frankle, Jonathan"}, Year: "Published 2019"}, "frankle2019lottery"},
+		{BibEntry{Title: "BERT: Pre-training of Deep Bidirectional Transformers", Authors: []string{"Jacob Devlin"}}, "devlinbert"},
+		{BibEntry{Title: "!!!"}, "paper"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.entry.CiteKey(), tt.entry.Title)
+	}
+}
+
+func TestFormatBibTeX(t *testing.T) {
+	entries := []BibEntry{
+		{Title: "Attention Is All You Need", Authors: []string{"Ashish Vaswani", "Noam Shazeer"}, Year: "2017", Venue: "Advances in Neural Information Processing Systems (NeurIPS)", ArxivID: "1706.03762"},
+		{Title: "Attention is all you need.", Authors: []string{"A. Vaswani"}, Year: "2017"},
+		{Title: "Deep Residual Learning", Authors: []string{"Kaiming He"}, Year: "2016", DOI: "10.1109/CVPR.2016.90"},
+		{Title: "Deep residual learning for image recognition", Authors: []string{"K. He"}, Year: "2016", DOI: "10.1109/cvpr.2016.90"},
+		{Title: "Attention for 100% of R&D_tasks", Authors: []string{"Ashish Vaswani"}, Year: "2017", Venue: "Journal of {Machine} Learning $Research"},
+		{Title: "Untitled preprint", Year: "n.d."},
+	}
+
+	want := `@inproceedings{vaswani2017attention,
+  title = {{Attention Is All You Need}},
+  author = {Ashish Vaswani and Noam Shazeer},
+  booktitle = {Advances in Neural Information Processing Systems (NeurIPS)},
+  year = {2017},
+  eprint = {1706.03762},
+  archivePrefix = {arXiv},
+}
+
+@misc{he2016deep,
+  title = {{Deep Residual Learning}},
+  author = {Kaiming He},
+  year = {2016},
+  doi = {10.1109/CVPR.2016.90},
+}
+
+@article{vaswani2017attentionb,
+  title = {{Attention for 100\% of R\&D\_tasks}},
+  author = {Ashish Vaswani},
+  journal = {Journal of \{Machine\} Learning \$Research},
+  year = {2017},
+}
+
+@misc{untitled,
+  title = {{Untitled preprint}},
+}
+`
+	bib, written := FormatBibTeX(entries)
+	assert.Equal(t, want, bib)
+	assert.Equal(t, 4, written, "Duplicate titles and DOIs should not be counted")
+}
@@ -14,13 +14,13 @@ import (
 
 // CitationExtractor handles citation extraction from papers
 type CitationExtractor struct {
-	geminiClient *analyzer.GeminiClient
+	llmClient analyzer.LLMClient
 }
 
 // NewCitationExtractor creates a new citation extractor
-func NewCitationExtractor(geminiClient *analyzer.GeminiClient) *CitationExtractor {
+func NewCitationExtractor(llmClient analyzer.LLMClient) *CitationExtractor {
 	return &CitationExtractor{
-		geminiClient: geminiClient,
+		llmClient: llmClient,
 	}
 }
 
@@ -177,7 +177,7 @@ Return a JSON array with this structure:
 Return ONLY the JSON array, no additional text.
 `, mainContent, refSummary)
 
-	response, err := ce.geminiClient.GenerateText(ctx, prompt)
+	response, err := ce.llmClient.GenerateText(ctx, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("Gemini API call failed: %w", err)
 	}
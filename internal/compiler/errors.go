@@ -0,0 +1,96 @@
+package compiler
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// maxErrorLines caps how much compiler output is embedded in an error
+const maxErrorLines = 20
+
+// CompileError describes a failed LaTeX compilation
+type CompileError struct {
+	Err     error    // Underlying process error
+	Excerpt []string // Most relevant lines from the compiler output
+	LogPath string   // Full compiler output, empty if it couldn't be written
+}
+
+func (e *CompileError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Err.Error())
+	if len(e.Excerpt) > 0 {
+		b.WriteString("\n")
+		b.WriteString(strings.Join(e.Excerpt, "\n"))
+	}
+	if e.LogPath != "" {
+		fmt.Fprintf(&b, "\n(full log: %s)", e.LogPath)
+	}
+	return b.String()
+}
+
+func (e *CompileError) Unwrap() error {
+	return e.Err
+}
+
+// newCompileError saves the full output to logPath and builds an error with an excerpt
+func newCompileError(err error, output []byte, logPath string) *CompileError {
+	compileErr := &CompileError{
+		Err:     err,
+		Excerpt: extractErrorLines(string(output), maxErrorLines),
+	}
+
+	if len(output) > 0 {
+		if writeErr := os.WriteFile(logPath, output, 0644); writeErr != nil {
+			log.Printf("     ⚠️  Could not write compile log: %v", writeErr)
+		} else {
+			compileErr.LogPath = logPath
+		}
+	}
+
+	return compileErr
+}
+
+// extractErrorLines pulls the LaTeX error lines ("! ..." plus the "l.<n>"
+// context that follows) out of compiler output. If no error markers are
+// found it falls back to the tail of the output.
+func extractErrorLines(output string, maxLines int) []string {
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+
+	var relevant []string
+	for i := 0; i < len(lines); i++ {
+		if !strings.HasPrefix(lines[i], "!") {
+			continue
+		}
+
+		relevant = append(relevant, strings.TrimRight(lines[i], " "))
+
+		// Keep the context up to and including the line-number marker
+		for j := i + 1; j < len(lines) && j <= i+3; j++ {
+			line := strings.TrimRight(lines[j], " ")
+			if line == "" || strings.HasPrefix(line, "!") {
+				break
+			}
+			relevant = append(relevant, line)
+			i = j
+			if strings.HasPrefix(line, "l.") {
+				break
+			}
+		}
+	}
+
+	if len(relevant) == 0 {
+		for _, line := range lines {
+			if strings.TrimSpace(line) != "" {
+				relevant = append(relevant, strings.TrimRight(line, " "))
+			}
+		}
+	}
+
+	if len(relevant) > maxLines {
+		relevant = relevant[len(relevant)-maxLines:]
+	}
+
+	return relevant
+}
@@ -0,0 +1,58 @@
+package compiler
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractErrorLines_LatexErrors(t *testing.T) {
+	output := `This is pdfTeX, Version 3.141592653
+(./paper.tex
+LaTeX2e <2023-11-01>
+! LaTeX Error: File ` + "`missingpkg.sty'" + ` not found.
+
+Type X to quit or <RETURN> to proceed,
+l.5 \usepackage
+               {missingpkg}^^M
+! Undefined control sequence.
+l.42 \foo
+         {bar}
+No pages of output.`
+
+	lines := extractErrorLines(output, maxErrorLines)
+
+	require.NotEmpty(t, lines)
+	assert.Contains(t, lines[0], "! LaTeX Error: File `missingpkg.sty' not found.")
+	assert.Contains(t, lines, "! Undefined control sequence.")
+	assert.Contains(t, lines, "l.42 \\foo")
+	assert.NotContains(t, lines, "LaTeX2e <2023-11-01>")
+}
+
+func TestExtractErrorLines_FallbackToTail(t *testing.T) {
+	output := "line1\nline2\n\nline3\nline4"
+
+	lines := extractErrorLines(output, 2)
+
+	assert.Equal(t, []string{"line3", "line4"}, lines)
+}
+
+func TestNewCompileError_WritesLog(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "paper.compile.log")
+	baseErr := errors.New("latexmk compilation failed: exit status 12")
+
+	err := newCompileError(baseErr, []byte("! Emergency stop.\n"), logPath)
+
+	assert.ErrorIs(t, err, baseErr)
+	assert.Equal(t, logPath, err.LogPath)
+	assert.Contains(t, err.Error(), "! Emergency stop.")
+	assert.Contains(t, err.Error(), logPath)
+
+	data, readErr := os.ReadFile(logPath)
+	require.NoError(t, readErr)
+	assert.Equal(t, "! Emergency stop.\n", string(data))
+}
@@ -1,34 +1,59 @@
 package compiler
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 )
 
 type LatexCompiler struct {
 	engine     string // "pdflatex", "xelatex", "lualatex"
+	bibEngine  string // "bibtex" or "biber"
 	useLatexmk bool
 	cleanAux   bool
 	outputDir  string
 }
 
+// bibliographyRegex matches the commands that require a bibliography pass
+var bibliographyRegex = regexp.MustCompile(`\\(?:bibliography|addbibresource)\{`)
+
+// commandWaitDelay bounds how long a cancelled tool's output is waited for,
+// in case a child process it spawned keeps the pipes open
+const commandWaitDelay = 5 * time.Second
+
 // NewLatexCompiler creates a new LaTeX compiler
 func NewLatexCompiler(engine string, useLatexmk, cleanAux bool, outputDir string) *LatexCompiler {
 	return &LatexCompiler{
 		engine:     engine,
+		bibEngine:  "bibtex",
 		useLatexmk: useLatexmk,
 		cleanAux:   cleanAux,
 		outputDir:  outputDir,
 	}
 }
 
+// SetBibEngine sets the bibliography backend ("bibtex" or "biber")
+func (lc *LatexCompiler) SetBibEngine(bibEngine string) {
+	if bibEngine != "" {
+		lc.bibEngine = bibEngine
+	}
+}
+
 // Compile compiles a .tex file to PDF
 func (lc *LatexCompiler) Compile(texPath string) (string, error) {
+	return lc.CompileContext(context.Background(), texPath)
+}
+
+// CompileContext compiles a .tex file to PDF, killing the LaTeX tools if ctx
+// is cancelled or its deadline passes
+func (lc *LatexCompiler) CompileContext(ctx context.Context, texPath string) (string, error) {
 	workDir := filepath.Dir(texPath)
 	texFile := filepath.Base(texPath)
 	baseName := strings.TrimSuffix(texFile, ".tex")
@@ -41,17 +66,26 @@ func (lc *LatexCompiler) Compile(texPath string) (string, error) {
 		return "", fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	needsBib := hasBibliography(texPath)
+
+	var output []byte
 	var err error
 	if lc.useLatexmk {
-		err = lc.compileWithLatexmk(workDir, texFile)
+		output, err = lc.compileWithLatexmk(ctx, workDir, texFile, needsBib)
 	} else {
-		err = lc.compileManual(workDir, texFile)
+		output, err = lc.compileManual(ctx, workDir, texFile, needsBib)
 	}
 
 	if err != nil {
-		return "", err
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("compilation stopped: %w", ctx.Err())
+		}
+		return "", newCompileError(err, output, filepath.Join(workDir, baseName+".compile.log"))
 	}
 
+	// Drop the log from an earlier failed attempt so it isn't mistaken for this run
+	os.Remove(filepath.Join(workDir, baseName+".compile.log"))
+
 	// Move compiled PDF to output directory
 	compiledPDF := filepath.Join(workDir, baseName+".pdf")
 	if err := os.Rename(compiledPDF, outputPDF); err != nil {
@@ -66,58 +100,112 @@ func (lc *LatexCompiler) Compile(texPath string) (string, error) {
 	return outputPDF, nil
 }
 
-// compileWithLatexmk compiles using latexmk
-func (lc *LatexCompiler) compileWithLatexmk(workDir, texFile string) error {
+// compileWithLatexmk compiles using latexmk, returning the combined tool output
+func (lc *LatexCompiler) compileWithLatexmk(ctx context.Context, workDir, texFile string, needsBib bool) ([]byte, error) {
 	log.Printf("     → Running latexmk (automatic multi-pass)...")
 	startTime := time.Now()
 
-	cmd := exec.Command("latexmk",
-		"-pdf",
-		"-interaction=nonstopmode",
-		"-halt-on-error",
-		texFile,
-	)
-	cmd.Dir = workDir
+	args := []string{"-pdf", "-interaction=nonstopmode", "-halt-on-error"}
+	if needsBib {
+		// -bibtex lets latexmk run bibtex/biber whenever the .bib changes
+		args = append(args, "-bibtex")
+	}
+	args = append(args, texFile)
 
-	output, err := cmd.CombinedOutput()
+	output, err := commandContext(ctx, workDir, "latexmk", args...).CombinedOutput()
 	if err != nil {
-		return fmt.Errorf("latexmk compilation failed: %w\nOutput: %s", err, output)
+		return output, fmt.Errorf("latexmk compilation failed: %w", err)
 	}
 
 	log.Printf("     ✓ latexmk complete (%.2fs)", time.Since(startTime).Seconds())
-	return nil
+	return output, nil
 }
 
-// compileManual performs manual compilation with multiple passes
-func (lc *LatexCompiler) compileManual(workDir, texFile string) error {
+// compileManual performs manual compilation with multiple passes,
+// returning the output of every pass run so far. When the document has a
+// bibliography the sequence is latex → bibtex/biber → latex → latex.
+func (lc *LatexCompiler) compileManual(ctx context.Context, workDir, texFile string, needsBib bool) ([]byte, error) {
 	// Usually need 2-3 passes for references and TOC
 	log.Printf("     → Running %s (3 passes for references/TOC)...", lc.engine)
+	var allOutput []byte
 	for i := 0; i < 3; i++ {
 		passStart := time.Now()
 		log.Printf("       Pass %d/3...", i+1)
 
-		cmd := exec.Command(lc.engine,
+		cmd := commandContext(ctx, workDir, lc.engine,
 			"-interaction=nonstopmode",
 			"-halt-on-error",
 			texFile,
 		)
-		cmd.Dir = workDir
 
 		output, err := cmd.CombinedOutput()
+		allOutput = append(allOutput, output...)
 		if err != nil {
-			return fmt.Errorf("compilation pass %d failed: %w\nOutput: %s", i+1, err, output)
+			return allOutput, fmt.Errorf("compilation pass %d failed: %w", i+1, err)
 		}
 
 		log.Printf("       ✓ Pass %d complete (%.2fs)", i+1, time.Since(passStart).Seconds())
+
+		// Resolve citations after the first pass has written the .aux/.bcf
+		if i == 0 && needsBib {
+			output, err := lc.runBibEngine(ctx, workDir, strings.TrimSuffix(texFile, ".tex"))
+			allOutput = append(allOutput, output...)
+			if err != nil {
+				return allOutput, err
+			}
+		}
 	}
 
-	return nil
+	return allOutput, nil
+}
+
+// runBibEngine runs bibtex or biber on the given document. bibtex exits with
+// status 1 for warnings such as an undefined citation key; those are logged
+// and the compile continues, since the document still builds.
+func (lc *LatexCompiler) runBibEngine(ctx context.Context, workDir, baseName string) ([]byte, error) {
+	log.Printf("       → Running %s for bibliography...", lc.bibEngine)
+	startTime := time.Now()
+
+	output, err := commandContext(ctx, workDir, lc.bibEngine, baseName).CombinedOutput()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if filepath.Base(lc.bibEngine) == "bibtex" && errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			log.Printf("       ⚠️  %s reported warnings (%.2fs):", lc.bibEngine, time.Since(startTime).Seconds())
+			for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+				if strings.HasPrefix(line, "Warning--") {
+					log.Printf("          %s", line)
+				}
+			}
+			return output, nil
+		}
+		return output, fmt.Errorf("%s failed: %w", lc.bibEngine, err)
+	}
+
+	log.Printf("       ✓ %s complete (%.2fs)", lc.bibEngine, time.Since(startTime).Seconds())
+	return output, nil
+}
+
+// commandContext builds a command run in workDir that is killed when ctx is done
+func commandContext(ctx context.Context, workDir, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = workDir
+	cmd.WaitDelay = commandWaitDelay
+	return cmd
+}
+
+// hasBibliography reports whether the tex file declares a bibliography
+func hasBibliography(texPath string) bool {
+	content, err := os.ReadFile(texPath)
+	if err != nil {
+		return false
+	}
+	return bibliographyRegex.Match(content)
 }
 
 // cleanAuxiliaryFiles removes auxiliary LaTeX files
 func (lc *LatexCompiler) cleanAuxiliaryFiles(workDir, baseName string) {
 	log.Printf("     🧹 Cleaning auxiliary files...")
-	extensions := []string{".aux", ".log", ".out", ".toc", ".fdb_latexmk", ".fls", ".synctex.gz"}
+	extensions := []string{".aux", ".log", ".out", ".toc", ".fdb_latexmk", ".fls", ".synctex.gz", ".bbl", ".blg", ".bcf", ".run.xml"}
 
 	cleaned := 0
 	for _, ext := range extensions {
@@ -0,0 +1,118 @@
+package compiler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasBibliography(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{"bibtex", "\\bibliographystyle{plain}\n\\bibliography{refs}", true},
+		{"biblatex", "\\usepackage{biblatex}\n\\addbibresource{refs.bib}", true},
+		{"style only", "\\bibliographystyle{plain}", false},
+		{"none", "\\section{Intro}", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			texPath := filepath.Join(t.TempDir(), "paper.tex")
+			require.NoError(t, os.WriteFile(texPath, []byte(tt.content), 0644))
+
+			assert.Equal(t, tt.expected, hasBibliography(texPath))
+		})
+	}
+}
+
+// writeFakeEngine writes a shell script standing in for pdflatex. It sleeps
+// for tex files whose name contains "Slow" and otherwise writes an empty PDF.
+func writeFakeEngine(t *testing.T) string {
+	t.Helper()
+
+	script := `#!/bin/sh
+case "$3" in
+  *Slow*) exec sleep 30 ;;
+esac
+: > "${3%.tex}.pdf"
+`
+	path := filepath.Join(t.TempDir(), "fakelatex")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	return path
+}
+
+func TestCompileContextStopsSlowEngine(t *testing.T) {
+	dir := t.TempDir()
+	texPath := filepath.Join(dir, "Slow_Paper.tex")
+	require.NoError(t, os.WriteFile(texPath, []byte("\\section{Intro}"), 0644))
+
+	lc := NewLatexCompiler(writeFakeEngine(t), false, false, filepath.Join(dir, "reports"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := lc.CompileContext(ctx, texPath)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 10*time.Second)
+}
+
+func TestCompileContextFakeEngine(t *testing.T) {
+	dir := t.TempDir()
+	texPath := filepath.Join(dir, "Fast_Paper.tex")
+	require.NoError(t, os.WriteFile(texPath, []byte("\\section{Intro}"), 0644))
+
+	lc := NewLatexCompiler(writeFakeEngine(t), false, false, filepath.Join(dir, "reports"))
+
+	reportPath, err := lc.CompileContext(context.Background(), texPath)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "reports", "Fast_Paper.pdf"), reportPath)
+	assert.FileExists(t, reportPath)
+}
+
+// writeFakeBibEngine creates an executable with the given name that exits with status
+func writeFakeBibEngine(t *testing.T, name string, status int) string {
+	t.Helper()
+	script := fmt.Sprintf("#!/bin/sh\necho \"Warning--I didn't find a database entry for \\\"smith2020\\\"\"\nexit %d\n", status)
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	return path
+}
+
+func TestRunBibEngine_ExitStatus(t *testing.T) {
+	tests := []struct {
+		engine  string
+		status  int
+		wantErr bool
+	}{
+		{"bibtex", 0, false},
+		{"bibtex", 1, false}, // warnings only, e.g. an unresolved \cite
+		{"bibtex", 2, true},
+		{"biber", 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s_%d", tt.engine, tt.status), func(t *testing.T) {
+			lc := NewLatexCompiler("pdflatex", false, false, t.TempDir())
+			lc.SetBibEngine(writeFakeBibEngine(t, tt.engine, tt.status))
+
+			output, err := lc.runBibEngine(context.Background(), t.TempDir(), "paper")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Contains(t, string(output), "smith2020", "Output should be kept for the compile log")
+		})
+	}
+}
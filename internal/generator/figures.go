@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Figure is an image to embed in a report
+type Figure struct {
+	Path    string // Relative to the .tex file's directory
+	Caption string
+}
+
+// graphicxRegex matches a \usepackage line that loads graphicx
+var graphicxRegex = regexp.MustCompile(`\\usepackage(\[[^\]]*\])?\{[^}]*\bgraphicx\b[^}]*\}`)
+
+// InsertFigures adds a "Key Figures" section with an \includegraphics for
+// each figure just before \end{document}, loading graphicx if the document
+// doesn't already
+func InsertFigures(latexContent string, figures []Figure) string {
+	if len(figures) == 0 {
+		return latexContent
+	}
+
+	var section strings.Builder
+	section.WriteString("\\section{Key Figures}\n")
+	for _, figure := range figures {
+		section.WriteString("\\begin{figure}[htbp]\n")
+		section.WriteString("\\centering\n")
+		fmt.Fprintf(&section, "\\includegraphics[width=0.85\\linewidth,height=0.4\\textheight,keepaspectratio]{%s}\n", figure.Path)
+		if figure.Caption != "" {
+			fmt.Fprintf(&section, "\\caption{%s}\n", escapeLatex(figure.Caption))
+		}
+		section.WriteString("\\end{figure}\n")
+	}
+	section.WriteString("\n")
+
+	if end := strings.LastIndex(latexContent, `\end{document}`); end != -1 {
+		latexContent = latexContent[:end] + section.String() + latexContent[end:]
+	} else {
+		latexContent = strings.TrimRight(latexContent, "\n") + "\n\n" + section.String()
+	}
+
+	if graphicxRegex.MatchString(latexContent) {
+		return latexContent
+	}
+	return addPackage(latexContent, `\usepackage{graphicx}`)
+}
+
+// addPackage inserts a \usepackage line after \documentclass, or at the top
+// when there is no \documentclass line
+func addPackage(latexContent, usepackage string) string {
+	start := strings.Index(latexContent, `\documentclass`)
+	if start == -1 {
+		return usepackage + "\n" + latexContent
+	}
+
+	lineEnd := strings.Index(latexContent[start:], "\n")
+	if lineEnd == -1 {
+		return latexContent + "\n" + usepackage + "\n"
+	}
+	insertAt := start + lineEnd + 1
+	return latexContent[:insertAt] + usepackage + "\n" + latexContent[insertAt:]
+}
@@ -0,0 +1,37 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertFigures(t *testing.T) {
+	doc := "\\documentclass{article}\n\\usepackage{amsmath,graphicx}\n\\begin{document}\n\\section{Summary}\n\\end{document}\n"
+
+	out := InsertFigures(doc, []Figure{
+		{Path: "figures/3f9a1111/img-003-001.png", Caption: "Model & data flow"},
+		{Path: "figures/3f9a1111/img-005-000.png"},
+	})
+
+	assert.Contains(t, out, "\\section{Summary}\n\\section{Key Figures}\n")
+	assert.Contains(t, out, "{figures/3f9a1111/img-003-001.png}\n\\caption{Model \\& data flow}")
+	assert.Equal(t, 2, strings.Count(out, "\\includegraphics"))
+	assert.Equal(t, 1, strings.Count(out, "\\caption"))
+	assert.True(t, strings.HasSuffix(out, "\\end{document}\n"))
+	assert.Equal(t, 1, strings.Count(out, "graphicx"), "graphicx is already loaded")
+}
+
+func TestInsertFiguresAddsGraphicx(t *testing.T) {
+	doc := "\\documentclass[11pt]{article}\n\\begin{document}\nText\n\\end{document}"
+
+	out := InsertFigures(doc, []Figure{{Path: "fig.png"}})
+
+	assert.True(t, strings.HasPrefix(out, "\\documentclass[11pt]{article}\n\\usepackage{graphicx}\n\\begin{document}"))
+}
+
+func TestInsertFiguresWithoutFigures(t *testing.T) {
+	doc := "\\documentclass{article}\n\\begin{document}\n\\end{document}"
+	assert.Equal(t, doc, InsertFigures(doc, nil))
+}
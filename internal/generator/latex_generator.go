@@ -1,46 +1,151 @@
 package generator
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
 )
 
 type LatexGenerator struct {
 	outputDir string
+	template  *template.Template // nil writes the generated document as-is
 }
 
-// NewLatexGenerator creates a new LaTeX generator
-func NewLatexGenerator(outputDir string) *LatexGenerator {
-	return &LatexGenerator{
+// NewLatexGenerator creates a new LaTeX generator. When templatePath is set,
+// generated reports are placed into that template's {{.Title}}/{{.Body}};
+// otherwise the built-in document from the model is used unchanged.
+func NewLatexGenerator(outputDir, templatePath string) (*LatexGenerator, error) {
+	lg := &LatexGenerator{
 		outputDir: outputDir,
 	}
+
+	if templatePath != "" {
+		tmpl, err := LoadReportTemplate(templatePath)
+		if err != nil {
+			return nil, err
+		}
+		lg.template = tmpl
+	}
+
+	return lg, nil
 }
 
-// GenerateLatexFile writes LaTeX content to a file
-func (lg *LatexGenerator) GenerateLatexFile(paperTitle, latexContent string) (string, error) {
+// sourceHashKey starts the comment on the first line of a generated file
+// that records which PDF it came from
+const sourceHashKey = "archivist-source-hash:"
+
+// sourceHashMarker prefixes that comment in .tex files
+const sourceHashMarker = "% " + sourceHashKey + " "
+
+// GenerateLatexFile writes LaTeX content to a file. The source file hash is
+// recorded in the file so that two different papers sharing a title get
+// distinct filenames instead of overwriting each other.
+func (lg *LatexGenerator) GenerateLatexFile(paperTitle, latexContent, fileHash string) (string, error) {
 	// Sanitize filename
 	filename := sanitizeFilename(paperTitle)
 	if filename == "" {
 		filename = "paper_analysis"
 	}
 
-	// Create output path
-	outputPath := filepath.Join(lg.outputDir, filename+".tex")
-
 	// Ensure output directory exists
 	if err := os.MkdirAll(lg.outputDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	// Write LaTeX content
-	if err := os.WriteFile(outputPath, []byte(latexContent), 0644); err != nil {
+	if lg.template != nil {
+		rendered, err := renderReport(lg.template, paperTitle, latexContent)
+		if err != nil {
+			return "", err
+		}
+		latexContent = rendered
+	}
+
+	if fileHash != "" {
+		latexContent = sourceHashMarker + fileHash + "\n" + latexContent
+	}
+
+	// Write LaTeX content, disambiguating the path if another paper already owns it
+	outputPath, err := writeReportFile(lg.outputDir, filename, ".tex", fileHash, []byte(latexContent))
+	if err != nil {
 		return "", fmt.Errorf("failed to write LaTeX file: %w", err)
 	}
 
 	return outputPath, nil
 }
 
+// reportFileMu serialises resolving and writing report files, so concurrent
+// workers generating papers with the same title can't both claim one path
+var reportFileMu sync.Mutex
+
+// writeReportFile writes data to the path resolveOutputPath picks for fileHash
+// and returns that path
+func writeReportFile(dir, filename, ext, fileHash string, data []byte) (string, error) {
+	reportFileMu.Lock()
+	defer reportFileMu.Unlock()
+
+	outputPath := resolveOutputPath(dir, filename, ext, fileHash)
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		return "", err
+	}
+
+	return outputPath, nil
+}
+
+// resolveOutputPath returns the path for filename+ext in dir, appending a short
+// hash suffix (e.g. Title_3f9a.tex) when the plain name belongs to a different source
+func resolveOutputPath(dir, filename, ext, fileHash string) string {
+	outputPath := filepath.Join(dir, filename+ext)
+	if fileHash == "" || ownedBy(outputPath, fileHash) {
+		return outputPath
+	}
+
+	for _, n := range []int{4, 8, len(fileHash)} {
+		if n > len(fileHash) {
+			n = len(fileHash)
+		}
+		candidate := filepath.Join(dir, fmt.Sprintf("%s_%s%s", filename, fileHash[:n], ext))
+		if ownedBy(candidate, fileHash) {
+			return candidate
+		}
+	}
+
+	return filepath.Join(dir, fmt.Sprintf("%s_%s%s", filename, fileHash, ext))
+}
+
+// ownedBy reports whether path is free to use for the given source hash: it
+// doesn't exist yet, was generated from the same source, or predates hash tracking
+func ownedBy(path, fileHash string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+	defer f.Close()
+
+	firstLine, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && firstLine == "" {
+		return true
+	}
+
+	// LaTeX comments start with %, Markdown reports use an HTML comment
+	comment := strings.TrimSpace(firstLine)
+	if strings.HasPrefix(comment, "<!--") {
+		comment = strings.TrimSuffix(strings.TrimPrefix(comment, "<!--"), "-->")
+	} else {
+		comment = strings.TrimPrefix(comment, "%")
+	}
+	comment = strings.TrimSpace(comment)
+
+	if !strings.HasPrefix(comment, sourceHashKey) {
+		return true
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(comment, sourceHashKey)) == fileHash
+}
+
 // sanitizeFilename removes invalid characters
 func sanitizeFilename(name string) string {
 	// Simple sanitization
@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateLatexFile_TitleCollision(t *testing.T) {
+	lg, err := NewLatexGenerator(t.TempDir(), "")
+	require.NoError(t, err)
+
+	pathA, err := lg.GenerateLatexFile("Attention Is All You Need", "content A", "3f9a1111aaaa")
+	require.NoError(t, err)
+	pathB, err := lg.GenerateLatexFile("Attention Is All You Need", "content B", "7c2e2222bbbb")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, pathA, pathB, "Different sources with the same title must not share a file")
+	assert.Equal(t, "Attention_Is_All_You_Need.tex", filepath.Base(pathA))
+	assert.Equal(t, "Attention_Is_All_You_Need_7c2e.tex", filepath.Base(pathB))
+
+	dataA, err := os.ReadFile(pathA)
+	require.NoError(t, err)
+	assert.Contains(t, string(dataA), "content A")
+
+	dataB, err := os.ReadFile(pathB)
+	require.NoError(t, err)
+	assert.Contains(t, string(dataB), "content B")
+}
+
+func TestGenerateLatexFile_ConcurrentTitleCollision(t *testing.T) {
+	lg, err := NewLatexGenerator(t.TempDir(), "")
+	require.NoError(t, err)
+
+	const workers = 32
+	paths := make([]string, workers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			hash := fmt.Sprintf("%04x%08d", i, i)
+			path, err := lg.GenerateLatexFile("Attention Is All You Need", "content "+hash, hash)
+			assert.NoError(t, err)
+			paths[i] = path
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for i, path := range paths {
+		assert.False(t, seen[path], "Two sources were written to %s", path)
+		seen[path] = true
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), fmt.Sprintf("content %04x%08d", i, i))
+	}
+}
+
+func TestGenerateLatexFile_SameSourceReusesPath(t *testing.T) {
+	lg, err := NewLatexGenerator(t.TempDir(), "")
+	require.NoError(t, err)
+
+	_, err = lg.GenerateLatexFile("Paper", "content A", "aaaa1111")
+	require.NoError(t, err)
+	pathB1, err := lg.GenerateLatexFile("Paper", "content B", "bbbb2222")
+	require.NoError(t, err)
+	pathB2, err := lg.GenerateLatexFile("Paper", "content B v2", "bbbb2222")
+	require.NoError(t, err)
+
+	assert.Equal(t, pathB1, pathB2, "Reprocessing the same source should overwrite its own file")
+}
+
+const generatedReport = `\documentclass{article}
+\usepackage{amsmath}
+\title{Ignored}
+\begin{document}
+\maketitle
+\section{Summary}
+Residual learning.
+\end{document}
+`
+
+func writeTemplate(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "report.tex.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestGenerateLatexFile_WithTemplate(t *testing.T) {
+	tmpl := writeTemplate(t, "\\documentclass{report}\n{{.Preamble}}\n\\title{ {{.Title}} }\n\\begin{document}\n\\maketitle\n{{.Body}}\n\\end{document}\n")
+	lg, err := NewLatexGenerator(t.TempDir(), tmpl)
+	require.NoError(t, err)
+
+	path, err := lg.GenerateLatexFile("ResNet & Friends", generatedReport, "")
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	out := string(data)
+
+	assert.Contains(t, out, `\documentclass{report}`)
+	assert.NotContains(t, out, `\documentclass{article}`)
+	assert.Contains(t, out, `\usepackage{amsmath}`)
+	assert.Contains(t, out, `\title{ ResNet \& Friends }`)
+	assert.Contains(t, out, "\\section{Summary}\nResidual learning.")
+	assert.Equal(t, 1, strings.Count(out, `\maketitle`))
+	assert.Equal(t, 1, strings.Count(out, `\end{document}`))
+}
+
+func TestGenerateLatexFile_WithoutTemplateKeepsContent(t *testing.T) {
+	lg, err := NewLatexGenerator(t.TempDir(), "")
+	require.NoError(t, err)
+
+	path, err := lg.GenerateLatexFile("Paper", generatedReport, "")
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, generatedReport, string(data))
+}
+
+func TestLoadReportTemplate_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"syntax error", "{{.Body", "failed to parse"},
+		{"unknown field", "{{.Body}} {{.Author}}", "failed to render"},
+		{"missing body", "{{.Title}}", "does not include {{.Body}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadReportTemplate(writeTemplate(t, tt.content))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := NewLatexGenerator(t.TempDir(), filepath.Join(t.TempDir(), "missing.tmpl"))
+	assert.ErrorContains(t, err, "failed to read LaTeX template")
+}
+
+func TestSplitLatexDocument_NoDocumentEnvironment(t *testing.T) {
+	preamble, body := splitLatexDocument("\\section{Only}\nText\n")
+	assert.Empty(t, preamble)
+	assert.Equal(t, "\\section{Only}\nText", body)
+}
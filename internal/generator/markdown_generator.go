@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+)
+
+// MarkdownGenerator writes Markdown reports, the alternative to LaTeX/PDF
+// for readers without a LaTeX toolchain
+type MarkdownGenerator struct {
+	outputDir string
+}
+
+// NewMarkdownGenerator creates a generator that writes into outputDir
+func NewMarkdownGenerator(outputDir string) *MarkdownGenerator {
+	return &MarkdownGenerator{
+		outputDir: outputDir,
+	}
+}
+
+// GenerateMarkdownFile writes a Markdown report. Like GenerateLatexFile, the
+// source file hash is recorded so papers sharing a title don't overwrite each other.
+func (mg *MarkdownGenerator) GenerateMarkdownFile(paperTitle, content, fileHash string) (string, error) {
+	filename := sanitizeFilename(paperTitle)
+	if filename == "" {
+		filename = "paper_analysis"
+	}
+
+	if err := os.MkdirAll(mg.outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if fileHash != "" {
+		content = fmt.Sprintf("<!-- %s %s -->\n", sourceHashKey, fileHash) + content
+	}
+
+	outputPath, err := writeReportFile(mg.outputDir, filename, ".md", fileHash, []byte(content))
+	if err != nil {
+		return "", fmt.Errorf("failed to write Markdown file: %w", err)
+	}
+
+	return outputPath, nil
+}
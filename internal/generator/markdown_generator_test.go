@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateMarkdownFile_TitleCollision(t *testing.T) {
+	mg := NewMarkdownGenerator(t.TempDir())
+
+	pathA, err := mg.GenerateMarkdownFile("Attention Is All You Need", "# A", "3f9a1111aaaa")
+	require.NoError(t, err)
+	pathB, err := mg.GenerateMarkdownFile("Attention Is All You Need", "# B", "7c2e2222bbbb")
+	require.NoError(t, err)
+	pathA2, err := mg.GenerateMarkdownFile("Attention Is All You Need", "# A v2", "3f9a1111aaaa")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Attention_Is_All_You_Need.md", filepath.Base(pathA))
+	assert.Equal(t, "Attention_Is_All_You_Need_7c2e.md", filepath.Base(pathB))
+	assert.Equal(t, pathA, pathA2, "Reprocessing the same source should overwrite its own file")
+
+	data, err := os.ReadFile(pathA)
+	require.NoError(t, err)
+	assert.Equal(t, "<!-- archivist-source-hash: 3f9a1111aaaa -->\n# A v2", string(data))
+}
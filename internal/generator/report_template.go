@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// ReportTemplateData is what a user template (latex.template) can reference
+type ReportTemplateData struct {
+	Title    string // Paper title, escaped for LaTeX
+	Preamble string // Preamble of the generated document, without \documentclass
+	Body     string // Everything between \begin{document} and \end{document}
+}
+
+// templateCheckBody is rendered when validating a template to make sure
+// {{.Body}} actually ends up in the output
+const templateCheckBody = "archivist-template-check-body"
+
+// LoadReportTemplate reads and parses a report template. The template must
+// render with sample data and include {{.Body}}, so mistakes surface at
+// startup rather than after a paper has been analyzed.
+func LoadReportTemplate(path string) (*template.Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LaTeX template: %w", err)
+	}
+
+	tmpl, err := template.New(path).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse LaTeX template %s: %w", path, err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, ReportTemplateData{Title: "Title", Body: templateCheckBody}); err != nil {
+		return nil, fmt.Errorf("failed to render LaTeX template %s: %w", path, err)
+	}
+	if !strings.Contains(out.String(), templateCheckBody) {
+		return nil, fmt.Errorf("LaTeX template %s does not include {{.Body}}", path)
+	}
+
+	return tmpl, nil
+}
+
+// renderReport places a generated document into tmpl. The generated preamble
+// and \maketitle are dropped from the body, since the template owns the title page.
+func renderReport(tmpl *template.Template, title, latexContent string) (string, error) {
+	preamble, body := splitLatexDocument(latexContent)
+
+	var out bytes.Buffer
+	err := tmpl.Execute(&out, ReportTemplateData{
+		Title:    escapeLatex(title),
+		Preamble: preamble,
+		Body:     body,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render LaTeX template: %w", err)
+	}
+	return out.String(), nil
+}
+
+// splitLatexDocument separates a full document into its preamble (minus
+// \documentclass) and body. Content without \begin{document} is all body.
+func splitLatexDocument(content string) (string, string) {
+	start := strings.Index(content, `\begin{document}`)
+	if start == -1 {
+		return "", strings.TrimSpace(content)
+	}
+
+	var preamble []string
+	for _, line := range strings.Split(content[:start], "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), `\documentclass`) {
+			continue
+		}
+		preamble = append(preamble, line)
+	}
+
+	body := content[start+len(`\begin{document}`):]
+	if end := strings.LastIndex(body, `\end{document}`); end != -1 {
+		body = body[:end]
+	}
+
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		if strings.TrimSpace(line) == `\maketitle` {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(preamble, "\n")), strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// latexEscaper escapes the characters that are special in LaTeX text
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+func escapeLatex(s string) string {
+	return latexEscaper.Replace(s)
+}
@@ -0,0 +1,188 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// nameSuffixes are generational suffixes ignored when normalizing author names
+var nameSuffixes = map[string]bool{
+	"jr": true, "sr": true, "ii": true, "iii": true, "iv": true,
+}
+
+// normalizeAuthorName returns the key used to recognise the same author
+// written differently: "Y. LeCun", "Yann LeCun" and "LeCun, Yann" all become
+// "lecun y". The key is the lowercase last name followed by the first initial.
+func normalizeAuthorName(name string) string {
+	// "Last, First" puts the last name first; a trailing ", Jr." does not
+	var parts []string
+	for _, part := range strings.Split(name, ",") {
+		if part = strings.TrimSpace(part); part != "" && !isNameSuffix(part) {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 2 {
+		parts = []string{parts[1], parts[0]}
+	}
+
+	// Lowercase and strip punctuation. Hyphens and apostrophes join the word
+	// ("O'Brien" -> "obrien"); anything else separates words ("Y.LeCun").
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.Join(parts, " ")) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '-' || r == '\'' || r == '’':
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	var words []string
+	for _, word := range strings.Fields(b.String()) {
+		if !nameSuffixes[word] {
+			words = append(words, word)
+		}
+	}
+
+	switch len(words) {
+	case 0:
+		return strings.ToLower(strings.TrimSpace(name))
+	case 1:
+		return words[0]
+	}
+
+	initial := []rune(words[0])[0]
+	return words[len(words)-1] + " " + string(initial)
+}
+
+// isNameSuffix reports whether a comma-separated part is just a suffix like "Jr."
+func isNameSuffix(part string) bool {
+	return nameSuffixes[strings.ToLower(strings.Trim(part, ". "))]
+}
+
+// resolveAuthorCypher binds `a` to the author a statement refers to, creating
+// it if needed. An author sharing $orcid wins, then one with exactly
+// $author_name, then one with the same normalized $author_key. carry lists
+// variables already bound by the query (e.g. "p, ") that must stay in scope.
+func resolveAuthorCypher(carry string) string {
+	return `
+		OPTIONAL MATCH (byOrcid:Author {orcid: $orcid}) WHERE $orcid <> ''
+		WITH ` + carry + `byOrcid LIMIT 1
+		OPTIONAL MATCH (byName:Author {name: $author_name})
+		WITH ` + carry + `coalesce(byOrcid, byName) AS found
+		CALL {
+			WITH found
+			WITH found WHERE found IS NULL
+			MERGE (a:Author {name_key: $author_key})
+			ON CREATE SET a.name = $author_name
+			RETURN a
+			UNION
+			WITH found
+			WITH found WHERE found IS NOT NULL
+			RETURN found AS a
+		}
+		SET a.name_key = coalesce(a.name_key, $author_key)
+	`
+}
+
+// matchAuthorCypher binds variable to an existing author by exact name, or
+// failing that by normalized key
+func matchAuthorCypher(variable, nameParam, keyParam string) string {
+	return fmt.Sprintf(`
+		CALL {
+			MATCH (x:Author) WHERE x.name = $%[2]s OR x.name_key = $%[3]s
+			RETURN x AS %[1]s ORDER BY x.name = $%[2]s DESC LIMIT 1
+		}
+	`, variable, nameParam, keyParam)
+}
+
+// MergeAuthors folds duplicate author nodes into the canonical one. Papers,
+// affiliations and co-authorships of each alias move to the canonical author,
+// which also takes the alias's ORCID if it has none, and the aliases are deleted.
+func (eb *EnhancedNeo4jBuilder) MergeAuthors(ctx context.Context, canonical string, aliases []string) error {
+	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
+	defer session.Close(ctx)
+
+	merged, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, "MATCH (c:Author {name: $canonical}) RETURN count(c)",
+			map[string]interface{}{"canonical": canonical})
+		if err != nil {
+			return nil, err
+		}
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if count, _ := record.Values[0].(int64); count == 0 {
+			return nil, fmt.Errorf("author not found: %s", canonical)
+		}
+
+		merged := 0
+		for _, alias := range aliases {
+			if alias == canonical {
+				continue
+			}
+			params := map[string]interface{}{
+				"canonical":     canonical,
+				"canonical_key": normalizeAuthorName(canonical),
+				"alias":         alias,
+			}
+			for _, query := range mergeAuthorQueries {
+				result, err := tx.Run(ctx, query, params)
+				if err != nil {
+					return nil, fmt.Errorf("failed to merge %s into %s: %w", alias, canonical, err)
+				}
+				if _, err := result.Consume(ctx); err != nil {
+					return nil, err
+				}
+			}
+			merged++
+		}
+		return merged, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✓ Merged %d alias(es) into author %s", merged, canonical)
+	return nil
+}
+
+// mergeAuthorQueries move an alias's relationships to the canonical author,
+// then delete the alias. Each runs with $canonical, $canonical_key and $alias.
+var mergeAuthorQueries = []string{
+	`
+		MATCH (c:Author {name: $canonical}), (alias:Author {name: $alias})
+		MATCH (p:Paper)-[r:WRITTEN_BY]->(alias)
+		MERGE (p)-[moved:WRITTEN_BY]->(c)
+		SET moved += properties(r)
+		DELETE r
+	`,
+	`
+		MATCH (c:Author {name: $canonical}), (alias:Author {name: $alias})
+		MATCH (alias)-[r:AFFILIATED_WITH]->(i:Institution)
+		MERGE (c)-[moved:AFFILIATED_WITH]->(i)
+		SET moved += properties(r)
+		DELETE r
+	`,
+	`
+		MATCH (c:Author {name: $canonical}), (alias:Author {name: $alias})
+		MATCH (alias)-[r:CO_AUTHORED_WITH]-(other:Author)
+		WHERE other <> c
+		MERGE (c)-[moved:CO_AUTHORED_WITH]-(other)
+		SET moved += properties(r)
+		DELETE r
+	`,
+	`
+		MATCH (c:Author {name: $canonical}), (alias:Author {name: $alias})
+		SET c.orcid = CASE WHEN coalesce(c.orcid, '') = '' THEN alias.orcid ELSE c.orcid END,
+			c.name_key = coalesce(c.name_key, $canonical_key)
+		DETACH DELETE alias
+	`,
+}
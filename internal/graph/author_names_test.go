@@ -0,0 +1,47 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAuthorNameCollapsesVariants(t *testing.T) {
+	for _, name := range []string{
+		"Yann LeCun",
+		"Y. LeCun",
+		"Y.LeCun",
+		"LeCun, Yann",
+		"LeCun, Y.",
+		"  yann   lecun ",
+		"Yann A. LeCun",
+	} {
+		assert.Equal(t, "lecun y", normalizeAuthorName(name), name)
+	}
+}
+
+func TestNormalizeAuthorNameKeepsDistinctAuthors(t *testing.T) {
+	assert.NotEqual(t, normalizeAuthorName("Yann LeCun"), normalizeAuthorName("Geoffrey Hinton"))
+	assert.NotEqual(t, normalizeAuthorName("Yann LeCun"), normalizeAuthorName("Xavier LeCun"))
+}
+
+func TestNormalizeAuthorNameEdgeCases(t *testing.T) {
+	assert.Equal(t, "king m", normalizeAuthorName("Martin Luther King, Jr."))
+	assert.Equal(t, "king m", normalizeAuthorName("King, Martin Luther, Jr."))
+	assert.Equal(t, "obrien c", normalizeAuthorName("Conan O'Brien"))
+	assert.Equal(t, "smithjones a", normalizeAuthorName("Smith-Jones, Anna"))
+	assert.Equal(t, "plato", normalizeAuthorName("Plato"))
+	assert.Equal(t, "müller j", normalizeAuthorName("Jürgen Müller"))
+	assert.Equal(t, "", normalizeAuthorName("  "))
+}
+
+func TestAuthorStatementsUseNormalizedKey(t *testing.T) {
+	stmt := authorStatement(&AuthorNode{Name: "LeCun, Yann", ORCID: "0000-0002-1825-0097"})
+	assert.Equal(t, "lecun y", stmt.params["author_key"])
+	assert.Equal(t, "LeCun, Yann", stmt.params["author_name"])
+	assert.Contains(t, stmt.query, "MERGE (a:Author {name_key: $author_key})")
+
+	stmt = authorshipStatement(&AuthorshipRelationship{PaperTitle: "Gradient-Based Learning", AuthorName: "Y. LeCun"})
+	assert.Equal(t, "lecun y", stmt.params["author_key"])
+	assert.Equal(t, "", stmt.params["orcid"])
+}
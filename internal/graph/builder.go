@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	neo4jconfig "github.com/neo4j/neo4j-go-driver/v5/neo4j/config"
 )
 
 // GraphConfig holds Neo4j configuration
@@ -15,12 +16,19 @@ type GraphConfig struct {
 	Username string
 	Password string
 	Database string
+
+	MaxConnectionPoolSize int           // Driver connection pool size; 0 keeps the driver default
+	ConnectionTimeout     time.Duration // Wait for a free pooled connection; 0 keeps the driver default
+	MaxConcurrentSessions int           // Sessions open at once during batch ingestion; 0 = unlimited
+	ConnectAttempts       int           // Connectivity checks before giving up; 0 = try once
+	ConnectRetryDelay     time.Duration // Wait before the first retry, doubling after each failure
 }
 
 // GraphBuilder handles Neo4j knowledge graph construction
 type GraphBuilder struct {
-	driver neo4j.DriverWithContext
-	config *GraphConfig
+	driver   neo4j.DriverWithContext
+	config   *GraphConfig
+	sessions sessionLimiter // Bounds concurrent batch ingestion sessions
 }
 
 // NewGraphBuilder creates a new graph builder
@@ -28,23 +36,29 @@ func NewGraphBuilder(config *GraphConfig) (*GraphBuilder, error) {
 	driver, err := neo4j.NewDriverWithContext(
 		config.URI,
 		neo4j.BasicAuth(config.Username, config.Password, ""),
+		func(c *neo4jconfig.Config) {
+			if config.MaxConnectionPoolSize > 0 {
+				c.MaxConnectionPoolSize = config.MaxConnectionPoolSize
+			}
+			if config.ConnectionTimeout > 0 {
+				c.ConnectionAcquisitionTimeout = config.ConnectionTimeout
+			}
+		},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Neo4j driver: %w", err)
 	}
 
-	// Verify connectivity
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	if err := driver.VerifyConnectivity(ctx); err != nil {
-		driver.Close(ctx)
+	// Verify connectivity, waiting for Neo4j if it is still starting
+	if err := verifyWithRetry(driver.VerifyConnectivity, config.ConnectAttempts, config.ConnectRetryDelay, time.Sleep); err != nil {
+		driver.Close(context.Background())
 		return nil, fmt.Errorf("failed to connect to Neo4j: %w", err)
 	}
 
 	return &GraphBuilder{
-		driver: driver,
-		config: config,
+		driver:   driver,
+		config:   config,
+		sessions: newSessionLimiter(config.MaxConcurrentSessions),
 	}, nil
 }
 
@@ -82,8 +96,32 @@ func (gb *GraphBuilder) InitializeSchema(ctx context.Context) error {
 	return nil
 }
 
-// AddPaper creates or updates a paper node
+// AddPaper creates or updates a paper node. If another paper already has its
+// DOI, the paper is stored without one and a warning is logged.
 func (gb *GraphBuilder) AddPaper(ctx context.Context, paper *PaperNode) error {
+	doiOwner, err := gb.writePaper(ctx, paper, paper.DOI)
+	if err != nil && paper.DOI != "" && isDOIConflict(err) {
+		// Another paper claimed the DOI between our check and the write
+		doiOwner = "another paper"
+		_, err = gb.writePaper(ctx, paper, "")
+	} else if doiOwner != "" {
+		doiOwner = fmt.Sprintf("%q", doiOwner)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add paper node: %w", err)
+	}
+
+	if doiOwner != "" {
+		log.Printf("⚠️  DOI %s of %q already belongs to %s; stored the paper without it", paper.DOI, paper.Title, doiOwner)
+	}
+	log.Printf("✓ Added paper node: %s", paper.Title)
+	return nil
+}
+
+// writePaper merges paper into the graph with the given DOI. It returns the
+// title of the paper that already had the DOI, if any, in which case the DOI
+// was left off.
+func (gb *GraphBuilder) writePaper(ctx context.Context, paper *PaperNode, doi string) (string, error) {
 	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
 		DatabaseName: gb.config.Database,
 	})
@@ -99,8 +137,11 @@ func (gb *GraphBuilder) AddPaper(ctx context.Context, paper *PaperNode) error {
 			p.metrics = $metrics,
 			p.year = $year,
 			p.authors = $authors,
-			p.abstract = $abstract
-		RETURN p.title as title
+			p.abstract = $abstract,
+			p.content_hash = coalesce($content_hash, p.content_hash)
+		REMOVE p.stub
+	` + setPaperIdentifiersCypher + `
+		RETURN p.title as title, doi_owner
 	`
 
 	params := map[string]interface{}{
@@ -114,19 +155,40 @@ func (gb *GraphBuilder) AddPaper(ctx context.Context, paper *PaperNode) error {
 		"year":          paper.Year,
 		"authors":       paper.Authors,
 		"abstract":      paper.Abstract,
+		"content_hash":  optionalString(paper.ContentHash),
+		"doi":           doi,
+		"arxiv_id":      paper.ArxivID,
 	}
 
-	_, err := session.Run(ctx, query, params)
+	result, err := session.Run(ctx, query, params)
 	if err != nil {
-		return fmt.Errorf("failed to add paper node: %w", err)
+		return "", err
 	}
-
-	log.Printf("✓ Added paper node: %s", paper.Title)
-	return nil
+	record, err := result.Single(ctx)
+	if err != nil {
+		return "", err
+	}
+	owner, _ := record.Get("doi_owner")
+	ownerTitle, _ := owner.(string)
+	return ownerTitle, nil
 }
 
-// AddCitation creates a citation relationship
+// AddCitation creates a citation relationship. The cited title is resolved to
+// an existing paper, tolerating differences in case, punctuation and small
+// typos (see bestTitleMatch); if none matches, a stub paper node is created
+// for it, which is filled in if the paper is processed later.
 func (gb *GraphBuilder) AddCitation(ctx context.Context, citation *CitationRelationship) error {
+	target, score, ok, err := gb.resolvePaperTitle(ctx, citation.TargetPaper)
+	if err != nil {
+		return fmt.Errorf("failed to resolve cited paper: %w", err)
+	}
+	if !ok {
+		target = citation.TargetPaper
+	} else if target != citation.TargetPaper {
+		log.Printf("🔗 Fuzzy-matched cited title %q to existing paper %q (similarity %.2f)",
+			citation.TargetPaper, target, score)
+	}
+
 	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
 		DatabaseName: gb.config.Database,
 	})
@@ -134,7 +196,8 @@ func (gb *GraphBuilder) AddCitation(ctx context.Context, citation *CitationRelat
 
 	query := `
 		MATCH (source:Paper {title: $source})
-		MATCH (target:Paper {title: $target})
+		MERGE (target:Paper {title: $target})
+		ON CREATE SET target.stub = true
 		MERGE (source)-[r:CITES {
 			importance: $importance,
 			context: $context
@@ -144,12 +207,12 @@ func (gb *GraphBuilder) AddCitation(ctx context.Context, citation *CitationRelat
 
 	params := map[string]interface{}{
 		"source":     citation.SourcePaper,
-		"target":     citation.TargetPaper,
+		"target":     target,
 		"importance": citation.Importance,
 		"context":    citation.Context,
 	}
 
-	_, err := session.Run(ctx, query, params)
+	_, err = session.Run(ctx, query, params)
 	if err != nil {
 		return fmt.Errorf("failed to add citation: %w", err)
 	}
@@ -351,6 +414,116 @@ func (gb *GraphBuilder) GetStats(ctx context.Context) (*GraphStats, error) {
 	return nil, fmt.Errorf("failed to retrieve stats")
 }
 
+// Citation chain limits keep variable-length traversals bounded on dense graphs
+const (
+	maxCitationChainDepth = 5
+	maxCitationChainPaths = 100
+)
+
+// GetCitationChain returns citation paths starting at the given paper, up to maxDepth hops.
+// Paths are ordered by length, then by the titles along the path.
+func (gb *GraphBuilder) GetCitationChain(ctx context.Context, title string, maxDepth int) ([]*GraphPath, error) {
+	if maxDepth < 1 {
+		maxDepth = 1
+	}
+	if maxDepth > maxCitationChainDepth {
+		maxDepth = maxCitationChainDepth
+	}
+
+	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: gb.config.Database,
+		AccessMode:   neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	// Depth can't be a query parameter in a variable-length pattern
+	query := fmt.Sprintf(`
+		MATCH path = (p:Paper {title: $title})-[:CITES*1..%d]->(cited:Paper)
+		RETURN [n IN nodes(path) | n.title] as nodes,
+			   [r IN relationships(path) | type(r)] as relationships,
+			   length(path) as length,
+			   reduce(w = 0.0, r IN relationships(path) | w + coalesce(r.weight, 1.0)) as total_weight
+		ORDER BY length, nodes
+		LIMIT $limit
+	`, maxDepth)
+
+	result, err := session.Run(ctx, query, map[string]interface{}{
+		"title": title,
+		"limit": maxCitationChainPaths,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get citation chain: %w", err)
+	}
+
+	var paths []*GraphPath
+	for result.Next(ctx) {
+		record := result.Record()
+		path := &GraphPath{}
+
+		if val, ok := record.Get("nodes"); ok && val != nil {
+			for _, n := range val.([]interface{}) {
+				if name, ok := n.(string); ok {
+					path.Nodes = append(path.Nodes, name)
+				}
+			}
+		}
+		if val, ok := record.Get("relationships"); ok && val != nil {
+			for _, r := range val.([]interface{}) {
+				path.Relationships = append(path.Relationships, r.(string))
+			}
+		}
+		if val, ok := record.Get("length"); ok && val != nil {
+			path.Length = int(val.(int64))
+		}
+		if val, ok := record.Get("total_weight"); ok && val != nil {
+			path.TotalWeight = val.(float64)
+		}
+
+		paths = append(paths, path)
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read citation chain: %w", err)
+	}
+
+	return paths, nil
+}
+
+// GetCitingPapers returns the titles of papers that cite the given paper, alphabetically
+func (gb *GraphBuilder) GetCitingPapers(ctx context.Context, title string) ([]string, error) {
+	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: gb.config.Database,
+		AccessMode:   neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (citing:Paper)-[:CITES]->(p:Paper {title: $title})
+		RETURN DISTINCT citing.title as title
+		ORDER BY title
+		LIMIT $limit
+	`
+
+	result, err := session.Run(ctx, query, map[string]interface{}{
+		"title": title,
+		"limit": maxCitationChainPaths,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get citing papers: %w", err)
+	}
+
+	var titles []string
+	for result.Next(ctx) {
+		if val, ok := result.Record().Get("title"); ok && val != nil {
+			titles = append(titles, val.(string))
+		}
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read citing papers: %w", err)
+	}
+
+	return titles, nil
+}
+
 // DeletePaper removes a paper and all its relationships
 func (gb *GraphBuilder) DeletePaper(ctx context.Context, title string) error {
 	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
@@ -0,0 +1,51 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	// connectAttemptTimeout bounds a single connectivity check
+	connectAttemptTimeout = 10 * time.Second
+	// maxConnectRetryDelay caps the doubling wait between connectivity checks
+	maxConnectRetryDelay = 30 * time.Second
+)
+
+// verifyWithRetry calls verify up to attempts times, each bounded by
+// connectAttemptTimeout, until it succeeds. The wait between tries starts at
+// delay and doubles after each failure, up to maxConnectRetryDelay, so the
+// caller rides out Neo4j still starting (e.g. right after docker compose up).
+// sleep is time.Sleep outside tests.
+func verifyWithRetry(verify func(ctx context.Context) error, attempts int, delay time.Duration, sleep func(time.Duration)) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), connectAttemptTimeout)
+		err = verify(ctx)
+		cancel()
+		if err == nil {
+			if attempt > 1 {
+				log.Printf("✓ Neo4j reachable after %d attempts", attempt)
+			}
+			return nil
+		}
+		if attempt == attempts {
+			break
+		}
+
+		log.Printf("⏳ Neo4j not reachable yet (attempt %d/%d): %v; retrying in %s", attempt, attempts, err, delay)
+		sleep(delay)
+		delay = min(delay*2, maxConnectRetryDelay)
+	}
+
+	if attempts > 1 {
+		return fmt.Errorf("gave up after %d attempts: %w", attempts, err)
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyWithRetrySucceedsAfterFailures(t *testing.T) {
+	calls := 0
+	verify := func(ctx context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	var waits []time.Duration
+	sleep := func(d time.Duration) { waits = append(waits, d) }
+
+	assert.NoError(t, verifyWithRetry(verify, 5, time.Second, sleep))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, waits)
+}
+
+func TestVerifyWithRetryGivesUp(t *testing.T) {
+	calls := 0
+	verify := func(ctx context.Context) error {
+		calls++
+		return errors.New("connection refused")
+	}
+	var waits []time.Duration
+	sleep := func(d time.Duration) { waits = append(waits, d) }
+
+	err := verifyWithRetry(verify, 4, 20*time.Second, sleep)
+	assert.ErrorContains(t, err, "gave up after 4 attempts: connection refused")
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, []time.Duration{20 * time.Second, maxConnectRetryDelay, maxConnectRetryDelay}, waits)
+}
+
+func TestVerifyWithRetryTriesOnceByDefault(t *testing.T) {
+	calls := 0
+	verify := func(ctx context.Context) error {
+		calls++
+		return errors.New("connection refused")
+	}
+
+	err := verifyWithRetry(verify, 0, time.Second, func(time.Duration) { t.Fatal("slept without retrying") })
+	assert.EqualError(t, err, "connection refused")
+	assert.Equal(t, 1, calls)
+}
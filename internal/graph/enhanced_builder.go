@@ -39,7 +39,7 @@ func NewEnhancedGraphBuilder(
 	}
 
 	// Initialize embedding client
-	embeddingClient, err := rag.NewEmbeddingClient(apiKey)
+	embeddingClient, err := rag.NewEmbeddingClient(apiKey, rag.DefaultEmbeddingModel, int(vectorConfig.VectorSize))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create embedding client: %w", err)
 	}
@@ -92,7 +92,7 @@ func (egb *EnhancedGraphBuilder) AddPaperWithEmbeddings(ctx context.Context, pap
 	}
 
 	// Step 2: Generate embeddings for chunks
-	embeddings, err := egb.embeddingClient.GenerateBatchEmbeddings(ctx, chunks)
+	embeddings, err := egb.embeddingClient.GenerateEmbeddings(ctx, chunks)
 	if err != nil {
 		return fmt.Errorf("failed to generate embeddings: %w", err)
 	}
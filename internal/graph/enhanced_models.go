@@ -38,6 +38,9 @@ type PaperNodeEnhanced struct {
 	// Embedding
 	EmbeddingID    string `json:"embedding_id"` // Link to Qdrant
 
+	// Change detection
+	ContentHash    string `json:"content_hash,omitempty"` // Hash of the source PDF; re-ingestion is skipped while it matches
+
 	// Analytics (computed)
 	CitationCount  int     `json:"citation_count,omitempty"`
 	PageRank       float64 `json:"pagerank,omitempty"`
@@ -288,6 +291,20 @@ type CollaborationNetwork struct {
 	Density        float64                       `json:"density"`
 }
 
+// ConceptPair is two concepts that appear together in the same papers
+type ConceptPair struct {
+	A            string `json:"a"`
+	B            string `json:"b"`
+	SharedPapers int    `json:"shared_papers"`
+}
+
+// PaperRecommendation is a paper suggested as a next read from a set of seed papers
+type PaperRecommendation struct {
+	Title  string  `json:"title"`
+	Score  float64 `json:"score"`  // 0-1, higher is more relevant
+	Reason string  `json:"reason"` // Why it was suggested, e.g. "shares concepts attention; cites \"BERT\""
+}
+
 // ============================================================================
 // ANALYTICS TYPES
 // ============================================================================
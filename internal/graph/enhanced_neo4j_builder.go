@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
+	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
@@ -59,6 +61,8 @@ func (eb *EnhancedNeo4jBuilder) InitializeEnhancedSchema(ctx context.Context) er
 		"CREATE INDEX paper_year_index IF NOT EXISTS FOR (p:Paper) ON (p.year)",
 		"CREATE INDEX paper_venue_index IF NOT EXISTS FOR (p:Paper) ON (p.venue)",
 		"CREATE INDEX author_field_index IF NOT EXISTS FOR (a:Author) ON (a.field)",
+		"CREATE INDEX author_name_key_index IF NOT EXISTS FOR (a:Author) ON (a.name_key)",
+		"CREATE INDEX author_orcid_index IF NOT EXISTS FOR (a:Author) ON (a.orcid)",
 		"CREATE INDEX institution_country_index IF NOT EXISTS FOR (i:Institution) ON (i.country)",
 		"CREATE INDEX concept_category_index IF NOT EXISTS FOR (c:Concept) ON (c.category)",
 		"CREATE INDEX method_type_index IF NOT EXISTS FOR (m:Method) ON (m.type)",
@@ -80,17 +84,164 @@ func (eb *EnhancedNeo4jBuilder) InitializeEnhancedSchema(ctx context.Context) er
 }
 
 // ============================================================================
-// NODE CREATION METHODS
+// BATCH INGESTION
 // ============================================================================
 
-// AddAuthor creates an author node
-func (eb *EnhancedNeo4jBuilder) AddAuthor(ctx context.Context, author *AuthorNode) error {
+// cypherStatement is a single parameterized query run as part of a batch
+type cypherStatement struct {
+	query  string
+	params map[string]interface{}
+}
+
+// BatchIngestPaper writes a paper, its authors and methods, and any relationships in a
+// single write transaction, so ingestion is atomic and costs one round of session setup.
+// rels may contain any of the *...Relationship types accepted by the Link* methods, as
+// well as *InstitutionNode, *VenueNode, and *DatasetNode. Paper may be nil when only
+// linking existing nodes.
+func (eb *EnhancedNeo4jBuilder) BatchIngestPaper(ctx context.Context, paper *PaperNodeEnhanced, authors []*AuthorNode, methods []*MethodNode, rels ...interface{}) error {
+	statements := make([]cypherStatement, 0, 1+len(authors)+len(methods)+len(rels))
+
+	if paper != nil {
+		statements = append(statements, paperEnhancedStatement(paper))
+	}
+	for _, author := range authors {
+		statements = append(statements, authorStatement(author))
+	}
+	for _, method := range methods {
+		statements = append(statements, methodStatement(method))
+	}
+	for _, rel := range rels {
+		stmt, err := relationshipStatement(rel)
+		if err != nil {
+			return err
+		}
+		statements = append(statements, stmt)
+	}
+
+	if err := eb.runBatch(ctx, statements); err != nil {
+		if paper != nil {
+			return fmt.Errorf("failed to ingest paper %s: %w", paper.Title, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// runBatch executes statements in order inside one write transaction. It waits
+// while config.MaxConcurrentSessions other batches hold a session.
+func (eb *EnhancedNeo4jBuilder) runBatch(ctx context.Context, statements []cypherStatement) error {
+	if len(statements) == 0 {
+		return nil
+	}
+
+	if err := eb.sessions.acquire(ctx); err != nil {
+		return fmt.Errorf("waiting for a Neo4j session: %w", err)
+	}
+	defer eb.sessions.release()
+
 	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
 	defer session.Close(ctx)
 
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		for _, stmt := range statements {
+			result, err := tx.Run(ctx, stmt.query, stmt.params)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := result.Consume(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	return err
+}
+
+// relationshipStatement maps a relationship (or auxiliary node) to its query
+func relationshipStatement(rel interface{}) (cypherStatement, error) {
+	switch r := rel.(type) {
+	case *AuthorshipRelationship:
+		return authorshipStatement(r), nil
+	case *AffiliationRelationship:
+		return affiliationStatement(r), nil
+	case *UsesMethodRelationship:
+		return usesMethodStatement(r), nil
+	case *PublishedInRelationship:
+		return publishedInStatement(r), nil
+	case *CoAuthorshipRelationship:
+		return coAuthorshipStatement(r), nil
+	case *UsesDatasetRelationship:
+		return usesDatasetStatement(r), nil
+	case *ExtendsRelationship:
+		return extendsStatement(r), nil
+	case *InstitutionNode:
+		return institutionStatement(r), nil
+	case *VenueNode:
+		return venueStatement(r), nil
+	case *DatasetNode:
+		return datasetStatement(r), nil
+	default:
+		return cypherStatement{}, fmt.Errorf("unsupported batch item type %T", rel)
+	}
+}
+
+// paperEnhancedStatement builds the query for a paper node
+func paperEnhancedStatement(paper *PaperNodeEnhanced) cypherStatement {
 	query := `
-		MERGE (a:Author {name: $name})
-		SET a.orcid = $orcid,
+		MERGE (p:Paper {title: $title})
+		SET p.pdf_path = $pdf_path,
+			p.year = $year,
+			p.processed_at = datetime($processed_at),
+			p.abstract = $abstract,
+			p.keywords = $keywords,
+			p.authors = $authors,
+			p.venue = $venue,
+			p.methodologies = $methodologies,
+			p.datasets = $datasets,
+			p.metrics = $metrics,
+			p.embedding_id = $embedding_id,
+			p.content_hash = coalesce($content_hash, p.content_hash)
+		REMOVE p.stub
+	` + setPaperIdentifiersCypher + `
+		RETURN p.title
+	`
+
+	params := map[string]interface{}{
+		"title":         paper.Title,
+		"doi":           paper.DOI,
+		"arxiv_id":      paper.ArxivID,
+		"pdf_path":      paper.PDFPath,
+		"year":          paper.Year,
+		"processed_at":  paper.ProcessedAt.Format(time.RFC3339),
+		"abstract":      paper.Abstract,
+		"keywords":      paper.Keywords,
+		"authors":       paper.Authors,
+		"venue":         paper.Venue,
+		"methodologies": paper.Methodologies,
+		"datasets":      paper.Datasets,
+		"metrics":       paper.Metrics,
+		"embedding_id":  paper.EmbeddingID,
+		"content_hash":  optionalString(paper.ContentHash),
+	}
+
+	return cypherStatement{query: query, params: params}
+}
+
+// ============================================================================
+// NODE CREATION METHODS
+// ============================================================================
+
+// AddAuthor creates an author node
+func (eb *EnhancedNeo4jBuilder) AddAuthor(ctx context.Context, author *AuthorNode) error {
+	return eb.runBatch(ctx, []cypherStatement{authorStatement(author)})
+}
+
+// authorStatement builds the query for an author node. The author is matched
+// by ORCID or normalized name, so spelling variants share one node.
+func authorStatement(author *AuthorNode) cypherStatement {
+	query := resolveAuthorCypher("") + `
+		SET a.orcid = CASE WHEN $orcid <> '' THEN $orcid ELSE a.orcid END,
 			a.email = $email,
 			a.affiliation = $affiliation,
 			a.field = $field,
@@ -102,7 +253,8 @@ func (eb *EnhancedNeo4jBuilder) AddAuthor(ctx context.Context, author *AuthorNod
 	`
 
 	params := map[string]interface{}{
-		"name":            author.Name,
+		"author_name":     author.Name,
+		"author_key":      normalizeAuthorName(author.Name),
 		"orcid":           author.ORCID,
 		"email":           author.Email,
 		"affiliation":     author.Affiliation,
@@ -113,15 +265,16 @@ func (eb *EnhancedNeo4jBuilder) AddAuthor(ctx context.Context, author *AuthorNod
 		"paper_count":     author.PaperCount,
 	}
 
-	_, err := session.Run(ctx, query, params)
-	return err
+	return cypherStatement{query: query, params: params}
 }
 
 // AddInstitution creates an institution node
 func (eb *EnhancedNeo4jBuilder) AddInstitution(ctx context.Context, inst *InstitutionNode) error {
-	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
-	defer session.Close(ctx)
+	return eb.runBatch(ctx, []cypherStatement{institutionStatement(inst)})
+}
 
+// institutionStatement builds the query for an institution node
+func institutionStatement(inst *InstitutionNode) cypherStatement {
 	query := `
 		MERGE (i:Institution {name: $name})
 		SET i.country = $country,
@@ -141,15 +294,16 @@ func (eb *EnhancedNeo4jBuilder) AddInstitution(ctx context.Context, inst *Instit
 		"website":         inst.Website,
 	}
 
-	_, err := session.Run(ctx, query, params)
-	return err
+	return cypherStatement{query: query, params: params}
 }
 
 // AddMethod creates a method node
 func (eb *EnhancedNeo4jBuilder) AddMethod(ctx context.Context, method *MethodNode) error {
-	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
-	defer session.Close(ctx)
+	return eb.runBatch(ctx, []cypherStatement{methodStatement(method)})
+}
 
+// methodStatement builds the query for a method node
+func methodStatement(method *MethodNode) cypherStatement {
 	query := `
 		MERGE (m:Method {name: $name})
 		SET m.type = $type,
@@ -173,15 +327,16 @@ func (eb *EnhancedNeo4jBuilder) AddMethod(ctx context.Context, method *MethodNod
 		"variants":        method.Variants,
 	}
 
-	_, err := session.Run(ctx, query, params)
-	return err
+	return cypherStatement{query: query, params: params}
 }
 
 // AddVenue creates a venue node
 func (eb *EnhancedNeo4jBuilder) AddVenue(ctx context.Context, venue *VenueNode) error {
-	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
-	defer session.Close(ctx)
+	return eb.runBatch(ctx, []cypherStatement{venueStatement(venue)})
+}
 
+// venueStatement builds the query for a venue node
+func venueStatement(venue *VenueNode) cypherStatement {
 	query := `
 		MERGE (v:Venue {name: $name})
 		SET v.short_name = $short_name,
@@ -201,15 +356,16 @@ func (eb *EnhancedNeo4jBuilder) AddVenue(ctx context.Context, venue *VenueNode)
 		"acceptance_rate": venue.AcceptanceRate,
 	}
 
-	_, err := session.Run(ctx, query, params)
-	return err
+	return cypherStatement{query: query, params: params}
 }
 
 // AddDataset creates a dataset node
 func (eb *EnhancedNeo4jBuilder) AddDataset(ctx context.Context, dataset *DatasetNode) error {
-	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
-	defer session.Close(ctx)
+	return eb.runBatch(ctx, []cypherStatement{datasetStatement(dataset)})
+}
 
+// datasetStatement builds the query for a dataset node
+func datasetStatement(dataset *DatasetNode) cypherStatement {
 	query := `
 		MERGE (d:Dataset {name: $name})
 		SET d.type = $type,
@@ -233,8 +389,7 @@ func (eb *EnhancedNeo4jBuilder) AddDataset(ctx context.Context, dataset *Dataset
 		"benchmark_for":   dataset.BenchmarkFor,
 	}
 
-	_, err := session.Run(ctx, query, params)
-	return err
+	return cypherStatement{query: query, params: params}
 }
 
 // ============================================================================
@@ -243,12 +398,14 @@ func (eb *EnhancedNeo4jBuilder) AddDataset(ctx context.Context, dataset *Dataset
 
 // LinkPaperToAuthor creates authorship relationship
 func (eb *EnhancedNeo4jBuilder) LinkPaperToAuthor(ctx context.Context, rel *AuthorshipRelationship) error {
-	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
-	defer session.Close(ctx)
+	return eb.runBatch(ctx, []cypherStatement{authorshipStatement(rel)})
+}
 
+// authorshipStatement builds the query for an authorship relationship
+func authorshipStatement(rel *AuthorshipRelationship) cypherStatement {
 	query := `
 		MATCH (p:Paper {title: $paper_title})
-		MERGE (a:Author {name: $author_name})
+	` + resolveAuthorCypher("p, ") + `
 		MERGE (p)-[r:WRITTEN_BY {position: $position, is_corresponding: $is_corresponding}]->(a)
 		RETURN p.title, a.name
 	`
@@ -256,21 +413,23 @@ func (eb *EnhancedNeo4jBuilder) LinkPaperToAuthor(ctx context.Context, rel *Auth
 	params := map[string]interface{}{
 		"paper_title":      rel.PaperTitle,
 		"author_name":      rel.AuthorName,
+		"author_key":       normalizeAuthorName(rel.AuthorName),
+		"orcid":            "",
 		"position":         rel.Position,
 		"is_corresponding": rel.IsCorresponding,
 	}
 
-	_, err := session.Run(ctx, query, params)
-	return err
+	return cypherStatement{query: query, params: params}
 }
 
 // LinkAuthorToInstitution creates affiliation relationship
 func (eb *EnhancedNeo4jBuilder) LinkAuthorToInstitution(ctx context.Context, rel *AffiliationRelationship) error {
-	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
-	defer session.Close(ctx)
+	return eb.runBatch(ctx, []cypherStatement{affiliationStatement(rel)})
+}
 
-	query := `
-		MATCH (a:Author {name: $author_name})
+// affiliationStatement builds the query for an affiliation relationship
+func affiliationStatement(rel *AffiliationRelationship) cypherStatement {
+	query := matchAuthorCypher("a", "author_name", "author_key") + `
 		MERGE (i:Institution {name: $institution_name})
 		MERGE (a)-[r:AFFILIATED_WITH {
 			role: $role,
@@ -282,21 +441,23 @@ func (eb *EnhancedNeo4jBuilder) LinkAuthorToInstitution(ctx context.Context, rel
 
 	params := map[string]interface{}{
 		"author_name":       rel.AuthorName,
+		"author_key":        normalizeAuthorName(rel.AuthorName),
 		"institution_name":  rel.InstitutionName,
 		"role":              rel.Role,
 		"start_year":        rel.StartYear,
 		"end_year":          rel.EndYear,
 	}
 
-	_, err := session.Run(ctx, query, params)
-	return err
+	return cypherStatement{query: query, params: params}
 }
 
 // LinkPaperToMethod creates uses-method relationship
 func (eb *EnhancedNeo4jBuilder) LinkPaperToMethod(ctx context.Context, rel *UsesMethodRelationship) error {
-	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
-	defer session.Close(ctx)
+	return eb.runBatch(ctx, []cypherStatement{usesMethodStatement(rel)})
+}
 
+// usesMethodStatement builds the query for a uses-method relationship
+func usesMethodStatement(rel *UsesMethodRelationship) cypherStatement {
 	query := `
 		MATCH (p:Paper {title: $paper_title})
 		MERGE (m:Method {name: $method_name})
@@ -314,15 +475,16 @@ func (eb *EnhancedNeo4jBuilder) LinkPaperToMethod(ctx context.Context, rel *Uses
 		"description":    rel.Description,
 	}
 
-	_, err := session.Run(ctx, query, params)
-	return err
+	return cypherStatement{query: query, params: params}
 }
 
 // LinkPaperToVenue creates published-in relationship
 func (eb *EnhancedNeo4jBuilder) LinkPaperToVenue(ctx context.Context, rel *PublishedInRelationship) error {
-	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
-	defer session.Close(ctx)
+	return eb.runBatch(ctx, []cypherStatement{publishedInStatement(rel)})
+}
 
+// publishedInStatement builds the query for a published-in relationship
+func publishedInStatement(rel *PublishedInRelationship) cypherStatement {
 	query := `
 		MATCH (p:Paper {title: $paper_title})
 		MERGE (v:Venue {name: $venue_name})
@@ -342,18 +504,19 @@ func (eb *EnhancedNeo4jBuilder) LinkPaperToVenue(ctx context.Context, rel *Publi
 		"best_paper_award": rel.BestPaperAward,
 	}
 
-	_, err := session.Run(ctx, query, params)
-	return err
+	return cypherStatement{query: query, params: params}
 }
 
 // LinkCoAuthors creates co-authorship relationship
 func (eb *EnhancedNeo4jBuilder) LinkCoAuthors(ctx context.Context, rel *CoAuthorshipRelationship) error {
-	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
-	defer session.Close(ctx)
+	return eb.runBatch(ctx, []cypherStatement{coAuthorshipStatement(rel)})
+}
 
-	query := `
-		MATCH (a1:Author {name: $author1})
-		MATCH (a2:Author {name: $author2})
+// coAuthorshipStatement builds the query for a co-authorship relationship
+func coAuthorshipStatement(rel *CoAuthorshipRelationship) cypherStatement {
+	query := matchAuthorCypher("a1", "author1", "author1_key") +
+		matchAuthorCypher("a2", "author2", "author2_key") + `
+		WITH a1, a2 WHERE a1 <> a2
 		MERGE (a1)-[r:CO_AUTHORED_WITH {
 			joint_papers: $joint_papers,
 			first_colab: $first_colab,
@@ -366,21 +529,24 @@ func (eb *EnhancedNeo4jBuilder) LinkCoAuthors(ctx context.Context, rel *CoAuthor
 	params := map[string]interface{}{
 		"author1":      rel.Author1,
 		"author2":      rel.Author2,
+		"author1_key":  normalizeAuthorName(rel.Author1),
+		"author2_key":  normalizeAuthorName(rel.Author2),
 		"joint_papers": rel.JointPapers,
 		"first_colab":  rel.FirstColab,
 		"last_colab":   rel.LastColab,
 		"weight":       rel.Weight,
 	}
 
-	_, err := session.Run(ctx, query, params)
-	return err
+	return cypherStatement{query: query, params: params}
 }
 
 // LinkPaperToDataset creates uses-dataset relationship
 func (eb *EnhancedNeo4jBuilder) LinkPaperToDataset(ctx context.Context, rel *UsesDatasetRelationship) error {
-	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
-	defer session.Close(ctx)
+	return eb.runBatch(ctx, []cypherStatement{usesDatasetStatement(rel)})
+}
 
+// usesDatasetStatement builds the query for a uses-dataset relationship
+func usesDatasetStatement(rel *UsesDatasetRelationship) cypherStatement {
 	query := `
 		MATCH (p:Paper {title: $paper_title})
 		MERGE (d:Dataset {name: $dataset_name})
@@ -402,15 +568,16 @@ func (eb *EnhancedNeo4jBuilder) LinkPaperToDataset(ctx context.Context, rel *Use
 		"score":        rel.Score,
 	}
 
-	_, err := session.Run(ctx, query, params)
-	return err
+	return cypherStatement{query: query, params: params}
 }
 
 // AddExtensionRelationship creates extends/improves relationship
 func (eb *EnhancedNeo4jBuilder) AddExtensionRelationship(ctx context.Context, rel *ExtendsRelationship) error {
-	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
-	defer session.Close(ctx)
+	return eb.runBatch(ctx, []cypherStatement{extendsStatement(rel)})
+}
 
+// extendsStatement builds the query for an extends relationship
+func extendsStatement(rel *ExtendsRelationship) cypherStatement {
 	query := `
 		MATCH (source:Paper {title: $source_paper})
 		MATCH (target:Paper {title: $target_paper})
@@ -428,8 +595,7 @@ func (eb *EnhancedNeo4jBuilder) AddExtensionRelationship(ctx context.Context, re
 		"description":    rel.Description,
 	}
 
-	_, err := session.Run(ctx, query, params)
-	return err
+	return cypherStatement{query: query, params: params}
 }
 
 // ============================================================================
@@ -477,16 +643,34 @@ func (eb *EnhancedNeo4jBuilder) GetAuthorImpact(ctx context.Context, authorName
 	return nil, fmt.Errorf("author not found: %s", authorName)
 }
 
-// GetCollaborationNetwork retrieves co-authorship network
+// GetCollaborationNetwork retrieves the co-authorship network within depth
+// hops of an author: the author, their colleagues, and every co-authorship
+// between any two of them
 func (eb *EnhancedNeo4jBuilder) GetCollaborationNetwork(ctx context.Context, authorName string, depth int) (*CollaborationNetwork, error) {
+	if depth < 1 {
+		depth = 1
+	}
+
 	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
 	defer session.Close(ctx)
 
 	query := `
-		MATCH path = (a:Author {name: $author_name})-[:CO_AUTHORED_WITH*1..` + fmt.Sprintf("%d", depth) + `]-(colleague:Author)
-		WITH collect(DISTINCT colleague.name) as authors,
-		     collect({author1: a.name, author2: colleague.name, weight: last(relationships(path)).weight}) as connections
-		RETURN authors, connections
+		MATCH (a:Author {name: $author_name})
+		OPTIONAL MATCH (a)-[:CO_AUTHORED_WITH*1..` + fmt.Sprintf("%d", depth) + `]-(colleague:Author)
+		WITH a, collect(DISTINCT colleague) as colleagues
+		WITH [a] + [c IN colleagues WHERE c <> a] as members
+		UNWIND members as m1
+		OPTIONAL MATCH (m1)-[r:CO_AUTHORED_WITH]-(m2:Author)
+		WHERE m2 IN members AND m1.name < m2.name
+		WITH members, collect(CASE WHEN r IS NULL THEN null ELSE {
+			author1: m1.name,
+			author2: m2.name,
+			joint_papers: r.joint_papers,
+			first_colab: r.first_colab,
+			last_colab: r.last_colab,
+			weight: r.weight
+		} END) as connections
+		RETURN [m IN members | m.name] as authors, connections
 	`
 
 	result, err := session.Run(ctx, query, map[string]interface{}{"author_name": authorName})
@@ -506,10 +690,316 @@ func (eb *EnhancedNeo4jBuilder) GetCollaborationNetwork(ctx context.Context, aut
 		}
 
 		// Extract connections
-		// This would need more processing in a real implementation
+		if connections, ok := record.Values[1].([]interface{}); ok {
+			network.Connections = parseCoAuthorships(connections)
+		}
+		network.Density = networkDensity(len(network.Authors), len(network.Connections))
 
 		return network, nil
 	}
 
 	return nil, fmt.Errorf("no collaboration network found for: %s", authorName)
 }
+
+// parseCoAuthorships converts the connection maps returned by
+// GetCollaborationNetwork's query into relationships. A pair linked by more
+// than one relationship (in either direction) is kept once.
+func parseCoAuthorships(connections []interface{}) []CoAuthorshipRelationship {
+	seen := make(map[[2]string]bool)
+	rels := []CoAuthorshipRelationship{}
+
+	for _, c := range connections {
+		props, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		author1, _ := props["author1"].(string)
+		author2, _ := props["author2"].(string)
+		if author1 == "" || author2 == "" || author1 == author2 {
+			continue
+		}
+
+		pair := [2]string{author1, author2}
+		if author2 < author1 {
+			pair = [2]string{author2, author1}
+		}
+		if seen[pair] {
+			continue
+		}
+		seen[pair] = true
+
+		rels = append(rels, CoAuthorshipRelationship{
+			Author1:     author1,
+			Author2:     author2,
+			JointPapers: int(int64Property(props, "joint_papers")),
+			FirstColab:  int(int64Property(props, "first_colab")),
+			LastColab:   int(int64Property(props, "last_colab")),
+			Weight:      float64Property(props, "weight"),
+		})
+	}
+
+	return rels
+}
+
+// networkDensity is the share of possible co-authorships among authors that exist
+func networkDensity(authors, edges int) float64 {
+	if authors < 2 {
+		return 0
+	}
+	possible := float64(authors*(authors-1)) / 2
+	return float64(edges) / possible
+}
+
+// int64Property reads an integer property, returning 0 if it is missing
+func int64Property(props map[string]interface{}, key string) int64 {
+	switch v := props[key].(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	}
+	return 0
+}
+
+// float64Property reads a numeric property, returning 0 if it is missing
+func float64Property(props map[string]interface{}, key string) float64 {
+	switch v := props[key].(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	}
+	return 0
+}
+
+// GetConceptCooccurrence finds pairs of concepts that are used or mentioned
+// by at least minSharedPapers of the same papers, most shared first
+func (eb *EnhancedNeo4jBuilder) GetConceptCooccurrence(ctx context.Context, minSharedPapers int) ([]ConceptPair, error) {
+	if minSharedPapers < 1 {
+		minSharedPapers = 1
+	}
+
+	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
+	defer session.Close(ctx)
+
+	// Ordering the names counts each pair once instead of as (A,B) and (B,A)
+	query := `
+		MATCH (c1:Concept)<-[:USES_CONCEPT|MENTIONS]-(p:Paper)-[:USES_CONCEPT|MENTIONS]->(c2:Concept)
+		WHERE c1.name < c2.name
+		WITH c1.name as concept_a, c2.name as concept_b, count(DISTINCT p) as shared_papers
+		WHERE shared_papers >= $min_shared_papers
+		RETURN concept_a, concept_b, shared_papers
+		ORDER BY shared_papers DESC, concept_a, concept_b
+	`
+
+	result, err := session.Run(ctx, query, map[string]interface{}{"min_shared_papers": minSharedPapers})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query concept co-occurrence: %w", err)
+	}
+
+	pairs := []ConceptPair{}
+	for result.Next(ctx) {
+		record := result.Record()
+		pairs = append(pairs, ConceptPair{
+			A:            record.Values[0].(string),
+			B:            record.Values[1].(string),
+			SharedPapers: int(record.Values[2].(int64)),
+		})
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read concept co-occurrence: %w", err)
+	}
+
+	return pairs, nil
+}
+
+// maxFoundationalPapers caps how many of the earliest papers count as foundational
+const maxFoundationalPapers = 5
+
+// conceptPaper is a dated paper that uses or mentions a concept
+type conceptPaper struct {
+	title string
+	year  int
+}
+
+// GetConceptEvolution traces how a concept spread through the library: papers
+// per year, the earliest (foundational) papers, and the annual growth rate
+func (eb *EnhancedNeo4jBuilder) GetConceptEvolution(ctx context.Context, concept string) (*ConceptEvolution, error) {
+	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{DatabaseName: eb.config.Database})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (p:Paper)-[:USES_CONCEPT|MENTIONS]->(c:Concept {name: $concept})
+		WHERE p.year IS NOT NULL AND p.year > 0
+		RETURN DISTINCT p.title as title, p.year as year
+		ORDER BY year, title
+	`
+
+	result, err := session.Run(ctx, query, map[string]interface{}{"concept": concept})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query concept evolution: %w", err)
+	}
+
+	var papers []conceptPaper
+	for result.Next(ctx) {
+		record := result.Record()
+		title, _ := record.Values[0].(string)
+		year, _ := record.Values[1].(int64)
+		papers = append(papers, conceptPaper{title: title, year: int(year)})
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read concept evolution: %w", err)
+	}
+
+	if len(papers) == 0 {
+		return nil, fmt.Errorf("no dated papers found for concept: %s", concept)
+	}
+
+	return buildConceptEvolution(concept, papers), nil
+}
+
+// buildConceptEvolution summarizes papers sorted by year into a ConceptEvolution.
+// GrowthRate is the compound annual growth in papers per year between the first
+// and the most recent year the concept appeared in.
+func buildConceptEvolution(concept string, papers []conceptPaper) *ConceptEvolution {
+	evolution := &ConceptEvolution{
+		Name:               concept,
+		Timeline:           make(map[int]int),
+		FoundationalPapers: []string{},
+	}
+	if len(papers) == 0 {
+		return evolution
+	}
+
+	firstYear, lastYear := papers[0].year, papers[0].year
+	for _, paper := range papers {
+		evolution.Timeline[paper.year]++
+		if paper.year < firstYear {
+			firstYear = paper.year
+		}
+		if paper.year > lastYear {
+			lastYear = paper.year
+		}
+	}
+
+	for _, paper := range papers {
+		if paper.year == firstYear && len(evolution.FoundationalPapers) < maxFoundationalPapers {
+			evolution.FoundationalPapers = append(evolution.FoundationalPapers, paper.title)
+		}
+	}
+
+	if lastYear > firstYear {
+		first := float64(evolution.Timeline[firstYear])
+		last := float64(evolution.Timeline[lastYear])
+		evolution.GrowthRate = math.Pow(last/first, 1/float64(lastYear-firstYear)) - 1
+	}
+
+	return evolution
+}
+
+// Trends with a yearly change within ±trendStableBand of the average are stable
+const trendStableBand = 0.1
+
+// Trend classifications for TrendAnalysis.PredictedTrend
+const (
+	TrendGrowing   = "growing"
+	TrendStable    = "stable"
+	TrendDeclining = "declining"
+)
+
+// GetTrendAnalysis counts papers per year that use or mention a concept or
+// method (matched case-insensitively), and classifies whether it is growing,
+// stable or declining in the library
+func (eb *EnhancedNeo4jBuilder) GetTrendAnalysis(ctx context.Context, topic string) (*TrendAnalysis, error) {
+	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: eb.config.Database,
+		AccessMode:   neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (p:Paper)-[:USES_CONCEPT|MENTIONS|USES_METHOD]->(t)
+		WHERE (t:Concept OR t:Method) AND toLower(t.name) = toLower($topic)
+		  AND p.year IS NOT NULL AND p.year > 0
+		RETURN p.year as year, count(DISTINCT p) as papers
+		ORDER BY year
+	`
+
+	result, err := session.Run(ctx, query, map[string]interface{}{"topic": topic})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trend: %w", err)
+	}
+
+	timeline := make(map[int]int)
+	for result.Next(ctx) {
+		record := result.Record()
+		year, _ := record.Values[0].(int64)
+		papers, _ := record.Values[1].(int64)
+		timeline[int(year)] += int(papers)
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trend: %w", err)
+	}
+
+	if len(timeline) == 0 {
+		return nil, fmt.Errorf("no dated papers found for concept or method: %s", topic)
+	}
+
+	return buildTrendAnalysis(topic, timeline), nil
+}
+
+// buildTrendAnalysis summarizes a year → paper count timeline. Years without
+// papers between the first and last year count as zero. GrowthRate is the slope
+// of a least-squares line through the yearly counts, relative to the average
+// count per year (0.2 = the topic gains 20% of its average each year). The
+// peak is the year with the most papers, the latest one on ties.
+func buildTrendAnalysis(topic string, timeline map[int]int) *TrendAnalysis {
+	trend := &TrendAnalysis{
+		Topic:          topic,
+		Timeline:       timeline,
+		PredictedTrend: TrendStable,
+	}
+	if len(timeline) == 0 {
+		return trend
+	}
+
+	firstYear, lastYear := math.MaxInt, math.MinInt
+	for year, count := range timeline {
+		if year < firstYear {
+			firstYear = year
+		}
+		if year > lastYear {
+			lastYear = year
+		}
+		if count > timeline[trend.PeakYear] || (count == timeline[trend.PeakYear] && year > trend.PeakYear) {
+			trend.PeakYear = year
+		}
+	}
+
+	years := float64(lastYear - firstYear + 1)
+	if years < 2 {
+		return trend
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for year := firstYear; year <= lastYear; year++ {
+		x := float64(year - firstYear)
+		y := float64(timeline[year])
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	slope := (years*sumXY - sumX*sumY) / (years*sumXX - sumX*sumX)
+	trend.GrowthRate = slope / (sumY / years)
+
+	switch {
+	case trend.GrowthRate > trendStableBand:
+		trend.PredictedTrend = TrendGrowing
+	case trend.GrowthRate < -trendStableBand:
+		trend.PredictedTrend = TrendDeclining
+	}
+
+	return trend
+}
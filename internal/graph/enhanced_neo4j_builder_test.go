@@ -0,0 +1,129 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// seededConnections mimics the driver output of GetCollaborationNetwork's
+// query for a small network: Alice-Bob, Alice-Carol and Bob-Carol co-authored,
+// Dave only with Alice. Bob-Alice is also recorded in the other direction.
+func seededConnections() []interface{} {
+	return []interface{}{
+		map[string]interface{}{"author1": "Alice", "author2": "Bob", "joint_papers": int64(3), "first_colab": int64(2018), "last_colab": int64(2023), "weight": 3.0},
+		map[string]interface{}{"author1": "Alice", "author2": "Carol", "joint_papers": int64(1), "first_colab": int64(2021), "last_colab": int64(2021), "weight": 1.0},
+		map[string]interface{}{"author1": "Bob", "author2": "Carol", "joint_papers": int64(2), "first_colab": int64(2020), "last_colab": int64(2022), "weight": 2.0},
+		map[string]interface{}{"author1": "Alice", "author2": "Dave", "joint_papers": int64(1), "first_colab": nil, "last_colab": nil, "weight": nil},
+		map[string]interface{}{"author1": "Bob", "author2": "Alice", "joint_papers": int64(3), "weight": 3.0},
+	}
+}
+
+func TestParseCoAuthorships(t *testing.T) {
+	rels := parseCoAuthorships(seededConnections())
+
+	assert.Len(t, rels, 4)
+	assert.Equal(t, CoAuthorshipRelationship{
+		Author1: "Alice", Author2: "Bob", JointPapers: 3, FirstColab: 2018, LastColab: 2023, Weight: 3.0,
+	}, rels[0])
+	assert.Equal(t, "Dave", rels[3].Author2)
+	assert.Zero(t, rels[3].FirstColab)
+	assert.Zero(t, rels[3].Weight)
+}
+
+func TestParseCoAuthorshipsSkipsMalformed(t *testing.T) {
+	rels := parseCoAuthorships([]interface{}{
+		nil,
+		"not a map",
+		map[string]interface{}{"author1": "Alice"},
+		map[string]interface{}{"author1": "Alice", "author2": "Alice"},
+	})
+
+	assert.Empty(t, rels)
+}
+
+func TestNetworkDensity(t *testing.T) {
+	rels := parseCoAuthorships(seededConnections())
+
+	// 4 authors have 6 possible pairs, 4 of which co-authored
+	assert.InDelta(t, 4.0/6.0, networkDensity(4, len(rels)), 1e-9)
+	assert.Equal(t, 1.0, networkDensity(2, 1))
+	assert.Zero(t, networkDensity(1, 0))
+	assert.Zero(t, networkDensity(0, 0))
+}
+
+func TestBuildConceptEvolution(t *testing.T) {
+	papers := []conceptPaper{
+		{"Attention Is All You Need", 2017},
+		{"BERT", 2018},
+		{"GPT-2", 2019},
+		{"RoBERTa", 2019},
+		{"T5", 2019},
+		{"ViT", 2019},
+	}
+
+	evolution := buildConceptEvolution("Transformer", papers)
+
+	assert.Equal(t, "Transformer", evolution.Name)
+	assert.Equal(t, map[int]int{2017: 1, 2018: 1, 2019: 4}, evolution.Timeline)
+	assert.Equal(t, []string{"Attention Is All You Need"}, evolution.FoundationalPapers)
+	// 1 paper in 2017 to 4 in 2019 doubles every year
+	assert.InDelta(t, 1.0, evolution.GrowthRate, 1e-9)
+}
+
+func TestBuildConceptEvolutionSingleYear(t *testing.T) {
+	papers := []conceptPaper{{"A", 2020}, {"B", 2020}}
+
+	evolution := buildConceptEvolution("Diffusion", papers)
+
+	assert.Equal(t, map[int]int{2020: 2}, evolution.Timeline)
+	assert.Equal(t, []string{"A", "B"}, evolution.FoundationalPapers)
+	assert.Zero(t, evolution.GrowthRate)
+}
+
+func TestBuildConceptEvolutionCapsFoundationalPapers(t *testing.T) {
+	var papers []conceptPaper
+	for _, title := range []string{"A", "B", "C", "D", "E", "F", "G"} {
+		papers = append(papers, conceptPaper{title, 2015})
+	}
+	papers = append(papers, conceptPaper{"H", 2016})
+
+	evolution := buildConceptEvolution("GAN", papers)
+
+	assert.Len(t, evolution.FoundationalPapers, maxFoundationalPapers)
+	assert.InDelta(t, -6.0/7.0, evolution.GrowthRate, 1e-9)
+}
+
+func TestBuildTrendAnalysisGrowing(t *testing.T) {
+	trend := buildTrendAnalysis("diffusion", map[int]int{2020: 1, 2021: 2, 2022: 3, 2023: 6})
+
+	assert.Equal(t, "diffusion", trend.Topic)
+	assert.Equal(t, 2023, trend.PeakYear)
+	// Least-squares slope is 1.6 papers/year against an average of 3
+	assert.InDelta(t, 1.6/3.0, trend.GrowthRate, 1e-9)
+	assert.Equal(t, TrendGrowing, trend.PredictedTrend)
+}
+
+func TestBuildTrendAnalysisDecliningWithGaps(t *testing.T) {
+	// 2018 and 2019 had no papers and count as zero
+	trend := buildTrendAnalysis("LSTM", map[int]int{2016: 4, 2017: 4, 2020: 1})
+
+	assert.Equal(t, 2017, trend.PeakYear, "latest year wins a tie")
+	assert.Less(t, trend.GrowthRate, -trendStableBand)
+	assert.Equal(t, TrendDeclining, trend.PredictedTrend)
+}
+
+func TestBuildTrendAnalysisStable(t *testing.T) {
+	trend := buildTrendAnalysis("dropout", map[int]int{2019: 3, 2020: 2, 2021: 3, 2022: 3})
+
+	assert.InDelta(t, 0.0, trend.GrowthRate, trendStableBand)
+	assert.Equal(t, TrendStable, trend.PredictedTrend)
+}
+
+func TestBuildTrendAnalysisSingleYear(t *testing.T) {
+	trend := buildTrendAnalysis("RLHF", map[int]int{2022: 5})
+
+	assert.Equal(t, 2022, trend.PeakYear)
+	assert.Zero(t, trend.GrowthRate)
+	assert.Equal(t, TrendStable, trend.PredictedTrend)
+}
@@ -0,0 +1,210 @@
+package graph
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// exportNode is a Paper, Concept, or Author node prepared for export
+type exportNode struct {
+	ID    string
+	Label string
+	Name  string
+}
+
+// exportEdge is a relationship between two exported nodes
+type exportEdge struct {
+	Source string
+	Target string
+	Type   string
+}
+
+// exportGraph is a snapshot of the graph in a form that's easy to serialize
+type exportGraph struct {
+	Nodes []exportNode
+	Edges []exportEdge
+}
+
+// ExportGraphML writes all Paper, Concept, and Author nodes and their relationships as GraphML
+func (gb *GraphBuilder) ExportGraphML(ctx context.Context, w io.Writer) error {
+	g, err := gb.fetchExportGraph(ctx)
+	if err != nil {
+		return err
+	}
+	return writeGraphML(w, g)
+}
+
+// ExportDOT writes all Paper, Concept, and Author nodes and their relationships in Graphviz DOT format
+func (gb *GraphBuilder) ExportDOT(ctx context.Context, w io.Writer) error {
+	g, err := gb.fetchExportGraph(ctx)
+	if err != nil {
+		return err
+	}
+	return writeDOT(w, g)
+}
+
+// fetchExportGraph loads nodes and relationships, assigning stable IDs (n0, n1, ...)
+// ordered by label and name so repeated exports diff cleanly
+func (gb *GraphBuilder) fetchExportGraph(ctx context.Context) (*exportGraph, error) {
+	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: gb.config.Database,
+		AccessMode:   neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	nodeQuery := `
+		MATCH (n)
+		WHERE n:Paper OR n:Concept OR n:Author
+		RETURN elementId(n) as id,
+			   labels(n)[0] as label,
+			   coalesce(n.title, n.name, '') as name
+		ORDER BY label, name
+	`
+
+	result, err := session.Run(ctx, nodeQuery, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nodes: %w", err)
+	}
+
+	g := &exportGraph{}
+	ids := make(map[string]string)
+	for result.Next(ctx) {
+		record := result.Record()
+		elementID, _ := record.Values[0].(string)
+		label, _ := record.Values[1].(string)
+		name, _ := record.Values[2].(string)
+
+		id := fmt.Sprintf("n%d", len(g.Nodes))
+		ids[elementID] = id
+		g.Nodes = append(g.Nodes, exportNode{ID: id, Label: label, Name: name})
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read nodes: %w", err)
+	}
+
+	edgeQuery := `
+		MATCH (a)-[r]->(b)
+		WHERE (a:Paper OR a:Concept OR a:Author) AND (b:Paper OR b:Concept OR b:Author)
+		RETURN elementId(a) as source, elementId(b) as target, type(r) as type
+	`
+
+	result, err = session.Run(ctx, edgeQuery, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query relationships: %w", err)
+	}
+
+	for result.Next(ctx) {
+		record := result.Record()
+		source, _ := record.Values[0].(string)
+		target, _ := record.Values[1].(string)
+		relType, _ := record.Values[2].(string)
+
+		sourceID, ok1 := ids[source]
+		targetID, ok2 := ids[target]
+		if !ok1 || !ok2 {
+			continue
+		}
+		g.Edges = append(g.Edges, exportEdge{Source: sourceID, Target: targetID, Type: relType})
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read relationships: %w", err)
+	}
+
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		return a.Type < b.Type
+	})
+
+	return g, nil
+}
+
+// writeGraphML serializes the graph as GraphML
+func writeGraphML(w io.Writer, g *exportGraph) error {
+	var b strings.Builder
+
+	b.WriteString(xml.Header)
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	b.WriteString(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+	b.WriteString(`  <key id="name" for="node" attr.name="name" attr.type="string"/>` + "\n")
+	b.WriteString(`  <key id="type" for="edge" attr.name="type" attr.type="string"/>` + "\n")
+	b.WriteString(`  <graph id="archivist" edgedefault="directed">` + "\n")
+
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "    <node id=\"%s\">\n", xmlEscape(n.ID))
+		fmt.Fprintf(&b, "      <data key=\"label\">%s</data>\n", xmlEscape(n.Label))
+		fmt.Fprintf(&b, "      <data key=\"name\">%s</data>\n", xmlEscape(n.Name))
+		b.WriteString("    </node>\n")
+	}
+
+	for i, e := range g.Edges {
+		fmt.Fprintf(&b, "    <edge id=\"e%d\" source=\"%s\" target=\"%s\">\n", i, xmlEscape(e.Source), xmlEscape(e.Target))
+		fmt.Fprintf(&b, "      <data key=\"type\">%s</data>\n", xmlEscape(e.Type))
+		b.WriteString("    </edge>\n")
+	}
+
+	b.WriteString("  </graph>\n")
+	b.WriteString("</graphml>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeDOT serializes the graph in Graphviz DOT format
+func writeDOT(w io.Writer, g *exportGraph) error {
+	var b strings.Builder
+
+	b.WriteString("digraph archivist {\n")
+	b.WriteString("  rankdir=LR;\n")
+
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", n.ID, dotQuote(n.Name), dotShape(n.Label))
+	}
+
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", e.Source, e.Target, dotQuote(e.Type))
+	}
+
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// xmlEscape escapes text for use in XML content and attribute values
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// dotQuote returns s as a double-quoted DOT string
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// dotShape picks a node shape per label so node kinds are distinguishable
+func dotShape(label string) string {
+	switch label {
+	case "Paper":
+		return "box"
+	case "Author":
+		return "ellipse"
+	default:
+		return "diamond"
+	}
+}
@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleExportGraph() *exportGraph {
+	return &exportGraph{
+		Nodes: []exportNode{
+			{ID: "n0", Label: "Paper", Name: `Attention & "Transformers" <2017>`},
+			{ID: "n1", Label: "Paper", Name: "BERT"},
+			{ID: "n2", Label: "Concept", Name: "self-attention"},
+		},
+		Edges: []exportEdge{
+			{Source: "n1", Target: "n0", Type: "CITES"},
+			{Source: "n0", Target: "n2", Type: "USES_CONCEPT"},
+		},
+	}
+}
+
+func TestWriteGraphML_IsValidXML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeGraphML(&buf, sampleExportGraph()))
+
+	var doc struct {
+		Graph struct {
+			Nodes []struct {
+				ID string `xml:"id,attr"`
+			} `xml:"node"`
+			Edges []struct {
+				Source string `xml:"source,attr"`
+				Target string `xml:"target,attr"`
+			} `xml:"edge"`
+		} `xml:"graph"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Len(t, doc.Graph.Nodes, 3)
+	assert.Len(t, doc.Graph.Edges, 2)
+	assert.Equal(t, "n1", doc.Graph.Edges[0].Source)
+	assert.Contains(t, buf.String(), "Attention &amp; &#34;Transformers&#34; &lt;2017&gt;")
+}
+
+func TestWriteDOT_EscapesLabels(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeDOT(&buf, sampleExportGraph()))
+
+	out := buf.String()
+	assert.Contains(t, out, "digraph archivist {")
+	assert.Contains(t, out, `n0 [label="Attention & \"Transformers\" <2017>", shape=box];`)
+	assert.Contains(t, out, `n2 [label="self-attention", shape=diamond];`)
+	assert.Contains(t, out, `n1 -> n0 [label="CITES"];`)
+}
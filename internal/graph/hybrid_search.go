@@ -11,6 +11,7 @@ import (
 	"archivist/internal/rag"
 	"archivist/internal/vectorstore"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	qdrant "github.com/qdrant/go-client/qdrant"
 )
 
@@ -306,26 +307,116 @@ func (hse *HybridSearchEngine) findSeedPapers(ctx context.Context, query string)
 	return seeds
 }
 
-// traverseCitations traverses citation relationships in the graph
+// Traversal tuning: each citation hop halves the score, and depth is capped
+// so variable-length matches stay cheap on large graphs
+const (
+	citationHopDecay   = 0.5
+	maxTraversalDepth  = 3
+	traversalPathLimit = 500
+)
+
+// traverseCitations scores papers reachable through CITES relationships.
+// Each path contributes citationHopDecay^hops; scores accumulate across paths
+// and are capped at 1.0 so a single seed never outweighs itself.
 func (hse *HybridSearchEngine) traverseCitations(ctx context.Context, startPaper string, maxDepth int) map[string]float64 {
+	query := fmt.Sprintf(`
+		MATCH path = (start:Paper {title: $title})-[:CITES*1..%d]->(p:Paper)
+		WHERE p.title <> $title
+		RETURN p.title AS title, length(path) AS hops
+		LIMIT $limit
+	`, clampTraversalDepth(maxDepth))
+
 	scores := make(map[string]float64)
-	// This is a simplified version. In production, use Cypher queries
-	// to traverse the graph efficiently
+	err := hse.runTraversal(ctx, query, startPaper, func(record *neo4j.Record) {
+		title, _ := record.Get("title")
+		hops, _ := record.Get("hops")
+		paper, ok := title.(string)
+		depth, ok2 := hops.(int64)
+		if !ok || !ok2 {
+			return
+		}
+		accumulateScore(scores, paper, math.Pow(citationHopDecay, float64(depth)))
+	})
+	if err != nil {
+		log.Printf("Warning: Citation traversal failed for '%s': %v", startPaper, err)
+	}
 
-	// TODO: Implement actual graph traversal using Neo4j Cypher
-	// For now, return empty map
 	return scores
 }
 
-// traverseSimilar traverses similarity relationships
+// traverseSimilar scores papers reachable through SIMILAR_TO relationships.
+// A path's score is the product of the relationship scores along it.
 func (hse *HybridSearchEngine) traverseSimilar(ctx context.Context, startPaper string, maxDepth int) map[string]float64 {
+	query := fmt.Sprintf(`
+		MATCH path = (start:Paper {title: $title})-[:SIMILAR_TO*1..%d]-(p:Paper)
+		WHERE p.title <> $title
+		RETURN p.title AS title,
+			   reduce(s = 1.0, r IN relationships(path) | s * coalesce(r.score, 0.0)) AS score
+		LIMIT $limit
+	`, clampTraversalDepth(maxDepth))
+
 	scores := make(map[string]float64)
-	// This is a simplified version. In production, use Cypher queries
+	err := hse.runTraversal(ctx, query, startPaper, func(record *neo4j.Record) {
+		title, _ := record.Get("title")
+		score, _ := record.Get("score")
+		paper, ok := title.(string)
+		weight, ok2 := score.(float64)
+		if !ok || !ok2 {
+			return
+		}
+		accumulateScore(scores, paper, weight)
+	})
+	if err != nil {
+		log.Printf("Warning: Similarity traversal failed for '%s': %v", startPaper, err)
+	}
 
-	// TODO: Implement actual similarity traversal using Neo4j Cypher
 	return scores
 }
 
+// runTraversal runs a read-only traversal query from startPaper and hands each record to fn
+func (hse *HybridSearchEngine) runTraversal(ctx context.Context, query, startPaper string, fn func(*neo4j.Record)) error {
+	if hse.enhancedBuilder == nil || hse.enhancedBuilder.graphBuilder == nil {
+		return fmt.Errorf("graph builder not configured")
+	}
+	gb := hse.enhancedBuilder.graphBuilder
+
+	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: gb.config.Database,
+		AccessMode:   neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, query, map[string]interface{}{
+		"title": startPaper,
+		"limit": traversalPathLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	for result.Next(ctx) {
+		fn(result.Record())
+	}
+
+	return result.Err()
+}
+
+// clampTraversalDepth keeps the variable-length hop bound within [1, maxTraversalDepth]
+func clampTraversalDepth(depth int) int {
+	if depth < 1 {
+		return 1
+	}
+	if depth > maxTraversalDepth {
+		return maxTraversalDepth
+	}
+	return depth
+}
+
+// accumulateScore adds score to a paper's running total, capped at 1.0
+func accumulateScore(scores map[string]float64, paper string, score float64) {
+	scores[paper] = math.Min(scores[paper]+score, 1.0)
+}
+
 // buildQdrantFilter converts generic filters to Qdrant filter format
 func buildQdrantFilter(filters map[string]interface{}) *qdrant.Filter {
 	conditions := make([]*qdrant.Condition, 0)
@@ -0,0 +1,33 @@
+package graph
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// setPaperIdentifiersCypher sets the DOI and arXiv ID of the paper bound to p
+// from $doi and $arxiv_id, keeping the stored values when they're empty. Two
+// copies of a paper can claim the same DOI; since paper_doi_unique allows only
+// one, a DOI another paper already has is left off p and that paper's title
+// is bound to doi_owner instead of failing the whole write.
+const setPaperIdentifiersCypher = `
+		WITH p
+		OPTIONAL MATCH (owner:Paper {doi: $doi}) WHERE owner <> p
+		WITH p, head(collect(owner.title)) AS doi_owner
+		SET p.doi = CASE WHEN $doi = '' OR doi_owner IS NOT NULL THEN p.doi ELSE $doi END,
+			p.arxiv_id = CASE WHEN $arxiv_id = '' THEN p.arxiv_id ELSE $arxiv_id END
+`
+
+// isDOIConflict reports whether err is Neo4j rejecting a write because another
+// paper already has the DOI (paper_doi_unique). setPaperIdentifiersCypher
+// avoids this, except when two papers with the same DOI are written at once.
+func isDOIConflict(err error) bool {
+	var neoErr *neo4j.Neo4jError
+	if !errors.As(err, &neoErr) {
+		return false
+	}
+	return neoErr.Code == "Neo.ClientError.Schema.ConstraintValidationFailed" &&
+		strings.Contains(neoErr.Msg, "doi")
+}
@@ -0,0 +1,35 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDOIConflict(t *testing.T) {
+	conflict := &neo4j.Neo4jError{
+		Code: "Neo.ClientError.Schema.ConstraintValidationFailed",
+		Msg:  "Node(12) already exists with label `Paper` and property `doi` = '10.1000/xyz'",
+	}
+	assert.True(t, isDOIConflict(conflict))
+	assert.True(t, isDOIConflict(fmt.Errorf("failed to ingest paper: %w", conflict)))
+
+	titleConflict := &neo4j.Neo4jError{
+		Code: "Neo.ClientError.Schema.ConstraintValidationFailed",
+		Msg:  "Node(12) already exists with label `Paper` and property `title` = 'A'",
+	}
+	assert.False(t, isDOIConflict(titleConflict))
+	assert.False(t, isDOIConflict(errors.New("connection refused")))
+	assert.False(t, isDOIConflict(nil))
+}
+
+func TestPaperEnhancedStatementGuardsDOI(t *testing.T) {
+	stmt := paperEnhancedStatement(&PaperNodeEnhanced{Title: "A", DOI: "10.1000/xyz", ArxivID: "2101.00001"})
+
+	assert.Contains(t, stmt.query, setPaperIdentifiersCypher)
+	assert.Equal(t, "10.1000/xyz", stmt.params["doi"])
+	assert.Equal(t, "2101.00001", stmt.params["arxiv_id"])
+}
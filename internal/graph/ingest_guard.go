@@ -0,0 +1,89 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// PaperContentHash returns the content_hash stored on a paper node and whether
+// the paper is in the graph at all. Papers ingested before hashes were stored
+// exist with an empty hash; stub nodes created for cited papers don't count.
+func (gb *GraphBuilder) PaperContentHash(ctx context.Context, title string) (string, bool, error) {
+	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: gb.config.Database,
+		AccessMode:   neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (p:Paper {title: $title}) WHERE coalesce(p.stub, false) = false
+		RETURN coalesce(p.content_hash, '') as content_hash LIMIT 1
+	`
+	result, err := session.Run(ctx, query, map[string]interface{}{"title": title})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to look up paper: %w", err)
+	}
+
+	if result.Next(ctx) {
+		hash, _ := result.Record().Values[0].(string)
+		return hash, true, nil
+	}
+	return "", false, result.Err()
+}
+
+// NeedsIngestion reports whether a paper has to be (re)written to the graph:
+// it isn't there yet, force is set, or contentHash differs from the stored hash
+func (gb *GraphBuilder) NeedsIngestion(ctx context.Context, title, contentHash string, force bool) (bool, error) {
+	if force {
+		return true, nil
+	}
+
+	stored, exists, err := gb.PaperContentHash(ctx, title)
+	if err != nil {
+		return false, err
+	}
+	return needsIngestion(exists, stored, contentHash), nil
+}
+
+// needsIngestion decides whether a paper that may already be in the graph has
+// changed. Without a new hash to compare, an existing paper is left alone.
+func needsIngestion(exists bool, storedHash, contentHash string) bool {
+	if !exists {
+		return true
+	}
+	return contentHash != "" && contentHash != storedHash
+}
+
+// IngestPaperIfChanged runs BatchIngestPaper unless paper is already in the
+// graph with the same ContentHash and force is false. It reports whether the
+// paper was written.
+func (eb *EnhancedNeo4jBuilder) IngestPaperIfChanged(ctx context.Context, force bool, paper *PaperNodeEnhanced, authors []*AuthorNode, methods []*MethodNode, rels ...interface{}) (bool, error) {
+	if paper == nil {
+		return false, fmt.Errorf("paper is required")
+	}
+
+	needed, err := eb.NeedsIngestion(ctx, paper.Title, paper.ContentHash, force)
+	if err != nil {
+		return false, err
+	}
+	if !needed {
+		log.Printf("⏭️  Paper unchanged in graph, skipping ingestion: %s", paper.Title)
+		return false, nil
+	}
+
+	if err := eb.BatchIngestPaper(ctx, paper, authors, methods, rels...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// optionalString maps "" to nil so Cypher coalesce() keeps the stored value
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
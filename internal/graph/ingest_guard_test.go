@@ -0,0 +1,35 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNeedsIngestion(t *testing.T) {
+	tests := []struct {
+		name        string
+		exists      bool
+		storedHash  string
+		contentHash string
+		want        bool
+	}{
+		{name: "new paper", exists: false, contentHash: "abc", want: true},
+		{name: "new paper without hash", exists: false, want: true},
+		{name: "unchanged", exists: true, storedHash: "abc", contentHash: "abc", want: false},
+		{name: "changed", exists: true, storedHash: "abc", contentHash: "def", want: true},
+		{name: "stored before hashes were kept", exists: true, contentHash: "abc", want: true},
+		{name: "no hash to compare", exists: true, storedHash: "abc", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, needsIngestion(tt.exists, tt.storedHash, tt.contentHash))
+		})
+	}
+}
+
+func TestOptionalString(t *testing.T) {
+	assert.Nil(t, optionalString(""))
+	assert.Equal(t, "abc", optionalString("abc"))
+}
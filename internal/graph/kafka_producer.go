@@ -24,6 +24,15 @@ type PaperProcessedEvent struct {
 	PDFPath      string    `json:"pdf_path"`
 	ProcessedAt  time.Time `json:"processed_at"`
 	Priority     int       `json:"priority"`
+	ContentHash  string    `json:"content_hash,omitempty"` // Lets the graph service skip papers it already has
+	Force        bool      `json:"force,omitempty"`        // Re-ingest even if the paper is unchanged
+
+	// Parsed from the source PDF; the graph service prefers these over what it finds in the report
+	Authors  []string `json:"authors,omitempty"`
+	Year     int      `json:"year,omitempty"`
+	Abstract string   `json:"abstract,omitempty"`
+	DOI      string   `json:"doi,omitempty"`
+	ArxivID  string   `json:"arxiv_id,omitempty"`
 }
 
 // NewKafkaProducer creates a new Kafka producer
@@ -56,20 +65,24 @@ func NewKafkaProducer(brokers []string, topic string, enabled bool) *KafkaProduc
 	}
 }
 
-// PublishPaperProcessed publishes a paper.processed event to Kafka (non-blocking)
-func (kp *KafkaProducer) PublishPaperProcessed(ctx context.Context, paperTitle, latexContent, pdfPath string) error {
+// Enabled reports whether events are actually published
+func (kp *KafkaProducer) Enabled() bool {
+	return kp.enabled
+}
+
+// PublishPaperProcessed publishes a paper.processed event to Kafka (non-blocking).
+// The graph service skips papers already in the graph with the same ContentHash
+// unless Force is set. ProcessedAt defaults to now.
+func (kp *KafkaProducer) PublishPaperProcessed(ctx context.Context, event PaperProcessedEvent) error {
 	if !kp.enabled {
 		// Kafka disabled, skip publishing
 		return nil
 	}
 
-	event := PaperProcessedEvent{
-		PaperTitle:   paperTitle,
-		LatexContent: latexContent,
-		PDFPath:      pdfPath,
-		ProcessedAt:  time.Now(),
-		Priority:     0,
+	if event.ProcessedAt.IsZero() {
+		event.ProcessedAt = time.Now()
 	}
+	paperTitle := event.PaperTitle
 
 	// Marshal to JSON
 	messageBytes, err := json.Marshal(event)
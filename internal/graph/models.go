@@ -14,6 +14,9 @@ type PaperNode struct {
 	Year           int       `json:"year,omitempty"`
 	Authors        []string  `json:"authors,omitempty"`
 	Abstract       string    `json:"abstract,omitempty"`
+	ContentHash    string    `json:"content_hash,omitempty"` // Hash of the source PDF, to detect changes on re-ingestion
+	DOI            string    `json:"doi,omitempty"`
+	ArxivID        string    `json:"arxiv_id,omitempty"`
 }
 
 // ConceptNode represents a concept in the knowledge graph
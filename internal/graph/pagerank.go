@@ -0,0 +1,276 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// PageRank parameters shared by the GDS and fallback implementations
+const (
+	pageRankDamping       = 0.85
+	pageRankMaxIterations = 20
+	pageRankTolerance     = 1e-7
+	pageRankGraphName     = "archivist_pagerank"
+)
+
+// ComputePageRank ranks papers by influence over CITES relationships and stores
+// the score in each paper's pagerank property. It uses Neo4j Graph Data Science
+// when installed and otherwise falls back to computing PageRank client-side.
+func (gb *GraphBuilder) ComputePageRank(ctx context.Context) error {
+	gdsErr := gb.computePageRankGDS(ctx)
+	if gdsErr == nil {
+		log.Println("✓ PageRank computed with Graph Data Science")
+		return nil
+	}
+	log.Printf("⚠️  GDS PageRank unavailable (%v), using fallback implementation", gdsErr)
+
+	if err := gb.computePageRankFallback(ctx); err != nil {
+		return fmt.Errorf("failed to compute PageRank (GDS: %v): %w", gdsErr, err)
+	}
+
+	log.Println("✓ PageRank computed with fallback implementation")
+	return nil
+}
+
+// computePageRankGDS runs gds.pageRank.write over a temporary Paper/CITES projection
+func (gb *GraphBuilder) computePageRankGDS(ctx context.Context) (err error) {
+	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: gb.config.Database,
+	})
+	defer session.Close(ctx)
+
+	if err := runWrite(ctx, session, "RETURN gds.version() as version", nil); err != nil {
+		return fmt.Errorf("GDS plugin not installed: %w", err)
+	}
+
+	params := map[string]interface{}{"graph": pageRankGraphName}
+	dropQuery := "CALL gds.graph.drop($graph, false) YIELD graphName RETURN graphName"
+
+	// Drop any projection left over from an interrupted run
+	if err := runWrite(ctx, session, dropQuery, params); err != nil {
+		return fmt.Errorf("failed to drop stale projection: %w", err)
+	}
+
+	if err := runWrite(ctx, session, "CALL gds.graph.project($graph, 'Paper', 'CITES') YIELD graphName RETURN graphName", params); err != nil {
+		return fmt.Errorf("failed to project citation graph: %w", err)
+	}
+	defer func() {
+		if dropErr := runWrite(ctx, session, dropQuery, params); dropErr != nil && err == nil {
+			err = fmt.Errorf("failed to drop projection: %w", dropErr)
+		}
+	}()
+
+	query := `
+		CALL gds.pageRank.write($graph, {
+			writeProperty: 'pagerank',
+			dampingFactor: $damping,
+			maxIterations: $iterations,
+			tolerance: $tolerance
+		})
+		YIELD nodePropertiesWritten
+		RETURN nodePropertiesWritten
+	`
+	err = runWrite(ctx, session, query, map[string]interface{}{
+		"graph":      pageRankGraphName,
+		"damping":    pageRankDamping,
+		"iterations": pageRankMaxIterations,
+		"tolerance":  pageRankTolerance,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to run GDS PageRank: %w", err)
+	}
+
+	return nil
+}
+
+// runWrite runs query in a write transaction and consumes its result, so
+// errors raised while the query executes are returned rather than dropped
+func runWrite(ctx context.Context, session neo4j.SessionWithContext, query string, params map[string]interface{}) error {
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (any, error) {
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(ctx)
+	})
+	return err
+}
+
+// computePageRankFallback reads the citation graph, computes PageRank locally, and writes scores back
+func (gb *GraphBuilder) computePageRankFallback(ctx context.Context) error {
+	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: gb.config.Database,
+	})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, "MATCH (p:Paper) RETURN p.title as title", nil)
+	if err != nil {
+		return fmt.Errorf("failed to read papers: %w", err)
+	}
+	var titles []string
+	for result.Next(ctx) {
+		if title, ok := result.Record().Values[0].(string); ok {
+			titles = append(titles, title)
+		}
+	}
+	if err := result.Err(); err != nil {
+		return fmt.Errorf("failed to read papers: %w", err)
+	}
+
+	result, err = session.Run(ctx, "MATCH (a:Paper)-[:CITES]->(b:Paper) RETURN a.title as source, b.title as target", nil)
+	if err != nil {
+		return fmt.Errorf("failed to read citations: %w", err)
+	}
+	citations := make(map[string][]string)
+	for result.Next(ctx) {
+		record := result.Record()
+		source, ok1 := record.Values[0].(string)
+		target, ok2 := record.Values[1].(string)
+		if ok1 && ok2 {
+			citations[source] = append(citations[source], target)
+		}
+	}
+	if err := result.Err(); err != nil {
+		return fmt.Errorf("failed to read citations: %w", err)
+	}
+
+	ranks := computePageRank(titles, citations, pageRankDamping, pageRankMaxIterations, pageRankTolerance)
+
+	rows := make([]map[string]interface{}, 0, len(ranks))
+	for title, score := range ranks {
+		rows = append(rows, map[string]interface{}{"title": title, "score": score})
+	}
+
+	query := `
+		UNWIND $rows as row
+		MATCH (p:Paper {title: row.title})
+		SET p.pagerank = row.score
+	`
+	if err := runWrite(ctx, session, query, map[string]interface{}{"rows": rows}); err != nil {
+		return fmt.Errorf("failed to write PageRank scores: %w", err)
+	}
+
+	return nil
+}
+
+// computePageRank runs power-iteration PageRank. Papers with no outgoing
+// citations spread their rank evenly across all papers.
+func computePageRank(titles []string, citations map[string][]string, damping float64, maxIterations int, tolerance float64) map[string]float64 {
+	n := len(titles)
+	ranks := make(map[string]float64, n)
+	if n == 0 {
+		return ranks
+	}
+
+	index := make(map[string]bool, n)
+	for _, title := range titles {
+		index[title] = true
+		ranks[title] = 1.0 / float64(n)
+	}
+
+	// Ignore citations to papers outside the corpus
+	outLinks := make(map[string][]string, len(citations))
+	for source, targets := range citations {
+		if !index[source] {
+			continue
+		}
+		for _, target := range targets {
+			if index[target] {
+				outLinks[source] = append(outLinks[source], target)
+			}
+		}
+	}
+
+	for i := 0; i < maxIterations; i++ {
+		var danglingRank float64
+		for _, title := range titles {
+			if len(outLinks[title]) == 0 {
+				danglingRank += ranks[title]
+			}
+		}
+
+		base := (1-damping)/float64(n) + damping*danglingRank/float64(n)
+		next := make(map[string]float64, n)
+		for _, title := range titles {
+			next[title] = base
+		}
+		for source, targets := range outLinks {
+			share := damping * ranks[source] / float64(len(targets))
+			for _, target := range targets {
+				next[target] += share
+			}
+		}
+
+		var delta float64
+		for _, title := range titles {
+			delta += math.Abs(next[title] - ranks[title])
+		}
+		ranks = next
+		if delta < tolerance {
+			break
+		}
+	}
+
+	return ranks
+}
+
+// GetTopPapersByPageRank returns the highest-ranked papers. ComputePageRank must have run first.
+func (gb *GraphBuilder) GetTopPapersByPageRank(ctx context.Context, limit int) ([]*PaperNodeEnhanced, error) {
+	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: gb.config.Database,
+		AccessMode:   neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	query := `
+		MATCH (p:Paper)
+		WHERE p.pagerank IS NOT NULL
+		RETURN p.title as title,
+			   p.pdf_path as pdf_path,
+			   p.year as year,
+			   p.pagerank as pagerank
+		ORDER BY p.pagerank DESC, p.title
+		LIMIT $limit
+	`
+
+	result, err := session.Run(ctx, query, map[string]interface{}{"limit": limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top papers: %w", err)
+	}
+
+	var papers []*PaperNodeEnhanced
+	for result.Next(ctx) {
+		record := result.Record()
+		paper := &PaperNodeEnhanced{}
+
+		if val, ok := record.Get("title"); ok && val != nil {
+			paper.Title = val.(string)
+		}
+		if val, ok := record.Get("pdf_path"); ok && val != nil {
+			paper.PDFPath = val.(string)
+		}
+		if val, ok := record.Get("year"); ok && val != nil {
+			if year, ok := val.(int64); ok {
+				paper.Year = int(year)
+			}
+		}
+		if val, ok := record.Get("pagerank"); ok && val != nil {
+			paper.PageRank = val.(float64)
+		}
+
+		papers = append(papers, paper)
+	}
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read top papers: %w", err)
+	}
+
+	if len(papers) == 0 {
+		return nil, fmt.Errorf("no PageRank scores found (run ComputePageRank first)")
+	}
+
+	return papers, nil
+}
@@ -0,0 +1,47 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputePageRank_MostCitedRanksHighest(t *testing.T) {
+	titles := []string{"A", "B", "C", "D"}
+	citations := map[string][]string{
+		"B": {"A"},
+		"C": {"A", "B"},
+		"D": {"A"},
+	}
+
+	ranks := computePageRank(titles, citations, 0.85, 100, 1e-9)
+
+	assert.Len(t, ranks, 4)
+	assert.Greater(t, ranks["A"], ranks["B"])
+	assert.Greater(t, ranks["B"], ranks["C"])
+	assert.InDelta(t, ranks["C"], ranks["D"], 1e-9)
+
+	var total float64
+	for _, r := range ranks {
+		total += r
+	}
+	assert.InDelta(t, 1.0, total, 1e-6, "Ranks should sum to 1")
+}
+
+func TestComputePageRank_IgnoresCitationsOutsideCorpus(t *testing.T) {
+	titles := []string{"A", "B"}
+	citations := map[string][]string{
+		"A":        {"External"},
+		"External": {"B"},
+	}
+
+	ranks := computePageRank(titles, citations, 0.85, 100, 1e-9)
+
+	assert.Len(t, ranks, 2)
+	assert.InDelta(t, 0.5, ranks["A"], 1e-9)
+	assert.InDelta(t, 0.5, ranks["B"], 1e-9)
+}
+
+func TestComputePageRank_Empty(t *testing.T) {
+	assert.Empty(t, computePageRank(nil, nil, 0.85, 20, 1e-7))
+}
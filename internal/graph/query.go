@@ -0,0 +1,168 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// ErrWriteQuery is returned by RunReadQuery for queries that could modify the graph
+var ErrWriteQuery = errors.New("only read queries are allowed")
+
+// writeClauses are the Cypher clauses that modify the graph or schema
+var writeClauses = map[string]bool{
+	"CREATE": true, "MERGE": true, "DELETE": true, "DETACH": true, "SET": true,
+	"REMOVE": true, "DROP": true, "FOREACH": true, "LOAD": true,
+}
+
+// RunReadQuery runs an ad-hoc read-only Cypher query and returns one map per
+// row, keyed by the RETURN column names. Nodes and relationships are returned
+// as their property maps and paths as the list of their nodes' properties.
+// Queries using a write clause (CREATE, MERGE, DELETE, SET, ...) are rejected
+// with ErrWriteQuery, and the query runs in a read transaction so Neo4j
+// refuses any write that slips through, e.g. from a procedure.
+func (gb *GraphBuilder) RunReadQuery(ctx context.Context, cypher string, params map[string]interface{}) ([]map[string]interface{}, error) {
+	if strings.TrimSpace(cypher) == "" {
+		return nil, fmt.Errorf("query is empty")
+	}
+	if clause := findWriteClause(cypher); clause != "" {
+		return nil, fmt.Errorf("%w: query uses %s", ErrWriteQuery, clause)
+	}
+
+	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: gb.config.Database,
+		AccessMode:   neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	rows, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		result, err := tx.Run(ctx, cypher, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var rows []map[string]interface{}
+		for result.Next(ctx) {
+			record := result.Record()
+			row := make(map[string]interface{}, len(record.Keys))
+			for i, key := range record.Keys {
+				row[key] = plainValue(record.Values[i])
+			}
+			rows = append(rows, row)
+		}
+		return rows, result.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+
+	return rows.([]map[string]interface{}), nil
+}
+
+// findWriteClause returns the first write clause in cypher, or "" if there is
+// none. String literals, backquoted names and comments are skipped, as are
+// property names, labels, parameters and map keys, so e.g. p.set or
+// {create: 1} are not mistaken for clauses.
+func findWriteClause(cypher string) string {
+	code := []rune(stripLiterals(cypher))
+
+	for i := 0; i < len(code); {
+		if !isIdentRune(code[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(code) && isIdentRune(code[i]) {
+			i++
+		}
+
+		if start > 0 && strings.ContainsRune(".:$", code[start-1]) {
+			continue
+		}
+		next := i
+		for next < len(code) && unicode.IsSpace(code[next]) {
+			next++
+		}
+		if next < len(code) && code[next] == ':' {
+			continue
+		}
+
+		if word := strings.ToUpper(string(code[start:i])); writeClauses[word] {
+			return word
+		}
+	}
+	return ""
+}
+
+// stripLiterals blanks out string literals, backquoted names and comments in
+// cypher, keeping the surrounding code intact
+func stripLiterals(cypher string) string {
+	var sb strings.Builder
+	runes := []rune(cypher)
+
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '\'' || r == '"' || r == '`':
+			// Skip to the closing quote; backslash escapes the next character
+			for i++; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && r != '`' {
+					i++
+				}
+			}
+			sb.WriteString(" ")
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			sb.WriteString("\n")
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			for i += 2; i+1 < len(runes) && !(runes[i] == '*' && runes[i+1] == '/'); i++ {
+			}
+			i++
+			sb.WriteString(" ")
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// isIdentRune reports whether r can be part of a Cypher identifier
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// plainValue converts driver values into maps, slices and scalars that print
+// and encode as JSON cleanly
+func plainValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case neo4j.Node:
+		return plainValue(v.Props)
+	case neo4j.Relationship:
+		return plainValue(v.Props)
+	case neo4j.Path:
+		nodes := make([]interface{}, len(v.Nodes))
+		for i, node := range v.Nodes {
+			nodes[i] = plainValue(node)
+		}
+		return nodes
+	case map[string]interface{}:
+		plain := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			plain[key] = plainValue(item)
+		}
+		return plain
+	case []interface{}:
+		plain := make([]interface{}, len(v))
+		for i, item := range v {
+			plain[i] = plainValue(item)
+		}
+		return plain
+	default:
+		return v
+	}
+}
@@ -0,0 +1,46 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindWriteClause(t *testing.T) {
+	tests := []struct {
+		cypher string
+		want   string
+	}{
+		{"MATCH (p:Paper) RETURN p.title LIMIT 10", ""},
+		{"MATCH (p:Paper) WHERE p.title CONTAINS 'Create a Set' RETURN p", ""},
+		{"MATCH (p:Paper) RETURN p.set, p.`delete me` AS merge_count", ""},
+		{"MATCH (p:Paper {create: $set}) RETURN {merge: p.title}", ""},
+		{"MATCH (n:Set) RETURN n // then DELETE n", ""},
+		{"MATCH (n) /* MERGE */ RETURN count(n)", ""},
+		{`MATCH (p) WHERE p.title = "it's \"SET\"" RETURN p`, ""},
+		{"CREATE (p:Paper {title: 'x'})", "CREATE"},
+		{"match (p:Paper) detach delete p", "DETACH"},
+		{"MATCH (p:Paper) SET p.read = true", "SET"},
+		{"MATCH (p) REMOVE p.stub", "REMOVE"},
+		{"MATCH (a), (b) MERGE (a)-[:CITES]->(b)", "MERGE"},
+		{"CALL { MATCH (p) DELETE p } RETURN 1", "DELETE"},
+		{"LOAD CSV FROM 'file:///x.csv' AS row RETURN row", "LOAD"},
+		{"DROP CONSTRAINT paper_doi_unique", "DROP"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, findWriteClause(tt.cypher), tt.cypher)
+	}
+}
+
+func TestPlainValue(t *testing.T) {
+	node := neo4j.Node{Labels: []string{"Paper"}, Props: map[string]interface{}{"title": "A"}}
+	rel := neo4j.Relationship{Type: "CITES", Props: map[string]interface{}{"importance": "high"}}
+
+	assert.Equal(t, map[string]interface{}{"title": "A"}, plainValue(node))
+	assert.Equal(t, map[string]interface{}{"importance": "high"}, plainValue(rel))
+	assert.Equal(t, []interface{}{map[string]interface{}{"title": "A"}, int64(3)}, plainValue([]interface{}{node, int64(3)}))
+	assert.Equal(t, []interface{}{map[string]interface{}{"title": "A"}}, plainValue(neo4j.Path{Nodes: []neo4j.Node{node}}))
+	assert.Equal(t, "x", plainValue("x"))
+}
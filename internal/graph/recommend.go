@@ -0,0 +1,259 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Recommendation scoring: each signal is normalized to [0, 1] and weighted
+const (
+	recommendOverlapWeight    = 0.4 // Shared concepts and methods
+	recommendCitationWeight   = 0.3 // Citation distance to a seed paper
+	recommendSimilarityWeight = 0.3 // Best SIMILAR_TO score to a seed paper
+
+	recommendOverlapCap   = 5  // Shared concepts/methods beyond this don't add to the score
+	recommendReasonItems  = 3  // Concept/method names listed in a reason
+	defaultRecommendLimit = 10 // Used when RecommendPapers gets limit <= 0
+)
+
+// recommendationCandidate collects what links an unread paper to the seed papers
+type recommendationCandidate struct {
+	title          string
+	sharedConcepts []string
+	sharedMethods  []string
+	citationHops   int    // 1 = cites or is cited by a seed, 2 = through one other paper, 0 = unlinked
+	citationLink   string // How it is linked, e.g. `cites "BERT"`
+	similarity     float64
+	similarTo      string // Seed with the best similarity score
+}
+
+// RecommendPapers suggests papers to read next given papers already read.
+// Papers outside seedTitles are scored by shared concepts and methods,
+// citation proximity and SIMILAR_TO score to the seeds; the best limit
+// papers are returned, highest score first, each with a reason.
+func (eb *EnhancedNeo4jBuilder) RecommendPapers(ctx context.Context, seedTitles []string, limit int) ([]PaperRecommendation, error) {
+	if len(seedTitles) == 0 {
+		return nil, fmt.Errorf("at least one seed paper is required")
+	}
+	if limit <= 0 {
+		limit = defaultRecommendLimit
+	}
+
+	session := eb.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: eb.config.Database,
+		AccessMode:   neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	candidates := make(map[string]*recommendationCandidate)
+	candidate := func(title string) *recommendationCandidate {
+		c, ok := candidates[title]
+		if !ok {
+			c = &recommendationCandidate{title: title}
+			candidates[title] = c
+		}
+		return c
+	}
+	params := map[string]interface{}{"seeds": seedTitles}
+
+	run := func(what, query string, fn func(*neo4j.Record)) error {
+		result, err := session.Run(ctx, query, params)
+		if err != nil {
+			return fmt.Errorf("failed to query %s: %w", what, err)
+		}
+		for result.Next(ctx) {
+			fn(result.Record())
+		}
+		if err := result.Err(); err != nil {
+			return fmt.Errorf("failed to read %s: %w", what, err)
+		}
+		return nil
+	}
+
+	err := run("shared concepts", `
+		MATCH (s:Paper)-[:USES_CONCEPT|MENTIONS]->(c:Concept)<-[:USES_CONCEPT|MENTIONS]-(p:Paper)
+		WHERE s.title IN $seeds AND NOT p.title IN $seeds
+		RETURN p.title as title, collect(DISTINCT c.name) as names
+	`, func(record *neo4j.Record) {
+		if title, ok := record.Values[0].(string); ok {
+			candidate(title).sharedConcepts = stringList(record.Values[1])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = run("shared methods", `
+		MATCH (s:Paper)-[:USES_METHOD]->(m:Method)<-[:USES_METHOD]-(p:Paper)
+		WHERE s.title IN $seeds AND NOT p.title IN $seeds
+		RETURN p.title as title, collect(DISTINCT m.name) as names
+	`, func(record *neo4j.Record) {
+		if title, ok := record.Values[0].(string); ok {
+			candidate(title).sharedMethods = stringList(record.Values[1])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = run("citations", `
+		MATCH (s:Paper)-[r:CITES]-(p:Paper)
+		WHERE s.title IN $seeds AND NOT p.title IN $seeds
+		RETURN p.title as title, s.title as seed, startNode(r) = p as cites_seed
+		ORDER BY seed
+	`, func(record *neo4j.Record) {
+		title, ok1 := record.Values[0].(string)
+		seed, ok2 := record.Values[1].(string)
+		if !ok1 || !ok2 {
+			return
+		}
+		c := candidate(title)
+		if c.citationHops == 1 {
+			return
+		}
+		c.citationHops = 1
+		if citesSeed, _ := record.Values[2].(bool); citesSeed {
+			c.citationLink = fmt.Sprintf("cites %q", seed)
+		} else {
+			c.citationLink = fmt.Sprintf("cited by %q", seed)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = run("citation neighbourhood", `
+		MATCH (s:Paper)-[:CITES]-(:Paper)-[:CITES]-(p:Paper)
+		WHERE s.title IN $seeds AND NOT p.title IN $seeds
+		RETURN DISTINCT p.title as title, s.title as seed
+		ORDER BY seed
+	`, func(record *neo4j.Record) {
+		title, ok1 := record.Values[0].(string)
+		seed, ok2 := record.Values[1].(string)
+		if !ok1 || !ok2 {
+			return
+		}
+		if c := candidate(title); c.citationHops == 0 {
+			c.citationHops = 2
+			c.citationLink = fmt.Sprintf("2 citations away from %q", seed)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = run("similar papers", `
+		MATCH (s:Paper)-[r:SIMILAR_TO]-(p:Paper)
+		WHERE s.title IN $seeds AND NOT p.title IN $seeds
+		RETURN p.title as title, s.title as seed, max(coalesce(r.score, 0.0)) as score
+	`, func(record *neo4j.Record) {
+		title, ok1 := record.Values[0].(string)
+		seed, ok2 := record.Values[1].(string)
+		score, ok3 := record.Values[2].(float64)
+		if !ok1 || !ok2 || !ok3 {
+			return
+		}
+		if c := candidate(title); score > c.similarity {
+			c.similarity = score
+			c.similarTo = seed
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*recommendationCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		list = append(list, c)
+	}
+	return rankRecommendations(list, limit), nil
+}
+
+// rankRecommendations scores candidates, keeping the best limit ones with a
+// positive score, highest first and then by title
+func rankRecommendations(candidates []*recommendationCandidate, limit int) []PaperRecommendation {
+	recommendations := []PaperRecommendation{}
+	for _, c := range candidates {
+		if score := c.score(); score > 0 {
+			recommendations = append(recommendations, PaperRecommendation{
+				Title:  c.title,
+				Score:  score,
+				Reason: c.reason(),
+			})
+		}
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].Title < recommendations[j].Title
+	})
+
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	return recommendations
+}
+
+// score combines the candidate's signals into a value in [0, 1]
+func (c *recommendationCandidate) score() float64 {
+	overlap := math.Min(float64(len(c.sharedConcepts)+len(c.sharedMethods)), recommendOverlapCap) / recommendOverlapCap
+
+	var citation float64
+	switch c.citationHops {
+	case 1:
+		citation = 1
+	case 2:
+		citation = 0.5
+	}
+
+	similarity := math.Max(0, math.Min(c.similarity, 1))
+
+	return recommendOverlapWeight*overlap + recommendCitationWeight*citation + recommendSimilarityWeight*similarity
+}
+
+// reason explains in one line why the candidate was recommended
+func (c *recommendationCandidate) reason() string {
+	var parts []string
+	if len(c.sharedConcepts) > 0 {
+		parts = append(parts, "shares concepts "+listSome(c.sharedConcepts))
+	}
+	if len(c.sharedMethods) > 0 {
+		parts = append(parts, "uses methods "+listSome(c.sharedMethods))
+	}
+	if c.citationLink != "" {
+		parts = append(parts, c.citationLink)
+	}
+	if c.similarity > 0 {
+		parts = append(parts, fmt.Sprintf("%.0f%% similar to %q", c.similarity*100, c.similarTo))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// listSome lists up to recommendReasonItems names in alphabetical order
+func listSome(names []string) string {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	if len(sorted) <= recommendReasonItems {
+		return strings.Join(sorted, ", ")
+	}
+	return fmt.Sprintf("%s (+%d more)", strings.Join(sorted[:recommendReasonItems], ", "), len(sorted)-recommendReasonItems)
+}
+
+// stringList converts a list value from the driver to strings, skipping non-strings
+func stringList(value interface{}) []string {
+	items, _ := value.([]interface{})
+	var strs []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
@@ -0,0 +1,59 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankRecommendations(t *testing.T) {
+	candidates := []*recommendationCandidate{
+		{title: "Unrelated"},
+		{title: "Only Similar", similarity: 0.5, similarTo: "Seed"},
+		{
+			title:          "Close Neighbour",
+			sharedConcepts: []string{"attention", "transformer"},
+			sharedMethods:  []string{"fine-tuning"},
+			citationHops:   1,
+			citationLink:   `cites "Seed"`,
+			similarity:     0.9,
+			similarTo:      "Seed",
+		},
+		{title: "Two Hops", citationHops: 2, citationLink: `2 citations away from "Seed"`},
+	}
+
+	recommendations := rankRecommendations(candidates, 10)
+
+	require.Len(t, recommendations, 3, "papers with no signal are not recommended")
+	assert.Equal(t, "Close Neighbour", recommendations[0].Title)
+	assert.InDelta(t, 0.4*3.0/5.0+0.3+0.3*0.9, recommendations[0].Score, 1e-9)
+	assert.Equal(t, `shares concepts attention, transformer; uses methods fine-tuning; cites "Seed"; 90% similar to "Seed"`,
+		recommendations[0].Reason)
+	// Two hops and a 0.5 similarity score the same, so title order decides
+	assert.Equal(t, "Only Similar", recommendations[1].Title)
+	assert.Equal(t, "Two Hops", recommendations[2].Title)
+}
+
+func TestRankRecommendationsLimitAndOverlapCap(t *testing.T) {
+	many := []string{"a", "b", "c", "d", "e", "f", "g"}
+	candidates := []*recommendationCandidate{
+		{title: "B", sharedConcepts: many},
+		{title: "A", sharedConcepts: many[:5]},
+		{title: "C", sharedConcepts: many[:1]},
+	}
+
+	recommendations := rankRecommendations(candidates, 2)
+
+	require.Len(t, recommendations, 2)
+	// Overlap is capped, so A and B tie and are ordered by title
+	assert.Equal(t, "A", recommendations[0].Title)
+	assert.Equal(t, "B", recommendations[1].Title)
+	assert.Equal(t, recommendations[0].Score, recommendations[1].Score)
+	assert.Equal(t, "shares concepts a, b, c (+4 more)", recommendations[1].Reason)
+}
+
+func TestStringList(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, stringList([]interface{}{"a", nil, int64(3), "b"}))
+	assert.Nil(t, stringList(nil))
+}
@@ -0,0 +1,36 @@
+package graph
+
+import "context"
+
+// sessionLimiter caps how many Neo4j sessions are open at once. A nil
+// limiter doesn't limit anything.
+type sessionLimiter chan struct{}
+
+// newSessionLimiter returns a limiter allowing max concurrent sessions, or nil
+// when max <= 0
+func newSessionLimiter(max int) sessionLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return make(sessionLimiter, max)
+}
+
+// acquire blocks until a session slot is free or ctx is done
+func (l sessionLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a slot taken by a successful acquire
+func (l sessionLimiter) release() {
+	if l != nil {
+		<-l
+	}
+}
@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionLimiterBlocksAtMax(t *testing.T) {
+	limiter := newSessionLimiter(2)
+	ctx := context.Background()
+
+	require.NoError(t, limiter.acquire(ctx))
+	require.NoError(t, limiter.acquire(ctx))
+
+	acquired := make(chan struct{})
+	go func() {
+		limiter.acquire(ctx)
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("third session acquired while two were open")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	limiter.release()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("session not acquired after a slot was released")
+	}
+}
+
+func TestSessionLimiterRespectsContext(t *testing.T) {
+	limiter := newSessionLimiter(1)
+	require.NoError(t, limiter.acquire(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.acquire(ctx), context.DeadlineExceeded)
+}
+
+func TestSessionLimiterUnlimited(t *testing.T) {
+	limiter := newSessionLimiter(0)
+	assert.Nil(t, limiter)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.acquire(context.Background()))
+	}
+	limiter.release()
+}
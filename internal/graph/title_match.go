@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// titleMatchThreshold is the similarity a cited title needs to an existing
+// paper's title to be linked to it instead of getting a stub node
+const titleMatchThreshold = 0.9
+
+// normalizeTitle returns the key used to recognise the same title written
+// differently: lowercase, punctuation dropped, whitespace collapsed.
+// "Attention Is All You Need." and "attention is all you need" are equal.
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '\'' || r == '’':
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// titleSimilarity scores two normalized titles in [0, 1] by edit distance
+// relative to the longer one
+func titleSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+// bestTitleMatch picks the candidate closest to title. A candidate with the
+// same normalized title wins outright; otherwise the most similar one at or
+// above threshold is returned. ok is false if nothing is close enough.
+func bestTitleMatch(title string, candidates []string, threshold float64) (match string, score float64, ok bool) {
+	key := normalizeTitle(title)
+	keyLen := len([]rune(key))
+	if keyLen == 0 {
+		return "", 0, false
+	}
+
+	for _, candidate := range candidates {
+		candidateKey := normalizeTitle(candidate)
+		if candidateKey == key {
+			return candidate, 1, true
+		}
+
+		// Titles whose lengths differ this much can't reach the threshold
+		candidateLen := len([]rune(candidateKey))
+		longest, shortest := max(keyLen, candidateLen), min(keyLen, candidateLen)
+		if float64(longest-shortest)/float64(longest) > 1-threshold {
+			continue
+		}
+
+		if s := titleSimilarity(key, candidateKey); s >= threshold && s > score {
+			match, score, ok = candidate, s, true
+		}
+	}
+
+	return match, score, ok
+}
+
+// resolvePaperTitle maps title to the title of the Paper node it refers to.
+// An exact match is used as is; otherwise the closest title by bestTitleMatch,
+// with its similarity score. ok is false if no paper matches.
+func (gb *GraphBuilder) resolvePaperTitle(ctx context.Context, title string) (resolved string, score float64, ok bool, err error) {
+	session := gb.driver.NewSession(ctx, neo4j.SessionConfig{
+		DatabaseName: gb.config.Database,
+		AccessMode:   neo4j.AccessModeRead,
+	})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx, "MATCH (p:Paper {title: $title}) RETURN p.title LIMIT 1", map[string]interface{}{"title": title})
+	if err != nil {
+		return "", 0, false, fmt.Errorf("failed to look up paper: %w", err)
+	}
+	if result.Next(ctx) {
+		return title, 1, true, nil
+	}
+	if err := result.Err(); err != nil {
+		return "", 0, false, fmt.Errorf("failed to look up paper: %w", err)
+	}
+
+	result, err = session.Run(ctx, "MATCH (p:Paper) RETURN p.title", nil)
+	if err != nil {
+		return "", 0, false, fmt.Errorf("failed to list paper titles: %w", err)
+	}
+	var titles []string
+	for result.Next(ctx) {
+		if t, isString := result.Record().Values[0].(string); isString {
+			titles = append(titles, t)
+		}
+	}
+	if err := result.Err(); err != nil {
+		return "", 0, false, fmt.Errorf("failed to list paper titles: %w", err)
+	}
+
+	resolved, score, ok = bestTitleMatch(title, titles, titleMatchThreshold)
+	return resolved, score, ok, nil
+}
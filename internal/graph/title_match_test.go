@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTitleCollapsesVariants(t *testing.T) {
+	for _, title := range []string{
+		"Attention Is All You Need",
+		"Attention is all you need.",
+		"  ATTENTION IS ALL YOU NEED  ",
+		"Attention Is All You Need?!",
+	} {
+		assert.Equal(t, "attention is all you need", normalizeTitle(title), title)
+	}
+
+	assert.Equal(t, "self attention with relative position", normalizeTitle("Self-Attention with Relative Position"))
+	assert.Equal(t, "whats new", normalizeTitle("What's New"))
+	assert.Equal(t, "", normalizeTitle(" . "))
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, titleSimilarity("bert", "bert"))
+	assert.Equal(t, 1.0, titleSimilarity("", ""))
+	assert.InDelta(t, 0.75, titleSimilarity("bert", "bart"), 1e-9)
+	assert.Equal(t, 0.0, titleSimilarity("abc", ""))
+}
+
+func TestBestTitleMatch(t *testing.T) {
+	papers := []string{
+		"Attention Is All You Need",
+		"Deep Residual Learning for Image Recognition",
+		"BERT: Pre-training of Deep Bidirectional Transformers for Language Understanding",
+	}
+
+	tests := []struct {
+		name      string
+		title     string
+		want      string
+		wantExact bool
+		wantOK    bool
+	}{
+		{
+			name:      "case and trailing period",
+			title:     "attention is all you need.",
+			want:      "Attention Is All You Need",
+			wantExact: true,
+			wantOK:    true,
+		},
+		{
+			name:   "small typo",
+			title:  "Deep Residual Learning for Image Recogniton",
+			want:   "Deep Residual Learning for Image Recognition",
+			wantOK: true,
+		},
+		{
+			name:   "punctuation differences",
+			title:  "BERT - Pre-training of Deep Bidirectional Transformer for Language Understanding",
+			want:   "BERT: Pre-training of Deep Bidirectional Transformers for Language Understanding",
+			wantOK: true,
+		},
+		{
+			name:  "different paper",
+			title: "Attention Is Not All You Need",
+		},
+		{
+			name:  "unrelated title",
+			title: "Generative Adversarial Networks",
+		},
+		{
+			name:  "empty title",
+			title: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, score, ok := bestTitleMatch(tt.title, papers, titleMatchThreshold)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, match)
+			if tt.wantExact {
+				assert.Equal(t, 1.0, score)
+			} else if ok {
+				assert.GreaterOrEqual(t, score, titleMatchThreshold)
+				assert.Less(t, score, 1.0)
+			}
+		})
+	}
+}
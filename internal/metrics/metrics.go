@@ -0,0 +1,191 @@
+// Package metrics collects batch processing metrics and serves them in the
+// Prometheus text exposition format
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Paper statuses used as the status label of archivist_papers_processed_total
+const (
+	StatusCompleted   = "completed"
+	StatusFailed      = "failed"
+	StatusInterrupted = "interrupted"
+)
+
+// DurationBuckets are the upper bounds, in seconds, of the
+// archivist_paper_processing_seconds histogram
+var DurationBuckets = []float64{10, 30, 60, 120, 300, 600, 1200}
+
+// Recorder collects processing metrics for one batch. A nil *Recorder records
+// nothing, so callers don't need to check whether metrics are enabled.
+type Recorder struct {
+	mu sync.Mutex
+
+	papers        map[string]int64 // Finished papers by status
+	bucketCounts  []int64          // Papers per DurationBuckets entry, not cumulative
+	durationSum   float64          // Seconds
+	durationCount int64
+	promptTokens  int64
+	outputTokens  int64
+	cacheHits     int64
+	cacheMisses   int64
+	activeWorkers int64
+}
+
+// NewRecorder creates an empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		// Known statuses start at zero so rate() works from the first scrape
+		papers:       map[string]int64{StatusCompleted: 0, StatusFailed: 0, StatusInterrupted: 0},
+		bucketCounts: make([]int64, len(DurationBuckets)),
+	}
+}
+
+// PaperProcessed counts a finished paper and how long it took
+func (r *Recorder) PaperProcessed(status string, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.papers[status]++
+
+	seconds := duration.Seconds()
+	r.durationSum += seconds
+	r.durationCount++
+	for i, bound := range DurationBuckets {
+		if seconds <= bound {
+			r.bucketCounts[i]++
+			break
+		}
+	}
+}
+
+// AddTokens counts Gemini tokens spent on a paper
+func (r *Recorder) AddTokens(prompt, output int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.promptTokens += int64(prompt)
+	r.outputTokens += int64(output)
+}
+
+// CacheHit counts an analysis served from the cache
+func (r *Recorder) CacheHit() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cacheHits++
+}
+
+// CacheMiss counts an analysis that wasn't cached and went to the model
+func (r *Recorder) CacheMiss() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cacheMisses++
+}
+
+// WorkerBusy marks a worker as having started a paper
+func (r *Recorder) WorkerBusy() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.activeWorkers++
+}
+
+// WorkerIdle marks a worker as done with its paper
+func (r *Recorder) WorkerIdle() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.activeWorkers--
+}
+
+// WriteText writes the current metrics in the Prometheus text format
+func (r *Recorder) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ew := &errWriter{w: w}
+
+	ew.header("archivist_papers_processed_total", "counter", "Papers finished, by status.")
+	statuses := make([]string, 0, len(r.papers))
+	for status := range r.papers {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		ew.printf("archivist_papers_processed_total{status=%q} %d\n", status, r.papers[status])
+	}
+
+	ew.header("archivist_paper_processing_seconds", "histogram", "Time spent processing each paper.")
+	var cumulative int64
+	for i, bound := range DurationBuckets {
+		cumulative += r.bucketCounts[i]
+		ew.printf("archivist_paper_processing_seconds_bucket{le=%q} %d\n", formatFloat(bound), cumulative)
+	}
+	ew.printf("archivist_paper_processing_seconds_bucket{le=\"+Inf\"} %d\n", r.durationCount)
+	ew.printf("archivist_paper_processing_seconds_sum %s\n", formatFloat(r.durationSum))
+	ew.printf("archivist_paper_processing_seconds_count %d\n", r.durationCount)
+
+	ew.header("archivist_gemini_tokens_total", "counter", "Gemini tokens spent, by type.")
+	ew.printf("archivist_gemini_tokens_total{type=\"prompt\"} %d\n", r.promptTokens)
+	ew.printf("archivist_gemini_tokens_total{type=\"output\"} %d\n", r.outputTokens)
+
+	ew.header("archivist_cache_hits_total", "counter", "Analyses served from the cache.")
+	ew.printf("archivist_cache_hits_total %d\n", r.cacheHits)
+	ew.header("archivist_cache_misses_total", "counter", "Analyses not found in the cache.")
+	ew.printf("archivist_cache_misses_total %d\n", r.cacheMisses)
+
+	ew.header("archivist_active_workers", "gauge", "Workers currently processing a paper.")
+	ew.printf("archivist_active_workers %d\n", r.activeWorkers)
+
+	return ew.err
+}
+
+// ServeHTTP serves the metrics for Prometheus to scrape
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteText(w)
+}
+
+// formatFloat formats v the way Prometheus expects, e.g. "30" or "1.5"
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// errWriter keeps the first write error so WriteText can check it once
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...interface{}) {
+	if ew.err == nil {
+		_, ew.err = fmt.Fprintf(ew.w, format, args...)
+	}
+}
+
+func (ew *errWriter) header(name, kind, help string) {
+	ew.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorderWriteText(t *testing.T) {
+	r := NewRecorder()
+	r.PaperProcessed(StatusCompleted, 20*time.Second)
+	r.PaperProcessed(StatusCompleted, 45*time.Second)
+	r.PaperProcessed(StatusFailed, time.Hour)
+	r.AddTokens(1000, 250)
+	r.AddTokens(500, 50)
+	r.CacheHit()
+	r.CacheMiss()
+	r.CacheMiss()
+	r.WorkerBusy()
+	r.WorkerBusy()
+	r.WorkerIdle()
+
+	var b strings.Builder
+	require.NoError(t, r.WriteText(&b))
+	out := b.String()
+
+	for _, line := range []string{
+		"# TYPE archivist_papers_processed_total counter",
+		`archivist_papers_processed_total{status="completed"} 2`,
+		`archivist_papers_processed_total{status="failed"} 1`,
+		`archivist_papers_processed_total{status="interrupted"} 0`,
+		"# TYPE archivist_paper_processing_seconds histogram",
+		`archivist_paper_processing_seconds_bucket{le="10"} 0`,
+		`archivist_paper_processing_seconds_bucket{le="30"} 1`,
+		`archivist_paper_processing_seconds_bucket{le="60"} 2`,
+		`archivist_paper_processing_seconds_bucket{le="1200"} 2`,
+		`archivist_paper_processing_seconds_bucket{le="+Inf"} 3`,
+		"archivist_paper_processing_seconds_sum 3665",
+		"archivist_paper_processing_seconds_count 3",
+		`archivist_gemini_tokens_total{type="prompt"} 1500`,
+		`archivist_gemini_tokens_total{type="output"} 300`,
+		"archivist_cache_hits_total 1",
+		"archivist_cache_misses_total 2",
+		"# TYPE archivist_active_workers gauge",
+		"archivist_active_workers 1",
+	} {
+		assert.Contains(t, out, line+"\n")
+	}
+}
+
+func TestNilRecorderIsNoOp(t *testing.T) {
+	var r *Recorder
+	assert.NotPanics(t, func() {
+		r.PaperProcessed(StatusCompleted, time.Second)
+		r.AddTokens(1, 1)
+		r.CacheHit()
+		r.CacheMiss()
+		r.WorkerBusy()
+		r.WorkerIdle()
+	})
+
+	var s *Server
+	assert.NoError(t, s.Shutdown(context.Background()))
+}
+
+func TestServe(t *testing.T) {
+	r := NewRecorder()
+	r.CacheHit()
+
+	server, err := Serve(0, r)
+	require.NoError(t, err)
+	defer server.Shutdown(context.Background())
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/metrics", server.Addr()))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/plain")
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "archivist_cache_hits_total 1\n")
+}
+
+func TestServePortInUse(t *testing.T) {
+	server, err := Serve(0, NewRecorder())
+	require.NoError(t, err)
+	defer server.Shutdown(context.Background())
+
+	_, err = Serve(server.Addr().(*net.TCPAddr).Port, NewRecorder())
+	assert.ErrorContains(t, err, "failed to listen for metrics")
+}
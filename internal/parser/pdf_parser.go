@@ -3,6 +3,8 @@ package parser
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 )
 
 type PaperMetadata struct {
@@ -10,6 +12,15 @@ type PaperMetadata struct {
 	Authors  []string
 	Abstract string
 	Year     string
+	Venue    string // Conference or journal, if the paper names one
+	DOI      string // Lowercase, without a resolver prefix, e.g. 10.1145/3292500.3330701
+	ArxivID  string // Without the version suffix, e.g. 1706.03762 or hep-th/9901001
+
+	// Main topics, techniques, datasets and evaluation metrics of the paper
+	Keywords      []string
+	Methodologies []string
+	Datasets      []string
+	Metrics       []string
 }
 
 type PDFParser struct {
@@ -36,11 +47,25 @@ func (p *PDFParser) ExtractMetadata(ctx context.Context, pdfPath string) (*Paper
 - Authors (comma-separated)
 - Abstract
 - Publication year
-
-Return ONLY in this exact format:
+- Venue (conference or journal)
+- DOI, if printed on the paper
+- arXiv identifier, if printed on the paper
+- Keywords (comma-separated)
+- Methods and techniques used (comma-separated)
+- Datasets used (comma-separated)
+- Evaluation metrics reported (comma-separated)
+
+Return ONLY in this exact format, leaving a list empty if the paper has none:
 TITLE: [paper title]
 AUTHORS: [author1, author2, ...]
 YEAR: [year]
+VENUE: [conference or journal, empty if not stated]
+DOI: [DOI, empty if not stated]
+ARXIV: [arXiv identifier, empty if not stated]
+KEYWORDS: [keyword1, keyword2, ...]
+METHODS: [method1, method2, ...]
+DATASETS: [dataset1, dataset2, ...]
+METRICS: [metric1, metric2, ...]
 ABSTRACT: [abstract text]
 
 Be concise and accurate.`
@@ -65,10 +90,23 @@ func parseMetadataResponse(response string) *PaperMetadata {
 		if len(line) > 7 && line[:6] == "TITLE:" {
 			metadata.Title = trim(line[6:])
 		} else if len(line) > 9 && line[:8] == "AUTHORS:" {
-			authors := trim(line[8:])
-			metadata.Authors = splitComma(authors)
+			metadata.Authors = parseList(line[8:])
+		} else if len(line) > 10 && line[:9] == "KEYWORDS:" {
+			metadata.Keywords = parseList(line[9:])
+		} else if len(line) > 9 && line[:8] == "METHODS:" {
+			metadata.Methodologies = parseList(line[8:])
+		} else if len(line) > 10 && line[:9] == "DATASETS:" {
+			metadata.Datasets = parseList(line[9:])
+		} else if len(line) > 9 && line[:8] == "METRICS:" {
+			metadata.Metrics = parseList(line[8:])
 		} else if len(line) > 6 && line[:5] == "YEAR:" {
 			metadata.Year = trim(line[5:])
+		} else if len(line) > 7 && line[:6] == "VENUE:" {
+			metadata.Venue = trimBrackets(trim(line[6:]))
+		} else if len(line) > 5 && line[:4] == "DOI:" {
+			metadata.DOI = normalizeDOI(line[4:])
+		} else if len(line) > 7 && line[:6] == "ARXIV:" {
+			metadata.ArxivID = normalizeArxivID(line[6:])
 		} else if len(line) > 10 && line[:9] == "ABSTRACT:" {
 			metadata.Abstract = trim(line[9:])
 		}
@@ -77,6 +115,41 @@ func parseMetadataResponse(response string) *PaperMetadata {
 	return metadata
 }
 
+// parseList splits a comma-separated list from the response, dropping empty
+// entries. The prompt shows lists in brackets, which the model sometimes copies.
+func parseList(s string) []string {
+	var items []string
+	for _, item := range splitComma(trimBrackets(trim(s))) {
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+var (
+	doiRegex = regexp.MustCompile(`10\.\d{4,9}/\S+`)
+	// New-style (1706.03762v5) and legacy (hep-th/9901001) arXiv identifiers
+	arxivRegex = regexp.MustCompile(`\b(\d{4}\.\d{4,5}|[a-z]+(?:-[a-z]+)*(?:\.[A-Z]{2})?/\d{7})(?:v\d+)?\b`)
+)
+
+// normalizeDOI returns the DOI in s, lowercased and without any resolver
+// prefix or trailing punctuation, or "" if s holds none. DOIs are
+// case-insensitive, so two copies of a paper compare equal.
+func normalizeDOI(s string) string {
+	doi := doiRegex.FindString(s)
+	return strings.ToLower(strings.TrimRight(doi, ".,;)]"))
+}
+
+// normalizeArxivID returns the arXiv identifier in s without its version
+// suffix, or "" if s holds none
+func normalizeArxivID(s string) string {
+	if match := arxivRegex.FindStringSubmatch(s); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
 // Helper functions
 func splitLines(s string) []string {
 	var lines []string
@@ -122,3 +195,10 @@ func trim(s string) string {
 
 	return s[start:end]
 }
+
+func trimBrackets(s string) string {
+	if len(s) >= 2 && s[0] == '[' && s[len(s)-1] == ']' {
+		return trim(s[1 : len(s)-1])
+	}
+	return s
+}
@@ -0,0 +1,35 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMetadataResponse_Identifiers(t *testing.T) {
+	response := "TITLE: Attention Is All You Need\n" +
+		"AUTHORS: [Ashish Vaswani, Noam Shazeer]\n" +
+		"YEAR: 2017\n" +
+		"DOI: https://doi.org/10.48550/arXiv.1706.03762.\n" +
+		"ARXIV: arXiv:1706.03762v5 [cs.CL]\n"
+
+	metadata := parseMetadataResponse(response)
+
+	assert.Equal(t, "Attention Is All You Need", metadata.Title)
+	assert.Equal(t, "10.48550/arxiv.1706.03762", metadata.DOI)
+	assert.Equal(t, "1706.03762", metadata.ArxivID)
+}
+
+func TestNormalizeDOI(t *testing.T) {
+	assert.Equal(t, "10.1145/3292500.3330701", normalizeDOI("doi:10.1145/3292500.3330701"))
+	assert.Equal(t, "10.1000/abc", normalizeDOI("[10.1000/ABC]"))
+	assert.Empty(t, normalizeDOI("[DOI, empty if not stated]"))
+	assert.Empty(t, normalizeDOI(""))
+}
+
+func TestNormalizeArxivID(t *testing.T) {
+	assert.Equal(t, "2101.00001", normalizeArxivID("2101.00001"))
+	assert.Equal(t, "hep-th/9901001", normalizeArxivID("arXiv:hep-th/9901001v2"))
+	assert.Equal(t, "math.GT/0309136", normalizeArxivID("math.GT/0309136"))
+	assert.Empty(t, normalizeArxivID("not on arXiv"))
+}
@@ -25,6 +25,7 @@ func (i *Indexer) IndexPDFDirectly(ctx context.Context, pdfPath, geminiAPIKey st
 	prompt := `Extract all text content from this PDF research paper.
 Include all sections, paragraphs, equations, and technical content.
 Preserve the structure and formatting as much as possible.
+` + pageMarkerPrompt + `
 Return only the extracted text, no additional commentary.`
 
 	extractedText, err := geminiClient.AnalyzePDFWithVision(ctx, pdfPath, prompt)
@@ -50,6 +51,7 @@ Return only the extracted text, no additional commentary.`
 	}
 
 	log.Printf("  ✓ Created %d chunks", len(chunks))
+	assignPages(extractedText, chunks)
 
 	// Extract text for embedding
 	texts := make([]string, len(chunks))
@@ -59,7 +61,7 @@ Return only the extracted text, no additional commentary.`
 
 	// Generate embeddings in batch
 	log.Println("  🧮 Generating embeddings...")
-	embeddings, err := i.embedClient.GenerateBatchEmbeddings(ctx, texts)
+	embeddings, err := i.embedClient.GenerateEmbeddings(ctx, texts)
 	if err != nil {
 		return fmt.Errorf("failed to generate embeddings: %w", err)
 	}
@@ -86,6 +88,9 @@ Return only the extracted text, no additional commentary.`
 				"indexed_directly": "true",
 			},
 		}
+		if page, ok := chunk.Metadata[PageKey]; ok {
+			docs[idx].Metadata[PageKey] = page
+		}
 	}
 
 	// Store in vector database
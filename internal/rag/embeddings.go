@@ -1,30 +1,42 @@
 package rag
 
 import (
+	"archivist/internal/app"
 	"context"
 	"fmt"
+	"log"
 
 	"github.com/google/generative-ai-go/genai"
 	"google.golang.org/api/option"
 )
 
 const (
-	// EmbeddingModel is the Gemini embedding model to use
-	EmbeddingModel = "models/text-embedding-004"
-	// EmbeddingDimensions is the output dimension size
-	EmbeddingDimensions = 768
+	// DefaultEmbeddingModel is the Gemini embedding model used unless rag.embedding_model says otherwise
+	DefaultEmbeddingModel = app.DefaultEmbeddingModel
+	// DefaultEmbeddingDimensions is the output dimension size of DefaultEmbeddingModel
+	DefaultEmbeddingDimensions = app.DefaultEmbeddingDim
 )
 
 // EmbeddingClient handles text embedding generation using Gemini API
 type EmbeddingClient struct {
 	client *genai.Client
 	model  string
+	dim    int
 }
 
-// NewEmbeddingClient creates a new embedding client
-func NewEmbeddingClient(apiKey string) (*EmbeddingClient, error) {
+// NewEmbeddingClient creates an embedding client for model, which must
+// produce vectors of dim dimensions. An empty model or a dim of 0 falls back
+// to the defaults.
+func NewEmbeddingClient(apiKey, model string, dim int) (*EmbeddingClient, error) {
 	ctx := context.Background()
 
+	if model == "" {
+		model = DefaultEmbeddingModel
+	}
+	if dim <= 0 {
+		dim = DefaultEmbeddingDimensions
+	}
+
 	client, err := genai.NewClient(ctx, option.WithAPIKey(apiKey))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
@@ -32,10 +44,16 @@ func NewEmbeddingClient(apiKey string) (*EmbeddingClient, error) {
 
 	return &EmbeddingClient{
 		client: client,
-		model:  EmbeddingModel,
+		model:  model,
+		dim:    dim,
 	}, nil
 }
 
+// Dimensions returns the embedding size the client was configured for
+func (ec *EmbeddingClient) Dimensions() int {
+	return ec.dim
+}
+
 // Close closes the embedding client
 func (ec *EmbeddingClient) Close() error {
 	return ec.client.Close()
@@ -54,26 +72,91 @@ func (ec *EmbeddingClient) GenerateEmbedding(ctx context.Context, text string) (
 		return nil, fmt.Errorf("empty embedding returned")
 	}
 
+	if len(res.Embedding.Values) != ec.dim {
+		return nil, fmt.Errorf("model %s returned %d-dimensional embeddings but rag.embedding_dim is %d",
+			ec.model, len(res.Embedding.Values), ec.dim)
+	}
+
 	return res.Embedding.Values, nil
 }
 
-// GenerateBatchEmbeddings generates embeddings for multiple texts
-// Note: Processes one at a time as batch API may not be available in all versions
-func (ec *EmbeddingClient) GenerateBatchEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+// maxEmbeddingBatch is the most texts Gemini accepts in one batch request
+const maxEmbeddingBatch = 100
+
+// GenerateEmbeddings embeds texts using the batch endpoint, sending at most
+// maxEmbeddingBatch texts per request. A batch the endpoint rejects is
+// embedded one text at a time instead. Embeddings are returned in the order
+// of texts.
+func (ec *EmbeddingClient) GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	return embedInBatches(ctx, texts, maxEmbeddingBatch, ec.batchEmbed, ec.GenerateEmbedding)
+}
+
+// batchEmbed embeds texts in a single BatchEmbedContents call
+func (ec *EmbeddingClient) batchEmbed(ctx context.Context, texts []string) ([][]float32, error) {
+	model := ec.client.EmbeddingModel(ec.model)
+
+	batch := model.NewBatch()
+	for _, text := range texts {
+		batch.AddContent(genai.Text(text))
+	}
+
+	res, err := model.BatchEmbedContents(ctx, batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate batch embeddings: %w", err)
+	}
+
+	embeddings := make([][]float32, len(res.Embeddings))
+	for i, embedding := range res.Embeddings {
+		if embedding == nil || len(embedding.Values) == 0 {
+			return nil, fmt.Errorf("empty embedding returned for text %d", i)
+		}
+		if len(embedding.Values) != ec.dim {
+			return nil, fmt.Errorf("model %s returned %d-dimensional embeddings but rag.embedding_dim is %d",
+				ec.model, len(embedding.Values), ec.dim)
+		}
+		embeddings[i] = embedding.Values
+	}
+
+	return embeddings, nil
+}
+
+// embedInBatches splits texts into batches of batchSize for embedBatch,
+// falling back to embedOne for each text of a batch that fails or returns
+// the wrong number of embeddings
+func embedInBatches(
+	ctx context.Context,
+	texts []string,
+	batchSize int,
+	embedBatch func(context.Context, []string) ([][]float32, error),
+	embedOne func(context.Context, string) ([]float32, error),
+) ([][]float32, error) {
 	if len(texts) == 0 {
 		return nil, fmt.Errorf("no texts provided")
 	}
 
-	embeddings := make([][]float32, len(texts))
+	embeddings := make([][]float32, 0, len(texts))
 
-	// Process each text individually
-	// In production, optimize with actual batch API if available
-	for i, text := range texts {
-		embedding, err := ec.GenerateEmbedding(ctx, text)
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate embedding for text %d: %w", i, err)
+	for start := 0; start < len(texts); start += batchSize {
+		end := min(start+batchSize, len(texts))
+		batch := texts[start:end]
+
+		batchEmbeddings, err := embedBatch(ctx, batch)
+		if err == nil && len(batchEmbeddings) == len(batch) {
+			embeddings = append(embeddings, batchEmbeddings...)
+			continue
+		}
+		if err == nil {
+			err = fmt.Errorf("got %d embeddings for %d texts", len(batchEmbeddings), len(batch))
+		}
+		log.Printf("  ⚠️  Batch embedding failed (%v), embedding %d texts one at a time", err, len(batch))
+
+		for i, text := range batch {
+			embedding, err := embedOne(ctx, text)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate embedding for text %d: %w", start+i, err)
+			}
+			embeddings = append(embeddings, embedding)
 		}
-		embeddings[i] = embedding
 	}
 
 	return embeddings, nil
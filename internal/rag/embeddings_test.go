@@ -0,0 +1,86 @@
+package rag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEmbedding encodes text's position in the input so ordering can be checked
+func fakeEmbedding(text string) []float32 {
+	var n float32
+	fmt.Sscanf(text, "text %f", &n)
+	return []float32{n}
+}
+
+func fakeTexts(n int) []string {
+	texts := make([]string, n)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("text %d", i)
+	}
+	return texts
+}
+
+func TestEmbedInBatchesSplitsAndKeepsOrder(t *testing.T) {
+	var batchSizes []int
+	embedBatch := func(ctx context.Context, texts []string) ([][]float32, error) {
+		batchSizes = append(batchSizes, len(texts))
+		out := make([][]float32, len(texts))
+		for i, text := range texts {
+			out[i] = fakeEmbedding(text)
+		}
+		return out, nil
+	}
+	embedOne := func(ctx context.Context, text string) ([]float32, error) {
+		t.Fatalf("unexpected single embedding for %q", text)
+		return nil, nil
+	}
+
+	embeddings, err := embedInBatches(context.Background(), fakeTexts(5), 2, embedBatch, embedOne)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{2, 2, 1}, batchSizes)
+	require.Len(t, embeddings, 5)
+	for i, embedding := range embeddings {
+		assert.Equal(t, float32(i), embedding[0])
+	}
+}
+
+func TestEmbedInBatchesFallsBackToSingleCalls(t *testing.T) {
+	embedBatch := func(ctx context.Context, texts []string) ([][]float32, error) {
+		return nil, errors.New("batch not supported")
+	}
+	singles := 0
+	embedOne := func(ctx context.Context, text string) ([]float32, error) {
+		singles++
+		return fakeEmbedding(text), nil
+	}
+
+	embeddings, err := embedInBatches(context.Background(), fakeTexts(3), 2, embedBatch, embedOne)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, singles)
+	for i, embedding := range embeddings {
+		assert.Equal(t, float32(i), embedding[0])
+	}
+}
+
+func TestEmbedInBatchesReportsFailingText(t *testing.T) {
+	embedBatch := func(ctx context.Context, texts []string) ([][]float32, error) {
+		return nil, errors.New("batch not supported")
+	}
+	embedOne := func(ctx context.Context, text string) ([]float32, error) {
+		if text == "text 3" {
+			return nil, errors.New("quota exceeded")
+		}
+		return fakeEmbedding(text), nil
+	}
+
+	_, err := embedInBatches(context.Background(), fakeTexts(4), 2, embedBatch, embedOne)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "text 3")
+}
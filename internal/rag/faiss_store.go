@@ -3,11 +3,14 @@ package rag
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 )
 
@@ -17,33 +20,82 @@ type FAISSVectorStore struct {
 	documents   map[string]VectorDocument // docID -> document
 	embeddings  [][]float32               // list of embeddings
 	docIDs      []string                  // corresponding doc IDs
+	dim         int                       // embedding size every vector must have
 	mu          sync.RWMutex
 }
 
-// NewFAISSVectorStore creates a new FAISS-based vector store
-func NewFAISSVectorStore(indexDir string) (*FAISSVectorStore, error) {
+// faissIndexFile is the file inside the index directory holding the index
+const faissIndexFile = "faiss_index.json"
+
+// ErrDimensionMismatch is returned when a saved index was built with a
+// different embedding dimension than the one configured
+var ErrDimensionMismatch = errors.New("embedding dimension mismatch")
+
+// NewFAISSVectorStore creates a FAISS-based vector store in indexDir for
+// embeddings of dim dimensions, loading the index a previous run saved there.
+// An index holding vectors of another size fails with ErrDimensionMismatch.
+func NewFAISSVectorStore(indexDir string, dim int) (*FAISSVectorStore, error) {
 	// Create index directory if it doesn't exist
 	if err := os.MkdirAll(indexDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create index directory: %w", err)
+		return nil, fmt.Errorf("failed to create index directory %s: %w", indexDir, err)
+	}
+
+	// Fail now rather than after a paper has been embedded
+	if err := checkWritable(indexDir); err != nil {
+		return nil, err
 	}
 
 	vs := &FAISSVectorStore{
-		indexPath:  filepath.Join(indexDir, "faiss_index.json"),
+		indexPath:  filepath.Join(indexDir, faissIndexFile),
 		documents:  make(map[string]VectorDocument),
 		embeddings: [][]float32{},
 		docIDs:     []string{},
+		dim:        dim,
 	}
 
 	// Load existing index if available
 	if err := vs.load(); err != nil {
-		log.Printf("No existing index found, starting fresh: %v", err)
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to load index %s: %w", vs.indexPath, err)
+		}
+		log.Printf("No existing index in %s, starting fresh", indexDir)
 	} else {
 		log.Printf("✓ Loaded existing FAISS index with %d documents", len(vs.documents))
 	}
 
+	if len(vs.embeddings) > 0 && len(vs.embeddings[0]) != dim {
+		return nil, fmt.Errorf("%w: index %s holds %d-dimensional embeddings but rag.embedding_dim is %d; run 'rph reindex --force' to rebuild it",
+			ErrDimensionMismatch, vs.indexPath, len(vs.embeddings[0]), dim)
+	}
+
 	return vs, nil
 }
 
+// ResetFAISSIndex deletes the index saved in indexDir, so it can be rebuilt
+// after the embedding model changed
+func ResetFAISSIndex(indexDir string) error {
+	if err := os.Remove(filepath.Join(indexDir, faissIndexFile)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove index: %w", err)
+	}
+	return nil
+}
+
+// Dimensions returns the embedding size the store accepts
+func (vs *FAISSVectorStore) Dimensions() int {
+	return vs.dim
+}
+
+// checkWritable verifies that files can be created in dir
+func checkWritable(dir string) error {
+	probe, err := os.CreateTemp(dir, ".write_check_*")
+	if err != nil {
+		return fmt.Errorf("index directory %s is not writable: %w", dir, err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return nil
+}
+
 // AddDocument adds a document with its embedding to the vector store
 func (vs *FAISSVectorStore) AddDocument(ctx context.Context, doc VectorDocument) error {
 	vs.mu.Lock()
@@ -53,9 +105,9 @@ func (vs *FAISSVectorStore) AddDocument(ctx context.Context, doc VectorDocument)
 		return fmt.Errorf("document ID is required")
 	}
 
-	if len(doc.Embedding) != EmbeddingDimensions {
+	if len(doc.Embedding) != vs.dim {
 		return fmt.Errorf("embedding dimension mismatch: expected %d, got %d",
-			EmbeddingDimensions, len(doc.Embedding))
+			vs.dim, len(doc.Embedding))
 	}
 
 	// Add or update document
@@ -81,7 +133,7 @@ func (vs *FAISSVectorStore) AddDocument(ctx context.Context, doc VectorDocument)
 	return nil
 }
 
-// AddDocuments adds multiple documents in batch
+// AddDocuments adds multiple documents in batch and saves the index to disk
 func (vs *FAISSVectorStore) AddDocuments(ctx context.Context, docs []VectorDocument) error {
 	if len(docs) == 0 {
 		return nil
@@ -95,7 +147,10 @@ func (vs *FAISSVectorStore) AddDocuments(ctx context.Context, docs []VectorDocum
 	}
 
 	// Save to disk after batch
-	if err := vs.save(); err != nil {
+	vs.mu.RLock()
+	err := vs.save()
+	vs.mu.RUnlock()
+	if err != nil {
 		return fmt.Errorf("failed to save index: %w", err)
 	}
 
@@ -108,9 +163,9 @@ func (vs *FAISSVectorStore) Search(ctx context.Context, queryEmbedding []float32
 	vs.mu.RLock()
 	defer vs.mu.RUnlock()
 
-	if len(queryEmbedding) != EmbeddingDimensions {
+	if len(queryEmbedding) != vs.dim {
 		return nil, fmt.Errorf("query embedding dimension mismatch: expected %d, got %d",
-			EmbeddingDimensions, len(queryEmbedding))
+			vs.dim, len(queryEmbedding))
 	}
 
 	if topK <= 0 {
@@ -181,6 +236,81 @@ func (vs *FAISSVectorStore) SearchBySource(ctx context.Context, queryEmbedding [
 	return vs.Search(ctx, queryEmbedding, topK, filter)
 }
 
+// essenceChunks is how many leading body chunks stand in for a paper's
+// essence when it has no metadata chunks
+const essenceChunks = 3
+
+// maxEssenceChars keeps a paper's essence within the embedding model's input limit
+const maxEssenceChars = 8000
+
+// PaperEssence returns the text summarizing an indexed paper: its metadata
+// chunks (abstract, keywords, methods...) if it has them, otherwise its
+// first few chunks
+func (vs *FAISSVectorStore) PaperEssence(paperTitle string) (string, error) {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+
+	var metadata, body []VectorDocument
+	for _, doc := range vs.documents {
+		if doc.Source != paperTitle {
+			continue
+		}
+		if doc.Metadata[ChunkTypeKey] == ChunkTypeMetadata {
+			metadata = append(metadata, doc)
+		} else {
+			body = append(body, doc)
+		}
+	}
+
+	chunks := metadata
+	if len(chunks) == 0 {
+		sort.Slice(body, func(i, j int) bool { return body[i].ChunkIndex < body[j].ChunkIndex })
+		chunks = body[:min(essenceChunks, len(body))]
+	}
+	if len(chunks) == 0 {
+		return "", fmt.Errorf("%q is not in the vector index; run 'rph index' first", paperTitle)
+	}
+	sort.Slice(chunks, func(i, j int) bool { return chunks[i].ChunkIndex < chunks[j].ChunkIndex })
+
+	texts := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		texts[i] = chunk.ChunkText
+	}
+
+	essence := strings.Join(texts, "\n\n")
+	if len(essence) > maxEssenceChars {
+		essence = essence[:maxEssenceChars]
+	}
+	return essence, nil
+}
+
+// ScorePapers returns, for every indexed paper, the highest cosine
+// similarity between queryEmbedding and one of its chunks
+func (vs *FAISSVectorStore) ScorePapers(ctx context.Context, queryEmbedding []float32) (map[string]float32, error) {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+
+	if len(queryEmbedding) != vs.dim {
+		return nil, fmt.Errorf("query embedding dimension mismatch: expected %d, got %d",
+			vs.dim, len(queryEmbedding))
+	}
+
+	scores := make(map[string]float32)
+	for i, embedding := range vs.embeddings {
+		doc, exists := vs.documents[vs.docIDs[i]]
+		if !exists {
+			continue
+		}
+
+		similarity := cosineSimilarity(queryEmbedding, embedding)
+		if best, seen := scores[doc.Source]; !seen || similarity > best {
+			scores[doc.Source] = similarity
+		}
+	}
+
+	return scores, nil
+}
+
 // GetDocumentsBySource retrieves all chunks for a specific paper
 func (vs *FAISSVectorStore) GetDocumentsBySource(ctx context.Context, source string) ([]VectorDocument, error) {
 	vs.mu.RLock()
@@ -198,47 +328,77 @@ func (vs *FAISSVectorStore) GetDocumentsBySource(ctx context.Context, source str
 
 // DeleteBySource deletes all chunks for a specific paper
 func (vs *FAISSVectorStore) DeleteBySource(ctx context.Context, source string) (int, error) {
+	return vs.DeletePaper(source)
+}
+
+// DeletePaper removes every vector whose source is paperTitle, rewrites the
+// index on disk and returns the number of vectors removed
+func (vs *FAISSVectorStore) DeletePaper(paperTitle string) (int, error) {
 	vs.mu.Lock()
 	defer vs.mu.Unlock()
 
-	// Find documents to delete
-	toDelete := []string{}
-	for docID, doc := range vs.documents {
-		if doc.Source == source {
-			toDelete = append(toDelete, docID)
+	embeddings := make([][]float32, 0, len(vs.embeddings))
+	docIDs := make([]string, 0, len(vs.docIDs))
+	removed := 0
+
+	for i, docID := range vs.docIDs {
+		if doc, ok := vs.documents[docID]; ok && doc.Source == paperTitle {
+			delete(vs.documents, docID)
+			removed++
+			continue
 		}
+		embeddings = append(embeddings, vs.embeddings[i])
+		docIDs = append(docIDs, docID)
 	}
 
-	if len(toDelete) == 0 {
+	if removed == 0 {
 		return 0, nil
 	}
 
-	// Delete documents
-	for _, docID := range toDelete {
-		delete(vs.documents, docID)
-
-		// Remove from embeddings and docIDs
-		for i, id := range vs.docIDs {
-			if id == docID {
-				// Remove from embeddings
-				vs.embeddings = append(vs.embeddings[:i], vs.embeddings[i+1:]...)
-				// Remove from docIDs
-				vs.docIDs = append(vs.docIDs[:i], vs.docIDs[i+1:]...)
-				break
-			}
-		}
-	}
+	vs.embeddings = embeddings
+	vs.docIDs = docIDs
 
 	// Save changes
 	if err := vs.save(); err != nil {
 		return 0, fmt.Errorf("failed to save after deletion: %w", err)
 	}
 
-	log.Printf("✓ Deleted %d chunks for source: %s", len(toDelete), source)
-	return len(toDelete), nil
+	log.Printf("✓ Deleted %d chunks for source: %s", removed, paperTitle)
+	return removed, nil
 }
 
-// save persists the index to disk
+// GetIndexedPaperHashes returns the content hash each indexed paper was
+// embedded from, keyed by paper title. Papers indexed before hashes were
+// recorded map to an empty string.
+func (vs *FAISSVectorStore) GetIndexedPaperHashes() map[string]string {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+
+	hashes := make(map[string]string)
+	for _, doc := range vs.documents {
+		if hashes[doc.Source] == "" {
+			hashes[doc.Source] = doc.Metadata[ContentHashKey]
+		}
+	}
+
+	return hashes
+}
+
+// Clear removes every vector from the index and saves the empty index
+func (vs *FAISSVectorStore) Clear() error {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	vs.documents = make(map[string]VectorDocument)
+	vs.embeddings = [][]float32{}
+	vs.docIDs = []string{}
+
+	return vs.save()
+}
+
+// save persists the index to disk. The index is written to a temporary file
+// and renamed into place so an interrupted save can't truncate it.
+// Callers must hold vs.mu.
 func (vs *FAISSVectorStore) save() error {
 	// Create index data structure
 	indexData := struct {
@@ -257,10 +417,30 @@ func (vs *FAISSVectorStore) save() error {
 		return fmt.Errorf("failed to marshal index: %w", err)
 	}
 
-	// Write to file
-	if err := os.WriteFile(vs.indexPath, data, 0644); err != nil {
+	// Write to a temporary file, then swap it in
+	tmp, err := os.CreateTemp(filepath.Dir(vs.indexPath), faissIndexFile+".tmp*")
+	if err != nil {
 		return fmt.Errorf("failed to write index file: %w", err)
 	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to flush index file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write index file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), vs.indexPath); err != nil {
+		return fmt.Errorf("failed to replace index file: %w", err)
+	}
 
 	return nil
 }
@@ -284,9 +464,18 @@ func (vs *FAISSVectorStore) load() error {
 		return fmt.Errorf("failed to unmarshal index: %w", err)
 	}
 
-	vs.documents = indexData.Documents
-	vs.embeddings = indexData.Embeddings
-	vs.docIDs = indexData.DocIDs
+	if len(indexData.Embeddings) != len(indexData.DocIDs) {
+		return fmt.Errorf("index is inconsistent: %d embeddings for %d document IDs",
+			len(indexData.Embeddings), len(indexData.DocIDs))
+	}
+
+	if indexData.Documents != nil {
+		vs.documents = indexData.Documents
+	}
+	if indexData.Embeddings != nil {
+		vs.embeddings = indexData.Embeddings
+		vs.docIDs = indexData.DocIDs
+	}
 
 	return nil
 }
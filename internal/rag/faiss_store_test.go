@@ -0,0 +1,170 @@
+package rag
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDocuments returns n chunks of source with distinct unit embeddings
+func testDocuments(source string, n int) []VectorDocument {
+	docs := make([]VectorDocument, n)
+	for i := range docs {
+		embedding := make([]float32, DefaultEmbeddingDimensions)
+		embedding[i%DefaultEmbeddingDimensions] = 1
+		docs[i] = VectorDocument{
+			ID:         fmt.Sprintf("%s_chunk_%d", source, i),
+			ChunkText:  fmt.Sprintf("chunk %d of %s", i, source),
+			Embedding:  embedding,
+			Source:     source,
+			ChunkIndex: i,
+		}
+	}
+	return docs
+}
+
+func TestFAISSDeletePaper(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	store, err := NewFAISSVectorStore(dir, DefaultEmbeddingDimensions)
+	require.NoError(t, err)
+	require.NoError(t, store.AddDocuments(ctx, testDocuments("Attention", 3)))
+	require.NoError(t, store.AddDocuments(ctx, testDocuments("BERT", 2)))
+
+	removed, err := store.DeletePaper("Attention")
+	require.NoError(t, err)
+	assert.Equal(t, 3, removed)
+	assert.Equal(t, []string{"BERT"}, store.GetIndexedPapers())
+
+	// Remaining embeddings must still line up with their documents
+	results, err := store.Search(ctx, testDocuments("BERT", 2)[1].Embedding, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "BERT_chunk_1", results[0].Document.ID)
+
+	// The deletion is persisted
+	reopened, err := NewFAISSVectorStore(dir, DefaultEmbeddingDimensions)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BERT"}, reopened.GetIndexedPapers())
+	assert.Equal(t, 2, reopened.GetStats()["index_size"])
+}
+
+func TestFAISSDeletePaperUnknownTitle(t *testing.T) {
+	store, err := NewFAISSVectorStore(t.TempDir(), DefaultEmbeddingDimensions)
+	require.NoError(t, err)
+	require.NoError(t, store.AddDocuments(context.Background(), testDocuments("BERT", 2)))
+
+	removed, err := store.DeletePaper("GPT")
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+	assert.Equal(t, 2, store.GetStats()["index_size"])
+}
+
+func TestFAISSIndexSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	store, err := NewFAISSVectorStore(dir, DefaultEmbeddingDimensions)
+	require.NoError(t, err)
+	require.NoError(t, store.AddDocuments(ctx, testDocuments("Attention", 3)))
+	require.NoError(t, store.AddDocuments(ctx, testDocuments("BERT", 2)))
+
+	reopened, err := NewFAISSVectorStore(dir, DefaultEmbeddingDimensions)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Attention", "BERT"}, reopened.GetIndexedPapers())
+	assert.Equal(t, 5, reopened.GetStats()["index_size"])
+
+	// Vectors still line up with their documents after the reload
+	results, err := reopened.Search(ctx, testDocuments("Attention", 3)[2].Embedding, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Attention_chunk_2", results[0].Document.ID)
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, faissIndexFile, entries[0].Name())
+}
+
+func TestFAISSCorruptIndex(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, faissIndexFile), []byte("{not json"), 0644))
+
+	_, err := NewFAISSVectorStore(dir, DefaultEmbeddingDimensions)
+	assert.ErrorContains(t, err, "failed to load index")
+}
+
+func TestFAISSUnwritableIndexDir(t *testing.T) {
+	if os.Geteuid() == 0 {
+		t.Skip("directory permissions are not enforced for root")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.Chmod(dir, 0555))
+	t.Cleanup(func() { os.Chmod(dir, 0755) })
+
+	_, err := NewFAISSVectorStore(dir, DefaultEmbeddingDimensions)
+	assert.ErrorContains(t, err, "is not writable")
+}
+
+func TestFAISSDimensionMismatch(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewFAISSVectorStore(dir, DefaultEmbeddingDimensions)
+	require.NoError(t, err)
+	require.NoError(t, store.AddDocuments(context.Background(), testDocuments("Attention", 2)))
+
+	// Reopening for another embedding model must fail clearly
+	_, err = NewFAISSVectorStore(dir, 1536)
+	require.ErrorIs(t, err, ErrDimensionMismatch)
+	assert.Contains(t, err.Error(), "768")
+	assert.Contains(t, err.Error(), "1536")
+
+	// Resetting discards the old index
+	require.NoError(t, ResetFAISSIndex(dir))
+	reopened, err := NewFAISSVectorStore(dir, 1536)
+	require.NoError(t, err)
+	assert.Empty(t, reopened.GetIndexedPapers())
+}
+
+func TestFAISSPaperEssenceAndScores(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFAISSVectorStore(t.TempDir(), DefaultEmbeddingDimensions)
+	require.NoError(t, err)
+
+	bert := testDocuments("BERT", 5)
+	bert[4].Metadata = map[string]string{ChunkTypeKey: ChunkTypeMetadata}
+	bert[4].ChunkText = "Abstract of BERT"
+	require.NoError(t, store.AddDocuments(ctx, bert))
+	require.NoError(t, store.AddDocuments(ctx, testDocuments("GPT", 4)))
+
+	// Metadata chunks are preferred, otherwise the leading chunks stand in
+	essence, err := store.PaperEssence("BERT")
+	require.NoError(t, err)
+	assert.Equal(t, "Abstract of BERT", essence)
+
+	essence, err = store.PaperEssence("GPT")
+	require.NoError(t, err)
+	assert.Equal(t, "chunk 0 of GPT\n\nchunk 1 of GPT\n\nchunk 2 of GPT", essence)
+
+	_, err = store.PaperEssence("ResNet")
+	assert.Error(t, err)
+
+	// Each paper scores with its best chunk
+	scores, err := store.ScorePapers(ctx, bert[3].Embedding)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, scores["BERT"], 1e-6)
+	assert.InDelta(t, 1, scores["GPT"], 1e-6)
+
+	scores, err = store.ScorePapers(ctx, bert[4].Embedding)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, scores["BERT"], 1e-6)
+	assert.InDelta(t, 0, scores["GPT"], 1e-6)
+}
@@ -3,16 +3,34 @@ package rag
 import (
 	"context"
 	"crypto/md5"
+	"crypto/sha256"
 	"fmt"
 	"log"
 	"os"
+	"sort"
 )
 
+// ContentHashKey is the chunk metadata key holding the hash of the LaTeX
+// content the chunk was embedded from
+const ContentHashKey = "content_hash"
+
+// paperHashStore is implemented by vector stores that can report the content
+// hash of each indexed paper, allowing unchanged papers to be skipped
+type paperHashStore interface {
+	GetIndexedPaperHashes() map[string]string
+}
+
+// paperListStore is implemented by vector stores that can list the papers they hold
+type paperListStore interface {
+	GetIndexedPapers() []string
+}
+
 // Indexer handles indexing of papers into the vector store
 type Indexer struct {
-	chunker     *Chunker
-	embedClient *EmbeddingClient
-	vectorStore VectorStoreInterface
+	chunker           *Chunker
+	embedClient       *EmbeddingClient
+	vectorStore       VectorStoreInterface
+	metadataExtractor MetadataExtractor // Optional; adds metadata chunks to each paper
 }
 
 // NewIndexer creates a new indexer
@@ -24,6 +42,13 @@ func NewIndexer(chunker *Chunker, embedClient *EmbeddingClient, vectorStore Vect
 	}
 }
 
+// SetMetadataExtractor makes IndexPaper also embed the abstract, keywords,
+// methods, datasets and metrics extracted from each paper's PDF, tagged with
+// ChunkTypeMetadata. This costs a model call per paper.
+func (i *Indexer) SetMetadataExtractor(extractor MetadataExtractor) {
+	i.metadataExtractor = extractor
+}
+
 // IndexPaper indexes a paper by reading its LaTeX content and PDF
 func (i *Indexer) IndexPaper(ctx context.Context, paperTitle, latexContent, pdfPath string) error {
 	if paperTitle == "" {
@@ -45,44 +70,63 @@ func (i *Indexer) IndexPaper(ctx context.Context, paperTitle, latexContent, pdfP
 
 	log.Printf("  ✓ Created %d chunks", len(chunks))
 
-	// Extract text for embedding
-	texts := make([]string, len(chunks))
-	for idx, chunk := range chunks {
-		texts[idx] = chunk.Text
+	metaChunks := i.paperMetadataChunks(ctx, paperTitle, pdfPath)
+
+	// Extract text for embedding, metadata chunks after the body
+	texts := make([]string, 0, len(chunks)+len(metaChunks))
+	for _, chunk := range chunks {
+		texts = append(texts, chunk.Text)
+	}
+	for _, chunk := range metaChunks {
+		texts = append(texts, chunk.Text)
 	}
 
 	// Generate embeddings in batch
 	log.Println("  🧮 Generating embeddings...")
-	embeddings, err := i.embedClient.GenerateBatchEmbeddings(ctx, texts)
+	embeddings, err := i.embedClient.GenerateEmbeddings(ctx, texts)
 	if err != nil {
 		return fmt.Errorf("failed to generate embeddings: %w", err)
 	}
 
 	log.Printf("  ✓ Generated %d embeddings", len(embeddings))
 
-	// Create vector documents
-	docs := make([]VectorDocument, len(chunks))
-	for idx, chunk := range chunks {
-		docID := generateDocID(paperTitle, chunk.ChunkIndex)
-
-		docs[idx] = VectorDocument{
-			ID:         docID,
-			ChunkText:  chunk.Text,
-			Embedding:  embeddings[idx],
+	// Create vector documents. Metadata chunks carry the same content hash and
+	// source, so change detection and deletion treat them as part of the paper.
+	contentHash := ContentHash(latexContent)
+	docs := make([]VectorDocument, 0, len(texts))
+	newDoc := func(text, section string, chunkIndex int, embedding []float32) VectorDocument {
+		doc := VectorDocument{
+			ID:         generateDocID(paperTitle, chunkIndex),
+			ChunkText:  text,
+			Embedding:  embedding,
 			Source:     paperTitle,
-			Section:    chunk.Section,
-			ChunkIndex: chunk.ChunkIndex,
+			Section:    section,
+			ChunkIndex: chunkIndex,
 			Metadata: map[string]string{
-				"source":      paperTitle,
-				"section":     chunk.Section,
-				"chunk_index": fmt.Sprintf("%d", chunk.ChunkIndex),
+				"source":       paperTitle,
+				"section":      section,
+				"chunk_index":  fmt.Sprintf("%d", chunkIndex),
+				ContentHashKey: contentHash,
 			},
 		}
 
 		// Add PDF path if available
 		if pdfPath != "" {
-			docs[idx].Metadata["pdf_path"] = pdfPath
+			doc.Metadata["pdf_path"] = pdfPath
 		}
+		return doc
+	}
+
+	nextIndex := 0
+	for idx, chunk := range chunks {
+		docs = append(docs, newDoc(chunk.Text, chunk.Section, chunk.ChunkIndex, embeddings[idx]))
+		nextIndex = max(nextIndex, chunk.ChunkIndex+1)
+	}
+	for idx, chunk := range metaChunks {
+		doc := newDoc(chunk.Text, chunk.Section, nextIndex, embeddings[len(chunks)+idx])
+		doc.Metadata[ChunkTypeKey] = ChunkTypeMetadata
+		docs = append(docs, doc)
+		nextIndex++
 	}
 
 	// Store in vector database
@@ -91,7 +135,7 @@ func (i *Indexer) IndexPaper(ctx context.Context, paperTitle, latexContent, pdfP
 		return fmt.Errorf("failed to store vectors: %w", err)
 	}
 
-	log.Printf("  ✅ Successfully indexed paper: %s (%d chunks)", paperTitle, len(chunks))
+	log.Printf("  ✅ Successfully indexed paper: %s (%d chunks)", paperTitle, len(docs))
 
 	return nil
 }
@@ -120,6 +164,23 @@ func (i *Indexer) ReindexPaper(ctx context.Context, paperTitle, latexContent, pd
 	return i.IndexPaper(ctx, paperTitle, latexContent, pdfPath)
 }
 
+// IndexPaperIfChanged reindexes a paper unless the vector store already holds
+// chunks embedded from the same LaTeX content. It reports whether the paper
+// was (re)embedded.
+func (i *Indexer) IndexPaperIfChanged(ctx context.Context, paperTitle, latexContent, pdfPath string) (bool, error) {
+	if store, ok := i.vectorStore.(paperHashStore); ok {
+		if store.GetIndexedPaperHashes()[paperTitle] == ContentHash(latexContent) {
+			log.Printf("  ⏭️  %s is unchanged since it was indexed - skipping", paperTitle)
+			return false, nil
+		}
+	}
+
+	if err := i.ReindexPaper(ctx, paperTitle, latexContent, pdfPath); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // CheckIfIndexed checks if a paper is already indexed
 func (i *Indexer) CheckIfIndexed(ctx context.Context, paperTitle string) (bool, int, error) {
 	docs, err := i.vectorStore.GetDocumentsBySource(ctx, paperTitle)
@@ -130,12 +191,17 @@ func (i *Indexer) CheckIfIndexed(ctx context.Context, paperTitle string) (bool,
 	return len(docs) > 0, len(docs), nil
 }
 
-// GetIndexedPapers returns a list of all indexed papers
+// GetIndexedPapers returns the titles of all indexed papers, sorted. Metadata
+// chunks share their paper's title, so each paper is listed once.
 func (i *Indexer) GetIndexedPapers(ctx context.Context) ([]string, error) {
-	// This is a simplified version - in production, you'd maintain a separate index
-	// For now, we'll use a dummy implementation
-	// TODO: Implement proper paper listing from Redis
-	return []string{}, nil
+	store, ok := i.vectorStore.(paperListStore)
+	if !ok {
+		return nil, fmt.Errorf("vector store can't list indexed papers")
+	}
+
+	papers := store.GetIndexedPapers()
+	sort.Strings(papers)
+	return papers, nil
 }
 
 // Helper functions
@@ -146,6 +212,12 @@ func generateDocID(paperTitle string, chunkIndex int) string {
 	return fmt.Sprintf("%x_chunk_%d", hash, chunkIndex)
 }
 
+// ContentHash returns the hash recorded with a paper's chunks, used to tell
+// whether its LaTeX changed since it was indexed
+func ContentHash(latexContent string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(latexContent)))
+}
+
 func extractTitleFromPath(pdfPath string) string {
 	// Extract filename without extension
 	filename := pdfPath
@@ -0,0 +1,67 @@
+package rag
+
+import (
+	"archivist/internal/parser"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// ChunkTypeKey is the chunk metadata key marking chunks built from a paper's
+// extracted metadata (ChunkTypeMetadata) rather than its body text
+const ChunkTypeKey = "chunk_type"
+
+// ChunkTypeMetadata marks a chunk holding the paper's abstract, keywords,
+// methods, datasets or metrics
+const ChunkTypeMetadata = "metadata"
+
+// MetadataExtractor extracts structured metadata from a paper's PDF;
+// *parser.PDFParser implements it
+type MetadataExtractor interface {
+	ExtractMetadata(ctx context.Context, pdfPath string) (*parser.PaperMetadata, error)
+}
+
+// metadataChunk is one field of a paper's metadata, written out as text to embed
+type metadataChunk struct {
+	Section string
+	Text    string
+}
+
+// paperMetadataChunks extracts the metadata of the paper at pdfPath into
+// chunks, so questions like "what datasets does it use?" find a direct answer.
+// It returns nil if no extractor is set, there is no PDF or extraction fails.
+func (i *Indexer) paperMetadataChunks(ctx context.Context, paperTitle, pdfPath string) []metadataChunk {
+	if i.metadataExtractor == nil || pdfPath == "" {
+		return nil
+	}
+
+	log.Println("  🏷️  Extracting paper metadata...")
+	metadata, err := i.metadataExtractor.ExtractMetadata(ctx, pdfPath)
+	if err != nil {
+		log.Printf("  ⚠️  Warning: Failed to extract metadata, indexing the report only: %v", err)
+		return nil
+	}
+
+	chunks := metadataChunks(paperTitle, metadata)
+	log.Printf("  ✓ Created %d metadata chunks", len(chunks))
+	return chunks
+}
+
+// metadataChunks turns each non-empty metadata field into a chunk that names
+// the paper, so it still makes sense when retrieved on its own
+func metadataChunks(paperTitle string, metadata *parser.PaperMetadata) []metadataChunk {
+	var chunks []metadataChunk
+	add := func(section, format, value string) {
+		if value != "" {
+			chunks = append(chunks, metadataChunk{Section: section, Text: fmt.Sprintf(format, paperTitle, value)})
+		}
+	}
+
+	add("Abstract", "Abstract of %s:\n%s", strings.TrimSpace(metadata.Abstract))
+	add("Keywords", "Keywords of %s: %s", strings.Join(metadata.Keywords, ", "))
+	add("Methodologies", "Methods and techniques used in %s: %s", strings.Join(metadata.Methodologies, ", "))
+	add("Datasets", "Datasets used in %s: %s", strings.Join(metadata.Datasets, ", "))
+	add("Metrics", "Evaluation metrics reported in %s: %s", strings.Join(metadata.Metrics, ", "))
+	return chunks
+}
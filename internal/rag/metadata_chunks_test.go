@@ -0,0 +1,53 @@
+package rag
+
+import (
+	"archivist/internal/parser"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataChunks(t *testing.T) {
+	metadata := &parser.PaperMetadata{
+		Title:    "BERT",
+		Abstract: "  We introduce a new language representation model.  ",
+		Keywords: []string{"language models", "pre-training"},
+		Datasets: []string{"GLUE", "SQuAD v1.1"},
+		Metrics:  []string{"F1", "accuracy"},
+	}
+
+	chunks := metadataChunks("BERT", metadata)
+
+	require.Len(t, chunks, 4, "empty fields get no chunk")
+	assert.Equal(t, metadataChunk{Section: "Abstract", Text: "Abstract of BERT:\nWe introduce a new language representation model."}, chunks[0])
+	assert.Equal(t, "Keywords of BERT: language models, pre-training", chunks[1].Text)
+	assert.Equal(t, metadataChunk{Section: "Datasets", Text: "Datasets used in BERT: GLUE, SQuAD v1.1"}, chunks[2])
+	assert.Equal(t, "Metrics", chunks[3].Section)
+
+	assert.Empty(t, metadataChunks("BERT", &parser.PaperMetadata{Title: "BERT"}))
+}
+
+func TestMetadataChunksCountAsPartOfPaper(t *testing.T) {
+	store, err := NewFAISSVectorStore(t.TempDir(), DefaultEmbeddingDimensions)
+	require.NoError(t, err)
+
+	docs := testDocuments("BERT", 3)
+	for idx := range docs {
+		docs[idx].Metadata = map[string]string{ContentHashKey: "hash1"}
+	}
+	docs[2].Metadata[ChunkTypeKey] = ChunkTypeMetadata
+	require.NoError(t, store.AddDocuments(context.Background(), docs))
+	require.NoError(t, store.AddDocuments(context.Background(), testDocuments("Attention", 1)))
+
+	indexer := NewIndexer(nil, nil, store)
+	papers, err := indexer.GetIndexedPapers(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Attention", "BERT"}, papers)
+	assert.Equal(t, "hash1", store.GetIndexedPaperHashes()["BERT"])
+
+	removed, err := store.DeletePaper("BERT")
+	require.NoError(t, err)
+	assert.Equal(t, 3, removed)
+}
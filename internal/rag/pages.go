@@ -0,0 +1,92 @@
+package rag
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PageKey is the chunk metadata key holding the page of the source PDF the
+// chunk starts on. Only chunks indexed straight from the PDF have it.
+const PageKey = "page"
+
+// pageMarkerPrompt asks the model to mark page boundaries in extracted text
+// in the form pageMarkerRegex recognises
+const pageMarkerPrompt = `Start the text of every page with a line "[Page N]", where N is the page number.`
+
+// pageMarkerRegex matches the page markers requested by pageMarkerPrompt
+var pageMarkerRegex = regexp.MustCompile(`\[Page (\d+)\]`)
+
+// chunkProbeLength is how much of a chunk's start is used to locate it in the
+// full text
+const chunkProbeLength = 50
+
+// assignPages sets PageKey on each chunk to the page its text starts on,
+// judged by the last page marker before it in text (the text the chunks were
+// cut from). Chunks before the first marker, or text without markers, get none.
+func assignPages(text string, chunks []Chunk) {
+	// The chunker reflows whitespace, so chunks are located with it collapsed
+	text = collapseSpaces(text)
+	markers := pageMarkerRegex.FindAllStringSubmatchIndex(text, -1)
+	if len(markers) == 0 {
+		return
+	}
+
+	// Chunks are in text order but overlap, so each search starts at the
+	// previous chunk's start
+	cursor := 0
+	for i := range chunks {
+		probe := collapseSpaces(chunks[i].Text)
+		if len(probe) > chunkProbeLength {
+			probe = probe[:chunkProbeLength]
+		}
+		at := strings.Index(text[cursor:], probe)
+		if at < 0 {
+			continue
+		}
+		cursor += at
+
+		// A chunk opening with a marker starts on that page
+		page := 0
+		for _, marker := range markers {
+			if marker[0] > cursor {
+				break
+			}
+			page, _ = strconv.Atoi(text[marker[2]:marker[3]])
+		}
+		if page > 0 {
+			if chunks[i].Metadata == nil {
+				chunks[i].Metadata = make(map[string]string)
+			}
+			chunks[i].Metadata[PageKey] = strconv.Itoa(page)
+		}
+	}
+}
+
+// collapseSpaces replaces every run of whitespace with a single space
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// ChunkPage returns the source PDF page a chunk starts on, if known
+func ChunkPage(doc VectorDocument) (int, bool) {
+	page, err := strconv.Atoi(doc.Metadata[PageKey])
+	if err != nil || page <= 0 {
+		return 0, false
+	}
+	return page, true
+}
+
+// FormatCitation renders a chunk's source for display: "Title, p.7 (Section 3.2)",
+// leaving out the page or section when the chunk doesn't have them
+func FormatCitation(doc VectorDocument) string {
+	citation := doc.Source
+	if page, ok := ChunkPage(doc); ok {
+		citation += fmt.Sprintf(", p.%d", page)
+	}
+	if doc.Section != "" {
+		citation += fmt.Sprintf(" (Section %s)", doc.Section)
+	}
+	return citation
+}
@@ -0,0 +1,54 @@
+package rag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignPages(t *testing.T) {
+	text := "Preface text before any marker.\n" +
+		"[Page 1]\nAbstract. We propose the Transformer.\n" +
+		"[Page 2]\nIntroduction. Recurrent models\nprocess tokens in order.\n" +
+		"[Page 3]\nModel. Attention maps queries to outputs."
+
+	chunks := []Chunk{
+		{Text: "Preface text before any marker."},
+		{Text: "[Page 1] Abstract. We propose the Transformer."},
+		{Text: "Recurrent models process tokens in order."},
+		{Text: "[Page 3] Model. Attention maps queries to outputs."},
+		{Text: "Text the model made up along the way."},
+	}
+
+	assignPages(text, chunks)
+
+	assert.NotContains(t, chunks[0].Metadata, PageKey)
+	assert.Equal(t, "1", chunks[1].Metadata[PageKey])
+	assert.Equal(t, "2", chunks[2].Metadata[PageKey])
+	assert.Equal(t, "3", chunks[3].Metadata[PageKey])
+	assert.NotContains(t, chunks[4].Metadata, PageKey)
+}
+
+func TestAssignPagesWithoutMarkers(t *testing.T) {
+	chunks := []Chunk{{Text: "Plain text.", Metadata: map[string]string{}}}
+
+	assignPages("Plain text.", chunks)
+
+	assert.Empty(t, chunks[0].Metadata)
+}
+
+func TestFormatCitation(t *testing.T) {
+	doc := VectorDocument{
+		Source:   "Attention Is All You Need",
+		Section:  "3.2",
+		Metadata: map[string]string{PageKey: "7"},
+	}
+	assert.Equal(t, "Attention Is All You Need, p.7 (Section 3.2)", FormatCitation(doc))
+
+	doc.Metadata = nil
+	assert.Equal(t, "Attention Is All You Need (Section 3.2)", FormatCitation(doc))
+
+	doc.Section = ""
+	doc.Metadata = map[string]string{PageKey: "n/a"}
+	assert.Equal(t, "Attention Is All You Need", FormatCitation(doc))
+}
@@ -0,0 +1,41 @@
+package rag
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// YearKey is the chunk metadata key holding the paper's publication year.
+// Chunks without it are not affected by the recency boost.
+const YearKey = "year"
+
+// boostRecent scales each result's score by (1 - weight) for every year its
+// paper is older than currentYear, so newer papers outrank older ones of
+// similar relevance. Scores are left alone for chunks with no year, or a year
+// in the future.
+func boostRecent(results []SearchResult, weight float64, currentYear int) []SearchResult {
+	if weight <= 0 {
+		return results
+	}
+
+	for i := range results {
+		year, ok := chunkYear(results[i].Document)
+		if !ok || year >= currentYear {
+			continue
+		}
+		decay := math.Pow(1-weight, float64(currentYear-year))
+		results[i].Score = float32(float64(results[i].Score) * decay)
+	}
+
+	return results
+}
+
+// chunkYear reads the publication year from a chunk's metadata
+func chunkYear(doc VectorDocument) (int, bool) {
+	year, err := strconv.Atoi(strings.TrimSpace(doc.Metadata[YearKey]))
+	if err != nil || year <= 0 {
+		return 0, false
+	}
+	return year, true
+}
@@ -0,0 +1,54 @@
+package rag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func datedResult(source, year string, score float32) SearchResult {
+	result := searchResult(source, "chunk from "+source, score)
+	if year != "" {
+		result.Document.Metadata = map[string]string{YearKey: year}
+	}
+	return result
+}
+
+func TestBoostRecentReordersEqualScores(t *testing.T) {
+	r := &Retriever{}
+	results := []SearchResult{
+		datedResult("old", "2015", 0.8),
+		datedResult("new", "2023", 0.8),
+	}
+
+	ranked := r.rankAndDeduplicate(boostRecent(results, 0.1, 2024))
+
+	assert.Equal(t, "new", ranked[0].Document.Source)
+	assert.Equal(t, "old", ranked[1].Document.Source)
+	assert.InDelta(t, 0.8*0.9, ranked[0].Score, 1e-6)
+}
+
+func TestBoostRecentDisabledKeepsRanking(t *testing.T) {
+	r := &Retriever{}
+	results := []SearchResult{
+		datedResult("old", "2015", 0.81),
+		datedResult("new", "2023", 0.8),
+	}
+
+	ranked := r.rankAndDeduplicate(boostRecent(results, 0, 2024))
+
+	assert.Equal(t, "old", ranked[0].Document.Source)
+	assert.Equal(t, float32(0.81), ranked[0].Score)
+}
+
+func TestBoostRecentIgnoresChunksWithoutYear(t *testing.T) {
+	results := boostRecent([]SearchResult{
+		datedResult("undated", "", 0.7),
+		datedResult("garbled", "n.d.", 0.6),
+		datedResult("future", "2030", 0.5),
+	}, 0.2, 2024)
+
+	assert.Equal(t, float32(0.7), results[0].Score)
+	assert.Equal(t, float32(0.6), results[1].Score)
+	assert.Equal(t, float32(0.5), results[2].Score)
+}
@@ -0,0 +1,120 @@
+package rag
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// rerankCandidateFactor is how many times TopK candidates are fetched from
+	// the vector store when reranking, so the reranker has something to reorder
+	rerankCandidateFactor = 3
+
+	// Okapi BM25 parameters
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// rerankLexicalWeight is the share of the final score taken by BM25; the
+	// rest comes from the original vector similarity
+	rerankLexicalWeight = 0.5
+)
+
+// rerankBM25 re-scores results against the query by blending their vector
+// similarity with a BM25 score computed over the candidate set, and returns
+// the best topK. Result scores keep their original similarity values.
+func rerankBM25(query string, results []SearchResult, topK int) []SearchResult {
+	queryTerms := tokenize(query)
+	if len(results) == 0 || len(queryTerms) == 0 {
+		return truncateResults(results, topK)
+	}
+
+	docs := make([][]string, len(results))
+	totalLength := 0
+	for i, result := range results {
+		docs[i] = tokenize(result.Document.ChunkText)
+		totalLength += len(docs[i])
+	}
+	avgLength := float64(totalLength) / float64(len(docs))
+	if avgLength == 0 {
+		return truncateResults(results, topK)
+	}
+
+	// Document frequency of each query term across the candidates
+	docFreq := make(map[string]int)
+	for _, doc := range docs {
+		seen := make(map[string]bool)
+		for _, term := range doc {
+			seen[term] = true
+		}
+		for _, term := range queryTerms {
+			if seen[term] {
+				docFreq[term]++
+			}
+		}
+	}
+
+	lexical := make([]float64, len(docs))
+	maxLexical := 0.0
+	for i, doc := range docs {
+		termFreq := make(map[string]int)
+		for _, term := range doc {
+			termFreq[term]++
+		}
+
+		for _, term := range queryTerms {
+			tf := float64(termFreq[term])
+			if tf == 0 {
+				continue
+			}
+			df := float64(docFreq[term])
+			idf := math.Log(1 + (float64(len(docs))-df+0.5)/(df+0.5))
+			lexical[i] += idf * tf * (bm25K1 + 1) /
+				(tf + bm25K1*(1-bm25B+bm25B*float64(len(doc))/avgLength))
+		}
+
+		if lexical[i] > maxLexical {
+			maxLexical = lexical[i]
+		}
+	}
+
+	combined := make([]float64, len(results))
+	for i, result := range results {
+		combined[i] = float64(result.Score)
+		if maxLexical > 0 {
+			combined[i] = (1-rerankLexicalWeight)*float64(result.Score) +
+				rerankLexicalWeight*lexical[i]/maxLexical
+		}
+	}
+
+	order := make([]int, len(results))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return combined[order[a]] > combined[order[b]]
+	})
+
+	reranked := make([]SearchResult, len(results))
+	for i, idx := range order {
+		reranked[i] = results[idx]
+	}
+
+	return truncateResults(reranked, topK)
+}
+
+// tokenize lowercases text and splits it into alphanumeric terms
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// truncateResults returns at most topK results (all of them if topK <= 0)
+func truncateResults(results []SearchResult, topK int) []SearchResult {
+	if topK > 0 && len(results) > topK {
+		return results[:topK]
+	}
+	return results
+}
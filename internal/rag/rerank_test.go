@@ -0,0 +1,56 @@
+package rag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func searchResult(source, text string, score float32) SearchResult {
+	return SearchResult{
+		Document: VectorDocument{Source: source, ChunkText: text},
+		Score:    score,
+	}
+}
+
+func TestRerankBM25PromotesLexicalMatch(t *testing.T) {
+	results := []SearchResult{
+		searchResult("a", "The transformer uses multi-head attention over the sequence.", 0.82),
+		searchResult("b", "Attention layers are stacked six times in the encoder.", 0.81),
+		searchResult("c", "We apply dropout regularization with rate 0.1 to every sub-layer.", 0.78),
+	}
+
+	reranked := rerankBM25("what dropout regularization is used?", results, 3)
+
+	assert.Len(t, reranked, 3)
+	assert.Equal(t, "c", reranked[0].Document.Source)
+	assert.Equal(t, float32(0.78), reranked[0].Score, "original similarity is kept")
+}
+
+func TestRerankBM25TruncatesToTopK(t *testing.T) {
+	results := []SearchResult{
+		searchResult("a", "positional encoding", 0.9),
+		searchResult("b", "label smoothing", 0.8),
+		searchResult("c", "beam search", 0.7),
+	}
+
+	reranked := rerankBM25("beam search width", results, 2)
+
+	assert.Len(t, reranked, 2)
+	assert.Equal(t, "c", reranked[0].Document.Source)
+}
+
+func TestRerankBM25KeepsOrderWithoutLexicalOverlap(t *testing.T) {
+	results := []SearchResult{
+		searchResult("a", "positional encoding", 0.9),
+		searchResult("b", "label smoothing", 0.8),
+	}
+
+	assert.Equal(t, results, rerankBM25("optimizer schedule", results, 5))
+	assert.Equal(t, results, rerankBM25("???", results, 5))
+	assert.Empty(t, rerankBM25("query", nil, 5))
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"bleu", "score", "of", "28", "4"}, tokenize("BLEU score of 28.4!"))
+}
@@ -1,10 +1,12 @@
 package rag
 
 import (
+	"archivist/internal/app"
 	"context"
 	"fmt"
 	"log"
 	"sort"
+	"time"
 )
 
 // RetrievalConfig holds configuration for retrieval
@@ -13,14 +15,24 @@ type RetrievalConfig struct {
 	MinScore          float32 // Minimum similarity score threshold
 	IncludeSections   []string // Specific sections to prioritize
 	MaxContextLength  int     // Maximum total context length in characters
+	Rerank            bool    // Re-score candidates with BM25 before taking TopK
+	RecencyWeight     float64 // Score decay per year of a paper's age; 0 leaves the ranking unchanged
 }
 
-// DefaultRetrievalConfig returns default retrieval settings
-func DefaultRetrievalConfig() RetrievalConfig {
+// DefaultRetrievalConfig returns retrieval settings using TopK,
+// ScoreThreshold, Rerank and RecencyWeight from the rag section of config.yaml
+func DefaultRetrievalConfig(cfg app.RAGConfig) RetrievalConfig {
+	topK := cfg.TopK
+	if topK <= 0 {
+		topK = 5
+	}
+
 	return RetrievalConfig{
-		TopK:             5,
-		MinScore:         0.3,
+		TopK:             topK,
+		MinScore:         float32(cfg.ScoreThreshold),
 		MaxContextLength: 8000,
+		Rerank:           cfg.Rerank,
+		RecencyWeight:    cfg.RecencyWeight,
 	}
 }
 
@@ -62,9 +74,13 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, filter map[strin
 		return nil, fmt.Errorf("failed to generate query embedding: %w", err)
 	}
 
-	// Perform vector search
-	log.Printf("  📚 Searching vector store (top %d results)...", r.config.TopK)
-	results, err := r.vectorStore.Search(ctx, queryEmbedding, r.config.TopK, filter)
+	// Perform vector search, over-fetching candidates for the reranker
+	fetchK := r.config.TopK
+	if r.config.Rerank {
+		fetchK *= rerankCandidateFactor
+	}
+	log.Printf("  📚 Searching vector store (top %d results)...", fetchK)
+	results, err := r.vectorStore.Search(ctx, queryEmbedding, fetchK, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search vector store: %w", err)
 	}
@@ -76,9 +92,18 @@ func (r *Retriever) Retrieve(ctx context.Context, query string, filter map[strin
 	// Filter by minimum score if needed
 	filteredResults := r.filterByScore(results)
 
+	// Favor newer papers before ranking
+	if r.config.RecencyWeight > 0 {
+		filteredResults = boostRecent(filteredResults, r.config.RecencyWeight, time.Now().Year())
+	}
+
 	// Deduplicate and rank
 	rankedResults := r.rankAndDeduplicate(filteredResults)
 
+	if r.config.Rerank {
+		rankedResults = rerankBM25(query, rankedResults, r.config.TopK)
+	}
+
 	// Build context
 	context := r.buildContext(rankedResults)
 
@@ -116,13 +141,18 @@ func (r *Retriever) RetrieveMultiPaper(ctx context.Context, query string, paperT
 		return nil, fmt.Errorf("no chunks retrieved from %d papers", len(paperTitles))
 	}
 
-	// Sort by score and take top K
-	sort.Slice(allResults, func(i, j int) bool {
-		return allResults[i].Score > allResults[j].Score
-	})
-
-	if len(allResults) > r.config.TopK {
-		allResults = allResults[:r.config.TopK]
+	if r.config.Rerank {
+		// Rerank across papers so the merged order matches the single-paper order
+		allResults = rerankBM25(query, allResults, r.config.TopK)
+	} else {
+		// Sort by score and take top K
+		sort.Slice(allResults, func(i, j int) bool {
+			return allResults[i].Score > allResults[j].Score
+		})
+
+		if len(allResults) > r.config.TopK {
+			allResults = allResults[:r.config.TopK]
+		}
 	}
 
 	// Build context
@@ -226,6 +256,9 @@ func (r *Retriever) buildContext(results []SearchResult) *RetrievedContext {
 
 		// Add to context text with citation
 		chunkHeader := fmt.Sprintf("\n[Source: %s", doc.Source)
+		if page, ok := ChunkPage(doc); ok {
+			chunkHeader += fmt.Sprintf(", Page %d", page)
+		}
 		if doc.Section != "" {
 			chunkHeader += fmt.Sprintf(", Section: %s", doc.Section)
 		}
@@ -253,6 +286,10 @@ func (r *Retriever) buildContext(results []SearchResult) *RetrievedContext {
 func (r *Retriever) generateCitation(doc VectorDocument) string {
 	citation := fmt.Sprintf("Source: %s", doc.Source)
 
+	if page, ok := ChunkPage(doc); ok {
+		citation += fmt.Sprintf(", Page %d", page)
+	}
+
 	if doc.Section != "" {
 		citation += fmt.Sprintf(", Section: %s", doc.Section)
 	}
@@ -41,14 +41,17 @@ type VectorStore struct {
 	client     *redis.Client
 	indexName  string
 	keyPrefix  string
+	dim        int
 }
 
-// NewVectorStore creates a new vector store
-func NewVectorStore(client *redis.Client) (*VectorStore, error) {
+// NewVectorStore creates a vector store for embeddings of dim dimensions.
+// An existing index created for another dimension fails with ErrDimensionMismatch.
+func NewVectorStore(client *redis.Client, dim int) (*VectorStore, error) {
 	vs := &VectorStore{
 		client:    client,
 		indexName: VectorIndexName,
 		keyPrefix: VectorKeyPrefix,
+		dim:       dim,
 	}
 
 	// Create vector index if it doesn't exist
@@ -68,6 +71,14 @@ func (vs *VectorStore) createIndexIfNotExists(ctx context.Context) error {
 	}
 
 	if exists {
+		indexDim, err := vs.indexDimension(ctx)
+		if err != nil {
+			return err
+		}
+		if indexDim != 0 && indexDim != vs.dim {
+			return fmt.Errorf("%w: vector index '%s' was created for %d-dimensional embeddings but rag.embedding_dim is %d",
+				ErrDimensionMismatch, vs.indexName, indexDim, vs.dim)
+		}
 		log.Printf("✓ Vector index '%s' already exists", vs.indexName)
 		return nil
 	}
@@ -85,7 +96,7 @@ func (vs *VectorStore) createIndexIfNotExists(ctx context.Context) error {
 		"$.chunk_index", "AS", "chunk_index", "NUMERIC",
 		"$.embedding", "AS", "embedding", "VECTOR", "FLAT", "6",
 		"TYPE", "FLOAT32",
-		"DIM", fmt.Sprintf("%d", EmbeddingDimensions),
+		"DIM", fmt.Sprintf("%d", vs.dim),
 		"DISTANCE_METRIC", "COSINE",
 	}
 
@@ -98,6 +109,59 @@ func (vs *VectorStore) createIndexIfNotExists(ctx context.Context) error {
 	return nil
 }
 
+// indexDimension reads the vector dimension of the existing index from
+// FT.INFO, returning 0 when the reply doesn't mention one
+func (vs *VectorStore) indexDimension(ctx context.Context) (int, error) {
+	result := vs.client.Do(ctx, "FT.INFO", vs.indexName)
+	if result.Err() != nil {
+		return 0, fmt.Errorf("failed to inspect vector index: %w", result.Err())
+	}
+	return findDimension(result.Val()), nil
+}
+
+// findDimension walks an FT.INFO reply looking for the "dim" attribute,
+// which is nested differently across RediSearch versions
+func findDimension(reply interface{}) int {
+	switch v := reply.(type) {
+	case []interface{}:
+		for i, item := range v {
+			if key, ok := item.(string); ok && strings.EqualFold(key, "dim") && i+1 < len(v) {
+				if dim := toInt(v[i+1]); dim > 0 {
+					return dim
+				}
+			}
+			if dim := findDimension(item); dim > 0 {
+				return dim
+			}
+		}
+	case map[interface{}]interface{}:
+		for key, item := range v {
+			if k, ok := key.(string); ok && strings.EqualFold(k, "dim") {
+				if dim := toInt(item); dim > 0 {
+					return dim
+				}
+			}
+			if dim := findDimension(item); dim > 0 {
+				return dim
+			}
+		}
+	}
+	return 0
+}
+
+// toInt converts a RESP integer or numeric string to an int
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case int64:
+		return int(n)
+	case string:
+		var dim int
+		fmt.Sscanf(n, "%d", &dim)
+		return dim
+	}
+	return 0
+}
+
 // indexExists checks if the search index exists
 func (vs *VectorStore) indexExists(ctx context.Context) (bool, error) {
 	result := vs.client.Do(ctx, "FT._LIST")
@@ -125,9 +189,9 @@ func (vs *VectorStore) AddDocument(ctx context.Context, doc VectorDocument) erro
 		return fmt.Errorf("document ID is required")
 	}
 
-	if len(doc.Embedding) != EmbeddingDimensions {
+	if len(doc.Embedding) != vs.dim {
 		return fmt.Errorf("embedding dimension mismatch: expected %d, got %d",
-			EmbeddingDimensions, len(doc.Embedding))
+			vs.dim, len(doc.Embedding))
 	}
 
 	key := vs.keyPrefix + doc.ID
@@ -181,9 +245,9 @@ func (vs *VectorStore) AddDocuments(ctx context.Context, docs []VectorDocument)
 
 // Search performs vector similarity search
 func (vs *VectorStore) Search(ctx context.Context, queryEmbedding []float32, topK int, filter map[string]string) ([]SearchResult, error) {
-	if len(queryEmbedding) != EmbeddingDimensions {
+	if len(queryEmbedding) != vs.dim {
 		return nil, fmt.Errorf("query embedding dimension mismatch: expected %d, got %d",
-			EmbeddingDimensions, len(queryEmbedding))
+			vs.dim, len(queryEmbedding))
 	}
 
 	if topK <= 0 {
@@ -6,19 +6,39 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 )
 
+// DefaultBaseURL is where the search service listens by default
+const DefaultBaseURL = "http://localhost:8000"
+
+// DefaultCacheTTL is how long search results are reused for an identical query
+const DefaultCacheTTL = 10 * time.Minute
+
 // Client is a Go client for the Python search microservice
 type Client struct {
 	baseURL string
 	client  *http.Client
+
+	cacheMu  sync.Mutex
+	cache    map[string]cachedSearch
+	cacheTTL time.Duration
+	now      func() time.Time
+}
+
+// cachedSearch is a search response and when it stops being reused
+type cachedSearch struct {
+	response  SearchResponse
+	expiresAt time.Time
 }
 
 // NewClient creates a new search client
 func NewClient(baseURL string) *Client {
 	if baseURL == "" {
-		baseURL = "http://localhost:8000"
+		baseURL = DefaultBaseURL
 	}
 
 	return &Client{
@@ -26,9 +46,37 @@ func NewClient(baseURL string) *Client {
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		cache:    make(map[string]cachedSearch),
+		cacheTTL: DefaultCacheTTL,
+		now:      time.Now,
+	}
+}
+
+// BaseURL returns the search service URL the client talks to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// SetCacheTTL changes how long search results are reused. A TTL <= 0
+// disables the cache.
+func (c *Client) SetCacheTTL(ttl time.Duration) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	c.cacheTTL = ttl
+	if ttl <= 0 {
+		c.cache = make(map[string]cachedSearch)
 	}
 }
 
+// ClearCache drops all cached search results
+func (c *Client) ClearCache() {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	c.cache = make(map[string]cachedSearch)
+}
+
 // SearchQuery represents a search request
 type SearchQuery struct {
 	Query      string     `json:"query"`
@@ -49,6 +97,7 @@ type SearchResult struct {
 	Source          string    `json:"source"`
 	Venue           string    `json:"venue"`
 	ID              string    `json:"id"`
+	DOI             string    `json:"doi,omitempty"`
 	Categories      []string  `json:"categories"`
 	RelevanceScore  *float64  `json:"relevance_score,omitempty"`
 	FuzzyScore      *float64  `json:"fuzzy_score,omitempty"`
@@ -84,8 +133,18 @@ type HealthResponse struct {
 	Providers []string `json:"providers"`
 }
 
-// Search performs a search across all or specified sources
+// Search performs a search across all or specified sources. Results for an
+// identical query are served from the cache until the cache TTL expires.
 func (c *Client) Search(query *SearchQuery) (*SearchResponse, error) {
+	return c.search(query, true)
+}
+
+// SearchFresh performs a search without reading the cache, then caches the result
+func (c *Client) SearchFresh(query *SearchQuery) (*SearchResponse, error) {
+	return c.search(query, false)
+}
+
+func (c *Client) search(query *SearchQuery, useCache bool) (*SearchResponse, error) {
 	if query.Query == "" {
 		return nil, fmt.Errorf("search query cannot be empty")
 	}
@@ -94,6 +153,82 @@ func (c *Client) Search(query *SearchQuery) (*SearchResponse, error) {
 		query.MaxResults = 20
 	}
 
+	key := cacheKey(query)
+	if useCache {
+		if cached, ok := c.cachedResponse(key); ok {
+			return cached, nil
+		}
+	}
+
+	result, err := c.doSearch(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// The same paper often comes back from several sources
+	deduped := DedupeResults(result.Results)
+	result.Total -= len(result.Results) - len(deduped)
+	if result.Total < len(deduped) {
+		result.Total = len(deduped)
+	}
+	result.Results = deduped
+
+	c.storeResponse(key, result)
+	return result, nil
+}
+
+// cacheKey identifies a query regardless of case, spacing, and source order
+func cacheKey(query *SearchQuery) string {
+	sources := append([]string(nil), query.Sources...)
+	sort.Strings(sources)
+
+	key := fmt.Sprintf("%s|%d|%s",
+		strings.Join(strings.Fields(strings.ToLower(query.Query)), " "),
+		query.MaxResults,
+		strings.Join(sources, ","))
+	if query.StartDate != nil {
+		key += "|from=" + query.StartDate.Format(time.RFC3339)
+	}
+	if query.EndDate != nil {
+		key += "|to=" + query.EndDate.Format(time.RFC3339)
+	}
+	return key
+}
+
+// cachedResponse returns a copy of an unexpired cached response
+func (c *Client) cachedResponse(key string) (*SearchResponse, bool) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	entry, ok := c.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.cache, key)
+		return nil, false
+	}
+
+	response := entry.response
+	return &response, true
+}
+
+// storeResponse caches a copy of response, unless caching is disabled
+func (c *Client) storeResponse(key string, response *SearchResponse) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+
+	if c.cacheTTL <= 0 {
+		return
+	}
+	c.cache[key] = cachedSearch{
+		response:  *response,
+		expiresAt: c.now().Add(c.cacheTTL),
+	}
+}
+
+// doSearch sends the search request to the service
+func (c *Client) doSearch(query *SearchQuery) (*SearchResponse, error) {
 	// Marshal request
 	body, err := json.Marshal(query)
 	if err != nil {
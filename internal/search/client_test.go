@@ -0,0 +1,98 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCountingServer serves search responses and counts the requests it gets
+func newCountingServer(t *testing.T) (*httptest.Server, *int32) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		var query SearchQuery
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+		json.NewEncoder(w).Encode(SearchResponse{
+			Query:   query.Query,
+			Total:   1,
+			Results: []SearchResult{{Title: "Attention Is All You Need"}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestSearchCachesIdenticalQueries(t *testing.T) {
+	server, requests := newCountingServer(t)
+	client := NewClient(server.URL)
+
+	first, err := client.Search(&SearchQuery{Query: "Transformer  Attention", MaxResults: 20})
+	require.NoError(t, err)
+	second, err := client.Search(&SearchQuery{Query: "transformer attention", MaxResults: 20})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+	assert.Equal(t, first, second)
+
+	// A different result count is a different query
+	_, err = client.Search(&SearchQuery{Query: "transformer attention", MaxResults: 50})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+}
+
+func TestSearchCacheExpires(t *testing.T) {
+	server, requests := newCountingServer(t)
+	client := NewClient(server.URL)
+	now := time.Now()
+	client.now = func() time.Time { return now }
+
+	_, err := client.Search(&SearchQuery{Query: "diffusion"})
+	require.NoError(t, err)
+
+	now = now.Add(DefaultCacheTTL - time.Second)
+	_, err = client.Search(&SearchQuery{Query: "diffusion"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+
+	now = now.Add(time.Second)
+	_, err = client.Search(&SearchQuery{Query: "diffusion"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+}
+
+func TestSearchCacheBypassAndClear(t *testing.T) {
+	server, requests := newCountingServer(t)
+	client := NewClient(server.URL)
+	query := &SearchQuery{Query: "graph neural networks"}
+
+	_, err := client.Search(query)
+	require.NoError(t, err)
+	_, err = client.SearchFresh(query)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(requests))
+
+	client.ClearCache()
+	_, err = client.Search(query)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestSearchCacheDisabled(t *testing.T) {
+	server, requests := newCountingServer(t)
+	client := NewClient(server.URL)
+	client.SetCacheTTL(0)
+
+	for i := 0; i < 3; i++ {
+		_, err := client.Search(&SearchQuery{Query: "reinforcement learning"})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
@@ -0,0 +1,122 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DedupeResults collapses results that are the same paper found through
+// several sources, matched by DOI or normalized title. The first (highest
+// ranked) copy keeps its position; the copy with a direct PDF URL supplies the
+// links, and the sources and venues of all copies are merged.
+func DedupeResults(results []SearchResult) []SearchResult {
+	deduped := make([]SearchResult, 0, len(results))
+	byKey := make(map[string]int)
+
+	for _, result := range results {
+		keys := dedupeKeys(result)
+
+		existing := -1
+		for _, key := range keys {
+			if i, ok := byKey[key]; ok {
+				existing = i
+				break
+			}
+		}
+
+		if existing < 0 {
+			existing = len(deduped)
+			deduped = append(deduped, result)
+		} else {
+			deduped[existing] = mergeResults(deduped[existing], result)
+		}
+
+		for _, key := range append(keys, dedupeKeys(deduped[existing])...) {
+			byKey[key] = existing
+		}
+	}
+
+	return deduped
+}
+
+// dedupeKeys returns the identities a result can be matched on
+func dedupeKeys(result SearchResult) []string {
+	var keys []string
+	if doi := normalizeDOI(result.DOI); doi != "" {
+		keys = append(keys, "doi:"+doi)
+	}
+	if title := normalizeTitle(result.Title); title != "" {
+		keys = append(keys, "title:"+title)
+	}
+	return keys
+}
+
+// normalizeDOI lowercases a DOI and strips any resolver prefix
+func normalizeDOI(doi string) string {
+	doi = strings.ToLower(strings.TrimSpace(doi))
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi:"} {
+		doi = strings.TrimPrefix(doi, prefix)
+	}
+	return doi
+}
+
+// normalizeTitle lowercases a title and keeps only letters and digits, so
+// punctuation, case and spacing differences between sources don't matter
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			space = false
+		} else {
+			space = true
+		}
+	}
+	return b.String()
+}
+
+// mergeResults combines two copies of the same paper, keeping kept's rank
+func mergeResults(kept, dup SearchResult) SearchResult {
+	merged := kept
+	if kept.PDFURL == "" && dup.PDFURL != "" {
+		merged = dup
+		merged.RelevanceScore = kept.RelevanceScore
+		merged.FuzzyScore = kept.FuzzyScore
+		merged.SimilarityScore = kept.SimilarityScore
+	}
+
+	merged.Source = mergeLabels(kept.Source, dup.Source)
+	merged.Venue = mergeLabels(kept.Venue, dup.Venue)
+	merged.DOI = firstNonEmpty(merged.DOI, kept.DOI, dup.DOI)
+	merged.Abstract = firstNonEmpty(merged.Abstract, kept.Abstract, dup.Abstract)
+	return merged
+}
+
+// firstNonEmpty returns the first non-empty value
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// mergeLabels joins comma-separated labels such as sources, without repeats
+func mergeLabels(a, b string) string {
+	var labels []string
+	seen := make(map[string]bool)
+	for _, label := range strings.Split(a+","+b, ",") {
+		label = strings.TrimSpace(label)
+		if label == "" || seen[strings.ToLower(label)] {
+			continue
+		}
+		seen[strings.ToLower(label)] = true
+		labels = append(labels, label)
+	}
+	return strings.Join(labels, ", ")
+}
@@ -0,0 +1,73 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDedupeResultsByTitle(t *testing.T) {
+	results := []SearchResult{
+		{Title: "Attention Is All You Need", Source: "semantic_scholar", Venue: "NeurIPS", SourceURL: "https://s2/1"},
+		{Title: "Deep Residual Learning", Source: "arxiv", PDFURL: "https://arxiv.org/pdf/1512.03385"},
+		{Title: "attention is all you need.", Source: "arxiv", Venue: "arXiv", PDFURL: "https://arxiv.org/pdf/1706.03762"},
+	}
+
+	deduped := DedupeResults(results)
+
+	require.Len(t, deduped, 2)
+	// The first copy keeps its position but takes the PDF link from the arXiv copy
+	assert.Equal(t, "https://arxiv.org/pdf/1706.03762", deduped[0].PDFURL)
+	assert.Equal(t, "semantic_scholar, arxiv", deduped[0].Source)
+	assert.Equal(t, "NeurIPS, arXiv", deduped[0].Venue)
+	assert.Equal(t, "Deep Residual Learning", deduped[1].Title)
+}
+
+func TestDedupeResultsByDOI(t *testing.T) {
+	results := []SearchResult{
+		{Title: "BERT: Pre-training of Deep Bidirectional Transformers", DOI: "10.18653/v1/N19-1423", Source: "acl", PDFURL: "https://aclanthology.org/N19-1423.pdf"},
+		{Title: "BERT (NAACL version)", DOI: "https://doi.org/10.18653/V1/N19-1423", Source: "openreview"},
+	}
+
+	deduped := DedupeResults(results)
+
+	require.Len(t, deduped, 1)
+	assert.Equal(t, "https://aclanthology.org/N19-1423.pdf", deduped[0].PDFURL)
+	assert.Equal(t, "acl, openreview", deduped[0].Source)
+}
+
+func TestDedupeResultsKeepsDistinctPapers(t *testing.T) {
+	results := []SearchResult{
+		{Title: "GPT-2", Source: "arxiv"},
+		{Title: "GPT-3", Source: "arxiv"},
+		{Title: "", ID: "untitled-1"},
+		{Title: "", ID: "untitled-2"},
+	}
+
+	assert.Len(t, DedupeResults(results), 4)
+}
+
+func TestSearchDedupesResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(SearchResponse{
+			Total: 3,
+			Results: []SearchResult{
+				{Title: "Vision Transformer", Source: "arxiv", PDFURL: "https://arxiv.org/pdf/2010.11929"},
+				{Title: "Vision  Transformer", Source: "openreview"},
+				{Title: "Swin Transformer", Source: "arxiv"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	response, err := NewClient(server.URL).Search(&SearchQuery{Query: "vision transformer"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, response.Total)
+	assert.Len(t, response.Results, 2)
+	assert.Equal(t, "arxiv, openreview", response.Results[0].Source)
+}
@@ -0,0 +1,282 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DownloadEventKind says what happened to a file in DownloadResults
+type DownloadEventKind string
+
+const (
+	DownloadStarted   DownloadEventKind = "started"
+	DownloadProgress  DownloadEventKind = "progress"
+	DownloadRetrying  DownloadEventKind = "retrying"
+	DownloadCompleted DownloadEventKind = "completed"
+	DownloadSkipped   DownloadEventKind = "skipped" // A file with the same name is already in destDir
+	DownloadFailed    DownloadEventKind = "failed"
+)
+
+// DownloadEvent reports the progress of one search result's PDF
+type DownloadEvent struct {
+	Index      int // Position of the result in the slice passed to DownloadResults
+	Result     SearchResult
+	Kind       DownloadEventKind
+	Path       string // Final path in destDir
+	BytesDone  int64
+	BytesTotal int64 // -1 when the server doesn't send a length
+	Attempt    int
+	Err        error // Set for DownloadRetrying and DownloadFailed
+}
+
+// Download tuning; variables so tests can shorten the waits
+var (
+	maxDownloadAttempts   = 3
+	downloadRetryDelay    = 2 * time.Second
+	downloadProgressBytes = int64(256 * 1024) // Emit a progress event every this many bytes
+	downloadHTTPClient    = &http.Client{Timeout: 5 * time.Minute}
+)
+
+// DefaultDownloadConcurrency is used when DownloadResults is given concurrency < 1
+const DefaultDownloadConcurrency = 4
+
+// pdfMagic is how every PDF file starts
+var pdfMagic = []byte("%PDF-")
+
+// errNotPDF marks a download that succeeded but returned something else
+var errNotPDF = errors.New("downloaded file is not a PDF")
+
+// DownloadResults fetches the PDFs of results into destDir with at most
+// concurrency downloads at a time. Transient failures (network errors, 429
+// and 5xx) are retried; a response that isn't a PDF fails without being saved.
+// The returned channel is closed once every result has completed, been
+// skipped, or failed, and must be drained by the caller.
+func DownloadResults(ctx context.Context, results []SearchResult, destDir string, concurrency int) <-chan DownloadEvent {
+	if concurrency < 1 {
+		concurrency = DefaultDownloadConcurrency
+	}
+
+	events := make(chan DownloadEvent, concurrency*2)
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				d := &downloader{ctx: ctx, events: events, index: i, result: results[i]}
+				d.run(destDir)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(events)
+		defer wg.Wait()
+		defer close(jobs)
+
+		for i := range results {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// DownloadFilename is the file name a result is saved under
+func DownloadFilename(result SearchResult) string {
+	name := sanitizeFilename(result.Title)
+	if name == "" {
+		name = sanitizeFilename(result.ID)
+	}
+	if name == "" {
+		name = "paper"
+	}
+	return name + ".pdf"
+}
+
+// sanitizeFilename replaces characters that are invalid in file names
+func sanitizeFilename(name string) string {
+	invalid := []string{"/", "\\", ":", "*", "?", "\"", "<", ">", "|", "\n", "\r", "\t"}
+	for _, char := range invalid {
+		name = strings.ReplaceAll(name, char, "_")
+	}
+	name = strings.TrimSpace(name)
+	name = strings.Trim(name, ".")
+
+	if len(name) > 200 {
+		name = name[:200]
+	}
+	return name
+}
+
+// downloader fetches a single result and reports on it
+type downloader struct {
+	ctx    context.Context
+	events chan<- DownloadEvent
+	index  int
+	result SearchResult
+}
+
+// emit sends an event unless the download was cancelled
+func (d *downloader) emit(event DownloadEvent) {
+	event.Index = d.index
+	event.Result = d.result
+	select {
+	case d.events <- event:
+	case <-d.ctx.Done():
+	}
+}
+
+// run downloads the result into destDir, retrying transient failures
+func (d *downloader) run(destDir string) {
+	path := filepath.Join(destDir, DownloadFilename(d.result))
+
+	if d.result.PDFURL == "" {
+		d.emit(DownloadEvent{Kind: DownloadFailed, Path: path, Err: fmt.Errorf("result has no PDF URL")})
+		return
+	}
+	if _, err := os.Stat(path); err == nil {
+		d.emit(DownloadEvent{Kind: DownloadSkipped, Path: path})
+		return
+	}
+
+	var err error
+attempts:
+	for attempt := 1; attempt <= maxDownloadAttempts; attempt++ {
+		d.emit(DownloadEvent{Kind: DownloadStarted, Path: path, Attempt: attempt})
+
+		var size int64
+		size, err = d.fetch(path, attempt)
+		if err == nil {
+			d.emit(DownloadEvent{Kind: DownloadCompleted, Path: path, BytesDone: size, BytesTotal: size, Attempt: attempt})
+			return
+		}
+		if !isTransientDownloadError(err) || attempt == maxDownloadAttempts {
+			break
+		}
+
+		d.emit(DownloadEvent{Kind: DownloadRetrying, Path: path, Attempt: attempt, Err: err})
+		select {
+		case <-time.After(downloadRetryDelay * time.Duration(attempt)):
+		case <-d.ctx.Done():
+			err = d.ctx.Err()
+			break attempts
+		}
+	}
+
+	d.emit(DownloadEvent{Kind: DownloadFailed, Path: path, Err: err})
+}
+
+// downloadStatusError is a non-200 response from the PDF host
+type downloadStatusError struct {
+	code int
+}
+
+func (e *downloadStatusError) Error() string {
+	return fmt.Sprintf("download failed with status %d", e.code)
+}
+
+// isTransientDownloadError reports whether retrying a download may succeed
+func isTransientDownloadError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *downloadStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code == http.StatusTooManyRequests || statusErr.code >= http.StatusInternalServerError
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// fetch downloads the PDF to a temporary file next to path and renames it
+// into place once it is complete and looks like a PDF
+func (d *downloader) fetch(path string, attempt int) (int64, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodGet, d.result.PDFURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := downloadHTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, &downloadStatusError{code: resp.StatusCode}
+	}
+
+	// Check the magic bytes before writing anything
+	header := make([]byte, len(pdfMagic))
+	n, err := io.ReadFull(resp.Body, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return 0, err
+	}
+	if !bytes.Equal(header[:n], pdfMagic) {
+		return 0, fmt.Errorf("%w (content type %q)", errNotPDF, resp.Header.Get("Content-Type"))
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".download-*.part")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	progress := &progressWriter{d: d, path: path, total: resp.ContentLength, attempt: attempt}
+	written, err := io.Copy(io.MultiWriter(tmp, progress), io.MultiReader(bytes.NewReader(header), resp.Body))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	return written, nil
+}
+
+// progressWriter emits a progress event every downloadProgressBytes
+type progressWriter struct {
+	d        *downloader
+	path     string
+	total    int64
+	attempt  int
+	done     int64
+	reported int64
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	pw.done += int64(len(p))
+	if pw.done-pw.reported >= downloadProgressBytes {
+		pw.reported = pw.done
+		pw.d.emit(DownloadEvent{
+			Kind:       DownloadProgress,
+			Path:       pw.path,
+			BytesDone:  pw.done,
+			BytesTotal: pw.total,
+			Attempt:    pw.attempt,
+		})
+	}
+	return len(p), nil
+}
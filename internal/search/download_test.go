@@ -0,0 +1,178 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collectDownloads drains DownloadResults and returns the final event per result
+func collectDownloads(t *testing.T, results []SearchResult, destDir string, concurrency int) (map[int]DownloadEvent, []DownloadEvent) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	final := make(map[int]DownloadEvent)
+	var all []DownloadEvent
+	for event := range DownloadResults(ctx, results, destDir, concurrency) {
+		all = append(all, event)
+		switch event.Kind {
+		case DownloadCompleted, DownloadSkipped, DownloadFailed:
+			final[event.Index] = event
+		}
+	}
+	return final, all
+}
+
+func shortenDownloadRetries(t *testing.T) {
+	delay := downloadRetryDelay
+	downloadRetryDelay = time.Millisecond
+	t.Cleanup(func() { downloadRetryDelay = delay })
+}
+
+func TestDownloadResultsSavesPDFsConcurrently(t *testing.T) {
+	var inFlight, maxInFlight int32
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		mu.Lock()
+		if n > maxInFlight {
+			maxInFlight = n
+		}
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+
+		w.Write([]byte("%PDF-1.7 " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	var results []SearchResult
+	for _, title := range []string{"Paper A", "Paper B", "Paper C", "Paper D"} {
+		results = append(results, SearchResult{Title: title, PDFURL: server.URL + "/" + title})
+	}
+	dir := t.TempDir()
+
+	final, _ := collectDownloads(t, results, dir, 2)
+
+	require.Len(t, final, 4)
+	for i, result := range results {
+		assert.Equal(t, DownloadCompleted, final[i].Kind, result.Title)
+		data, err := os.ReadFile(filepath.Join(dir, result.Title+".pdf"))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	}
+	assert.LessOrEqual(t, maxInFlight, int32(2))
+
+	leftovers, err := filepath.Glob(filepath.Join(dir, ".download-*"))
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+}
+
+func TestDownloadResultsRejectsNonPDF(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html>Please log in</html>"))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	final, _ := collectDownloads(t, []SearchResult{{Title: "Paywalled", PDFURL: server.URL}}, dir, 1)
+
+	assert.Equal(t, DownloadFailed, final[0].Kind)
+	assert.ErrorIs(t, final[0].Err, errNotPDF)
+	assert.NoFileExists(t, filepath.Join(dir, "Paywalled.pdf"))
+}
+
+func TestDownloadResultsRetriesTransientFailures(t *testing.T) {
+	shortenDownloadRetries(t)
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("%PDF-1.4"))
+	}))
+	defer server.Close()
+
+	final, all := collectDownloads(t, []SearchResult{{Title: "Flaky", PDFURL: server.URL}}, t.TempDir(), 1)
+
+	assert.Equal(t, DownloadCompleted, final[0].Kind)
+	assert.Equal(t, 2, final[0].Attempt)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	var kinds []DownloadEventKind
+	for _, event := range all {
+		kinds = append(kinds, event.Kind)
+	}
+	assert.Contains(t, kinds, DownloadRetrying)
+}
+
+func TestDownloadResultsDoesNotRetryNotFound(t *testing.T) {
+	shortenDownloadRetries(t)
+
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	final, _ := collectDownloads(t, []SearchResult{{Title: "Gone", PDFURL: server.URL}}, t.TempDir(), 1)
+
+	assert.Equal(t, DownloadFailed, final[0].Kind)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestDownloadResultsReportsProgress(t *testing.T) {
+	step := downloadProgressBytes
+	downloadProgressBytes = 1024
+	t.Cleanup(func() { downloadProgressBytes = step })
+
+	body := append([]byte("%PDF-"), make([]byte, 10*1024)...)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	_, all := collectDownloads(t, []SearchResult{{Title: "Big", PDFURL: server.URL}}, t.TempDir(), 1)
+
+	progress := 0
+	for _, event := range all {
+		if event.Kind == DownloadProgress {
+			progress++
+			assert.Equal(t, int64(len(body)), event.BytesTotal)
+		}
+	}
+	assert.Positive(t, progress)
+}
+
+func TestDownloadResultsSkipsExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Existing.pdf"), []byte("%PDF-"), 0644))
+
+	final, _ := collectDownloads(t, []SearchResult{{Title: "Existing", PDFURL: "http://127.0.0.1:0/never"}}, dir, 1)
+
+	assert.Equal(t, DownloadSkipped, final[0].Kind)
+}
+
+func TestDownloadFilename(t *testing.T) {
+	assert.Equal(t, "Attention_ Is All You Need.pdf", DownloadFilename(SearchResult{Title: "Attention: Is All You Need"}))
+	assert.Equal(t, "2106.01234.pdf", DownloadFilename(SearchResult{ID: "2106.01234"}))
+	assert.Equal(t, "paper.pdf", DownloadFilename(SearchResult{}))
+}
@@ -0,0 +1,82 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Embedder turns text into a vector; *rag.EmbeddingClient implements it
+type Embedder interface {
+	GenerateEmbedding(ctx context.Context, text string) ([]float32, error)
+}
+
+// LocalIndex is the local vector index searched by FindSimilarLocal;
+// *rag.FAISSVectorStore implements it
+type LocalIndex interface {
+	// PaperEssence returns the text that best summarizes an indexed paper
+	PaperEssence(paperTitle string) (string, error)
+	// ScorePapers returns each indexed paper's best similarity to embedding
+	ScorePapers(ctx context.Context, embedding []float32) (map[string]float32, error)
+}
+
+// LocalMatch is a paper from the local library similar to another one
+type LocalMatch struct {
+	Title string  `json:"title"`
+	Score float32 `json:"score"` // Cosine similarity in [-1, 1]
+}
+
+// LocalFinder finds similar papers among those already in the library,
+// without calling the search service
+type LocalFinder struct {
+	embedder Embedder
+	index    LocalIndex
+}
+
+// NewLocalFinder creates a finder over the papers in index
+func NewLocalFinder(embedder Embedder, index LocalIndex) *LocalFinder {
+	return &LocalFinder{embedder: embedder, index: index}
+}
+
+// FindSimilarLocal embeds the essence of paperTitle and returns the topK
+// other indexed papers closest to it, most similar first
+func (f *LocalFinder) FindSimilarLocal(ctx context.Context, paperTitle string, topK int) ([]LocalMatch, error) {
+	if topK <= 0 {
+		topK = 10
+	}
+
+	essence, err := f.index.PaperEssence(paperTitle)
+	if err != nil {
+		return nil, err
+	}
+
+	embedding, err := f.embedder.GenerateEmbedding(ctx, essence)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed paper essence: %w", err)
+	}
+
+	scores, err := f.index.ScorePapers(ctx, embedding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search the vector index: %w", err)
+	}
+
+	matches := make([]LocalMatch, 0, len(scores))
+	for title, score := range scores {
+		if title == paperTitle {
+			continue
+		}
+		matches = append(matches, LocalMatch{Title: title, Score: score})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Title < matches[j].Title
+	})
+
+	if len(matches) > topK {
+		matches = matches[:topK]
+	}
+	return matches, nil
+}
@@ -0,0 +1,59 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEmbedder struct{ text string }
+
+func (e *fakeEmbedder) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	e.text = text
+	return []float32{1, 0}, nil
+}
+
+type fakeLocalIndex struct {
+	essences map[string]string
+	scores   map[string]float32
+}
+
+func (i *fakeLocalIndex) PaperEssence(paperTitle string) (string, error) {
+	essence, ok := i.essences[paperTitle]
+	if !ok {
+		return "", errors.New("not indexed")
+	}
+	return essence, nil
+}
+
+func (i *fakeLocalIndex) ScorePapers(ctx context.Context, embedding []float32) (map[string]float32, error) {
+	return i.scores, nil
+}
+
+func TestFindSimilarLocal(t *testing.T) {
+	embedder := &fakeEmbedder{}
+	index := &fakeLocalIndex{
+		essences: map[string]string{"BERT": "Abstract of BERT"},
+		scores:   map[string]float32{"BERT": 1, "GPT": 0.7, "ResNet": 0.2, "RoBERTa": 0.9, "ELMo": 0.7},
+	}
+
+	matches, err := NewLocalFinder(embedder, index).FindSimilarLocal(context.Background(), "BERT", 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Abstract of BERT", embedder.text, "the paper's essence is embedded")
+	assert.Equal(t, []LocalMatch{
+		{Title: "RoBERTa", Score: 0.9},
+		{Title: "ELMo", Score: 0.7},
+		{Title: "GPT", Score: 0.7},
+	}, matches, "the paper itself is excluded and ties are ordered by title")
+}
+
+func TestFindSimilarLocalUnindexedPaper(t *testing.T) {
+	index := &fakeLocalIndex{essences: map[string]string{}}
+
+	_, err := NewLocalFinder(&fakeEmbedder{}, index).FindSimilarLocal(context.Background(), "BERT", 3)
+	assert.Error(t, err)
+}
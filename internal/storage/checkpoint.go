@@ -0,0 +1,186 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Checkpoint files are batch_<id>.json inside the metadata directory
+const (
+	checkpointPrefix = "batch_"
+	checkpointSuffix = ".json"
+)
+
+// batchIDFormat names batches after the time they started
+const batchIDFormat = "20060102-150405"
+
+// BatchCheckpoint records which files of a batch have finished, so an
+// interrupted batch can be resumed without redoing them. It is rewritten after
+// every completed file and removed when the batch finishes. It is safe for
+// concurrent use.
+type BatchCheckpoint struct {
+	ID        string    `json:"id"`
+	Files     []string  `json:"files"`     // Files the batch was started with
+	Completed []string  `json:"completed"` // Hashes of files that finished successfully
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	mu   sync.Mutex
+	path string
+	done map[string]bool
+}
+
+// NewBatchCheckpoint starts a checkpoint for files in metadataDir. Nothing is
+// written until Save or MarkCompleted is called.
+func NewBatchCheckpoint(metadataDir string, files []string) *BatchCheckpoint {
+	now := time.Now()
+	id := now.Format(batchIDFormat)
+	return &BatchCheckpoint{
+		ID:        id,
+		Files:     files,
+		Completed: []string{},
+		StartedAt: now,
+		UpdatedAt: now,
+		path:      checkpointPath(metadataDir, id),
+		done:      make(map[string]bool),
+	}
+}
+
+// LoadBatchCheckpoint reads the checkpoint of batch id from metadataDir
+func LoadBatchCheckpoint(metadataDir, id string) (*BatchCheckpoint, error) {
+	path := checkpointPath(metadataDir, id)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no checkpoint for batch %s (it may have finished)", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var checkpoint BatchCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %w", path, err)
+	}
+	checkpoint.path = path
+	checkpoint.done = make(map[string]bool, len(checkpoint.Completed))
+	for _, hash := range checkpoint.Completed {
+		checkpoint.done[hash] = true
+	}
+	return &checkpoint, nil
+}
+
+// LatestBatchCheckpoint loads the most recently started unfinished batch in
+// metadataDir, or returns nil if there is none
+func LatestBatchCheckpoint(metadataDir string) (*BatchCheckpoint, error) {
+	ids, err := ListBatchCheckpoints(metadataDir)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	return LoadBatchCheckpoint(metadataDir, ids[len(ids)-1])
+}
+
+// ListBatchCheckpoints returns the IDs of unfinished batches, oldest first
+func ListBatchCheckpoints(metadataDir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(metadataDir, checkpointPrefix+"*"+checkpointSuffix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checkpoints: %w", err)
+	}
+
+	ids := make([]string, 0, len(paths))
+	for _, path := range paths {
+		name := filepath.Base(path)
+		ids = append(ids, strings.TrimSuffix(strings.TrimPrefix(name, checkpointPrefix), checkpointSuffix))
+	}
+	// IDs are timestamps, so they sort by start time
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// checkpointPath is where the checkpoint of batch id lives
+func checkpointPath(metadataDir, id string) string {
+	return filepath.Join(metadataDir, checkpointPrefix+id+checkpointSuffix)
+}
+
+// Path returns the location of the checkpoint file
+func (c *BatchCheckpoint) Path() string {
+	return c.path
+}
+
+// IsCompleted reports whether the file with fileHash already finished in this batch
+func (c *BatchCheckpoint) IsCompleted(fileHash string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.done[fileHash]
+}
+
+// MarkCompleted records that the file with fileHash finished and saves the checkpoint
+func (c *BatchCheckpoint) MarkCompleted(fileHash string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if fileHash == "" || c.done[fileHash] {
+		return nil
+	}
+	c.done[fileHash] = true
+	c.Completed = append(c.Completed, fileHash)
+	return c.save()
+}
+
+// Save writes the checkpoint
+func (c *BatchCheckpoint) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.save()
+}
+
+// save writes the checkpoint through a temp file so an interruption mid-write
+// can't corrupt it. The caller must hold the lock.
+func (c *BatchCheckpoint) save() error {
+	c.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(c.path), ".batch-*.json.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp checkpoint file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, c.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes the checkpoint once the batch has finished
+func (c *BatchCheckpoint) Remove() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
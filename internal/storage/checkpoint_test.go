@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCheckpoint_SaveAndResume(t *testing.T) {
+	dir := t.TempDir()
+	checkpoint := NewBatchCheckpoint(dir, []string{"lib/a.pdf", "lib/b.pdf", "lib/c.pdf"})
+	require.NoError(t, checkpoint.Save())
+
+	require.NoError(t, checkpoint.MarkCompleted("hashA"))
+	require.NoError(t, checkpoint.MarkCompleted("hashA"))
+	require.NoError(t, checkpoint.MarkCompleted(""))
+
+	loaded, err := LoadBatchCheckpoint(dir, checkpoint.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lib/a.pdf", "lib/b.pdf", "lib/c.pdf"}, loaded.Files)
+	assert.Equal(t, []string{"hashA"}, loaded.Completed)
+	assert.True(t, loaded.IsCompleted("hashA"))
+	assert.False(t, loaded.IsCompleted("hashB"))
+
+	// Resuming keeps adding to the same file
+	require.NoError(t, loaded.MarkCompleted("hashB"))
+	reloaded, err := LoadBatchCheckpoint(dir, checkpoint.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hashA", "hashB"}, reloaded.Completed)
+}
+
+func TestBatchCheckpoint_Remove(t *testing.T) {
+	dir := t.TempDir()
+	checkpoint := NewBatchCheckpoint(dir, []string{"lib/a.pdf"})
+	require.NoError(t, checkpoint.MarkCompleted("hashA"))
+
+	require.NoError(t, checkpoint.Remove())
+	_, err := os.Stat(checkpoint.Path())
+	assert.True(t, os.IsNotExist(err))
+	require.NoError(t, checkpoint.Remove(), "removing twice is not an error")
+
+	_, err = LoadBatchCheckpoint(dir, checkpoint.ID)
+	assert.ErrorContains(t, err, "no checkpoint for batch")
+}
+
+func TestLatestBatchCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+
+	latest, err := LatestBatchCheckpoint(dir)
+	require.NoError(t, err)
+	assert.Nil(t, latest)
+
+	older := NewBatchCheckpoint(dir, []string{"lib/old.pdf"})
+	older.ID = "20240101-090000"
+	older.path = checkpointPath(dir, older.ID)
+	require.NoError(t, older.Save())
+
+	newer := NewBatchCheckpoint(dir, []string{"lib/new.pdf"})
+	newer.ID = "20240102-090000"
+	newer.path = checkpointPath(dir, newer.ID)
+	require.NoError(t, newer.Save())
+
+	ids, err := ListBatchCheckpoints(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20240101-090000", "20240102-090000"}, ids)
+
+	latest, err = LatestBatchCheckpoint(dir)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, "20240102-090000", latest.ID)
+	assert.Equal(t, []string{"lib/new.pdf"}, latest.Files)
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// exportCSVHeader is the column layout written by WriteRecordsCSV
+var exportCSVHeader = []string{"PaperTitle", "FilePath", "Status", "ProcessedAt", "ReportPath", "Error"}
+
+// sortedForExport returns a copy of records oldest first, so repeated exports
+// of the same history are byte-for-byte identical
+func sortedForExport(records []ProcessingRecord) []ProcessingRecord {
+	sorted := append([]ProcessingRecord(nil), records...)
+	SortRecords(sorted, SortOldestFirst)
+	return sorted
+}
+
+// WriteRecordsJSON writes records as an indented JSON array of ProcessingRecord
+func WriteRecordsJSON(w io.Writer, records []ProcessingRecord) error {
+	sorted := sortedForExport(records)
+	if sorted == nil {
+		sorted = []ProcessingRecord{}
+	}
+
+	data, err := json.MarshalIndent(sorted, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal records: %w", err)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write records: %w", err)
+	}
+
+	return nil
+}
+
+// WriteRecordsCSV writes records as CSV with a header row
+func WriteRecordsCSV(w io.Writer, records []ProcessingRecord) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportCSVHeader); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+
+	for _, record := range sortedForExport(records) {
+		row := []string{
+			record.PaperTitle,
+			record.FilePath,
+			string(record.Status),
+			record.ProcessedAt.Format(time.RFC3339),
+			record.ReportPath,
+			record.Error,
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write record: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to flush CSV: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportTestRecords() []ProcessingRecord {
+	day := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	return []ProcessingRecord{
+		{
+			FileHash: "hash2", FilePath: "lib/b.pdf", PaperTitle: "Paper B", Status: StatusFailed,
+			ProcessedAt: day.Add(time.Hour), Error: "compile error, see log", PromptTokens: 50,
+		},
+		{
+			FileHash: "hash1", FilePath: "lib/a.pdf", PaperTitle: "Paper A", Status: StatusCompleted,
+			ProcessedAt: day, TexFilePath: "tex/A.tex", ReportPath: "reports/A.pdf",
+			PromptTokens: 1000, OutputTokens: 200, Duration: 42 * time.Second, Mode: "fast",
+			ModelUsed: "models/gemini-2.0-flash", DOI: "10.1000/xyz", ArxivID: "2401.00001",
+		},
+	}
+}
+
+func TestWriteRecordsJSON_RoundTrip(t *testing.T) {
+	records := exportTestRecords()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteRecordsJSON(&buf, records))
+
+	var decoded []ProcessingRecord
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded, 2)
+
+	// Oldest first, whatever order the store returned them in
+	assert.Equal(t, records[1], decoded[0])
+	assert.Equal(t, records[0], decoded[1])
+
+	var again bytes.Buffer
+	require.NoError(t, WriteRecordsJSON(&again, []ProcessingRecord{records[1], records[0]}))
+	assert.Equal(t, buf.String(), again.String(), "Export should not depend on input order")
+}
+
+func TestWriteRecordsJSON_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteRecordsJSON(&buf, nil))
+	assert.Equal(t, "[]", buf.String())
+}
+
+func TestWriteRecordsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteRecordsCSV(&buf, exportTestRecords()))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, []string{"PaperTitle", "FilePath", "Status", "ProcessedAt", "ReportPath", "Error"}, rows[0])
+	assert.Equal(t, []string{"Paper A", "lib/a.pdf", "completed", "2024-03-01T12:00:00Z", "reports/A.pdf", ""}, rows[1])
+	assert.Equal(t, []string{"Paper B", "lib/b.pdf", "failed", "2024-03-01T13:00:00Z", "", "compile error, see log"}, rows[2])
+}
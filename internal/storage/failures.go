@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// failureLogFileName is the dead-letter log inside the metadata directory
+const failureLogFileName = "failed.jsonl"
+
+// FailureEntry is one line of the dead-letter log: a paper that failed after
+// all of its retries
+type FailureEntry struct {
+	FilePath string    `json:"file_path"`
+	FileHash string    `json:"file_hash,omitempty"`
+	Category string    `json:"category"` // e.g. "rate_limit", "analysis", "compilation"
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+	Attempts int       `json:"attempts"` // Failed runs of this paper so far, including this one
+}
+
+// key identifies the paper an entry belongs to
+func (e FailureEntry) key() string {
+	if e.FileHash != "" {
+		return e.FileHash
+	}
+	return e.FilePath
+}
+
+// FailureLog appends to and reads the dead-letter log. Entries are never
+// rewritten, so the file is a full history of failures across batches. It is
+// safe for concurrent use by multiple workers.
+type FailureLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFailureLog returns the dead-letter log in metadataDir
+func NewFailureLog(metadataDir string) *FailureLog {
+	return &FailureLog{path: filepath.Join(metadataDir, failureLogFileName)}
+}
+
+// Path returns the location of the log file
+func (fl *FailureLog) Path() string {
+	return fl.path
+}
+
+// Append writes entry as a new line. FailedAt defaults to now and Attempts is
+// counted from the paper's earlier entries. The stored entry is returned.
+func (fl *FailureLog) Append(entry FailureEntry) (FailureEntry, error) {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+
+	if entry.FailedAt.IsZero() {
+		entry.FailedAt = time.Now()
+	}
+
+	previous, err := fl.read()
+	if err != nil {
+		return entry, err
+	}
+	entry.Attempts = 1
+	for _, earlier := range previous {
+		if earlier.key() == entry.key() {
+			entry.Attempts++
+		}
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return entry, fmt.Errorf("failed to marshal failure entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fl.path), 0755); err != nil {
+		return entry, fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	f, err := os.OpenFile(fl.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return entry, fmt.Errorf("failed to open failure log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return entry, fmt.Errorf("failed to write failure log: %w", err)
+	}
+	return entry, nil
+}
+
+// ReadAll returns every entry in the order they were written. A missing log
+// has no entries; lines that don't parse are skipped.
+func (fl *FailureLog) ReadAll() ([]FailureEntry, error) {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+	return fl.read()
+}
+
+// read loads the log. The caller must hold the lock.
+func (fl *FailureLog) read() ([]FailureEntry, error) {
+	f, err := os.Open(fl.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open failure log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []FailureEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry FailureEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read failure log: %w", err)
+	}
+	return entries, nil
+}
+
+// LatestFailures keeps the most recent entry of each paper, newest first
+func LatestFailures(entries []FailureEntry) []FailureEntry {
+	latest := make(map[string]FailureEntry)
+	for _, entry := range entries {
+		if current, ok := latest[entry.key()]; !ok || !entry.FailedAt.Before(current.FailedAt) {
+			latest[entry.key()] = entry
+		}
+	}
+
+	result := make([]FailureEntry, 0, len(latest))
+	for _, entry := range latest {
+		result = append(result, entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].FailedAt.After(result[j].FailedAt)
+	})
+	return result
+}
+
+// CountByCategory tallies entries per failure category
+func CountByCategory(entries []FailureEntry) map[string]int {
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		counts[entry.Category]++
+	}
+	return counts
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureLog_AppendCountsAttempts(t *testing.T) {
+	log := NewFailureLog(t.TempDir())
+
+	first, err := log.Append(FailureEntry{FilePath: "lib/a.pdf", FileHash: "hashA", Category: "rate_limit", Error: "quota"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Attempts)
+	assert.False(t, first.FailedAt.IsZero())
+
+	_, err = log.Append(FailureEntry{FilePath: "lib/b.pdf", FileHash: "hashB", Category: "compilation", Error: "latex"})
+	require.NoError(t, err)
+	second, err := log.Append(FailureEntry{FilePath: "lib/a.pdf", FileHash: "hashA", Category: "timeout", Error: "slow"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.Attempts)
+
+	entries, err := log.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "lib/a.pdf", entries[0].FilePath)
+	assert.Equal(t, "timeout", entries[2].Category)
+}
+
+func TestFailureLog_ReadAllSkipsMalformedLines(t *testing.T) {
+	dir := t.TempDir()
+	log := NewFailureLog(dir)
+
+	require.NoError(t, os.WriteFile(log.Path(), []byte("not json\n{\"file_path\":\"lib/a.pdf\",\"category\":\"analysis\"}\n\n"), 0644))
+
+	entries, err := log.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "analysis", entries[0].Category)
+}
+
+func TestFailureLog_MissingFile(t *testing.T) {
+	entries, err := NewFailureLog(t.TempDir()).ReadAll()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFailureLog_ConcurrentAppends(t *testing.T) {
+	log := NewFailureLog(t.TempDir())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := log.Append(FailureEntry{FilePath: "lib/a.pdf", FileHash: "hashA", Category: "analysis"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	entries, err := log.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, entries, 20)
+	assert.Equal(t, 20, LatestFailures(entries)[0].Attempts)
+}
+
+func TestLatestFailures(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	entries := []FailureEntry{
+		{FilePath: "lib/a.pdf", FileHash: "hashA", Category: "rate_limit", FailedAt: base, Attempts: 1},
+		{FilePath: "lib/b.pdf", FileHash: "hashB", Category: "compilation", FailedAt: base.Add(time.Minute), Attempts: 1},
+		{FilePath: "lib/a.pdf", FileHash: "hashA", Category: "timeout", FailedAt: base.Add(2 * time.Minute), Attempts: 2},
+		{FilePath: "lib/c.pdf", Category: "analysis", FailedAt: base.Add(-time.Minute), Attempts: 1},
+	}
+
+	latest := LatestFailures(entries)
+
+	require.Len(t, latest, 3)
+	assert.Equal(t, "timeout", latest[0].Category)
+	assert.Equal(t, 2, latest[0].Attempts)
+	assert.Equal(t, "lib/b.pdf", latest[1].FilePath)
+	assert.Equal(t, "lib/c.pdf", latest[2].FilePath)
+
+	assert.Equal(t, map[string]int{"timeout": 1, "compilation": 1, "analysis": 1}, CountByCategory(latest))
+}
@@ -0,0 +1,413 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultMetadataDir is where processing metadata is kept, relative to the working directory
+const DefaultMetadataDir = ".metadata"
+
+// jsonFileName is the JSON store's file inside the metadata directory
+const jsonFileName = "hashes.json"
+
+// ProcessingStatus represents the processing state of a paper
+type ProcessingStatus string
+
+const (
+	StatusPending    ProcessingStatus = "pending"
+	StatusProcessing ProcessingStatus = "processing"
+	StatusCompleted  ProcessingStatus = "completed"
+	StatusFailed     ProcessingStatus = "failed"
+)
+
+// ProcessingRecord tracks the processing history of a single paper
+type ProcessingRecord struct {
+	FileHash    string           `json:"file_hash"`
+	FilePath    string           `json:"file_path"`
+	PaperTitle  string           `json:"paper_title"`
+	Status      ProcessingStatus `json:"status"`
+	ProcessedAt time.Time        `json:"processed_at"`
+	TexFilePath string           `json:"tex_file_path,omitempty"`
+	ReportPath  string           `json:"report_path,omitempty"`
+	Error       string           `json:"error,omitempty"`
+
+	// Gemini tokens spent on this paper, summed across all attempts
+	PromptTokens int `json:"prompt_tokens,omitempty"`
+	OutputTokens int `json:"output_tokens,omitempty"`
+
+	// How the last successful run went
+	Duration  time.Duration `json:"duration,omitempty"`
+	Mode      string        `json:"mode,omitempty"`
+	ModelUsed string        `json:"model_used,omitempty"`
+
+	// Normalized title, authors and year, for processing.dedup_by_metadata
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Identifiers printed on the paper, when its metadata was extracted
+	DOI     string `json:"doi,omitempty"`
+	ArxivID string `json:"arxiv_id,omitempty"`
+}
+
+// Store is the set of operations shared by the metadata backends. Methods
+// returning several records sort them newest first (SortNewestFirst).
+type Store interface {
+	IsProcessed(fileHash string) bool
+	GetRecord(fileHash string) (ProcessingRecord, bool)
+	MarkProcessing(fileHash, filePath string) error
+	MarkCompleted(fileHash, paperTitle, texPath, reportPath string) error
+	MarkFailed(fileHash, errMsg string) error
+	AddTokenUsage(fileHash string, promptTokens, outputTokens int) error
+	RecordRunInfo(fileHash string, duration time.Duration, mode, modelUsed string) error
+	SetFingerprint(fileHash, fingerprint string) error
+	SetIdentifiers(fileHash, doi, arxivID string) error
+	GetAllRecords() []ProcessingRecord
+	GetRecordsByStatus(status ProcessingStatus) []ProcessingRecord
+	GetStaleProcessing(olderThan time.Duration) []ProcessingRecord
+	DeleteRecord(fileHash string) error
+	Close() error
+}
+
+// ParseStatus converts a user-supplied status name into a ProcessingStatus
+func ParseStatus(s string) (ProcessingStatus, error) {
+	switch status := ProcessingStatus(s); status {
+	case StatusPending, StatusProcessing, StatusCompleted, StatusFailed:
+		return status, nil
+	default:
+		return "", fmt.Errorf("unknown status %q (must be one of: pending, processing, completed, failed)", s)
+	}
+}
+
+// Metadata backends selectable via metadata.backend
+const (
+	BackendJSON   = "json"
+	BackendSQLite = "sqlite"
+)
+
+// Open opens the metadata store for the given backend in metadataDir.
+// An empty backend selects the JSON file store.
+func Open(backend, metadataDir string) (Store, error) {
+	var store Store
+	var err error
+	switch backend {
+	case BackendJSON, "":
+		store, err = NewMetadataStore(metadataDir)
+	case BackendSQLite:
+		store, err = NewSQLiteStore(metadataDir)
+	default:
+		return nil, fmt.Errorf("unknown metadata backend: %s", backend)
+	}
+	if err != nil {
+		// Don't hand back a non-nil interface wrapping a nil pointer
+		return nil, err
+	}
+	return store, nil
+}
+
+// MetadataStore persists processing records to a JSON file keyed by file hash.
+// It is safe for concurrent use by multiple workers.
+type MetadataStore struct {
+	mu              sync.RWMutex
+	filePath        string
+	ProcessedPapers map[string]ProcessingRecord `json:"processed_papers"`
+}
+
+// NewMetadataStore opens (or creates) the metadata store in the given directory
+func NewMetadataStore(metadataDir string) (*MetadataStore, error) {
+	if err := os.MkdirAll(metadataDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+
+	store := &MetadataStore{
+		filePath:        filepath.Join(metadataDir, jsonFileName),
+		ProcessedPapers: make(map[string]ProcessingRecord),
+	}
+
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// Close is a no-op; every update is already flushed to disk
+func (ms *MetadataStore) Close() error {
+	return nil
+}
+
+// load reads the metadata file from disk if it exists
+func (ms *MetadataStore) load() error {
+	data, err := os.ReadFile(ms.filePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read metadata file: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, ms); err != nil {
+		return fmt.Errorf("failed to parse metadata file: %w", err)
+	}
+
+	if ms.ProcessedPapers == nil {
+		ms.ProcessedPapers = make(map[string]ProcessingRecord)
+	}
+
+	return nil
+}
+
+// save writes the metadata file to disk. The caller must hold the write lock.
+// Data is written to a temp file and renamed so a crash never leaves a torn file.
+func (ms *MetadataStore) save() error {
+	data, err := json.MarshalIndent(ms, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(ms.filePath), ".hashes-*.json.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp metadata file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write metadata file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write metadata file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, ms.filePath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace metadata file: %w", err)
+	}
+
+	return nil
+}
+
+// IsProcessed reports whether the paper with the given hash completed successfully
+func (ms *MetadataStore) IsProcessed(fileHash string) bool {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	record, exists := ms.ProcessedPapers[fileHash]
+	return exists && record.Status == StatusCompleted
+}
+
+// GetRecord returns the processing record for the given hash
+func (ms *MetadataStore) GetRecord(fileHash string) (ProcessingRecord, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	record, exists := ms.ProcessedPapers[fileHash]
+	return record, exists
+}
+
+// MarkProcessing records that a paper has started processing
+func (ms *MetadataStore) MarkProcessing(fileHash, filePath string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	record := ms.ProcessedPapers[fileHash]
+	record.FileHash = fileHash
+	record.FilePath = filePath
+	record.Status = StatusProcessing
+	record.ProcessedAt = time.Now()
+	record.Error = ""
+	ms.ProcessedPapers[fileHash] = record
+
+	return ms.save()
+}
+
+// MarkCompleted records a successfully processed paper and its output files
+func (ms *MetadataStore) MarkCompleted(fileHash, paperTitle, texPath, reportPath string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	record := ms.ProcessedPapers[fileHash]
+	record.FileHash = fileHash
+	record.PaperTitle = paperTitle
+	record.Status = StatusCompleted
+	record.ProcessedAt = time.Now()
+	record.TexFilePath = texPath
+	record.ReportPath = reportPath
+	record.Error = ""
+	ms.ProcessedPapers[fileHash] = record
+
+	return ms.save()
+}
+
+// MarkFailed records a failed processing attempt along with its error message
+func (ms *MetadataStore) MarkFailed(fileHash, errMsg string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	record := ms.ProcessedPapers[fileHash]
+	record.FileHash = fileHash
+	record.Status = StatusFailed
+	record.ProcessedAt = time.Now()
+	record.Error = errMsg
+	ms.ProcessedPapers[fileHash] = record
+
+	return ms.save()
+}
+
+// AddTokenUsage adds tokens spent on a processing attempt to the paper's running totals
+func (ms *MetadataStore) AddTokenUsage(fileHash string, promptTokens, outputTokens int) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	record, ok := ms.ProcessedPapers[fileHash]
+	if !ok {
+		return fmt.Errorf("no processing record for hash %s", fileHash)
+	}
+	record.PromptTokens += promptTokens
+	record.OutputTokens += outputTokens
+	ms.ProcessedPapers[fileHash] = record
+
+	return ms.save()
+}
+
+// RecordRunInfo stores the duration, processing mode and model of the latest run
+func (ms *MetadataStore) RecordRunInfo(fileHash string, duration time.Duration, mode, modelUsed string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	record, ok := ms.ProcessedPapers[fileHash]
+	if !ok {
+		return fmt.Errorf("no processing record for hash %s", fileHash)
+	}
+	record.Duration = duration
+	record.Mode = mode
+	record.ModelUsed = modelUsed
+	ms.ProcessedPapers[fileHash] = record
+
+	return ms.save()
+}
+
+// SetFingerprint stores the paper's metadata fingerprint (see processing.dedup_by_metadata)
+func (ms *MetadataStore) SetFingerprint(fileHash, fingerprint string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	record, ok := ms.ProcessedPapers[fileHash]
+	if !ok {
+		return fmt.Errorf("no processing record for hash %s", fileHash)
+	}
+	record.Fingerprint = fingerprint
+	ms.ProcessedPapers[fileHash] = record
+
+	return ms.save()
+}
+
+// SetIdentifiers stores the paper's DOI and arXiv ID
+func (ms *MetadataStore) SetIdentifiers(fileHash, doi, arxivID string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	record, ok := ms.ProcessedPapers[fileHash]
+	if !ok {
+		return fmt.Errorf("no processing record for hash %s", fileHash)
+	}
+	record.DOI = doi
+	record.ArxivID = arxivID
+	ms.ProcessedPapers[fileHash] = record
+
+	return ms.save()
+}
+
+// GetAllRecords returns every processing record in the store, newest first
+func (ms *MetadataStore) GetAllRecords() []ProcessingRecord {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	records := make([]ProcessingRecord, 0, len(ms.ProcessedPapers))
+	for _, record := range ms.ProcessedPapers {
+		records = append(records, record)
+	}
+	SortRecords(records, SortNewestFirst)
+	return records
+}
+
+// GetRecordsByStatus returns the processing records with the given status, newest first
+func (ms *MetadataStore) GetRecordsByStatus(status ProcessingStatus) []ProcessingRecord {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	var records []ProcessingRecord
+	for _, record := range ms.ProcessedPapers {
+		if record.Status == status {
+			records = append(records, record)
+		}
+	}
+	SortRecords(records, SortNewestFirst)
+	return records
+}
+
+// SortKey selects the order SortRecords puts records in
+type SortKey int
+
+const (
+	SortNewestFirst SortKey = iota // Most recently processed first
+	SortOldestFirst                // Least recently processed first
+)
+
+// SortRecords orders records by ProcessedAt as selected by key. Ties are
+// broken by title and then file hash, so the order is the same on every run.
+func SortRecords(records []ProcessingRecord, key SortKey) {
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		if !a.ProcessedAt.Equal(b.ProcessedAt) {
+			if key == SortOldestFirst {
+				return a.ProcessedAt.Before(b.ProcessedAt)
+			}
+			return a.ProcessedAt.After(b.ProcessedAt)
+		}
+		if a.PaperTitle != b.PaperTitle {
+			return a.PaperTitle < b.PaperTitle
+		}
+		return a.FileHash < b.FileHash
+	})
+}
+
+// GetStaleProcessing returns records that have been stuck in StatusProcessing
+// for longer than olderThan, typically left behind by a crashed run
+func (ms *MetadataStore) GetStaleProcessing(olderThan time.Duration) []ProcessingRecord {
+	return filterStale(ms.GetRecordsByStatus(StatusProcessing), olderThan)
+}
+
+// filterStale keeps the records last updated more than olderThan ago
+func filterStale(records []ProcessingRecord, olderThan time.Duration) []ProcessingRecord {
+	var stale []ProcessingRecord
+	for _, record := range records {
+		if time.Since(record.ProcessedAt) > olderThan {
+			stale = append(stale, record)
+		}
+	}
+	return stale
+}
+
+// DeleteRecord removes the processing record for the given hash
+func (ms *MetadataStore) DeleteRecord(fileHash string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, ok := ms.ProcessedPapers[fileHash]; !ok {
+		return fmt.Errorf("no processing record for hash %s", fileHash)
+	}
+	delete(ms.ProcessedPapers, fileHash)
+
+	return ms.save()
+}
@@ -0,0 +1,291 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataStore_MarkCompleted(t *testing.T) {
+	store, err := NewMetadataStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.MarkProcessing("hash1", "lib/paper.pdf"))
+	assert.False(t, store.IsProcessed("hash1"), "Processing paper should not count as processed")
+
+	require.NoError(t, store.MarkCompleted("hash1", "Paper Title", "tex/Paper.tex", "reports/Paper.pdf"))
+	assert.True(t, store.IsProcessed("hash1"))
+
+	record, ok := store.GetRecord("hash1")
+	require.True(t, ok)
+	assert.Equal(t, "lib/paper.pdf", record.FilePath)
+	assert.Equal(t, "Paper Title", record.PaperTitle)
+	assert.Equal(t, "reports/Paper.pdf", record.ReportPath)
+	assert.Equal(t, StatusCompleted, record.Status)
+}
+
+func TestMetadataStore_MarkFailed(t *testing.T) {
+	store, err := NewMetadataStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.MarkProcessing("hash1", "lib/paper.pdf"))
+	require.NoError(t, store.MarkFailed("hash1", "quota exceeded"))
+
+	record, ok := store.GetRecord("hash1")
+	require.True(t, ok)
+	assert.Equal(t, StatusFailed, record.Status)
+	assert.Equal(t, "quota exceeded", record.Error)
+	assert.False(t, store.IsProcessed("hash1"))
+}
+
+func TestMetadataStore_Persistence(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+	require.NoError(t, store.MarkProcessing("hash1", "lib/a.pdf"))
+	require.NoError(t, store.MarkCompleted("hash1", "Paper A", "tex/a.tex", "reports/a.pdf"))
+	require.NoError(t, store.MarkProcessing("hash2", "lib/b.pdf"))
+	require.NoError(t, store.MarkFailed("hash2", "boom"))
+
+	reopened, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+
+	assert.Len(t, reopened.GetAllRecords(), 2)
+	assert.True(t, reopened.IsProcessed("hash1"))
+	assert.False(t, reopened.IsProcessed("hash2"))
+}
+
+func TestMetadataStore_AddTokenUsage(t *testing.T) {
+	store, err := NewMetadataStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.MarkProcessing("hash1", "lib/paper.pdf"))
+	require.NoError(t, store.AddTokenUsage("hash1", 1000, 200))
+	require.NoError(t, store.MarkFailed("hash1", "compile error"))
+
+	// A retry adds to the totals rather than replacing them
+	require.NoError(t, store.MarkProcessing("hash1", "lib/paper.pdf"))
+	require.NoError(t, store.AddTokenUsage("hash1", 1000, 250))
+
+	record, ok := store.GetRecord("hash1")
+	require.True(t, ok)
+	assert.Equal(t, 2000, record.PromptTokens)
+	assert.Equal(t, 450, record.OutputTokens)
+
+	assert.Error(t, store.AddTokenUsage("missing", 1, 1))
+}
+
+func TestMetadataStore_DeleteRecord(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, store.MarkCompleted("hash1", "Paper Title", "tex/Paper.tex", "reports/Paper.pdf"))
+	require.NoError(t, store.DeleteRecord("hash1"))
+
+	_, ok := store.GetRecord("hash1")
+	assert.False(t, ok)
+	assert.Error(t, store.DeleteRecord("hash1"))
+
+	reopened, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+	_, ok = reopened.GetRecord("hash1")
+	assert.False(t, ok, "Deletion should be persisted")
+}
+
+func TestMetadataStore_RecordRunInfo(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, store.MarkCompleted("hash1", "Paper Title", "tex/Paper.tex", "reports/Paper.pdf"))
+	require.NoError(t, store.RecordRunInfo("hash1", 42*time.Second, "fast", "models/gemini-2.0-flash"))
+	assert.Error(t, store.RecordRunInfo("missing", time.Second, "fast", "m"))
+
+	reopened, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+	record, ok := reopened.GetRecord("hash1")
+	require.True(t, ok)
+	assert.Equal(t, 42*time.Second, record.Duration)
+	assert.Equal(t, "fast", record.Mode)
+	assert.Equal(t, "models/gemini-2.0-flash", record.ModelUsed)
+}
+
+func TestMetadataStore_SetFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, store.MarkCompleted("hash1", "Paper Title", "tex/Paper.tex", "reports/Paper.pdf"))
+	require.NoError(t, store.SetFingerprint("hash1", "paper title|vaswani|2017"))
+	assert.Error(t, store.SetFingerprint("missing", "x"))
+
+	reopened, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+	record, ok := reopened.GetRecord("hash1")
+	require.True(t, ok)
+	assert.Equal(t, "paper title|vaswani|2017", record.Fingerprint)
+}
+
+func TestMetadataStore_SetIdentifiers(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, store.MarkCompleted("hash1", "Paper Title", "tex/Paper.tex", "reports/Paper.pdf"))
+	require.NoError(t, store.SetIdentifiers("hash1", "10.48550/arxiv.1706.03762", "1706.03762"))
+	assert.Error(t, store.SetIdentifiers("missing", "", ""))
+
+	reopened, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+	record, ok := reopened.GetRecord("hash1")
+	require.True(t, ok)
+	assert.Equal(t, "10.48550/arxiv.1706.03762", record.DOI)
+	assert.Equal(t, "1706.03762", record.ArxivID)
+}
+
+func TestMetadataStore_GetRecordsByStatus(t *testing.T) {
+	store, err := NewMetadataStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.MarkCompleted("hash1", "Paper A", "tex/A.tex", "reports/A.pdf"))
+	require.NoError(t, store.MarkFailed("hash2", "quota exceeded"))
+	require.NoError(t, store.MarkFailed("hash3", "compile error"))
+
+	failed := store.GetRecordsByStatus(StatusFailed)
+	assert.Len(t, failed, 2)
+	for _, record := range failed {
+		assert.Equal(t, StatusFailed, record.Status)
+	}
+	assert.Len(t, store.GetRecordsByStatus(StatusCompleted), 1)
+	assert.Empty(t, store.GetRecordsByStatus(StatusProcessing))
+}
+
+func TestMetadataStore_GetStaleProcessing(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+
+	// hash1 crashes mid-run, hash2 finishes
+	require.NoError(t, store.MarkProcessing("hash1", "lib/a.pdf"))
+	require.NoError(t, store.MarkProcessing("hash2", "lib/b.pdf"))
+	require.NoError(t, store.MarkCompleted("hash2", "Paper B", "tex/b.tex", "reports/b.pdf"))
+
+	// After a restart the interrupted paper is neither processed nor lost
+	reopened, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+	assert.False(t, reopened.IsProcessed("hash1"))
+	assert.Empty(t, reopened.GetStaleProcessing(time.Hour), "Recent runs may still be in progress")
+
+	stale := reopened.GetStaleProcessing(0)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "hash1", stale[0].FileHash)
+	assert.Equal(t, "lib/a.pdf", stale[0].FilePath)
+
+	// Re-running the paper clears it from the stale list
+	require.NoError(t, reopened.MarkProcessing("hash1", "lib/a.pdf"))
+	require.NoError(t, reopened.MarkCompleted("hash1", "Paper A", "tex/a.tex", "reports/a.pdf"))
+	assert.Empty(t, reopened.GetStaleProcessing(0))
+}
+
+func TestParseStatus(t *testing.T) {
+	status, err := ParseStatus("failed")
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, status)
+
+	_, err = ParseStatus("done")
+	assert.Error(t, err)
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	store, err := NewMetadataStore(t.TempDir())
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			hash := fmt.Sprintf("hash%d", i)
+			assert.NoError(t, store.MarkProcessing(hash, fmt.Sprintf("lib/paper%d.pdf", i)))
+			assert.NoError(t, store.MarkCompleted(hash, fmt.Sprintf("Paper %d", i), "", ""))
+			_, _ = store.GetRecord(hash)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, store.GetAllRecords(), 10)
+}
+
+// Run with -race: 50 writers hammering the same store must not corrupt hashes.json
+func TestConcurrentWriters_FileStaysValid(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+
+	const writers = 50
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			hash := fmt.Sprintf("hash%d", i)
+			assert.NoError(t, store.MarkProcessing(hash, fmt.Sprintf("lib/paper%d.pdf", i)))
+			if i%2 == 0 {
+				assert.NoError(t, store.MarkCompleted(hash, fmt.Sprintf("Paper %d", i), "", ""))
+			} else {
+				assert.NoError(t, store.MarkFailed(hash, "boom"))
+			}
+			_ = store.IsProcessed(hash)
+			_ = store.GetAllRecords()
+		}(i)
+	}
+	wg.Wait()
+
+	// Reload from disk to ensure the JSON is intact and complete
+	reopened, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+	assert.Len(t, reopened.GetAllRecords(), writers)
+
+	// No temp files should be left behind
+	leftovers, err := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+}
+
+func TestMetadataStore_GetAllRecordsNewestFirst(t *testing.T) {
+	store, err := NewMetadataStore(t.TempDir())
+	require.NoError(t, err)
+
+	base := time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC)
+	store.ProcessedPapers = map[string]ProcessingRecord{
+		"hash1": {FileHash: "hash1", PaperTitle: "Old", Status: StatusCompleted, ProcessedAt: base},
+		"hash2": {FileHash: "hash2", PaperTitle: "New B", Status: StatusFailed, ProcessedAt: base.Add(time.Hour)},
+		"hash3": {FileHash: "hash3", PaperTitle: "New A", Status: StatusCompleted, ProcessedAt: base.Add(time.Hour)},
+		"hash4": {FileHash: "hash4", PaperTitle: "Middle", Status: StatusCompleted, ProcessedAt: base.Add(time.Minute)},
+	}
+
+	titles := func(records []ProcessingRecord) []string {
+		var result []string
+		for _, record := range records {
+			result = append(result, record.PaperTitle)
+		}
+		return result
+	}
+
+	// Same order on every call despite map iteration, ties broken by title
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, []string{"New A", "New B", "Middle", "Old"}, titles(store.GetAllRecords()))
+	}
+	assert.Equal(t, []string{"New A", "Middle", "Old"}, titles(store.GetRecordsByStatus(StatusCompleted)))
+
+	records := store.GetAllRecords()
+	SortRecords(records, SortOldestFirst)
+	assert.Equal(t, []string{"Old", "Middle", "New A", "New B"}, titles(records))
+}
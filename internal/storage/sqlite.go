@@ -0,0 +1,424 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteFileName is the SQLite database inside the metadata directory
+const sqliteFileName = "metadata.db"
+
+const createRecordsTable = `
+CREATE TABLE IF NOT EXISTS records (
+	file_hash     TEXT PRIMARY KEY,
+	file_path     TEXT NOT NULL DEFAULT '',
+	paper_title   TEXT NOT NULL DEFAULT '',
+	status        TEXT NOT NULL,
+	processed_at  TEXT NOT NULL,
+	tex_file_path TEXT NOT NULL DEFAULT '',
+	report_path   TEXT NOT NULL DEFAULT '',
+	error         TEXT NOT NULL DEFAULT '',
+	prompt_tokens INTEGER NOT NULL DEFAULT 0,
+	output_tokens INTEGER NOT NULL DEFAULT 0,
+	duration_ns   INTEGER NOT NULL DEFAULT 0,
+	mode          TEXT NOT NULL DEFAULT '',
+	model_used    TEXT NOT NULL DEFAULT '',
+	fingerprint   TEXT NOT NULL DEFAULT '',
+	doi           TEXT NOT NULL DEFAULT '',
+	arxiv_id      TEXT NOT NULL DEFAULT ''
+)`
+
+// addedColumns lists columns introduced after the records table was first
+// created, so older databases can be upgraded in place
+var addedColumns = []struct{ name, definition string }{
+	{"duration_ns", "INTEGER NOT NULL DEFAULT 0"},
+	{"mode", "TEXT NOT NULL DEFAULT ''"},
+	{"model_used", "TEXT NOT NULL DEFAULT ''"},
+	{"fingerprint", "TEXT NOT NULL DEFAULT ''"},
+	{"doi", "TEXT NOT NULL DEFAULT ''"},
+	{"arxiv_id", "TEXT NOT NULL DEFAULT ''"},
+}
+
+const createStatusIndex = `CREATE INDEX IF NOT EXISTS idx_records_status ON records(status)`
+
+// createMetaTable holds store-level flags such as whether hashes.json was imported
+const createMetaTable = `
+CREATE TABLE IF NOT EXISTS meta (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+)`
+
+// metaJSONImported is set once hashes.json has been copied into the database
+const metaJSONImported = "json_imported"
+
+// sqliteDSNParams make concurrent rph processes (e.g. watch and process) wait
+// for the write lock instead of failing with SQLITE_BUSY
+const sqliteDSNParams = "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+
+const selectRecordColumns = `SELECT file_hash, file_path, paper_title, status, processed_at,
+	tex_file_path, report_path, error, prompt_tokens, output_tokens,
+	duration_ns, mode, model_used, fingerprint, doi, arxiv_id FROM records`
+
+// SQLiteStore persists processing records in a SQLite database. Each update
+// touches a single row, so large libraries don't pay for a full-file rewrite.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens (or creates) the SQLite metadata database in the given
+// directory. The first time it is opened, records from an existing hashes.json
+// are imported. hashes.json itself is left untouched, so switching back to the
+// JSON backend shows the history as it was before the switch.
+func NewSQLiteStore(metadataDir string) (*SQLiteStore, error) {
+	if err := os.MkdirAll(metadataDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", filepath.Join(metadataDir, sqliteFileName)+sqliteDSNParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open metadata database: %w", err)
+	}
+	// SQLite allows a single writer; serialise access within this process
+	db.SetMaxOpenConns(1)
+
+	for _, stmt := range []string{createRecordsTable, createStatusIndex, createMetaTable} {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create metadata tables: %w", err)
+		}
+	}
+
+	if err := addMissingColumns(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	store := &SQLiteStore{db: db}
+
+	jsonPath := filepath.Join(metadataDir, jsonFileName)
+	if _, err := os.Stat(jsonPath); err == nil {
+		var marker string
+		err := db.QueryRow("SELECT value FROM meta WHERE key = ?", metaJSONImported).Scan(&marker)
+		if err != nil && err != sql.ErrNoRows {
+			db.Close()
+			return nil, fmt.Errorf("failed to read import marker: %w", err)
+		}
+		var count int
+		if err := db.QueryRow("SELECT COUNT(*) FROM records").Scan(&count); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to count records: %w", err)
+		}
+		// Databases imported before the marker existed already hold the records
+		if err == sql.ErrNoRows && count == 0 {
+			imported, err := store.ImportJSON(metadataDir)
+			if err != nil {
+				db.Close()
+				return nil, err
+			}
+			log.Printf("✓ Imported %d record(s) from %s into SQLite", imported, jsonPath)
+		}
+	}
+
+	return store, nil
+}
+
+// Close closes the database
+func (ss *SQLiteStore) Close() error {
+	return ss.db.Close()
+}
+
+// ImportJSON copies every record from the JSON store in metadataDir into the
+// database in a single transaction, replacing rows with the same hash, and
+// marks the import as done
+func (ss *SQLiteStore) ImportJSON(metadataDir string) (int, error) {
+	jsonStore, err := NewMetadataStore(metadataDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", jsonFileName, err)
+	}
+	records := jsonStore.GetAllRecords()
+
+	tx, err := ss.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin import: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT OR REPLACE INTO records (file_hash, file_path, paper_title, status,
+		processed_at, tex_file_path, report_path, error, prompt_tokens, output_tokens,
+		duration_ns, mode, model_used, fingerprint, doi, arxiv_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare import: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, r := range records {
+		_, err := stmt.Exec(r.FileHash, r.FilePath, r.PaperTitle, string(r.Status),
+			formatTime(r.ProcessedAt), r.TexFilePath, r.ReportPath, r.Error, r.PromptTokens, r.OutputTokens,
+			int64(r.Duration), r.Mode, r.ModelUsed, r.Fingerprint, r.DOI, r.ArxivID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to import record %s: %w", r.FileHash, err)
+		}
+	}
+
+	_, err = tx.Exec("INSERT OR REPLACE INTO meta (key, value) VALUES (?, ?)",
+		metaJSONImported, formatTime(time.Now()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to record import: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit import: %w", err)
+	}
+
+	return len(records), nil
+}
+
+// IsProcessed reports whether the paper with the given hash completed successfully
+func (ss *SQLiteStore) IsProcessed(fileHash string) bool {
+	record, exists := ss.GetRecord(fileHash)
+	return exists && record.Status == StatusCompleted
+}
+
+// GetRecord returns the processing record for the given hash
+func (ss *SQLiteStore) GetRecord(fileHash string) (ProcessingRecord, bool) {
+	row := ss.db.QueryRow(selectRecordColumns+" WHERE file_hash = ?", fileHash)
+
+	record, err := scanRecord(row)
+	if err == sql.ErrNoRows {
+		return ProcessingRecord{}, false
+	}
+	if err != nil {
+		log.Printf("⚠️  Warning: failed to read metadata record %s: %v", fileHash, err)
+		return ProcessingRecord{}, false
+	}
+
+	return record, true
+}
+
+// MarkProcessing records that a paper has started processing
+func (ss *SQLiteStore) MarkProcessing(fileHash, filePath string) error {
+	_, err := ss.db.Exec(`INSERT INTO records (file_hash, file_path, status, processed_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(file_hash) DO UPDATE SET
+			file_path = excluded.file_path,
+			status = excluded.status,
+			processed_at = excluded.processed_at,
+			error = ''`,
+		fileHash, filePath, string(StatusProcessing), formatTime(time.Now()))
+	if err != nil {
+		return fmt.Errorf("failed to mark processing: %w", err)
+	}
+	return nil
+}
+
+// MarkCompleted records a successfully processed paper and its output files
+func (ss *SQLiteStore) MarkCompleted(fileHash, paperTitle, texPath, reportPath string) error {
+	_, err := ss.db.Exec(`INSERT INTO records (file_hash, paper_title, status, processed_at, tex_file_path, report_path)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(file_hash) DO UPDATE SET
+			paper_title = excluded.paper_title,
+			status = excluded.status,
+			processed_at = excluded.processed_at,
+			tex_file_path = excluded.tex_file_path,
+			report_path = excluded.report_path,
+			error = ''`,
+		fileHash, paperTitle, string(StatusCompleted), formatTime(time.Now()), texPath, reportPath)
+	if err != nil {
+		return fmt.Errorf("failed to mark completed: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed processing attempt along with its error message
+func (ss *SQLiteStore) MarkFailed(fileHash, errMsg string) error {
+	_, err := ss.db.Exec(`INSERT INTO records (file_hash, status, processed_at, error)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(file_hash) DO UPDATE SET
+			status = excluded.status,
+			processed_at = excluded.processed_at,
+			error = excluded.error`,
+		fileHash, string(StatusFailed), formatTime(time.Now()), errMsg)
+	if err != nil {
+		return fmt.Errorf("failed to mark failed: %w", err)
+	}
+	return nil
+}
+
+// AddTokenUsage adds tokens spent on a processing attempt to the paper's running totals
+func (ss *SQLiteStore) AddTokenUsage(fileHash string, promptTokens, outputTokens int) error {
+	res, err := ss.db.Exec(`UPDATE records SET
+			prompt_tokens = prompt_tokens + ?,
+			output_tokens = output_tokens + ?
+		WHERE file_hash = ?`,
+		promptTokens, outputTokens, fileHash)
+	if err != nil {
+		return fmt.Errorf("failed to add token usage: %w", err)
+	}
+	return requireRow(res, fileHash)
+}
+
+// RecordRunInfo stores the duration, processing mode and model of the latest run
+func (ss *SQLiteStore) RecordRunInfo(fileHash string, duration time.Duration, mode, modelUsed string) error {
+	res, err := ss.db.Exec(`UPDATE records SET duration_ns = ?, mode = ?, model_used = ? WHERE file_hash = ?`,
+		int64(duration), mode, modelUsed, fileHash)
+	if err != nil {
+		return fmt.Errorf("failed to record run info: %w", err)
+	}
+	return requireRow(res, fileHash)
+}
+
+// SetFingerprint stores the paper's metadata fingerprint (see processing.dedup_by_metadata)
+func (ss *SQLiteStore) SetFingerprint(fileHash, fingerprint string) error {
+	res, err := ss.db.Exec(`UPDATE records SET fingerprint = ? WHERE file_hash = ?`, fingerprint, fileHash)
+	if err != nil {
+		return fmt.Errorf("failed to set fingerprint: %w", err)
+	}
+	return requireRow(res, fileHash)
+}
+
+// SetIdentifiers stores the paper's DOI and arXiv ID
+func (ss *SQLiteStore) SetIdentifiers(fileHash, doi, arxivID string) error {
+	res, err := ss.db.Exec(`UPDATE records SET doi = ?, arxiv_id = ? WHERE file_hash = ?`, doi, arxivID, fileHash)
+	if err != nil {
+		return fmt.Errorf("failed to set identifiers: %w", err)
+	}
+	return requireRow(res, fileHash)
+}
+
+// GetAllRecords returns every processing record in the store, newest first
+func (ss *SQLiteStore) GetAllRecords() []ProcessingRecord {
+	return ss.queryRecords(selectRecordColumns)
+}
+
+// GetRecordsByStatus returns the processing records with the given status, newest first
+func (ss *SQLiteStore) GetRecordsByStatus(status ProcessingStatus) []ProcessingRecord {
+	return ss.queryRecords(selectRecordColumns+" WHERE status = ?", string(status))
+}
+
+// GetStaleProcessing returns records that have been stuck in StatusProcessing
+// for longer than olderThan, typically left behind by a crashed run
+func (ss *SQLiteStore) GetStaleProcessing(olderThan time.Duration) []ProcessingRecord {
+	return filterStale(ss.GetRecordsByStatus(StatusProcessing), olderThan)
+}
+
+// queryRecords runs a query selecting selectRecordColumns. Errors are logged,
+// matching the JSON store whose readers cannot fail.
+func (ss *SQLiteStore) queryRecords(query string, args ...interface{}) []ProcessingRecord {
+	rows, err := ss.db.Query(query, args...)
+	if err != nil {
+		log.Printf("⚠️  Warning: failed to read metadata records: %v", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var records []ProcessingRecord
+	for rows.Next() {
+		record, err := scanRecord(rows)
+		if err != nil {
+			log.Printf("⚠️  Warning: failed to read metadata record: %v", err)
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("⚠️  Warning: failed to read metadata records: %v", err)
+	}
+
+	// Sorted here rather than in SQL so both backends order ties the same way
+	SortRecords(records, SortNewestFirst)
+
+	return records
+}
+
+// DeleteRecord removes the processing record for the given hash
+func (ss *SQLiteStore) DeleteRecord(fileHash string) error {
+	res, err := ss.db.Exec("DELETE FROM records WHERE file_hash = ?", fileHash)
+	if err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+	return requireRow(res, fileHash)
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanRecord reads one row selected with selectRecordColumns
+func scanRecord(row rowScanner) (ProcessingRecord, error) {
+	var record ProcessingRecord
+	var status, processedAt string
+	var durationNs int64
+
+	err := row.Scan(&record.FileHash, &record.FilePath, &record.PaperTitle, &status, &processedAt,
+		&record.TexFilePath, &record.ReportPath, &record.Error, &record.PromptTokens, &record.OutputTokens,
+		&durationNs, &record.Mode, &record.ModelUsed, &record.Fingerprint, &record.DOI, &record.ArxivID)
+	if err != nil {
+		return ProcessingRecord{}, err
+	}
+
+	record.Status = ProcessingStatus(status)
+	record.Duration = time.Duration(durationNs)
+	record.ProcessedAt, _ = time.Parse(time.RFC3339Nano, processedAt)
+
+	return record, nil
+}
+
+// addMissingColumns upgrades a records table created by an older version
+func addMissingColumns(db *sql.DB) error {
+	rows, err := db.Query("PRAGMA table_info(records)")
+	if err != nil {
+		return fmt.Errorf("failed to inspect records table: %w", err)
+	}
+
+	existing := make(map[string]bool)
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to inspect records table: %w", err)
+		}
+		existing[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to inspect records table: %w", err)
+	}
+
+	for _, col := range addedColumns {
+		if existing[col.name] {
+			continue
+		}
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE records ADD COLUMN %s %s", col.name, col.definition)); err != nil {
+			return fmt.Errorf("failed to add column %s: %w", col.name, err)
+		}
+	}
+
+	return nil
+}
+
+// requireRow returns an error if an UPDATE or DELETE matched no record
+func requireRow(res sql.Result, fileHash string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("no processing record for hash %s", fileHash)
+	}
+	return nil
+}
+
+// formatTime stores timestamps as sortable RFC 3339 text
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteStore_MarkCompleted(t *testing.T) {
+	store, err := NewSQLiteStore(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.NoError(t, store.MarkProcessing("hash1", "lib/paper.pdf"))
+	assert.False(t, store.IsProcessed("hash1"), "Processing paper should not count as processed")
+
+	require.NoError(t, store.MarkCompleted("hash1", "Paper Title", "tex/Paper.tex", "reports/Paper.pdf"))
+	assert.True(t, store.IsProcessed("hash1"))
+
+	record, ok := store.GetRecord("hash1")
+	require.True(t, ok)
+	assert.Equal(t, "lib/paper.pdf", record.FilePath)
+	assert.Equal(t, "Paper Title", record.PaperTitle)
+	assert.Equal(t, "reports/Paper.pdf", record.ReportPath)
+	assert.Equal(t, StatusCompleted, record.Status)
+	assert.False(t, record.ProcessedAt.IsZero())
+}
+
+func TestSQLiteStore_FailedRetryAndDelete(t *testing.T) {
+	store, err := NewSQLiteStore(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.NoError(t, store.MarkProcessing("hash1", "lib/paper.pdf"))
+	require.NoError(t, store.AddTokenUsage("hash1", 1000, 200))
+	require.NoError(t, store.MarkFailed("hash1", "quota exceeded"))
+
+	record, ok := store.GetRecord("hash1")
+	require.True(t, ok)
+	assert.Equal(t, StatusFailed, record.Status)
+	assert.Equal(t, "quota exceeded", record.Error)
+
+	require.NoError(t, store.MarkProcessing("hash1", "lib/paper.pdf"))
+	require.NoError(t, store.AddTokenUsage("hash1", 500, 50))
+	record, _ = store.GetRecord("hash1")
+	assert.Empty(t, record.Error, "Retry should clear the previous error")
+	assert.Equal(t, 1500, record.PromptTokens)
+	assert.Equal(t, 250, record.OutputTokens)
+
+	assert.Error(t, store.AddTokenUsage("missing", 1, 1))
+
+	require.NoError(t, store.RecordRunInfo("hash1", 90*time.Second, "fast", "gpt-4o"))
+	record, _ = store.GetRecord("hash1")
+	assert.Equal(t, 90*time.Second, record.Duration)
+	assert.Equal(t, "fast", record.Mode)
+	assert.Equal(t, "gpt-4o", record.ModelUsed)
+
+	require.NoError(t, store.SetFingerprint("hash1", "paper a|smith|2020"))
+	record, _ = store.GetRecord("hash1")
+	assert.Equal(t, "paper a|smith|2020", record.Fingerprint)
+	assert.Error(t, store.SetFingerprint("missing", "x"))
+
+	require.NoError(t, store.SetIdentifiers("hash1", "10.1000/xyz", "2101.00001"))
+	record, _ = store.GetRecord("hash1")
+	assert.Equal(t, "10.1000/xyz", record.DOI)
+	assert.Equal(t, "2101.00001", record.ArxivID)
+	assert.Error(t, store.SetIdentifiers("missing", "", ""))
+
+	require.NoError(t, store.DeleteRecord("hash1"))
+	_, ok = store.GetRecord("hash1")
+	assert.False(t, ok)
+	assert.Error(t, store.DeleteRecord("hash1"))
+}
+
+func TestSQLiteStore_GetRecordsByStatus(t *testing.T) {
+	store, err := NewSQLiteStore(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.NoError(t, store.MarkCompleted("hash1", "Paper A", "tex/A.tex", "reports/A.pdf"))
+	require.NoError(t, store.MarkFailed("hash2", "quota exceeded"))
+	require.NoError(t, store.MarkFailed("hash3", "compile error"))
+
+	assert.Len(t, store.GetRecordsByStatus(StatusFailed), 2)
+	assert.Len(t, store.GetRecordsByStatus(StatusCompleted), 1)
+	assert.Empty(t, store.GetRecordsByStatus(StatusProcessing))
+}
+
+func TestSQLiteStore_GetStaleProcessing(t *testing.T) {
+	store, err := NewSQLiteStore(t.TempDir())
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.NoError(t, store.MarkProcessing("hash1", "lib/a.pdf"))
+	require.NoError(t, store.MarkProcessing("hash2", "lib/b.pdf"))
+	require.NoError(t, store.MarkCompleted("hash2", "Paper B", "tex/b.tex", "reports/b.pdf"))
+
+	assert.Empty(t, store.GetStaleProcessing(time.Hour))
+	stale := store.GetStaleProcessing(0)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "hash1", stale[0].FileHash)
+}
+
+func TestSQLiteStore_ImportsJSONOnce(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonStore, err := NewMetadataStore(dir)
+	require.NoError(t, err)
+	require.NoError(t, jsonStore.MarkProcessing("hash1", "lib/a.pdf"))
+	require.NoError(t, jsonStore.MarkCompleted("hash1", "Paper A", "tex/A.tex", "reports/A.pdf"))
+	require.NoError(t, jsonStore.MarkFailed("hash2", "compile error"))
+
+	store, err := NewSQLiteStore(dir)
+	require.NoError(t, err)
+
+	assert.Len(t, store.GetAllRecords(), 2)
+	record, ok := store.GetRecord("hash1")
+	require.True(t, ok)
+	assert.Equal(t, "Paper A", record.PaperTitle)
+	assert.Equal(t, "lib/a.pdf", record.FilePath)
+
+	assert.FileExists(t, filepath.Join(dir, jsonFileName), "hashes.json should be kept for switching back")
+
+	// Records deleted after the import must not come back on the next open
+	require.NoError(t, store.DeleteRecord("hash1"))
+	require.NoError(t, store.DeleteRecord("hash2"))
+	require.NoError(t, store.Close())
+
+	store, err = NewSQLiteStore(dir)
+	require.NoError(t, err)
+	defer store.Close()
+	assert.Empty(t, store.GetAllRecords())
+
+	// The JSON backend still sees the history from before the switch
+	jsonStore, err = NewMetadataStore(dir)
+	require.NoError(t, err)
+	assert.Len(t, jsonStore.GetAllRecords(), 2)
+}
+
+func TestOpen_Backends(t *testing.T) {
+	store, err := Open(BackendJSON, t.TempDir())
+	require.NoError(t, err)
+	assert.IsType(t, &MetadataStore{}, store)
+
+	store, err = Open(BackendSQLite, t.TempDir())
+	require.NoError(t, err)
+	assert.IsType(t, &SQLiteStore{}, store)
+	store.Close()
+
+	_, err = Open("postgres", t.TempDir())
+	assert.Error(t, err)
+}
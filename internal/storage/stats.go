@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"sort"
+	"time"
+)
+
+// LibraryStats aggregates the processing history of a library
+type LibraryStats struct {
+	Total      int
+	Completed  int
+	Failed     int
+	Processing int
+
+	// Durations of completed runs that recorded one
+	TimedRuns      int
+	AverageRuntime time.Duration
+	MedianRuntime  time.Duration
+
+	PromptTokens int
+	OutputTokens int
+
+	Oldest time.Time
+	Newest time.Time
+
+	// Completed papers per processing mode ("unknown" if not recorded)
+	ByMode map[string]int
+}
+
+// ComputeStats summarizes a set of processing records
+func ComputeStats(records []ProcessingRecord) LibraryStats {
+	stats := LibraryStats{
+		Total:  len(records),
+		ByMode: make(map[string]int),
+	}
+
+	var durations []time.Duration
+	for _, record := range records {
+		switch record.Status {
+		case StatusCompleted:
+			stats.Completed++
+			mode := record.Mode
+			if mode == "" {
+				mode = "unknown"
+			}
+			stats.ByMode[mode]++
+			if record.Duration > 0 {
+				durations = append(durations, record.Duration)
+			}
+		case StatusFailed:
+			stats.Failed++
+		case StatusProcessing:
+			stats.Processing++
+		}
+
+		stats.PromptTokens += record.PromptTokens
+		stats.OutputTokens += record.OutputTokens
+
+		if record.ProcessedAt.IsZero() {
+			continue
+		}
+		if stats.Oldest.IsZero() || record.ProcessedAt.Before(stats.Oldest) {
+			stats.Oldest = record.ProcessedAt
+		}
+		if record.ProcessedAt.After(stats.Newest) {
+			stats.Newest = record.ProcessedAt
+		}
+	}
+
+	stats.TimedRuns = len(durations)
+	if len(durations) > 0 {
+		var total time.Duration
+		for _, d := range durations {
+			total += d
+		}
+		stats.AverageRuntime = total / time.Duration(len(durations))
+
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		mid := len(durations) / 2
+		if len(durations)%2 == 0 {
+			stats.MedianRuntime = (durations[mid-1] + durations[mid]) / 2
+		} else {
+			stats.MedianRuntime = durations[mid]
+		}
+	}
+
+	return stats
+}
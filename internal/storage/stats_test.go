@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeStats(t *testing.T) {
+	day := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	records := []ProcessingRecord{
+		{Status: StatusCompleted, Mode: "fast", Duration: 10 * time.Second, ProcessedAt: day, PromptTokens: 100, OutputTokens: 10},
+		{Status: StatusCompleted, Mode: "fast", Duration: 30 * time.Second, ProcessedAt: day.Add(time.Hour), PromptTokens: 200, OutputTokens: 20},
+		{Status: StatusCompleted, Duration: 50 * time.Second, ProcessedAt: day.Add(2 * time.Hour)},
+		{Status: StatusCompleted, Mode: "quality", Duration: 90 * time.Second, ProcessedAt: day.Add(-time.Hour)},
+		{Status: StatusFailed, ProcessedAt: day.Add(3 * time.Hour), PromptTokens: 50},
+		{Status: StatusProcessing},
+	}
+
+	stats := ComputeStats(records)
+
+	assert.Equal(t, 6, stats.Total)
+	assert.Equal(t, 4, stats.Completed)
+	assert.Equal(t, 1, stats.Failed)
+	assert.Equal(t, 1, stats.Processing)
+
+	assert.Equal(t, 4, stats.TimedRuns)
+	assert.Equal(t, 45*time.Second, stats.AverageRuntime)
+	assert.Equal(t, 40*time.Second, stats.MedianRuntime)
+
+	assert.Equal(t, 350, stats.PromptTokens)
+	assert.Equal(t, 30, stats.OutputTokens)
+
+	assert.Equal(t, day.Add(-time.Hour), stats.Oldest)
+	assert.Equal(t, day.Add(3*time.Hour), stats.Newest, "Zero timestamps should be ignored")
+
+	assert.Equal(t, map[string]int{"fast": 2, "quality": 1, "unknown": 1}, stats.ByMode)
+}
+
+func TestComputeStats_Empty(t *testing.T) {
+	stats := ComputeStats(nil)
+	assert.Equal(t, 0, stats.Total)
+	assert.Zero(t, stats.MedianRuntime)
+	assert.True(t, stats.Oldest.IsZero())
+}
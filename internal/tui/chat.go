@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"log"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -21,8 +22,9 @@ import (
 
 // ChatResponseMsg is sent when a chat response is received
 type ChatResponseMsg struct {
-	Message *chat.Message
-	Err     error
+	Message   *chat.Message
+	SessionID string // Session the reply was saved to
+	Err       error
 }
 
 // InitChatSession initializes the chat session
@@ -44,36 +46,30 @@ func (m *Model) initChatSession() tea.Cmd {
 		}
 
 		// Initialize RAG components with FAISS
-		embedClient, err := rag.NewEmbeddingClient(m.config.Gemini.APIKey)
+		embedClient, err := rag.NewEmbeddingClient(m.config.Gemini.APIKey, m.config.RAG.EmbeddingModel, m.config.RAG.EmbeddingDim)
 		if err != nil {
 			return ChatResponseMsg{Err: fmt.Errorf("failed to create embedding client: %w", err)}
 		}
 		defer embedClient.Close()
 
 		// Use FAISS vector store
-		indexDir := filepath.Join(".metadata", "vector_index")
-		vectorStore, err := rag.NewFAISSVectorStore(indexDir)
+		vectorStore, err := rag.NewFAISSVectorStore(m.config.FAISS.IndexDir, m.config.RAG.EmbeddingDim)
 		if err != nil {
 			return ChatResponseMsg{Err: fmt.Errorf("failed to create FAISS vector store: %w", err)}
 		}
 
-		retrievalConfig := rag.DefaultRetrievalConfig()
+		retrievalConfig := rag.DefaultRetrievalConfig(m.config.RAG)
 		retriever := rag.NewRetriever(vectorStore, embedClient, retrievalConfig)
 
-		// Gemini client
-		geminiClient, err := analyzer.NewGeminiClient(
-			m.config.Gemini.APIKey,
-			m.config.Gemini.Model,
-			m.config.Gemini.Temperature,
-			m.config.Gemini.MaxTokens,
-		)
+		// LLM client
+		llmClient, err := analyzer.NewLLMClient(m.config, m.config.Gemini.Model, m.config.Gemini.Temperature)
 		if err != nil {
-			return ChatResponseMsg{Err: fmt.Errorf("failed to create Gemini client: %w", err)}
+			return ChatResponseMsg{Err: fmt.Errorf("failed to create LLM client: %w", err)}
 		}
-		defer geminiClient.Close()
+		defer llmClient.Close()
 
-		// Chat engine
-		chatEngine := chat.NewChatEngine(retriever, geminiClient, redisClient)
+		// Chat engine (this one only starts the session, so the prompt doesn't matter)
+		chatEngine := chat.NewChatEngine(retriever, llmClient, redisClient, nil)
 
 		// Start session (chatSelectedPapers now contains paper titles, not paths)
 		session, err := chatEngine.StartSession(ctx, m.chatSelectedPapers)
@@ -91,72 +87,99 @@ type ChatSessionStarted struct {
 	SessionID string
 }
 
-// SendChatMessage sends a message to the chat engine
-func SendChatMessage(config interface{}, sessionID string, userMessage string, selectedPapers []string) tea.Cmd {
+// ChatChunkMsg carries the next piece of a streamed chat reply
+type ChatChunkMsg struct {
+	Text string
+}
+
+// SendChatMessage sends a message to the chat engine. Reply chunks and the final
+// ChatResponseMsg are delivered on stream, which is closed when the reply is done;
+// read it with waitForChatMsg.
+func SendChatMessage(config interface{}, sessionID string, userMessage string, selectedPapers []string, stream chan<- tea.Msg) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-		defer cancel()
+		defer close(stream)
+		stream <- streamChatReply(config, sessionID, userMessage, selectedPapers, stream)
+		return nil
+	}
+}
 
-		cfg, ok := config.(*app.Config)
+// waitForChatMsg waits for the next message of a streamed chat reply
+func waitForChatMsg(stream <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-stream
 		if !ok {
-			return ChatResponseMsg{Err: fmt.Errorf("invalid config type")}
+			return nil
 		}
+		return msg
+	}
+}
 
-		// Initialize components
-		redisClient := redis.NewClient(&redis.Options{
-			Addr:     cfg.Cache.Redis.Addr,
-			Password: cfg.Cache.Redis.Password,
-			DB:       cfg.Cache.Redis.DB,
-		})
-		defer redisClient.Close()
+// streamChatReply runs one chat turn, sending ChatChunkMsgs on stream as the
+// reply is generated, and returns the final ChatResponseMsg
+func streamChatReply(config interface{}, sessionID string, userMessage string, selectedPapers []string, stream chan<- tea.Msg) tea.Msg {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
 
-		embedClient, err := rag.NewEmbeddingClient(cfg.Gemini.APIKey)
-		if err != nil {
-			return ChatResponseMsg{Err: err}
-		}
-		defer embedClient.Close()
+	cfg, ok := config.(*app.Config)
+	if !ok {
+		return ChatResponseMsg{Err: fmt.Errorf("invalid config type")}
+	}
 
-		// Use FAISS vector store
-		indexDir := filepath.Join(".metadata", "vector_index")
-		vectorStore, err := rag.NewFAISSVectorStore(indexDir)
-		if err != nil {
-			return ChatResponseMsg{Err: err}
-		}
+	// Initialize components
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     cfg.Cache.Redis.Addr,
+		Password: cfg.Cache.Redis.Password,
+		DB:       cfg.Cache.Redis.DB,
+	})
+	defer redisClient.Close()
 
-		retrievalConfig := rag.DefaultRetrievalConfig()
-		retriever := rag.NewRetriever(vectorStore, embedClient, retrievalConfig)
+	embedClient, err := rag.NewEmbeddingClient(cfg.Gemini.APIKey, cfg.RAG.EmbeddingModel, cfg.RAG.EmbeddingDim)
+	if err != nil {
+		return ChatResponseMsg{Err: err}
+	}
+	defer embedClient.Close()
 
-		geminiClient, err := analyzer.NewGeminiClient(
-			cfg.Gemini.APIKey,
-			cfg.Gemini.Model,
-			cfg.Gemini.Temperature,
-			cfg.Gemini.MaxTokens,
-		)
-		if err != nil {
-			return ChatResponseMsg{Err: err}
-		}
-		defer geminiClient.Close()
+	// Use FAISS vector store
+	vectorStore, err := rag.NewFAISSVectorStore(cfg.FAISS.IndexDir, cfg.RAG.EmbeddingDim)
+	if err != nil {
+		return ChatResponseMsg{Err: err}
+	}
 
-		chatEngine := chat.NewChatEngine(retriever, geminiClient, redisClient)
+	retrievalConfig := rag.DefaultRetrievalConfig(cfg.RAG)
+	retriever := rag.NewRetriever(vectorStore, embedClient, retrievalConfig)
 
-		// Get session
-		session, err := chatEngine.GetSession(ctx, sessionID)
-		if err != nil {
-			// Create new session if not found (selectedPapers now contains paper titles, not paths)
-			session, err = chatEngine.StartSession(ctx, selectedPapers)
-			if err != nil {
-				return ChatResponseMsg{Err: err}
-			}
-		}
+	llmClient, err := analyzer.NewLLMClient(cfg, cfg.Gemini.Model, cfg.Gemini.Temperature)
+	if err != nil {
+		return ChatResponseMsg{Err: err}
+	}
+	defer llmClient.Close()
 
-		// Send message
-		response, err := chatEngine.Chat(ctx, session, userMessage)
+	prompt, err := app.ParseChatPrompt(cfg.Chat.SystemPrompt)
+	if err != nil {
+		return ChatResponseMsg{Err: err}
+	}
+
+	chatEngine := chat.NewChatEngine(retriever, llmClient, redisClient, prompt)
+
+	// Get session
+	session, err := chatEngine.GetSession(ctx, sessionID)
+	if err != nil {
+		// Create new session if not found (selectedPapers now contains paper titles, not paths)
+		session, err = chatEngine.StartSession(ctx, selectedPapers)
 		if err != nil {
 			return ChatResponseMsg{Err: err}
 		}
+	}
 
-		return ChatResponseMsg{Message: response}
+	// Send message, forwarding the reply as it streams in
+	response, err := chatEngine.ChatStream(ctx, session, userMessage, func(chunk string) {
+		stream <- ChatChunkMsg{Text: chunk}
+	})
+	if err != nil {
+		return ChatResponseMsg{Err: err}
 	}
+
+	return ChatResponseMsg{Message: response, SessionID: session.ID}
 }
 
 // loadChatMenu loads the chat submenu
@@ -172,6 +195,16 @@ func (m *Model) loadChatMenu() {
 			description: "Pick any paper from library, process it, and start chatting",
 			action:      "chat_any",
 		},
+		item{
+			title:       "💬 Chat with Entire Library",
+			description: "Ask questions across every indexed paper",
+			action:      "chat_library",
+		},
+		item{
+			title:       "🕘 Resume Previous Chat",
+			description: "Continue a conversation from the last 24 hours",
+			action:      "chat_resume",
+		},
 	}
 
 	delegate := createStyledDelegate()
@@ -184,11 +217,126 @@ func (m *Model) loadChatMenu() {
 	m.chatMenu = chatMenu
 }
 
+// startLibraryChat opens a chat session with no paper filter, so retrieval
+// spans the whole FAISS index
+func (m *Model) startLibraryChat() {
+	m.chatIndexedCount = countIndexedPapers(m.config.FAISS.IndexDir, m.config.RAG.EmbeddingDim)
+	m.chatSelectedPapers = nil
+	m.chatMessages = []ChatMessage{}
+	if m.chatIndexedCount == 0 {
+		m.chatMessages = append(m.chatMessages, ChatMessage{
+			Role:    "assistant",
+			Content: "⚠️  No papers are indexed yet. Process papers with RAG indexing enabled, or run `rph index`, to chat with your library.",
+		})
+	}
+
+	m.navigateTo(screenChat)
+	m.chatInput = ""
+	m.chatLoading = false
+	m.chatSessionID = fmt.Sprintf("tui_session_%d", time.Now().UnixNano())
+}
+
+// countIndexedPapers returns the number of papers in the FAISS index at indexDir
+func countIndexedPapers(indexDir string, dim int) int {
+	vectorStore, err := rag.NewFAISSVectorStore(indexDir, dim)
+	if err != nil {
+		log.Printf("⚠️  Warning: Could not load vector store: %v", err)
+		return 0
+	}
+	return len(vectorStore.GetIndexedPapers())
+}
+
+// loadChatSessions loads the chat sessions saved in Redis, most recent first
+func (m *Model) loadChatSessions() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     m.config.Cache.Redis.Addr,
+		Password: m.config.Cache.Redis.Password,
+		DB:       m.config.Cache.Redis.DB,
+	})
+	defer redisClient.Close()
+
+	// Only Redis is needed to read session history
+	chatEngine := chat.NewChatEngine(nil, nil, redisClient, nil)
+	sessions, err := chatEngine.ListSessions(ctx)
+	if err != nil {
+		log.Printf("⚠️  Warning: Could not list chat sessions: %v", err)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUpdated.After(sessions[j].LastUpdated)
+	})
+
+	items := []list.Item{}
+	for _, session := range sessions {
+		title := "All papers"
+		if len(session.PaperTitles) > 0 {
+			title = strings.Join(session.PaperTitles, ", ")
+		}
+
+		items = append(items, item{
+			title:       title,
+			description: fmt.Sprintf("Last updated %s • %d messages", session.LastUpdated.Format("2006-01-02 15:04"), len(session.Messages)),
+			action:      session.ID,
+		})
+	}
+
+	delegate := createStyledDelegate()
+	sessionList := list.New(items, delegate, 0, 0)
+	sessionList.Title = fmt.Sprintf("🕘 Resume Previous Chat (%d sessions)", len(items))
+	sessionList.SetShowStatusBar(false)
+	sessionList.SetFilteringEnabled(false)
+	sessionList.Styles.Title = titleStyle
+
+	m.chatSessionList = sessionList
+}
+
+// resumeChatSession loads a saved session's history and reopens it in the chat screen
+func (m *Model) resumeChatSession(sessionID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr:     m.config.Cache.Redis.Addr,
+		Password: m.config.Cache.Redis.Password,
+		DB:       m.config.Cache.Redis.DB,
+	})
+	defer redisClient.Close()
+
+	chatEngine := chat.NewChatEngine(nil, nil, redisClient, nil)
+	session, err := chatEngine.GetSession(ctx, sessionID)
+	if err != nil {
+		log.Printf("❌ Failed to load chat session %s: %v", sessionID, err)
+		m.loadChatSessions()
+		return
+	}
+
+	m.chatMessages = make([]ChatMessage, 0, len(session.Messages))
+	for _, message := range session.Messages {
+		m.chatMessages = append(m.chatMessages, ChatMessage{
+			Role:      message.Role,
+			Content:   message.Content,
+			Citations: message.Citations,
+		})
+	}
+
+	m.chatSelectedPapers = session.PaperTitles
+	if len(m.chatSelectedPapers) == 0 {
+		m.chatIndexedCount = countIndexedPapers(m.config.FAISS.IndexDir, m.config.RAG.EmbeddingDim)
+	}
+
+	m.navigateTo(screenChat)
+	m.chatInput = ""
+	m.chatLoading = false
+	m.chatSessionID = session.ID
+}
+
 // loadPapersForChat loads papers for chat selection
 func (m *Model) loadPapersForChat() {
 	// Load FAISS vector store to check which papers are indexed
-	indexDir := filepath.Join(".metadata", "vector_index")
-	vectorStore, err := rag.NewFAISSVectorStore(indexDir)
+	vectorStore, err := rag.NewFAISSVectorStore(m.config.FAISS.IndexDir, m.config.RAG.EmbeddingDim)
 	if err != nil {
 		log.Printf("⚠️  Warning: Could not load vector store: %v", err)
 		vectorStore = nil
@@ -321,7 +469,8 @@ func (m *Model) loadAnyPaperForChat() {
 
 // renderChatScreen renders the chat interface
 func (m Model) renderChatScreen() string {
-	if m.chatLoading {
+	// Show the spinner until the first chunk of the reply arrives
+	if m.chatLoading && !m.chatStreaming {
 		return m.renderChatLoading()
 	}
 
@@ -339,21 +488,21 @@ func (m Model) renderChatScreen() string {
 		Foreground(lipgloss.Color("86")).
 		Render("💬 Chat Session") + "\n\n")
 
-	// Show selected papers
-	if len(m.chatSelectedPapers) > 0 {
-		chatHistory.WriteString(lipgloss.NewStyle().
-			Foreground(lipgloss.Color("242")).
-			Render("Papers: "))
-
-		for i, paperTitle := range m.chatSelectedPapers {
-			if i > 0 {
-				chatHistory.WriteString(", ")
-			}
-			chatHistory.WriteString(paperTitle)
+	// Show selected papers (none selected means the whole library)
+	chatHistory.WriteString(lipgloss.NewStyle().
+		Foreground(lipgloss.Color("242")).
+		Render("Papers: "))
+	if len(m.chatSelectedPapers) == 0 {
+		chatHistory.WriteString(fmt.Sprintf("All papers (%d indexed)", m.chatIndexedCount))
+	}
+	for i, paperTitle := range m.chatSelectedPapers {
+		if i > 0 {
+			chatHistory.WriteString(", ")
 		}
-		chatHistory.WriteString("\n")
-		chatHistory.WriteString(strings.Repeat("─", m.width-8) + "\n\n")
+		chatHistory.WriteString(paperTitle)
 	}
+	chatHistory.WriteString("\n")
+	chatHistory.WriteString(strings.Repeat("─", m.width-8) + "\n\n")
 
 	// Render messages
 	maxHeight := m.height - 15
@@ -383,7 +532,7 @@ func (m Model) renderChatScreen() string {
 				chatHistory.WriteString(lipgloss.NewStyle().
 					Foreground(lipgloss.Color("242")).
 					Italic(true).
-					Render("\n📚 Sources: " + strings.Join(msg.Citations, ", ")) + "\n")
+					Render("\n📚 Sources: " + strings.Join(msg.Citations, "; ")) + "\n")
 			}
 			chatHistory.WriteString("\n")
 		}
@@ -420,8 +569,5 @@ func (m Model) renderChatLoading() string {
 		Foreground(lipgloss.Color("86")).
 		Render("🤖 Archivist is thinking...")
 
-	spinner := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-	frame := spinner[int(time.Now().UnixMilli()/100)%len(spinner)]
-
-	return "\n\n" + loading + " " + frame + "\n\n" + helpStyle.Render("Please wait...")
+	return "\n\n" + loading + " " + spinnerFrame() + "\n\n" + helpStyle.Render("Please wait...")
 }
@@ -62,8 +62,8 @@ func (m Model) handleChatSpacebar() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Get current index
-	idx := m.chatPaperList.Index()
+	// Index into the full paper list, not the filtered view
+	idx := m.chatPaperList.GlobalIndex()
 
 	// Toggle selection
 	if m.multiSelectIndexes[idx] {
@@ -102,9 +102,14 @@ func (m Model) handleChatInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Clear input and set loading
 		m.chatInput = ""
 		m.chatLoading = true
+		m.chatStreaming = false
+		m.chatStream = make(chan tea.Msg, 64)
 
-		// Send message to chat engine
-		return m, SendChatMessage(m.config, m.chatSessionID, userMessage, m.chatSelectedPapers)
+		// Send message to chat engine and render the reply as it streams in
+		return m, tea.Batch(
+			SendChatMessage(m.config, m.chatSessionID, userMessage, m.chatSelectedPapers, m.chatStream),
+			waitForChatMsg(m.chatStream),
+		)
 
 	case "backspace":
 		if len(m.chatInput) > 0 {
@@ -124,9 +129,27 @@ func (m Model) handleChatInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleChatChunk appends the next piece of a streamed reply
+func (m Model) handleChatChunk(msg ChatChunkMsg) (tea.Model, tea.Cmd) {
+	if !m.chatStreaming {
+		m.chatStreaming = true
+		m.chatMessages = append(m.chatMessages, ChatMessage{Role: "assistant"})
+	}
+	m.chatMessages[len(m.chatMessages)-1].Content += msg.Text
+
+	return m, waitForChatMsg(m.chatStream)
+}
+
 // handleChatResponse handles response from chat engine
 func (m Model) handleChatResponse(msg ChatResponseMsg) (tea.Model, tea.Cmd) {
 	m.chatLoading = false
+	streamed := m.chatStreaming
+	m.chatStreaming = false
+	m.chatStream = nil
+	if msg.SessionID != "" {
+		// Keep replying into the same session
+		m.chatSessionID = msg.SessionID
+	}
 
 	if msg.Err != nil {
 		// Add error message
@@ -137,7 +160,10 @@ func (m Model) handleChatResponse(msg ChatResponseMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Add assistant message
+	// Replace the streamed partial reply with the final message, citations included
+	if streamed {
+		m.chatMessages = m.chatMessages[:len(m.chatMessages)-1]
+	}
 	m.chatMessages = append(m.chatMessages, ChatMessage{
 		Role:      "assistant",
 		Content:   msg.Message.Content,
@@ -16,8 +16,7 @@ import (
 // indexPaperIfNeeded checks if a paper is indexed, and indexes it if not
 func indexPaperIfNeeded(ctx context.Context, config *app.Config, paperTitle string) error {
 	// Check if already indexed
-	indexDir := filepath.Join(".metadata", "vector_index")
-	vectorStore, err := rag.NewFAISSVectorStore(indexDir)
+	vectorStore, err := rag.NewFAISSVectorStore(config.FAISS.IndexDir, config.RAG.EmbeddingDim)
 	if err != nil {
 		return fmt.Errorf("failed to load vector store: %w", err)
 	}
@@ -29,6 +29,11 @@ func (m *Model) loadGraphMenu() {
 			description: "View your processed papers and their relationships",
 			action:      "graph_my_papers",
 		},
+		item{
+			title:       "🧭 What to Read Next",
+			description: "Suggest papers from your library based on one you've read",
+			action:      "graph_recommend",
+		},
 		item{
 			title:       "🌐 Open Neo4j Browser",
 			description: "Visualize the graph at http://localhost:7474",
@@ -62,6 +67,10 @@ func (m *Model) handleGraphMenuAction(action string) {
 	case "graph_my_papers":
 		m.navigateTo(screenGraphMyPapers)
 		m.loadMyPapersInGraph()
+	case "graph_recommend":
+		m.err = nil
+		m.navigateTo(screenGraphRecommendSelect)
+		m.loadRecommendSeeds()
 	case "graph_neo4j":
 		// Display Neo4j URL info
 		m.err = fmt.Errorf("Open in browser: http://localhost:7474\nUsername: neo4j\nPassword: password")
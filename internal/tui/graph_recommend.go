@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"archivist/internal/graph"
+	"archivist/internal/storage"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recommendationsMsg is sent when graph recommendations for a paper are ready
+type recommendationsMsg struct {
+	seed            string
+	recommendations []graph.PaperRecommendation
+	err             error
+}
+
+// loadRecommendSeeds lists processed papers by title so one can be picked as
+// the starting point for recommendations
+func (m *Model) loadRecommendSeeds() {
+	store, err := storage.Open(m.config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		m.err = fmt.Errorf("Failed to open metadata store: %v", err)
+		return
+	}
+	defer store.Close()
+
+	var titles []string
+	for _, record := range store.GetRecordsByStatus(storage.StatusCompleted) {
+		if record.PaperTitle != "" {
+			titles = append(titles, record.PaperTitle)
+		}
+	}
+	sort.Strings(titles)
+
+	items := make([]list.Item, len(titles))
+	for i, title := range titles {
+		items[i] = item{
+			title:       title,
+			description: "Suggest papers to read after this one",
+			action:      title,
+		}
+	}
+
+	delegate := createStyledDelegate()
+	m.graphRecommendSeeds = list.New(items, delegate, 0, 0)
+	m.graphRecommendSeeds.Title = fmt.Sprintf("🧭 What did you just read? (%d processed papers)", len(titles))
+	m.graphRecommendSeeds.SetShowStatusBar(false)
+	m.graphRecommendSeeds.SetFilteringEnabled(true)
+	m.graphRecommendSeeds.Styles.Title = titleStyle
+	if m.width > 0 && m.height > 0 {
+		m.graphRecommendSeeds.SetSize(m.width-4, m.height-8)
+	}
+}
+
+// handleRecommendSeedSelection starts fetching recommendations for the selected paper
+func (m *Model) handleRecommendSeedSelection() (tea.Model, tea.Cmd) {
+	selectedItem := m.graphRecommendSeeds.SelectedItem()
+	if selectedItem == nil {
+		return m, nil
+	}
+
+	m.graphRecommendSeed = selectedItem.(item).action
+	m.graphRecommendLoading = true
+	m.graphRecommendError = ""
+	m.navigateTo(screenGraphRecommendations)
+
+	return m, m.fetchRecommendations(m.graphRecommendSeed)
+}
+
+// fetchRecommendations asks Neo4j for papers to read after seed
+func (m *Model) fetchRecommendations(seed string) tea.Cmd {
+	config := m.config
+	return func() tea.Msg {
+		builder, err := graph.NewEnhancedNeo4jBuilder(&graph.GraphConfig{
+			URI:                   config.Graph.Neo4j.URI,
+			Username:              config.Graph.Neo4j.Username,
+			Password:              config.Graph.Neo4j.Password,
+			Database:              config.Graph.Neo4j.Database,
+			MaxConnectionPoolSize: config.Graph.Neo4j.MaxConnectionPoolSize,
+			ConnectionTimeout:     time.Duration(config.Graph.Neo4j.ConnectionTimeout) * time.Second,
+			MaxConcurrentSessions: config.Graph.MaxGraphWorkers,
+			ConnectAttempts:       config.Graph.Neo4j.ConnectAttempts,
+			ConnectRetryDelay:     time.Duration(config.Graph.Neo4j.ConnectRetryDelay) * time.Second,
+		})
+		if err != nil {
+			return recommendationsMsg{seed: seed, err: fmt.Errorf("failed to connect to Neo4j: %w", err)}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		defer builder.Close(ctx)
+
+		recommendations, err := builder.RecommendPapers(ctx, []string{seed}, 20)
+		return recommendationsMsg{seed: seed, recommendations: recommendations, err: err}
+	}
+}
+
+// handleRecommendations shows fetched recommendations
+func (m *Model) handleRecommendations(msg recommendationsMsg) (tea.Model, tea.Cmd) {
+	if msg.seed != m.graphRecommendSeed {
+		return m, nil // The user picked another paper in the meantime
+	}
+	m.graphRecommendLoading = false
+
+	if msg.err != nil {
+		m.graphRecommendError = msg.err.Error()
+		return m, nil
+	}
+
+	items := make([]list.Item, len(msg.recommendations))
+	for i, rec := range msg.recommendations {
+		items[i] = item{
+			title:       fmt.Sprintf("%s (%.0f%%)", rec.Title, rec.Score*100),
+			description: rec.Reason,
+			action:      rec.Title,
+		}
+	}
+
+	delegate := createStyledDelegate()
+	m.graphRecommendations = list.New(items, delegate, 0, 0)
+	m.graphRecommendations.Title = "📚 Read next"
+	m.graphRecommendations.SetShowStatusBar(false)
+	m.graphRecommendations.SetFilteringEnabled(false)
+	m.graphRecommendations.Styles.Title = titleStyle
+	if m.width > 0 && m.height > 0 {
+		m.graphRecommendations.SetSize(m.width-4, m.height-10)
+	}
+
+	return m, nil
+}
+
+// renderGraphRecommendSeeds renders the paper picker for recommendations
+func (m Model) renderGraphRecommendSeeds() string {
+	if m.err != nil {
+		return titleStyle.Render("🧭 WHAT TO READ NEXT") + "\n\n" +
+			errorStyle.Render(m.err.Error()) + "\n\n" +
+			helpStyle.Render("Press 'esc' to go back")
+	}
+	if len(m.graphRecommendSeeds.Items()) == 0 {
+		return titleStyle.Render("🧭 WHAT TO READ NEXT") + "\n\n" +
+			infoStyle.Render("No processed papers yet. Process some papers with graph building enabled first.") + "\n\n" +
+			helpStyle.Render("Press 'esc' to go back")
+	}
+
+	return m.graphRecommendSeeds.View()
+}
+
+// renderGraphRecommendations renders the recommendations for the chosen paper
+func (m Model) renderGraphRecommendations() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("🧭 WHAT TO READ NEXT") + "\n\n")
+	b.WriteString(subtitleStyle.Render("After: "+m.graphRecommendSeed) + "\n\n")
+
+	switch {
+	case m.graphRecommendLoading:
+		b.WriteString(infoStyle.Render("Scoring papers in the knowledge graph...") + "\n")
+	case m.graphRecommendError != "":
+		b.WriteString(errorStyle.Render(m.graphRecommendError) + "\n\n")
+		b.WriteString(helpStyle.Render("Is Neo4j running? Start it with: docker-compose -f docker-compose-graph.yml up -d") + "\n")
+	case len(m.graphRecommendations.Items()) == 0:
+		b.WriteString(infoStyle.Render("No related papers found in the graph yet.") + "\n")
+	default:
+		b.WriteString(m.graphRecommendations.View())
+	}
+
+	return b.String()
+}
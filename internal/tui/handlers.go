@@ -10,7 +10,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -26,8 +25,8 @@ func (m Model) handleSpacebar() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Get current index
-	idx := m.multiPaperList.Index()
+	// Index into the full paper list, not the filtered view
+	idx := m.multiPaperList.GlobalIndex()
 
 	// Toggle selection
 	if m.multiSelectIndexes[idx] {
@@ -66,6 +65,11 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 					description: "Select a paper from your library to find similar papers",
 					action:      "similar",
 				},
+				item{
+					title:       "🗂️  Similar Papers in My Library",
+					description: "Find papers you already have that resemble an indexed paper",
+					action:      "similar_local",
+				},
 			}
 			delegate := createStyledDelegate()
 			m.searchModeMenu = list.New(modeItems, delegate, m.width, m.height)
@@ -81,6 +85,7 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 			m.searchInput = ""
 			m.searchLoading = false
 			m.searchError = ""
+			m.checkSearchService()
 		case "view_library":
 			m.navigateTo(screenViewLibrary)
 			m.loadLibraryPapers()
@@ -97,10 +102,7 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 			m.navigateTo(screenSelectMultiplePapers)
 			m.loadPapersForMultiSelection()
 		case "process_all":
-			// Exit TUI and trigger batch processing
-			m.processing = true
-			m.processingMsg = "batch"
-			return m, tea.Quit
+			return m.processLibrary()
 		case "settings":
 			m.navigateTo(screenSettings)
 			m.loadSettingsMenu()
@@ -119,15 +121,14 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 		}
 
 		// Collect selected papers
-		m.selectedPapers = []string{}
+		var papers []string
 		for idx := range m.multiSelectIndexes {
 			if idx < len(m.allPapersForSelect) {
-				m.selectedPapers = append(m.selectedPapers, m.allPapersForSelect[idx])
+				papers = append(papers, m.allPapersForSelect[idx])
 			}
 		}
 
-		// Exit TUI to start processing
-		return m, tea.Quit
+		return m.startBatch(papers)
 	} else if m.screen == screenViewLibrary {
 		// Handle PDF opening in library view
 		selectedItem := m.libraryList.SelectedItem()
@@ -150,9 +151,7 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 		// Handle paper selection
 		selectedItem := m.singlePaperList.SelectedItem()
 		if selectedItem != nil {
-			m.selectedPaper = selectedItem.(item).action
-			// Start processing
-			return m, tea.Quit
+			return m.startBatch([]string{selectedItem.(item).action})
 		}
 	} else if m.screen == screenChatMenu {
 		// Handle chat menu selection
@@ -166,8 +165,19 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 			case "chat_any":
 				m.navigateTo(screenChatSelectAnyPaper)
 				m.loadAnyPaperForChat()
+			case "chat_library":
+				m.startLibraryChat()
+			case "chat_resume":
+				m.navigateTo(screenChatResumeSelect)
+				m.loadChatSessions()
 			}
 		}
+	} else if m.screen == screenChatResumeSelect {
+		// Handle saved session selection
+		selectedItem := m.chatSessionList.SelectedItem()
+		if selectedItem != nil {
+			m.resumeChatSession(selectedItem.(item).action)
+		}
 	} else if m.screen == screenChatSelectAnyPaper {
 		// Handle selection from any paper list (similar to single select)
 		selectedItem := m.chatPaperList.SelectedItem()
@@ -189,6 +199,12 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 	} else if m.screen == screenSimilarFactorsEdit {
 		// Don't handle enter here - handled separately in Update
 		return m, nil
+	} else if m.screen == screenLocalSimilarSelect {
+		// Search the library for papers like the selected one
+		return m.handleLocalSimilarSelection()
+	} else if m.screen == screenGraphRecommendSelect {
+		// Suggest papers to read after the selected one
+		return m.handleRecommendSeedSelection()
 	} else if m.screen == screenGraphMenu {
 		// Handle graph menu selection
 		selectedItem := m.graphMenu.SelectedItem()
@@ -207,16 +223,7 @@ func handleOpenPDF(pdfPath string) error {
 	ui.ShowBanner()
 	ui.PrintInfo(fmt.Sprintf("Opening: %s", pdfPath))
 
-	// Try xdg-open (Linux), open (macOS), or start (Windows)
-	var cmd string
-	var args []string
-
-	// Detect OS and use appropriate command
-	cmd = "xdg-open" // Default for Linux
-	args = []string{pdfPath}
-
-	err := exec.Command(cmd, args...).Start()
-	if err != nil {
+	if err := fileutil.OpenFile(pdfPath); err != nil {
 		ui.PrintError(fmt.Sprintf("Failed to open PDF: %v", err))
 		ui.PrintInfo("Please open the file manually:")
 		ui.ColorBold.Printf("  %s\n\n", pdfPath)
@@ -248,356 +255,6 @@ func handleOpenPDF(pdfPath string) error {
 	return Run("config/config.yaml")
 }
 
-// handleSinglePaperProcessing processes a single selected paper
-func handleSinglePaperProcessing(paperPath string, config *app.Config) error {
-	// Clear screen and show banner
-	fmt.Print("\033[H\033[2J")
-	ui.ShowBanner()
-
-	// Initialize logger
-	logCleanup, err := app.InitLogger(config)
-	if err != nil {
-		ui.PrintError(fmt.Sprintf("Failed to initialize logger: %v", err))
-		return err
-	}
-	defer logCleanup()
-
-	// Select processing mode
-	selectedMode, err := ui.PromptMode()
-	if err != nil {
-		// User cancelled - return to TUI
-		ui.PrintWarning("Mode selection cancelled, returning to main menu...")
-		fmt.Println()
-		ui.PrintInfo("Press Enter to continue...")
-		fmt.Scanln()
-		return Run("config/config.yaml")
-	}
-
-	// Apply mode configuration
-	applyModeConfig(config, selectedMode)
-	ui.ShowModeDetailsWithConfig(selectedMode, config)
-
-	// Check dependencies
-	ui.PrintStage("Checking Dependencies", "Verifying LaTeX installation")
-	if err := compiler.CheckDependencies(config.Latex.Engine == "latexmk", config.Latex.Compiler); err != nil {
-		ui.PrintError(fmt.Sprintf("Dependency check failed: %v", err))
-		fmt.Println("\nPlease install the required LaTeX tools:")
-		fmt.Println("  sudo apt install texlive-latex-extra latexmk")
-		return err
-	}
-	ui.PrintSuccess("All dependencies installed")
-
-	// Confirm processing
-	if !ui.ConfirmProcessing(1) {
-		ui.PrintWarning("Processing cancelled by user")
-		return nil
-	}
-
-	// Ask if user wants to enable RAG indexing for chat
-	enableRAG := ui.PromptEnableRAG()
-
-	// Process the paper
-	fmt.Println()
-	ui.PrintStage("Processing Paper", filepath.Base(paperPath))
-	ctx := context.Background()
-	enableGraphBuilding := config.Graph.Enabled && ui.PromptEnableGraphBuilding()
-	if err := worker.ProcessBatch(ctx, []string{paperPath}, config, false, enableRAG, enableGraphBuilding); err != nil {
-		ui.PrintError(fmt.Sprintf("Processing failed: %v", err))
-		fmt.Println()
-		ui.PrintWarning("Processing encountered an error")
-		fmt.Println()
-		ui.PrintInfo("Error logged to .metadata/processing.log")
-		fmt.Println()
-		ui.PrintInfo("Press Enter to return to main menu (or wait 3 seconds)...")
-
-		// Wait for user input with timeout
-		done := make(chan bool, 1)
-		go func() {
-			reader := bufio.NewReader(os.Stdin)
-			reader.ReadString('\n')
-			done <- true
-		}()
-
-		// Wait for Enter or timeout
-		select {
-		case <-done:
-			// User pressed Enter
-		case <-time.After(3 * time.Second):
-			// Timeout after 3 seconds
-			fmt.Println("\nReturning to main menu...")
-		}
-
-		// Return to TUI
-		return Run("config/config.yaml")
-	}
-
-	// Processing successful, return to main menu
-	fmt.Println()
-	ui.PrintSuccess("Processing complete!")
-	fmt.Println()
-	ui.PrintInfo("Press Enter to return to main menu (or wait 3 seconds)...")
-
-	// Wait for user input with timeout
-	done := make(chan bool, 1)
-	go func() {
-		reader := bufio.NewReader(os.Stdin)
-		reader.ReadString('\n')
-		done <- true
-	}()
-
-	// Wait for Enter or timeout
-	select {
-	case <-done:
-		// User pressed Enter
-	case <-time.After(3 * time.Second):
-		// Timeout after 3 seconds
-		fmt.Println("\nReturning to main menu...")
-	}
-
-	// Return to TUI
-	return Run("config/config.yaml")
-}
-
-// handleBatchProcessing processes all papers in the library
-func handleBatchProcessing(config *app.Config) error {
-	// Clear screen and show banner
-	fmt.Print("\033[H\033[2J")
-	ui.ShowBanner()
-
-	// Initialize logger
-	logCleanup, err := app.InitLogger(config)
-	if err != nil {
-		ui.PrintError(fmt.Sprintf("Failed to initialize logger: %v", err))
-		return err
-	}
-	defer logCleanup()
-
-	// Select processing mode
-	selectedMode, err := ui.PromptMode()
-	if err != nil {
-		// User cancelled - return to TUI
-		ui.PrintWarning("Mode selection cancelled, returning to main menu...")
-		fmt.Println()
-		ui.PrintInfo("Press Enter to continue...")
-		fmt.Scanln()
-		return Run("config/config.yaml")
-	}
-
-	// Apply mode configuration
-	applyModeConfig(config, selectedMode)
-	ui.ShowModeDetailsWithConfig(selectedMode, config)
-
-	ui.PrintInfo(fmt.Sprintf("Using %d parallel workers", config.Processing.MaxWorkers))
-
-	// Check dependencies
-	ui.PrintStage("Checking Dependencies", "Verifying LaTeX installation")
-	if err := compiler.CheckDependencies(config.Latex.Engine == "latexmk", config.Latex.Compiler); err != nil {
-		ui.PrintError(fmt.Sprintf("Dependency check failed: %v", err))
-		fmt.Println("\nPlease install the required LaTeX tools:")
-		fmt.Println("  sudo apt install texlive-latex-extra latexmk")
-		return err
-	}
-	ui.PrintSuccess("All dependencies installed")
-
-	// Get all PDF files
-	files, err := fileutil.GetPDFFiles(config.InputDir)
-	if err != nil {
-		ui.PrintError(fmt.Sprintf("Failed to get PDF files: %v", err))
-		return err
-	}
-
-	if len(files) == 0 {
-		ui.PrintWarning("No PDF files found in library")
-		return nil
-	}
-
-	ui.PrintInfo(fmt.Sprintf("Found %d PDF file(s)", len(files)))
-
-	// Confirm processing
-	if !ui.ConfirmProcessing(len(files)) {
-		ui.PrintWarning("Processing cancelled by user")
-		return nil
-	}
-
-	// Process all files
-	fmt.Println()
-	ui.PrintStage("Processing Papers", "Starting batch processing")
-	ctx := context.Background()
-	// Ask if user wants to enable RAG indexing for chat
-	enableRAG := ui.PromptEnableRAG()
-	enableGraphBuilding := config.Graph.Enabled && ui.PromptEnableGraphBuilding()
-
-	if err := worker.ProcessBatch(ctx, files, config, false, enableRAG, enableGraphBuilding); err != nil {
-		ui.PrintError(fmt.Sprintf("Processing failed: %v", err))
-		fmt.Println()
-		ui.PrintWarning("Batch processing encountered an error")
-		fmt.Println()
-		ui.PrintInfo("Error logged to .metadata/processing.log")
-		fmt.Println()
-		ui.PrintInfo("Press Enter to return to main menu (or wait 3 seconds)...")
-
-		// Wait for user input with timeout
-		done := make(chan bool, 1)
-		go func() {
-			reader := bufio.NewReader(os.Stdin)
-			reader.ReadString('\n')
-			done <- true
-		}()
-
-		// Wait for Enter or timeout
-		select {
-		case <-done:
-			// User pressed Enter
-		case <-time.After(3 * time.Second):
-			// Timeout after 3 seconds
-			fmt.Println("\nReturning to main menu...")
-		}
-
-		// Return to TUI
-		return Run("config/config.yaml")
-	}
-
-	// Processing successful, return to main menu
-	fmt.Println()
-	ui.PrintSuccess("Batch processing complete!")
-	fmt.Println()
-	ui.PrintInfo("Press Enter to return to main menu (or wait 3 seconds)...")
-
-	// Wait for user input with timeout
-	done := make(chan bool, 1)
-	go func() {
-		reader := bufio.NewReader(os.Stdin)
-		reader.ReadString('\n')
-		done <- true
-	}()
-
-	// Wait for Enter or timeout
-	select {
-	case <-done:
-		// User pressed Enter
-	case <-time.After(3 * time.Second):
-		// Timeout after 3 seconds
-		fmt.Println("\nReturning to main menu...")
-	}
-
-	// Return to TUI
-	return Run("config/config.yaml")
-}
-
-// handleMultiplePapersProcessing processes multiple selected papers
-func handleMultiplePapersProcessing(paperPaths []string, config *app.Config) error {
-	// Clear screen and show banner
-	fmt.Print("\033[H\033[2J")
-	ui.ShowBanner()
-
-	// Initialize logger
-	logCleanup, err := app.InitLogger(config)
-	if err != nil {
-		ui.PrintError(fmt.Sprintf("Failed to initialize logger: %v", err))
-		return err
-	}
-	defer logCleanup()
-
-	// Select processing mode
-	selectedMode, err := ui.PromptMode()
-	if err != nil {
-		// User cancelled - return to TUI
-		ui.PrintWarning("Mode selection cancelled, returning to main menu...")
-		fmt.Println()
-		ui.PrintInfo("Press Enter to continue...")
-		fmt.Scanln()
-		return Run("config/config.yaml")
-	}
-
-	// Apply mode configuration
-	applyModeConfig(config, selectedMode)
-	ui.ShowModeDetailsWithConfig(selectedMode, config)
-
-	ui.PrintInfo(fmt.Sprintf("Using %d parallel workers", config.Processing.MaxWorkers))
-
-	// Check dependencies
-	ui.PrintStage("Checking Dependencies", "Verifying LaTeX installation")
-	if err := compiler.CheckDependencies(config.Latex.Engine == "latexmk", config.Latex.Compiler); err != nil {
-		ui.PrintError(fmt.Sprintf("Dependency check failed: %v", err))
-		fmt.Println("\nPlease install the required LaTeX tools:")
-		fmt.Println("  sudo apt install texlive-latex-extra latexmk")
-		return err
-	}
-	ui.PrintSuccess("All dependencies installed")
-
-	ui.PrintInfo(fmt.Sprintf("Found %d PDF file(s) to process", len(paperPaths)))
-
-	// Confirm processing
-	if !ui.ConfirmProcessing(len(paperPaths)) {
-		ui.PrintWarning("Processing cancelled by user")
-		return nil
-	}
-
-	// Process the papers
-	fmt.Println()
-	ui.PrintStage("Processing Papers", "Starting batch processing")
-	ctx := context.Background()
-	// Ask if user wants to enable RAG indexing for chat
-	enableRAG := ui.PromptEnableRAG()
-	enableGraphBuilding := config.Graph.Enabled && ui.PromptEnableGraphBuilding()
-
-	if err := worker.ProcessBatch(ctx, paperPaths, config, false, enableRAG, enableGraphBuilding); err != nil {
-		ui.PrintError(fmt.Sprintf("Processing failed: %v", err))
-		fmt.Println()
-		ui.PrintWarning("Processing encountered an error")
-		fmt.Println()
-		ui.PrintInfo("Error logged to .metadata/processing.log")
-		fmt.Println()
-		ui.PrintInfo("Press Enter to return to main menu (or wait 3 seconds)...")
-
-		// Wait for user input with timeout
-		done := make(chan bool, 1)
-		go func() {
-			reader := bufio.NewReader(os.Stdin)
-			reader.ReadString('\n')
-			done <- true
-		}()
-
-		// Wait for Enter or timeout
-		select {
-		case <-done:
-			// User pressed Enter
-		case <-time.After(3 * time.Second):
-			// Timeout after 3 seconds
-			fmt.Println("\nReturning to main menu...")
-		}
-
-		// Return to TUI
-		return Run("config/config.yaml")
-	}
-
-	// Processing successful, return to main menu
-	fmt.Println()
-	ui.PrintSuccess("Processing complete!")
-	fmt.Println()
-	ui.PrintInfo("Press Enter to return to main menu (or wait 3 seconds)...")
-
-	// Wait for user input with timeout
-	done := make(chan bool, 1)
-	go func() {
-		reader := bufio.NewReader(os.Stdin)
-		reader.ReadString('\n')
-		done <- true
-	}()
-
-	// Wait for Enter or timeout
-	select {
-	case <-done:
-		// User pressed Enter
-	case <-time.After(3 * time.Second):
-		// Timeout after 3 seconds
-		fmt.Println("\nReturning to main menu...")
-	}
-
-	// Return to TUI
-	return Run("config/config.yaml")
-}
-
 // handleProcessAndChat processes a paper and immediately starts chat
 func handleProcessAndChat(paperPath string, config *app.Config) error {
 	// Clear screen and show banner
@@ -703,6 +360,7 @@ func applyModeConfig(config *app.Config, mode ui.ProcessingMode) {
 	config.Gemini.Agentic.MultiStageAnalysis = modeConfig.MultiStageAnalysis
 	config.Gemini.Agentic.Stages.LatexGeneration.Validation = modeConfig.ValidationEnabled
 	config.Gemini.Model = modeConfig.Model
+	config.Processing.Mode = string(mode)
 
 	// Use fast model for methodology analysis (only one mode now)
 	config.Gemini.Agentic.Stages.MethodologyAnalysis.Model = "models/gemini-2.0-flash-exp"
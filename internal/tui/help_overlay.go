@@ -0,0 +1,255 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyHelp describes one key (or key combination) in the help overlay
+type keyHelp struct {
+	keys        string
+	description string
+}
+
+// screenHelp lists the keys a screen understands
+type screenHelp struct {
+	name string
+	keys []keyHelp
+}
+
+var (
+	navigateKeys = keyHelp{"↑/↓ k/j", "Move the selection"}
+	filterKeys   = keyHelp{"/", "Filter the list (Esc clears the filter)"}
+	backKeys     = keyHelp{"esc/q", "Back to the previous screen"}
+)
+
+// screenKeymaps is the per-screen keymap shown by the help overlay.
+// Screens without an entry fall back to defaultScreenHelp.
+var screenKeymaps = map[screen]screenHelp{
+	screenMain: {"Main Menu", []keyHelp{
+		navigateKeys,
+		{"enter", "Open the selected item"},
+		{"q", "Quit"},
+	}},
+	screenViewLibrary: {"Library", []keyHelp{
+		navigateKeys,
+		filterKeys,
+		{"enter", "Open the PDF"},
+		backKeys,
+	}},
+	screenViewProcessed: {"Processed Papers", []keyHelp{
+		navigateKeys,
+		filterKeys,
+		{"enter", "Open the report"},
+		backKeys,
+	}},
+	screenSelectPaper: {"Process Single Paper", []keyHelp{
+		navigateKeys,
+		{"enter", "Process the selected paper"},
+		backKeys,
+	}},
+	screenSelectMultiplePapers: {"Process Multiple Papers", []keyHelp{
+		navigateKeys,
+		filterKeys,
+		{"space", "Toggle the paper in the selection"},
+		{"enter", "Process the selected papers"},
+		backKeys,
+	}},
+	screenProcessing: {"Processing", []keyHelp{
+		{"esc/q", "Cancel processing"},
+		{"enter", "Back to the main menu once processing is done"},
+	}},
+	screenChatMenu: {"Chat", []keyHelp{
+		navigateKeys,
+		{"enter", "Choose how to chat"},
+		backKeys,
+	}},
+	screenChatSelectPapers: {"Chat - Select Papers", []keyHelp{
+		navigateKeys,
+		{"space", "Toggle the paper in the selection"},
+		{"enter", "Start chatting about the selected papers"},
+		backKeys,
+	}},
+	screenChatSelectAnyPaper: {"Chat - Any Paper", []keyHelp{
+		navigateKeys,
+		{"enter", "Process the paper, then chat about it"},
+		backKeys,
+	}},
+	screenChatResumeSelect: {"Chat - Resume Session", []keyHelp{
+		navigateKeys,
+		{"enter", "Resume the selected session"},
+		backKeys,
+	}},
+	screenChat: {"Chat", []keyHelp{
+		{"type", "Write a question"},
+		{"enter", "Send the question"},
+		{"backspace", "Delete the last character"},
+	}},
+	screenSearch: {"Search", []keyHelp{
+		{"type", "Enter the query, then the number of results"},
+		{"enter", "Continue / start the search"},
+		{"backspace", "Delete the last character"},
+		backKeys,
+	}},
+	screenSearchResults: {"Search Results", []keyHelp{
+		navigateKeys,
+		{"enter", "Download the selected paper"},
+		backKeys,
+	}},
+	screenSearchMode: {"Search Mode", []keyHelp{
+		navigateKeys,
+		{"enter", "Choose manual, similar-paper or library search"},
+		backKeys,
+	}},
+	screenSimilarPaperSelect: {"Similar Search - Select Paper", []keyHelp{
+		navigateKeys,
+		filterKeys,
+		{"enter", "Extract the paper's key factors"},
+		{"r", "Retry a failed extraction"},
+		{"esc", "Cancel a running extraction"},
+		backKeys,
+	}},
+	screenSimilarFactorsEdit: {"Similar Search - Edit Factors", []keyHelp{
+		{"↑/↓ k/j", "Move between factors"},
+		{"d", "Delete the selected factor"},
+		{"type + enter", "Add a new factor"},
+		{"tab", "Search for similar papers"},
+	}},
+	screenSettings: {"Settings", []keyHelp{
+		navigateKeys,
+		{"enter", "Open the selected setting"},
+		backKeys,
+	}},
+	screenDirectorySettings: {"Directory Settings", []keyHelp{
+		navigateKeys,
+		{"enter", "Choose / open a directory"},
+		{"s", "File browser: use the current directory"},
+		{"h", "File browser: show or hide hidden folders"},
+		{"g", "File browser: go to the home directory"},
+		{"r", "File browser: refresh"},
+		backKeys,
+	}},
+	screenGraphMenu: {"Knowledge Graph", []keyHelp{
+		navigateKeys,
+		{"enter", "Open the selected view"},
+		backKeys,
+	}},
+	screenGraphDashboard: {"Graph Dashboard", []keyHelp{
+		backKeys,
+	}},
+	screenGraphSearch: {"Graph Search", []keyHelp{
+		{"type", "Enter the query"},
+		{"enter", "Search the graph"},
+		backKeys,
+	}},
+	screenGraphMyPapers: {"My Papers in Graph", []keyHelp{
+		navigateKeys,
+		filterKeys,
+		{"enter", "View the paper"},
+		backKeys,
+	}},
+	screenGraphRecommendSelect: {"What to Read Next", []keyHelp{
+		navigateKeys,
+		filterKeys,
+		{"enter", "Suggest papers to read after this one"},
+		backKeys,
+	}},
+	screenGraphRecommendations: {"Reading Suggestions", []keyHelp{
+		navigateKeys,
+		backKeys,
+	}},
+	screenLocalSimilarSelect: {"Similar in Library - Select Paper", []keyHelp{
+		navigateKeys,
+		filterKeys,
+		{"enter", "Find indexed papers like this one"},
+		backKeys,
+	}},
+	screenLocalSimilarResults: {"Similar Papers in Library", []keyHelp{
+		navigateKeys,
+		backKeys,
+	}},
+}
+
+// defaultScreenHelp is shown for screens missing from screenKeymaps
+var defaultScreenHelp = screenHelp{"Current Screen", []keyHelp{
+	navigateKeys,
+	{"enter", "Select"},
+	backKeys,
+}}
+
+// globalKeys work on every screen
+var globalKeys = []keyHelp{
+	{"? / F1", "Show or hide this help (F1 while typing)"},
+	{"ctrl+p", "Command palette"},
+}
+
+// acceptsTextInput reports whether '?' should be typed rather than open the help
+func (m Model) acceptsTextInput() bool {
+	if m.commandPalette.active {
+		return true
+	}
+	if l := m.filterableList(); l != nil && l.SettingFilter() {
+		return true
+	}
+
+	switch m.screen {
+	case screenSearch, screenChat, screenGraphSearch:
+		return true
+	case screenDirectorySettings:
+		return m.directoryInputMode != "" && !m.fileBrowserActive
+	}
+	return false
+}
+
+// handleHelpKey toggles the help overlay. It reports whether the key was used.
+func (m *Model) handleHelpKey(msg tea.KeyMsg) bool {
+	if m.showHelp {
+		// The overlay swallows every key so the screen underneath keeps its state
+		if key.Matches(msg, keys.Help) || msg.String() == "esc" {
+			m.showHelp = false
+		}
+		return true
+	}
+
+	if key.Matches(msg, keys.Help) && (msg.String() == "f1" || !m.acceptsTextInput()) {
+		m.showHelp = true
+		return true
+	}
+	return false
+}
+
+// renderHelpOverlay renders the keys available on the current screen
+func (m Model) renderHelpOverlay() string {
+	help, ok := screenKeymaps[m.screen]
+	if !ok {
+		help = defaultScreenHelp
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("⌨️  Keyboard Shortcuts") + "\n")
+	b.WriteString(subtitleStyle.Render(help.name) + "\n")
+	writeKeyHelp(&b, help.keys)
+	b.WriteString("\n" + subtitleStyle.Render("Everywhere") + "\n")
+	writeKeyHelp(&b, globalKeys)
+	b.WriteString("\n" + helpStyle.Render("Press ? or Esc to close"))
+
+	width := 70
+	if m.width-4 < width {
+		width = m.width - 4
+	}
+	box := boxStyle.Width(width).Render(b.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// writeKeyHelp writes one aligned line per key
+func writeKeyHelp(b *strings.Builder, entries []keyHelp) {
+	for _, entry := range entries {
+		b.WriteString(fmt.Sprintf("  %s %s\n",
+			selectedItemStyle.Width(14).Render(entry.keys), entry.description))
+	}
+}
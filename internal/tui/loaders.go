@@ -31,6 +31,7 @@ func (m *Model) loadLibraryPapers() {
 	m.libraryList = list.New(items, delegate, 0, 0)
 	m.libraryList.Title = fmt.Sprintf("📚 Library Papers (%d total)", len(files))
 	m.libraryList.SetShowStatusBar(false)
+	m.libraryList.SetFilteringEnabled(true)
 	m.libraryList.Styles.Title = titleStyle
 	if m.width > 0 && m.height > 0 {
 		m.libraryList.SetSize(m.width-4, m.height-8)
@@ -60,6 +61,7 @@ func (m *Model) loadProcessedPapers() {
 	m.processedList = list.New(items, delegate, 0, 0)
 	m.processedList.Title = fmt.Sprintf("✅ Processed Papers (%d total)", len(items))
 	m.processedList.SetShowStatusBar(false)
+	m.processedList.SetFilteringEnabled(true)
 	m.processedList.Styles.Title = titleStyle
 	if m.width > 0 && m.height > 0 {
 		m.processedList.SetSize(m.width-4, m.height-8)
@@ -122,6 +124,7 @@ func (m *Model) loadPapersForMultiSelection() {
 	m.multiPaperList = list.New(items, delegate, 0, 0)
 	m.multiPaperList.Title = fmt.Sprintf("📋 Select Papers (Space to toggle, Enter to confirm) - %d available", len(items))
 	m.multiPaperList.SetShowStatusBar(false)
+	m.multiPaperList.SetFilteringEnabled(true)
 	m.multiPaperList.Styles.Title = titleStyle
 	if m.width > 0 && m.height > 0 {
 		m.multiPaperList.SetSize(m.width-4, m.height-8)
@@ -18,6 +18,15 @@ func tickEvery(interval time.Duration) tea.Cmd {
 	})
 }
 
+// spinnerFrames are the frames of the braille spinner shown while waiting
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerFrame returns the spinner frame for the current time, so every
+// redraw (e.g. on a LoadingTickMsg) advances it
+func spinnerFrame() string {
+	return spinnerFrames[int(time.Now().UnixMilli()/100)%len(spinnerFrames)]
+}
+
 // getPokeballAnimation returns frames for a Pokeball capture animation
 func getPokeballAnimation(frame int) string {
 	// More explicit keyframe-like sequence for: three shakes -> close -> glow
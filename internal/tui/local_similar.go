@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"archivist/internal/rag"
+	"archivist/internal/search"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// localSimilarLimit is how many library matches are shown for a paper
+const localSimilarLimit = 10
+
+// localSimilarMsg is sent when library matches for a paper are ready
+type localSimilarMsg struct {
+	seed    string
+	matches []search.LocalMatch
+	err     error
+}
+
+// loadLocalSimilarSeeds lists the papers in the vector index, the only ones
+// that can be compared against the rest of the library
+func (m *Model) loadLocalSimilarSeeds() {
+	m.err = nil
+
+	var titles []string
+	vectorStore, err := rag.NewFAISSVectorStore(m.config.FAISS.IndexDir, m.config.RAG.EmbeddingDim)
+	if err != nil {
+		m.err = fmt.Errorf("Failed to load vector index: %v", err)
+	} else {
+		titles = vectorStore.GetIndexedPapers()
+		sort.Strings(titles)
+	}
+
+	items := make([]list.Item, len(titles))
+	for i, title := range titles {
+		items[i] = item{
+			title:       title,
+			description: "Find papers like this one in your library",
+			action:      title,
+		}
+	}
+
+	delegate := createStyledDelegate()
+	m.localSimilarSeeds = list.New(items, delegate, 0, 0)
+	m.localSimilarSeeds.Title = fmt.Sprintf("🗂️  Select a paper (%d indexed)", len(titles))
+	m.localSimilarSeeds.SetShowStatusBar(false)
+	m.localSimilarSeeds.SetFilteringEnabled(true)
+	m.localSimilarSeeds.Styles.Title = titleStyle
+	if m.width > 0 && m.height > 0 {
+		m.localSimilarSeeds.SetSize(m.width-4, m.height-8)
+	}
+}
+
+// handleLocalSimilarSelection starts searching the library for papers like the selected one
+func (m *Model) handleLocalSimilarSelection() (tea.Model, tea.Cmd) {
+	selectedItem := m.localSimilarSeeds.SelectedItem()
+	if selectedItem == nil {
+		return m, nil
+	}
+
+	m.localSimilarSeed = selectedItem.(item).action
+	m.localSimilarLoading = true
+	m.localSimilarError = ""
+	m.navigateTo(screenLocalSimilarResults)
+
+	return m, m.fetchLocalSimilar(m.localSimilarSeed)
+}
+
+// fetchLocalSimilar searches the local vector index for papers like seed
+func (m *Model) fetchLocalSimilar(seed string) tea.Cmd {
+	config := m.config
+	return func() tea.Msg {
+		vectorStore, err := rag.NewFAISSVectorStore(config.FAISS.IndexDir, config.RAG.EmbeddingDim)
+		if err != nil {
+			return localSimilarMsg{seed: seed, err: fmt.Errorf("failed to load vector index: %w", err)}
+		}
+
+		embedClient, err := rag.NewEmbeddingClient(config.Gemini.APIKey, config.RAG.EmbeddingModel, config.RAG.EmbeddingDim)
+		if err != nil {
+			return localSimilarMsg{seed: seed, err: fmt.Errorf("failed to create embedding client: %w", err)}
+		}
+		defer embedClient.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		finder := search.NewLocalFinder(embedClient, vectorStore)
+		matches, err := finder.FindSimilarLocal(ctx, seed, localSimilarLimit)
+		return localSimilarMsg{seed: seed, matches: matches, err: err}
+	}
+}
+
+// handleLocalSimilar shows the library matches found for a paper
+func (m *Model) handleLocalSimilar(msg localSimilarMsg) (tea.Model, tea.Cmd) {
+	if msg.seed != m.localSimilarSeed {
+		return m, nil // The user picked another paper in the meantime
+	}
+	m.localSimilarLoading = false
+
+	if msg.err != nil {
+		m.localSimilarError = msg.err.Error()
+		return m, nil
+	}
+
+	items := make([]list.Item, len(msg.matches))
+	for i, match := range msg.matches {
+		items[i] = item{
+			title:       match.Title,
+			description: fmt.Sprintf("Similarity: %.0f%%", match.Score*100),
+			action:      match.Title,
+		}
+	}
+
+	delegate := createStyledDelegate()
+	m.localSimilarResults = list.New(items, delegate, 0, 0)
+	m.localSimilarResults.Title = "🗂️  Similar papers in your library"
+	m.localSimilarResults.SetShowStatusBar(false)
+	m.localSimilarResults.SetFilteringEnabled(false)
+	m.localSimilarResults.Styles.Title = titleStyle
+	if m.width > 0 && m.height > 0 {
+		m.localSimilarResults.SetSize(m.width-4, m.height-10)
+	}
+
+	return m, nil
+}
+
+// renderLocalSimilarSeeds renders the paper picker for library search
+func (m Model) renderLocalSimilarSeeds() string {
+	if m.err != nil {
+		return titleStyle.Render("🗂️  SIMILAR PAPERS IN MY LIBRARY") + "\n\n" +
+			errorStyle.Render(m.err.Error()) + "\n\n" +
+			helpStyle.Render("Press 'esc' to go back")
+	}
+	if len(m.localSimilarSeeds.Items()) == 0 {
+		return titleStyle.Render("🗂️  SIMILAR PAPERS IN MY LIBRARY") + "\n\n" +
+			infoStyle.Render("No papers are indexed yet. Run 'rph index' to index your processed papers.") + "\n\n" +
+			helpStyle.Render("Press 'esc' to go back")
+	}
+
+	return m.localSimilarSeeds.View()
+}
+
+// renderLocalSimilarResults renders the library matches for the chosen paper
+func (m Model) renderLocalSimilarResults() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("🗂️  SIMILAR PAPERS IN MY LIBRARY") + "\n\n")
+	b.WriteString(subtitleStyle.Render("Like: "+m.localSimilarSeed) + "\n\n")
+
+	switch {
+	case m.localSimilarLoading:
+		b.WriteString(infoStyle.Render("Searching your library...") + "\n")
+	case m.localSimilarError != "":
+		b.WriteString(errorStyle.Render(m.localSimilarError) + "\n")
+	case len(m.localSimilarResults.Items()) == 0:
+		b.WriteString(infoStyle.Render("No other papers are indexed yet.") + "\n")
+	default:
+		b.WriteString(m.localSimilarResults.View())
+	}
+
+	return b.String()
+}
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"archivist/internal/app"
+	"archivist/internal/search"
 	"archivist/internal/ui"
 	"fmt"
 	"time"
@@ -89,6 +90,9 @@ func InitialModel(configPath string) (*Model, error) {
 	mainMenu.Styles.Title = titleStyle
 	mainMenu.Styles.TitleBar = titleStyle
 
+	searchClient := search.NewClient(config.Search.BaseURL)
+	searchClient.SetCacheTTL(time.Duration(config.Search.CacheTTL) * time.Second)
+
 	m := &Model{
 		screen:             screenMain,
 		config:             config,
@@ -96,6 +100,7 @@ func InitialModel(configPath string) (*Model, error) {
 		commandPalette:     NewCommandPalette(),
 		multiSelectIndexes: make(map[int]bool),
 		graphServiceURL:    "http://localhost:8081", // Default graph service URL
+		searchClient:       searchClient,
 	}
 
 	// Pre-initialize settings menu to avoid blank screen
@@ -137,6 +142,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.chatMenu.SetSize(w, h)
 		case screenChatSelectPapers, screenChatSelectAnyPaper:
 			m.chatPaperList.SetSize(w, h)
+		case screenChatResumeSelect:
+			m.chatSessionList.SetSize(w, h)
 		case screenSearchResults:
 			m.searchResultsList.SetSize(w, h)
 		case screenSearchMode:
@@ -159,27 +166,69 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.graphMyPapers.Items() != nil {
 				m.graphMyPapers.SetSize(w, h)
 			}
+		case screenGraphRecommendSelect:
+			m.graphRecommendSeeds.SetSize(w, h)
+		case screenGraphRecommendations:
+			m.graphRecommendations.SetSize(w, h-2)
+		case screenLocalSimilarSelect:
+			m.localSimilarSeeds.SetSize(w, h)
+		case screenLocalSimilarResults:
+			m.localSimilarResults.SetSize(w, h-2)
 		}
 
 		return m, nil
 
+	case ChatChunkMsg:
+		return m.handleChatChunk(msg)
+
 	case ChatResponseMsg:
 		return m.handleChatResponse(msg)
 
 	case essenceExtractedMsg:
 		return m.handleEssenceExtracted(msg)
 
+	case recommendationsMsg:
+		return m.handleRecommendations(msg)
+
+	case localSimilarMsg:
+		return m.handleLocalSimilar(msg)
+
 	case searchResultMsg:
 		return m.handleSearchResult(msg)
 
+	case batchProgressMsg:
+		return m.handleBatchProgress(msg)
+
+	case batchDoneMsg:
+		return m.handleBatchDone(msg)
+
 	case LoadingTickMsg:
 		if m.searchLoading {
 			m.searchLoadingFrame++
 			return m, tickEvery(100 * time.Millisecond)
 		}
+		if m.similarExtractingEssence {
+			// The spinner frame follows the clock; the tick only redraws it
+			return m, tickEvery(100 * time.Millisecond)
+		}
 		return m, nil
 
 	case tea.KeyMsg:
+		// The help overlay opens on top of any screen
+		if m.handleHelpKey(msg) {
+			return m, nil
+		}
+
+		// The processing screen only reacts to cancel/back keys
+		if m.screen == screenProcessing {
+			return m.handleBatchKey(msg)
+		}
+
+		// While a paper's essence is being extracted, only cancelling is possible
+		if m.screen == screenSimilarPaperSelect && m.similarExtractingEssence {
+			return m.handleEssenceKey(msg)
+		}
+
 		// Handle similar factors editing separately
 		if m.screen == screenSimilarFactorsEdit {
 			return m.handleSimilarFactorsEdit(msg)
@@ -205,6 +254,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleGraphSearchInput(msg)
 		}
 
+		// While a filter is being typed, every key belongs to the filter input
+		if l := m.filterableList(); l != nil && l.SettingFilter() {
+			break
+		}
+
 		// Handle command palette toggle (Ctrl+P)
 		if msg.String() == "ctrl+p" {
 			m.commandPalette.Toggle()
@@ -237,6 +291,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		case "esc", "backspace":
+			// ESC clears an applied filter before leaving the screen
+			if l := m.filterableList(); msg.String() == "esc" && l != nil && l.IsFiltered() {
+				break
+			}
 			if m.screen != screenMain && m.screen != screenChat {
 				m.navigateBack()
 				return m, nil
@@ -245,6 +303,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m.handleChatInput(msg)
 			}
 
+		case "r":
+			// Retry a failed essence extraction for the same paper
+			if m.screen == screenSimilarPaperSelect && m.similarEssenceRetry && !m.similarExtractingEssence {
+				return m.retryEssenceExtraction()
+			}
+
 		case " ": // Spacebar for multi-select
 			if m.screen == screenSelectMultiplePapers {
 				return m.handleSpacebar()
@@ -277,6 +341,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.chatMenu, cmd = m.chatMenu.Update(msg)
 	case screenChatSelectPapers, screenChatSelectAnyPaper:
 		m.chatPaperList, cmd = m.chatPaperList.Update(msg)
+	case screenChatResumeSelect:
+		m.chatSessionList, cmd = m.chatSessionList.Update(msg)
 	case screenSearchResults:
 		m.searchResultsList, cmd = m.searchResultsList.Update(msg)
 	case screenSearchMode:
@@ -304,6 +370,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.graphMyPapers.Items() != nil {
 			m.graphMyPapers, cmd = m.graphMyPapers.Update(msg)
 		}
+	case screenGraphRecommendSelect:
+		m.graphRecommendSeeds, cmd = m.graphRecommendSeeds.Update(msg)
+	case screenGraphRecommendations:
+		m.graphRecommendations, cmd = m.graphRecommendations.Update(msg)
+	case screenLocalSimilarSelect:
+		m.localSimilarSeeds, cmd = m.localSimilarSeeds.Update(msg)
+	case screenLocalSimilarResults:
+		m.localSimilarResults, cmd = m.localSimilarResults.Update(msg)
 	}
 
 	return m, cmd
@@ -319,6 +393,7 @@ func (m Model) executeCommand(action string) (tea.Model, tea.Cmd) {
 		m.searchInputMode = "query"
 		m.searchLoading = false
 		m.searchError = ""
+		m.checkSearchService()
 	case "view_library":
 		m.navigateTo(screenViewLibrary)
 		m.loadLibraryPapers()
@@ -332,9 +407,7 @@ func (m Model) executeCommand(action string) (tea.Model, tea.Cmd) {
 		m.navigateTo(screenSelectMultiplePapers)
 		m.loadPapersForMultiSelection()
 	case "process_all":
-		m.processing = true
-		m.processingMsg = "batch"
-		return m, tea.Quit
+		return m.processLibrary()
 	case "main_menu":
 		m.screen = screenMain
 		m.screenHistory = []screen{} // Clear history
@@ -380,23 +453,12 @@ func Run(configPath string) error {
 	// Handle post-TUI actions
 	finalM := finalModel.(Model)
 
-	if finalM.processing && finalM.processingMsg == "batch" {
-		return handleBatchProcessing(finalM.config)
-	}
-
-	// Handle multiple papers selected
-	if len(finalM.selectedPapers) > 0 {
-		return handleMultiplePapersProcessing(finalM.selectedPapers, finalM.config)
-	}
-
 	if finalM.selectedPaper != "" {
 		switch finalM.processingMsg {
 		case "open_pdf", "open_report":
 			return handleOpenPDF(finalM.selectedPaper)
 		case "process_for_chat":
 			return handleProcessAndChat(finalM.selectedPaper, finalM.config)
-		default:
-			return handleSinglePaperProcessing(finalM.selectedPaper, finalM.config)
 		}
 	}
 
@@ -1,5 +1,7 @@
 package tui
 
+import "github.com/charmbracelet/bubbles/list"
+
 // navigateTo pushes current screen to history and navigates to new screen
 func (m *Model) navigateTo(newScreen screen) {
 	// Only push to history if we're moving to a different screen
@@ -22,19 +24,46 @@ func (m *Model) navigateBack() {
 	}
 }
 
+// filterableList returns the list on the current screen that supports
+// filtering with '/', or nil if the screen has none
+func (m *Model) filterableList() *list.Model {
+	switch m.screen {
+	case screenViewLibrary:
+		return &m.libraryList
+	case screenViewProcessed:
+		return &m.processedList
+	case screenSelectMultiplePapers:
+		return &m.multiPaperList
+	case screenSimilarPaperSelect:
+		return &m.similarPaperList
+	case screenGraphMyPapers:
+		return &m.graphMyPapers
+	case screenGraphRecommendSelect:
+		return &m.graphRecommendSeeds
+	case screenLocalSimilarSelect:
+		return &m.localSimilarSeeds
+	}
+	return nil
+}
+
 // getHelp returns context-appropriate help text
 func (m Model) getHelp() string {
 	switch m.screen {
 	case screenMain:
 		return "↑/↓: Navigate • Enter: Select • Q: Quit"
 	case screenViewLibrary:
-		return "↑/↓: Navigate • Enter: Open PDF • ESC: Back • Q: Quit"
+		return "↑/↓: Navigate • /: Filter • Enter: Open PDF • ESC: Back • Q: Quit"
 	case screenViewProcessed:
-		return "↑/↓: Navigate • Enter: Open Report • ESC: Back • Q: Quit"
+		return "↑/↓: Navigate • /: Filter • Enter: Open Report • ESC: Back • Q: Quit"
 	case screenSelectPaper:
 		return "↑/↓: Navigate • Enter: Process Paper • ESC: Back • Q: Quit"
 	case screenSelectMultiplePapers:
-		return "↑/↓: Navigate • Space: Toggle Selection • Enter: Process Selected • ESC: Back • Q: Quit"
+		return "↑/↓: Navigate • /: Filter • Space: Toggle Selection • Enter: Process Selected • ESC: Back • Q: Quit"
+	case screenProcessing:
+		if m.batchDone {
+			return "Enter/ESC: Back to Main Menu"
+		}
+		return "ESC: Cancel Processing"
 	case screenSearch:
 		return "Type to search • Enter: Search • ESC: Back • Q: Quit"
 	case screenSearchResults:
@@ -47,6 +76,14 @@ func (m Model) getHelp() string {
 		return "Type to search • Enter: Search • ESC: Back • Q: Quit"
 	case screenGraphMyPapers:
 		return "↑/↓: Navigate • Enter: View • ESC: Back • Q: Quit"
+	case screenGraphRecommendSelect:
+		return "↑/↓: Navigate • /: Filter • Enter: Suggest Papers • ESC: Back • Q: Quit"
+	case screenGraphRecommendations:
+		return "↑/↓: Navigate • ESC: Back • Q: Quit"
+	case screenLocalSimilarSelect:
+		return "↑/↓: Navigate • /: Filter • Enter: Find Similar • ESC: Back • Q: Quit"
+	case screenLocalSimilarResults:
+		return "↑/↓: Navigate • ESC: Back • Q: Quit"
 	default:
 		return "↑/↓: Navigate • Enter: Select • ESC: Back • Q: Quit"
 	}
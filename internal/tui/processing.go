@@ -0,0 +1,251 @@
+package tui
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/compiler"
+	"archivist/internal/ui"
+	"archivist/internal/worker"
+	"archivist/pkg/fileutil"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxBatchFailuresShown limits how many failed papers the processing screen lists
+const maxBatchFailuresShown = 5
+
+// batchProgressMsg carries a worker.BatchProgress update into the TUI
+type batchProgressMsg worker.BatchProgress
+
+// batchDoneMsg is sent once the batch has finished, failed or been cancelled
+type batchDoneMsg struct {
+	err error
+}
+
+// startBatch switches to the processing screen and processes files in the
+// background, streaming progress updates back to the TUI
+func (m Model) startBatch(files []string) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m.batchCancel = cancel
+	m.batchStream = make(chan tea.Msg, 64)
+	m.batchProgress = worker.BatchProgress{}
+	m.batchCurrent = ""
+	m.batchFailures = nil
+	m.batchDone = false
+	m.batchCancelled = false
+	m.batchErr = nil
+	m.batchBar = progress.New(progress.WithDefaultGradient())
+	m.navigateTo(screenProcessing)
+
+	return m, tea.Batch(
+		runBatch(ctx, m.config, files, m.batchStream),
+		waitForBatchMsg(m.batchStream),
+	)
+}
+
+// processLibrary starts a batch over every paper in the input directory
+func (m Model) processLibrary() (tea.Model, tea.Cmd) {
+	files, err := fileutil.GetPDFFiles(m.config.InputDir)
+	if err != nil {
+		m.err = err
+		return m, nil
+	}
+	return m.startBatch(files)
+}
+
+// runBatch processes files, delivering batchProgressMsgs and a final
+// batchDoneMsg on stream, which is closed afterwards
+func runBatch(ctx context.Context, config *app.Config, files []string, stream chan<- tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		defer close(stream)
+		stream <- batchDoneMsg{err: processFilesInBackground(ctx, config, files, stream)}
+		return nil
+	}
+}
+
+// waitForBatchMsg waits for the next update from a running batch
+func waitForBatchMsg(stream <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-stream
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// processFilesInBackground runs the worker batch with the prompts of the
+// terminal flow answered up front: fast mode, chat indexing on, and graph
+// building if it is enabled in the config. Services that are down are skipped.
+func processFilesInBackground(ctx context.Context, config *app.Config, files []string, stream chan<- tea.Msg) error {
+	// Keep log output off the screen while the TUI is drawn
+	previousOutput := log.Writer()
+	logConfig := *config
+	logConfig.Logging.Console = false
+	logCleanup, err := app.InitLogger(&logConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	defer logCleanup()
+	defer log.SetOutput(previousOutput)
+	if logConfig.Logging.File == "" {
+		log.SetOutput(io.Discard)
+	}
+
+	// Work on a copy so the mode settings don't leak into the TUI's config
+	batchConfig := *config
+	applyModeConfig(&batchConfig, ui.ModeFast)
+
+	if err := compiler.CheckDependencies(batchConfig.Latex.Engine == "latexmk", batchConfig.Latex.Compiler); err != nil {
+		return fmt.Errorf("LaTeX dependency check failed: %w (install with: sudo apt install texlive-latex-extra latexmk)", err)
+	}
+
+	updates := make(chan worker.BatchProgress)
+	forwarded := make(chan struct{})
+	go func() {
+		defer close(forwarded)
+		for update := range updates {
+			stream <- batchProgressMsg(update)
+		}
+	}()
+
+	err = worker.ProcessBatchWithOptions(ctx, files, &batchConfig, worker.BatchOptions{
+		EnableRAG:           true,
+		EnableGraphBuilding: batchConfig.Graph.Enabled,
+		Progress:            updates,
+	})
+
+	close(updates)
+	<-forwarded
+	return err
+}
+
+// handleBatchProgress records a progress update and waits for the next one
+func (m Model) handleBatchProgress(msg batchProgressMsg) (tea.Model, tea.Cmd) {
+	update := worker.BatchProgress(msg)
+
+	if update.Started != "" {
+		m.batchCurrent = filepath.Base(update.Started)
+		return m, waitForBatchMsg(m.batchStream)
+	}
+
+	m.batchProgress = update
+	if result := update.Result; result != nil && result.Error != nil {
+		m.batchFailures = append(m.batchFailures,
+			fmt.Sprintf("%s: %v", filepath.Base(result.Job.FilePath), result.Error))
+	}
+
+	return m, waitForBatchMsg(m.batchStream)
+}
+
+// handleBatchDone shows the final summary
+func (m Model) handleBatchDone(msg batchDoneMsg) (tea.Model, tea.Cmd) {
+	m.batchDone = true
+	m.batchErr = msg.err
+	m.batchCurrent = ""
+	m.batchStream = nil
+	if m.batchCancel != nil {
+		m.batchCancel()
+		m.batchCancel = nil
+	}
+	return m, nil
+}
+
+// handleBatchKey cancels a running batch, or returns to the main menu once it is done
+func (m Model) handleBatchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "esc", "q", "enter":
+		if !m.batchDone {
+			if msg.String() != "enter" && m.batchCancel != nil && !m.batchCancelled {
+				m.batchCancelled = true
+				m.batchCancel()
+			}
+			return m, nil
+		}
+
+		m.screen = screenMain
+		m.screenHistory = []screen{}
+	}
+	return m, nil
+}
+
+// renderProcessingScreen renders the progress of the running batch
+func (m Model) renderProcessingScreen() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("⚙️  PROCESSING PAPERS") + "\n\n")
+
+	total := m.batchProgress.Total
+	completed := m.batchProgress.Completed
+
+	percent := 0.0
+	if total > 0 {
+		percent = float64(completed) / float64(total)
+	}
+
+	barWidth := m.width - 20
+	if barWidth > 60 {
+		barWidth = 60
+	}
+	if barWidth < 10 {
+		barWidth = 10
+	}
+	m.batchBar.Width = barWidth
+	b.WriteString(m.batchBar.ViewAs(percent))
+	b.WriteString(fmt.Sprintf("  %d/%d\n\n", completed, total))
+
+	switch {
+	case m.batchDone:
+		// Summary follows below
+	case m.batchCancelled:
+		b.WriteString(warningStyle.Render("Cancelling... waiting for papers in progress to stop") + "\n\n")
+	case total == 0:
+		b.WriteString(infoStyle.Render("Preparing batch...") + "\n\n")
+	case m.batchCurrent != "":
+		b.WriteString(infoStyle.Render("📄 Working on: ") + m.batchCurrent + "\n\n")
+	default:
+		b.WriteString(infoStyle.Render("Waiting for a worker...") + "\n\n")
+	}
+
+	b.WriteString(successStyle.Render(fmt.Sprintf("✅ %d succeeded", m.batchProgress.Succeeded)))
+	b.WriteString("   ")
+	b.WriteString(errorStyle.Render(fmt.Sprintf("❌ %d failed", m.batchProgress.Failed)))
+	b.WriteString("\n")
+
+	if len(m.batchFailures) > 0 {
+		b.WriteString("\n")
+		failures := m.batchFailures
+		if len(failures) > maxBatchFailuresShown {
+			failures = failures[len(failures)-maxBatchFailuresShown:]
+		}
+		for _, failure := range failures {
+			b.WriteString(errorStyle.Render("  • ") + failure + "\n")
+		}
+	}
+
+	if m.batchDone {
+		b.WriteString("\n")
+		switch {
+		case m.batchCancelled:
+			b.WriteString(warningStyle.Render("⚠️  Processing cancelled") + "\n")
+		case m.batchErr != nil && total == 0:
+			b.WriteString(errorStyle.Render(fmt.Sprintf("❌ %v", m.batchErr)) + "\n")
+		case total == 0:
+			b.WriteString(infoStyle.Render("Nothing to process - the selected papers are already processed") + "\n")
+		case m.batchErr != nil:
+			b.WriteString(warningStyle.Render(fmt.Sprintf("⚠️  %v", m.batchErr)) + "\n")
+			b.WriteString(helpStyle.Render("Details are in the processing log") + "\n")
+		default:
+			b.WriteString(successStyle.Render("🎉 Processing complete! Reports are in "+m.config.ReportOutputDir) + "\n")
+		}
+	}
+
+	return b.String()
+}
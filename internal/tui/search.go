@@ -35,6 +35,13 @@ func cleanTextForDisplay(text string) string {
 	return text
 }
 
+// checkSearchService records whether the configured search service is
+// reachable, so the search screens can warn without probing it on every render
+func (m *Model) checkSearchService() bool {
+	m.searchServiceDown = !m.searchClient.IsServiceRunning()
+	return !m.searchServiceDown
+}
+
 // renderSearchScreen renders the search input screen
 func (m Model) renderSearchScreen() string {
 	var sb strings.Builder
@@ -71,14 +78,14 @@ func (m Model) renderSearchScreen() string {
 		sb.WriteString(helpStyle.Render("  • \"attention mechanisms\"\n\n"))
 	}
 
-	// Check if search service is running
-	client := search.NewClient("http://localhost:8000")
-	if !client.IsServiceRunning() {
-		sb.WriteString(warningStyle.Render("\n⚠️  Search service is not running\n\n"))
+	// Warn if the search service was unreachable when the search flow opened
+	if m.searchServiceDown {
+		sb.WriteString(warningStyle.Render(fmt.Sprintf("\n⚠️  Search service is not reachable at %s\n\n", m.searchClient.BaseURL())))
 		sb.WriteString(helpStyle.Render("To start the search service:\n"))
 		sb.WriteString(helpStyle.Render("  cd services/search-engine\n"))
 		sb.WriteString(helpStyle.Render("  source venv/bin/activate\n"))
 		sb.WriteString(helpStyle.Render("  python run.py\n"))
+		sb.WriteString(helpStyle.Render("Or point search.base_url in config.yaml at a running service\n"))
 	} else {
 		sb.WriteString(successStyle.Render("✓ Search service is running\n"))
 	}
@@ -116,12 +123,10 @@ func (m *Model) handleSearchEnter() (tea.Model, tea.Cmd) {
 		// Clear previous error
 		m.searchError = ""
 
-		// Create search client
-		client := search.NewClient("http://localhost:8000")
-
 		// Check if service is running
-		if !client.IsServiceRunning() {
-			m.searchError = "Search service is not running"
+		client := m.searchClient
+		if !m.checkSearchService() {
+			m.searchError = fmt.Sprintf("Search service is not reachable at %s", client.BaseURL())
 			return m, nil
 		}
 
@@ -4,10 +4,12 @@ import (
 	"archivist/internal/analyzer"
 	"archivist/internal/search"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -37,6 +39,11 @@ func (m Model) renderSearchModeScreen() string {
 	sb.WriteString(titleStyle.Render("🔍 Search Mode Selection") + "\n\n")
 	sb.WriteString("Choose how you want to search for papers:\n\n")
 
+	if m.searchServiceDown {
+		sb.WriteString(warningStyle.Render(fmt.Sprintf("⚠️  Search service is not reachable at %s", m.searchClient.BaseURL())) + "\n")
+		sb.WriteString(helpStyle.Render("Start it (services/search-engine: python run.py) or set search.base_url in config.yaml") + "\n\n")
+	}
+
 	sb.WriteString(m.searchModeMenu.View())
 
 	return sb.String()
@@ -50,13 +57,21 @@ func (m Model) renderSimilarPaperSelectScreen() string {
 	sb.WriteString("Choose a paper to find similar papers:\n\n")
 
 	if m.similarExtractingEssence {
-		sb.WriteString(successStyle.Render("🔄 Extracting paper essence...") + "\n\n")
+		sb.WriteString(successStyle.Render("Extracting paper essence... "+spinnerFrame()) + "\n\n")
 		sb.WriteString(helpStyle.Render("Analyzing paper to identify key concepts, methodology, and techniques...\n"))
+		sb.WriteString(helpStyle.Render("Press Esc to cancel\n"))
 		return sb.String()
 	}
 
 	if m.similarEssenceError != "" {
-		sb.WriteString(warningStyle.Render("⚠️  " + m.similarEssenceError) + "\n\n")
+		sb.WriteString(warningStyle.Render("⚠️  " + m.similarEssenceError) + "\n")
+		if m.similarEssenceHint != "" {
+			sb.WriteString(helpStyle.Render(m.similarEssenceHint) + "\n")
+		}
+		if m.similarEssenceRetry {
+			sb.WriteString(helpStyle.Render("Press r to retry, Enter to pick another paper, or Esc to go back") + "\n")
+		}
+		sb.WriteString("\n")
 	}
 
 	sb.WriteString(m.similarPaperList.View())
@@ -158,8 +173,16 @@ func (m *Model) handleSearchModeSelection() (tea.Model, tea.Cmd) {
 			m.similarPaperList.SetSize(m.width-4, m.height-8)
 		}
 
+		m.similarEssenceError = ""
+		m.similarEssenceHint = ""
+		m.similarEssenceRetry = false
 		m.navigateTo(screenSimilarPaperSelect)
 		return m, nil
+
+	case "similar_local":
+		m.loadLocalSimilarSeeds()
+		m.navigateTo(screenLocalSimilarSelect)
+		return m, nil
 	}
 
 	return m, nil
@@ -173,31 +196,62 @@ func (m *Model) handleSimilarPaperSelection() (tea.Model, tea.Cmd) {
 	}
 
 	m.selectedSimilarPaper = selectedItem.(item).action
+	return m.retryEssenceExtraction()
+}
+
+// retryEssenceExtraction (re)starts essence extraction for the selected paper
+func (m *Model) retryEssenceExtraction() (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background())
+
 	m.similarExtractingEssence = true
+	m.similarEssenceCancel = cancel
+	m.similarEssenceID++
 	m.similarEssenceError = ""
+	m.similarEssenceHint = ""
+	m.similarEssenceRetry = false
 
-	// Extract essence in a goroutine (simulated async)
-	return m, m.extractPaperEssence()
+	return m, tea.Batch(
+		m.extractPaperEssence(ctx, m.similarEssenceID),
+		tickEvery(100*time.Millisecond),
+	)
+}
+
+// handleEssenceKey cancels the running essence extraction on Esc or Ctrl+C,
+// returning to paper selection; other keys are ignored until it finishes
+func (m *Model) handleEssenceKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		if m.similarEssenceCancel != nil {
+			m.similarEssenceCancel()
+			m.similarEssenceCancel = nil
+		}
+		m.similarExtractingEssence = false
+	}
+	return m, nil
 }
 
-// extractPaperEssence extracts the essence from the selected paper
-func (m *Model) extractPaperEssence() tea.Cmd {
+// extractPaperEssence extracts the essence from the selected paper. The
+// result carries id so handleEssenceExtracted can drop it if the extraction
+// was cancelled or superseded in the meantime.
+func (m *Model) extractPaperEssence(ctx context.Context, id int) tea.Cmd {
+	config := m.config
+	paperPath := m.selectedSimilarPaper
+
 	return func() tea.Msg {
 		// Create analyzer
-		analyzerInstance, err := analyzer.NewAnalyzer(m.config)
+		analyzerInstance, err := analyzer.NewAnalyzer(config)
 		if err != nil {
-			return essenceExtractedMsg{err: fmt.Errorf("failed to create analyzer: %w", err)}
+			return essenceExtractedMsg{id: id, err: fmt.Errorf("failed to create analyzer: %w", err)}
 		}
 		defer analyzerInstance.Close()
 
 		// Create similar paper finder
-		finder := analyzer.NewSimilarPaperFinder(analyzerInstance, "http://localhost:8000")
+		finder := analyzer.NewSimilarPaperFinder(analyzerInstance, config.Search.BaseURL)
 
 		// Extract essence
-		ctx := context.Background()
-		essence, err := finder.ExtractEssence(ctx, m.selectedSimilarPaper)
+		essence, err := finder.ExtractEssence(ctx, paperPath)
 		if err != nil {
-			return essenceExtractedMsg{err: fmt.Errorf("failed to extract essence: %w", err)}
+			return essenceExtractedMsg{id: id, err: err}
 		}
 
 		// Convert essence to factors list
@@ -215,14 +269,29 @@ func (m *Model) extractPaperEssence() tea.Cmd {
 		factors = append(factors, essence.Techniques...)
 
 		return essenceExtractedMsg{
+			id:      id,
 			factors: factors,
 			essence: essence,
 		}
 	}
 }
 
+// essenceErrorHint suggests a fix for a failed essence extraction
+func essenceErrorHint(err error) string {
+	switch {
+	case errors.Is(err, analyzer.ErrPDFUnreadable):
+		return "The PDF could not be read. Check that the file is a valid, unencrypted PDF, or pick another paper."
+	case errors.Is(err, analyzer.ErrLLMUnreachable):
+		return "The LLM service could not be reached. Check your network connection and try again in a moment."
+	case errors.Is(err, analyzer.ErrLLMRequest):
+		return "The LLM API rejected the request. Check your API key and quota in config.yaml or the environment."
+	}
+	return ""
+}
+
 // essenceExtractedMsg is sent when essence extraction completes
 type essenceExtractedMsg struct {
+	id      int // similarEssenceID of the extraction this came from
 	factors []string
 	essence *analyzer.PaperEssence
 	err     error
@@ -230,10 +299,21 @@ type essenceExtractedMsg struct {
 
 // handleEssenceExtracted processes the essence extraction result
 func (m *Model) handleEssenceExtracted(msg essenceExtractedMsg) (tea.Model, tea.Cmd) {
+	// A cancelled extraction finishes in the background; its result is stale
+	if !m.similarExtractingEssence || msg.id != m.similarEssenceID {
+		return m, nil
+	}
+
 	m.similarExtractingEssence = false
+	if m.similarEssenceCancel != nil {
+		m.similarEssenceCancel()
+		m.similarEssenceCancel = nil
+	}
 
 	if msg.err != nil {
-		m.similarEssenceError = msg.err.Error()
+		m.similarEssenceError = "Failed to extract essence: " + msg.err.Error()
+		m.similarEssenceHint = essenceErrorHint(msg.err)
+		m.similarEssenceRetry = true
 		return m, nil
 	}
 
@@ -359,12 +439,10 @@ func (m *Model) executeSimilarSearch() (tea.Model, tea.Cmd) {
 	// Build search query from factors
 	query := strings.Join(m.similarFactors, " ")
 
-	// Create search client
-	client := search.NewClient("http://localhost:8000")
-
 	// Check if service is running
-	if !client.IsServiceRunning() {
-		m.searchError = "Search service is not running"
+	client := m.searchClient
+	if !m.checkSearchService() {
+		m.searchError = fmt.Sprintf("Search service is not reachable at %s", client.BaseURL())
 		return m, nil
 	}
 
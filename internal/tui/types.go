@@ -2,9 +2,14 @@ package tui
 
 import (
 	"archivist/internal/app"
+	"archivist/internal/search"
+	"archivist/internal/worker"
+	"context"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // Screen types
@@ -20,6 +25,7 @@ const (
 	screenChatMenu
 	screenChatSelectPapers
 	screenChatSelectAnyPaper
+	screenChatResumeSelect // Pick a saved chat session to resume
 	screenChat
 	screenSearch
 	screenSearchResults
@@ -33,6 +39,10 @@ const (
 	screenGraphDashboard       // Graph statistics dashboard
 	screenGraphSearch          // Semantic graph search
 	screenGraphMyPapers        // User's papers in the graph
+	screenGraphRecommendSelect // Pick a paper to get reading suggestions for
+	screenGraphRecommendations // Papers to read next
+	screenLocalSimilarSelect   // Pick an indexed paper to find library matches for
+	screenLocalSimilarResults  // Similar papers already in the library
 )
 
 // Model represents the TUI application state
@@ -47,23 +57,37 @@ type Model struct {
 	multiPaperList     list.Model
 	commandPalette     CommandPalette
 	selectedPaper      string
-	selectedPapers     []string          // For multi-select
 	multiSelectIndexes map[int]bool      // Track selected indices
 	allPapersForSelect []string          // Store all available papers
 	width              int
 	height             int
 	err                error
-	processing         bool
 	processingMsg      string
+	showHelp           bool              // Is the keyboard help overlay open
+
+	// Batch processing fields
+	batchBar           progress.Model        // Progress bar on the processing screen
+	batchStream        chan tea.Msg          // Updates from the running batch
+	batchCancel        context.CancelFunc    // Stops the running batch
+	batchProgress      worker.BatchProgress  // Latest counters
+	batchCurrent       string                // Paper most recently picked up by a worker
+	batchFailures      []string              // "file: error" for each failed paper
+	batchDone          bool                  // Has the batch finished
+	batchCancelled     bool                  // Did the user cancel the batch
+	batchErr           error                 // Error the batch finished with
 
 	// Chat-related fields
 	chatMenu           list.Model        // Chat submenu
 	chatPaperList      list.Model        // For selecting papers to chat about
+	chatSessionList    list.Model        // Saved sessions to resume
 	chatMessages       []ChatMessage     // Chat history
 	chatInput          string            // Current input text
 	chatSessionID      string            // Current chat session ID
-	chatSelectedPapers []string          // Papers selected for chat
+	chatSelectedPapers []string          // Papers selected for chat (empty = whole library)
+	chatIndexedCount   int               // Papers in the FAISS index, shown for library-wide chat
 	chatLoading        bool              // Is response being generated
+	chatStreaming      bool              // Has the reply started arriving (last message is partial)
+	chatStream         chan tea.Msg      // Chunks of the reply being streamed
 	processingForChat  bool              // Is processing a paper for chat
 
 	// Search-related fields
@@ -74,6 +98,8 @@ type Model struct {
 	searchLoading      bool              // Is search in progress
 	searchLoadingFrame int               // For loading animation
 	searchError        string            // Error message from search
+	searchClient       *search.Client    // Shared so repeated searches hit its result cache
+	searchServiceDown  bool              // Search service was unreachable when the search flow opened
 
 	// Similar paper search fields
 	searchModeMenu          list.Model        // Menu for choosing search mode
@@ -84,6 +110,15 @@ type Model struct {
 	similarFactorInput      string            // Input for adding new factor
 	similarExtractingEssence bool             // Is extracting essence
 	similarEssenceError     string            // Error during essence extraction
+	similarEssenceHint      string            // What the user can do about the error
+	similarEssenceRetry     bool              // Extraction failed and 'r' retries it
+	similarEssenceCancel    context.CancelFunc // Stops the running extraction
+	similarEssenceID        int               // Current extraction; results of cancelled ones are dropped
+	localSimilarSeeds       list.Model        // Indexed papers to compare against the library
+	localSimilarSeed        string            // Paper the library matches are for
+	localSimilarResults     list.Model        // Similar papers already in the library
+	localSimilarLoading     bool              // Is the library being searched
+	localSimilarError       string            // Why the library search failed
 
 	// Settings fields
 	settingsMenu            list.Model        // Settings menu
@@ -106,6 +141,11 @@ type Model struct {
 	graphSearchQuery        string            // Semantic search query
 	graphSearchResults      list.Model        // Semantic search results
 	graphMyPapers           list.Model        // User's papers in graph
+	graphRecommendSeeds     list.Model        // Processed papers to base suggestions on
+	graphRecommendSeed      string            // Paper the suggestions are for
+	graphRecommendations    list.Model        // Suggested papers to read next
+	graphRecommendLoading   bool              // Are suggestions being computed
+	graphRecommendError     string            // Why suggestions couldn't be computed
 }
 
 // Item represents a menu item
@@ -158,7 +198,7 @@ var keys = keyMap{
 		key.WithHelp("q", "quit"),
 	),
 	Help: key.NewBinding(
-		key.WithKeys("?"),
+		key.WithKeys("?", "f1"),
 		key.WithHelp("?", "help"),
 	),
 }
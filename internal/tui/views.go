@@ -10,6 +10,10 @@ func (m Model) View() string {
 		return "Loading..."
 	}
 
+	if m.showHelp {
+		return m.renderHelpOverlay()
+	}
+
 	var content string
 
 	// Header
@@ -31,6 +35,13 @@ func (m Model) View() string {
 		} else {
 			content = m.chatPaperList.View() + "\n" + helpStyle.Render("Tip: Use Space to toggle selection, Enter to start chat")
 		}
+	case screenChatResumeSelect:
+		if len(m.chatSessionList.Items()) == 0 {
+			content = warningStyle.Render("\n⚠️  No previous chat sessions found\n\n") +
+				helpStyle.Render("Sessions are kept in Redis for 24 hours\nPress ESC to go back")
+		} else {
+			content = m.chatSessionList.View() + "\n" + helpStyle.Render("Tip: Press Enter to resume the selected conversation")
+		}
 	case screenChat:
 		content = m.renderChatScreen()
 	case screenSelectPaper:
@@ -49,6 +60,8 @@ func (m Model) View() string {
 			listView := m.multiPaperList.View()
 			content = listView + "\n" + helpStyle.Render("Tip: Use Space to toggle selection, Enter to confirm")
 		}
+	case screenProcessing:
+		content = m.renderProcessingScreen()
 	case screenSearch:
 		content = m.renderSearchScreen()
 	case screenSearchResults:
@@ -76,12 +89,25 @@ func (m Model) View() string {
 		content = m.renderGraphSearch()
 	case screenGraphMyPapers:
 		content = m.renderGraphMyPapers()
+	case screenGraphRecommendSelect:
+		content = m.renderGraphRecommendSeeds()
+	case screenGraphRecommendations:
+		content = m.renderGraphRecommendations()
+	case screenLocalSimilarSelect:
+		content = m.renderLocalSimilarSeeds()
+	case screenLocalSimilarResults:
+		content = m.renderLocalSimilarResults()
 	}
 
 	// Footer with help (add Ctrl+P hint)
 	helpText := m.getHelp()
 	if !m.commandPalette.active {
 		helpText += " • Ctrl+P: Command Palette"
+		if m.acceptsTextInput() {
+			helpText += " • F1: Help"
+		} else {
+			helpText += " • ?: Help"
+		}
 	}
 	help := helpStyle.Render(helpText)
 
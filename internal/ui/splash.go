@@ -8,7 +8,12 @@ import (
 )
 
 // AnimatedSplash displays a Japanese elegant retro-style animated splash screen
+// It is skipped when color is disabled, since it is only an animation.
 func AnimatedSplash() {
+	if !ColorEnabled() {
+		return
+	}
+
 	// Clear screen
 	fmt.Print("\033[H\033[2J")
 
@@ -202,12 +207,15 @@ func ShowWelcomeMessage(inputDir, outputDir string) {
 	dustyBlue := "\033[38;5;109m"
 	warmGray := "\033[38;5;145m"
 	reset := "\033[0m"
+	if !ColorEnabled() {
+		charcoal, softBrown, beige, sage, dustyBlue, warmGray, reset = "", "", "", "", "", "", ""
+	}
 
 	// Print minimalist welcome box
 	fmt.Println()
 	fmt.Println(charcoal + "┌" + repeat("─", 76) + "┐" + reset)
 	fmt.Println(charcoal + "│" + reset + center("", 76) + charcoal + "│" + reset)
-	fmt.Println(charcoal + "│" + reset + center(softBrown+"Welcome to ARCHIVIST"+reset, 90) + charcoal + "│" + reset)
+	fmt.Println(charcoal + "│" + reset + center(softBrown+"Welcome to ARCHIVIST"+reset, 76+len(softBrown)+len(reset)) + charcoal + "│" + reset)
 	fmt.Println(charcoal + "│" + reset + center("", 76) + charcoal + "│" + reset)
 	fmt.Println(charcoal + "└" + repeat("─", 76) + "┘" + reset)
 	fmt.Println()
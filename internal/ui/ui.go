@@ -22,6 +22,18 @@ var (
 	ColorBold    = color.New(color.Bold)
 )
 
+// DisableColor turns off color in every Color* and Print* helper and the
+// splash screen. Color is already off when NO_COLOR is set or stdout isn't a
+// terminal (e.g. piped to a file or a CI log).
+func DisableColor() {
+	color.NoColor = true
+}
+
+// ColorEnabled reports whether output may contain ANSI color codes
+func ColorEnabled() bool {
+	return !color.NoColor
+}
+
 // ProcessingMode represents the processing mode
 type ProcessingMode string
 
@@ -304,7 +316,7 @@ func CreateProgressBar(total int, description string) *progressbar.ProgressBar {
 			BarStart:      "[",
 			BarEnd:        "]",
 		}),
-		progressbar.OptionEnableColorCodes(true),
+		progressbar.OptionEnableColorCodes(ColorEnabled()),
 		progressbar.OptionSetPredictTime(true),
 	)
 }
@@ -335,8 +347,30 @@ func PrintStage(stage, description string) {
 	ColorSubtle.Printf("└─ %s\n", description)
 }
 
-// PrintSummary prints a processing summary
-func PrintSummary(successful, failed, skipped int, totalTime time.Duration) {
+// PaperTokenUsage holds the Gemini tokens spent on one paper
+type PaperTokenUsage struct {
+	Paper        string
+	PromptTokens int
+	OutputTokens int
+}
+
+// CacheStats counts analysis cache lookups over a batch
+type CacheStats struct {
+	Hits   int
+	Misses int
+}
+
+// HitRate returns the percentage of lookups served from the cache
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses) * 100
+}
+
+// PrintSummary prints a processing summary, including token usage for papers that called Gemini.
+// cache is nil when the analysis cache is disabled.
+func PrintSummary(successful, failed, skipped int, totalTime time.Duration, usage []PaperTokenUsage, cache *CacheStats) {
 	fmt.Println()
 	ColorBold.Println("═══════════════════════════════════════════════════════════════")
 	ColorBold.Println("                    PROCESSING SUMMARY                         ")
@@ -358,6 +392,21 @@ func PrintSummary(successful, failed, skipped int, totalTime time.Duration) {
 	if successful > 0 {
 		ColorInfo.Printf("  📊 Avg Time:    %s per paper\n", formatDuration(totalTime/time.Duration(successful)))
 	}
+	if cache != nil && cache.Hits+cache.Misses > 0 {
+		ColorInfo.Printf("  🗄️  Cache:       hits: %d, misses: %d, hit rate: %.0f%%\n", cache.Hits, cache.Misses, cache.HitRate())
+	}
+
+	if len(usage) > 0 {
+		var totalPrompt, totalOutput int
+		fmt.Println()
+		ColorBold.Println("  🪙 Token Usage:")
+		for _, u := range usage {
+			ColorSubtle.Printf("     %s: %d in / %d out\n", u.Paper, u.PromptTokens, u.OutputTokens)
+			totalPrompt += u.PromptTokens
+			totalOutput += u.OutputTokens
+		}
+		ColorInfo.Printf("  🪙 Total Tokens: %d (%d in / %d out)\n", totalPrompt+totalOutput, totalPrompt, totalOutput)
+	}
 	fmt.Println()
 }
 
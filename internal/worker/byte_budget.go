@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"fmt"
+	"os"
+)
+
+// byteBudget caps the combined size of the PDFs being processed at once
+// (processing.max_inflight_bytes), so a few huge scans don't run side by side.
+// It is owned by the pool's dispatcher goroutine and not safe for concurrent use.
+type byteBudget struct {
+	limit    int64 // Zero or negative means unlimited
+	inflight int64
+}
+
+// enabled reports whether the budget limits anything
+func (b *byteBudget) enabled() bool {
+	return b.limit > 0
+}
+
+// admits reports whether a job of size bytes may start now. A job larger than
+// the whole budget is still admitted once nothing else is running.
+func (b *byteBudget) admits(size int64) bool {
+	return !b.enabled() || b.inflight == 0 || b.inflight+size <= b.limit
+}
+
+// acquire counts a started job against the budget
+func (b *byteBudget) acquire(size int64) {
+	b.inflight += size
+}
+
+// release returns a finished job's bytes to the budget
+func (b *byteBudget) release(size int64) {
+	b.inflight -= size
+	if b.inflight < 0 {
+		b.inflight = 0
+	}
+}
+
+// sizeJob fills in job.Size from the file on disk if it isn't known yet.
+// Files that can't be read count as zero bytes; the worker reports the error.
+func sizeJob(job *ProcessingJob) *ProcessingJob {
+	if job.Size == 0 {
+		if info, err := os.Stat(job.FilePath); err == nil {
+			job.Size = info.Size()
+		}
+	}
+	return job
+}
+
+// humanBytes formats a byte count for log messages
+func humanBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
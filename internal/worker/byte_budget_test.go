@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestByteBudgetAdmits(t *testing.T) {
+	unlimited := byteBudget{}
+	unlimited.acquire(1 << 40)
+	assert.True(t, unlimited.admits(1<<40))
+
+	b := byteBudget{limit: 100}
+	assert.True(t, b.admits(500), "an oversized job runs once nothing else is in flight")
+
+	b.acquire(60)
+	assert.True(t, b.admits(40))
+	assert.False(t, b.admits(41))
+
+	b.release(60)
+	assert.Zero(t, b.inflight)
+	b.release(10)
+	assert.Zero(t, b.inflight)
+}
+
+func TestSizeJob(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "paper.pdf")
+	require.NoError(t, os.WriteFile(path, make([]byte, 1234), 0644))
+
+	assert.Equal(t, int64(1234), sizeJob(&ProcessingJob{FilePath: path}).Size)
+	assert.Equal(t, int64(7), sizeJob(&ProcessingJob{FilePath: path, Size: 7}).Size)
+	assert.Zero(t, sizeJob(&ProcessingJob{FilePath: "missing.pdf"}).Size)
+}
+
+func TestDispatchWaitsForByteBudget(t *testing.T) {
+	wp := &WorkerPool{
+		incoming: make(chan *ProcessingJob, 3),
+		jobs:     make(chan *ProcessingJob),
+		released: make(chan int64, 1),
+		budget:   byteBudget{limit: 100},
+	}
+	wp.incoming <- &ProcessingJob{FilePath: "a.pdf", Size: 60}
+	wp.incoming <- &ProcessingJob{FilePath: "b.pdf", Size: 60}
+	wp.incoming <- &ProcessingJob{FilePath: "c.pdf", Size: 30}
+	close(wp.incoming)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go wp.dispatch(ctx)
+
+	first := <-wp.jobs
+	assert.Equal(t, "a.pdf", first.FilePath)
+
+	select {
+	case job := <-wp.jobs:
+		t.Fatalf("%s started while a.pdf held most of the budget", job.FilePath)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	wp.released <- first.Size
+	assert.Equal(t, "b.pdf", (<-wp.jobs).FilePath)
+	assert.Equal(t, "c.pdf", (<-wp.jobs).FilePath, "c.pdf fits next to b.pdf")
+
+	_, open := <-wp.jobs
+	assert.False(t, open)
+}
+
+func TestHumanBytes(t *testing.T) {
+	assert.Equal(t, "512 B", humanBytes(512))
+	assert.Equal(t, "1.5 KiB", humanBytes(1536))
+	assert.Equal(t, "100.0 MiB", humanBytes(100<<20))
+}
@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"archivist/internal/analyzer"
+	"archivist/internal/app"
+	"archivist/internal/parser"
+	"archivist/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrDuplicate is reported for papers skipped by processing.dedup_by_metadata
+// because they match one that was already processed
+var ErrDuplicate = errors.New("duplicate of an already processed paper")
+
+// checkDuplicate extracts the paper's metadata and looks for a completed paper,
+// or one claimed earlier in this batch, with the same fingerprint. It returns
+// the metadata so it can be reused for the graph, and whether job is a
+// duplicate, in which case result.Error is set. Papers whose metadata can't be
+// extracted are processed as usual.
+func (wp *WorkerPool) checkDuplicate(ctx context.Context, paperAnalyzer *analyzer.Analyzer, job *ProcessingJob, result *ProcessingResult) (*parser.PaperMetadata, bool) {
+	stepStart := time.Now()
+	log.Printf("  🔎 Checking for an already processed copy of this paper...")
+	metadata, err := parser.NewPDFParser(paperAnalyzer).ExtractMetadata(ctx, job.FilePath)
+	if err != nil {
+		log.Printf("  ⚠️  Duplicate check skipped: %v", err)
+		return nil, false
+	}
+
+	fingerprint := paperFingerprint(metadata)
+	if fingerprint == "" {
+		return metadata, false
+	}
+	result.Fingerprint = fingerprint
+
+	if record, ok := findDuplicate(wp.metadataStore.GetRecordsByStatus(storage.StatusCompleted), job.FileHash, fingerprint); ok {
+		result.PaperTitle = record.PaperTitle
+		result.Error = fmt.Errorf("%w %q (%s); use --force to process it anyway", ErrDuplicate, record.PaperTitle, record.FilePath)
+		return metadata, true
+	}
+	if other, claimed := wp.claimFingerprint(fingerprint, job.FilePath); claimed {
+		result.PaperTitle = metadata.Title
+		result.Error = fmt.Errorf("%w %q (%s, in this batch); use --force to process it anyway", ErrDuplicate, metadata.Title, other)
+		return metadata, true
+	}
+
+	app.Debugf("  ✓ No duplicate found (%.2fs)", time.Since(stepStart).Seconds())
+	return metadata, false
+}
+
+// claimFingerprint records that filePath is being processed as the paper with
+// fingerprint. If another file of the batch already claimed it, that file is
+// returned along with true.
+func (wp *WorkerPool) claimFingerprint(fingerprint, filePath string) (string, bool) {
+	wp.fingerprintsMu.Lock()
+	defer wp.fingerprintsMu.Unlock()
+
+	if other, ok := wp.fingerprints[fingerprint]; ok {
+		return other, true
+	}
+	if wp.fingerprints == nil {
+		wp.fingerprints = make(map[string]string)
+	}
+	wp.fingerprints[fingerprint] = filePath
+	return "", false
+}
+
+// findDuplicate returns the record among records, other than fileHash's own,
+// with the given fingerprint. Records from before processing.dedup_by_metadata
+// was turned on have no fingerprint and are matched on their title alone.
+func findDuplicate(records []storage.ProcessingRecord, fileHash, fingerprint string) (storage.ProcessingRecord, bool) {
+	title, _, _ := strings.Cut(fingerprint, "|")
+	for _, record := range records {
+		if record.FileHash == fileHash {
+			continue
+		}
+		if record.Fingerprint == fingerprint {
+			return record, true
+		}
+		if record.Fingerprint == "" && normalizeText(record.PaperTitle) == title {
+			return record, true
+		}
+	}
+	return storage.ProcessingRecord{}, false
+}
+
+// paperFingerprint reduces a paper's title, authors and year to a string that
+// is the same for two copies of the paper, e.g. "attention is all you
+// need|parmar,shazeer,vaswani|2017". Authors are compared by surname only, as
+// initials and ordering vary between extractions. It returns "" if the paper
+// has no title.
+func paperFingerprint(metadata *parser.PaperMetadata) string {
+	title := normalizeText(metadata.Title)
+	if title == "" {
+		return ""
+	}
+
+	var surnames []string
+	for _, author := range metadata.Authors {
+		if words := strings.Fields(normalizeText(author)); len(words) > 0 {
+			surnames = append(surnames, words[len(words)-1])
+		}
+	}
+	sort.Strings(surnames)
+
+	var year string
+	if y := publicationYear(metadata.Year); y != 0 {
+		year = strconv.Itoa(y)
+	}
+
+	return title + "|" + strings.Join(surnames, ",") + "|" + year
+}
+
+// normalizeText lowercases s and keeps only its letters and digits, as words
+// separated by single spaces
+func normalizeText(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
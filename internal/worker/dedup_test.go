@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"archivist/internal/parser"
+	"archivist/internal/storage"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaperFingerprint(t *testing.T) {
+	scan := &parser.PaperMetadata{
+		Title:   "Attention Is All You Need",
+		Authors: []string{"Ashish Vaswani", "Noam Shazeer", "Niki Parmar"},
+		Year:    "2017",
+	}
+	arxiv := &parser.PaperMetadata{
+		Title:   "Attention is all you need.",
+		Authors: []string{"N. Parmar", "A. Vaswani", "N. Shazeer"},
+		Year:    "NeurIPS 2017",
+	}
+
+	assert.Equal(t, "attention is all you need|parmar,shazeer,vaswani|2017", paperFingerprint(scan))
+	assert.Equal(t, paperFingerprint(scan), paperFingerprint(arxiv))
+	assert.Equal(t, "bert||", paperFingerprint(&parser.PaperMetadata{Title: "BERT", Year: "unknown"}))
+	assert.Empty(t, paperFingerprint(&parser.PaperMetadata{Title: " -- ", Authors: []string{"Someone"}}))
+}
+
+func TestFindDuplicate(t *testing.T) {
+	fingerprint := "attention is all you need|vaswani|2017"
+	records := []storage.ProcessingRecord{
+		{FileHash: "other", PaperTitle: "Attention Is All You Need", Fingerprint: "attention is all you need|smith|2020"},
+		{FileHash: "self", PaperTitle: "Attention Is All You Need", Fingerprint: fingerprint},
+	}
+
+	// A paper's own record and different papers with the same title don't count
+	_, ok := findDuplicate(records, "self", fingerprint)
+	assert.False(t, ok)
+
+	records = append(records, storage.ProcessingRecord{FileHash: "legacy", PaperTitle: "Attention Is All You Need!"})
+	record, ok := findDuplicate(records, "self", fingerprint)
+	assert.True(t, ok, "records without a fingerprint match on title")
+	assert.Equal(t, "legacy", record.FileHash)
+
+	records = append(records[:1], storage.ProcessingRecord{FileHash: "copy", Fingerprint: fingerprint})
+	record, ok = findDuplicate(records, "self", fingerprint)
+	assert.True(t, ok)
+	assert.Equal(t, "copy", record.FileHash)
+}
+
+func TestClaimFingerprint(t *testing.T) {
+	wp := &WorkerPool{}
+
+	_, claimed := wp.claimFingerprint("bert||2018", "lib/bert.pdf")
+	assert.False(t, claimed)
+	_, claimed = wp.claimFingerprint("gpt||2018", "lib/gpt.pdf")
+	assert.False(t, claimed)
+
+	other, claimed := wp.claimFingerprint("bert||2018", "lib/1810.04805.pdf")
+	assert.True(t, claimed)
+	assert.Equal(t, "lib/bert.pdf", other)
+}
@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"archivist/internal/storage"
+	"archivist/pkg/fileutil"
+	"errors"
+	"log"
+	"strings"
+)
+
+// Failure categories written to the dead-letter log
+const (
+	FailureTimeout     = "timeout"     // Ran past timeout_per_paper
+	FailureRateLimit   = "rate_limit"  // API quota or 429 after all retries
+	FailureAuth        = "auth"        // Invalid API key or missing permission
+	FailureAnalysis    = "analysis"    // The model call failed for another reason
+	FailureGeneration  = "generation"  // Writing the .tex/.md file failed
+	FailureCompilation = "compilation" // LaTeX didn't compile
+	FailureInvalidPDF  = "invalid_pdf" // Empty, truncated or not a PDF; never sent to the model
+	FailureOther       = "other"
+)
+
+// Substrings of API errors that mean the quota ran out
+var rateLimitMarkers = []string{"RESOURCE_EXHAUSTED", "quota", "rate limit", "Error 429", "status 429"}
+
+// Substrings of API errors that mean the credentials were rejected
+var authMarkers = []string{"API key not valid", "API_KEY_INVALID", "PERMISSION_DENIED", "UNAUTHENTICATED", "status 401", "status 403"}
+
+// failureCategory sorts a failed job's error into one of the Failure* categories
+func failureCategory(err error) string {
+	if errors.Is(err, ErrPaperTimeout) {
+		return FailureTimeout
+	}
+	if errors.Is(err, fileutil.ErrInvalidPDF) {
+		return FailureInvalidPDF
+	}
+
+	msg := err.Error()
+	for _, marker := range rateLimitMarkers {
+		if strings.Contains(msg, marker) {
+			return FailureRateLimit
+		}
+	}
+	for _, marker := range authMarkers {
+		if strings.Contains(msg, marker) {
+			return FailureAuth
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(msg, "analysis failed"), strings.HasPrefix(msg, "failed to create analyzer"):
+		return FailureAnalysis
+	case strings.HasPrefix(msg, "LaTeX generation failed"), strings.HasPrefix(msg, "Markdown generation failed"):
+		return FailureGeneration
+	case strings.HasPrefix(msg, "PDF compilation failed"):
+		return FailureCompilation
+	}
+	return FailureOther
+}
+
+// recordFailure appends a permanently failed job to the dead-letter log.
+// Interrupted jobs and skipped duplicates didn't fail and are not recorded.
+func (wp *WorkerPool) recordFailure(result *ProcessingResult) {
+	if result.Error == nil || errors.Is(result.Error, ErrInterrupted) || errors.Is(result.Error, ErrDuplicate) {
+		return
+	}
+	appendFailure(wp.failureLog, result.Job, result.Error)
+}
+
+// rejectInvalidPDF marks a file that failed fileutil.ValidatePDF as failed in
+// the metadata store and the dead-letter log, so it is never queued
+func rejectInvalidPDF(store storage.Store, failureLog *storage.FailureLog, job *ProcessingJob, err error) {
+	if store != nil && job.FileHash != "" {
+		markErr := store.MarkProcessing(job.FileHash, job.FilePath) // Records the file path
+		if markErr == nil {
+			markErr = store.MarkFailed(job.FileHash, err.Error())
+		}
+		if markErr != nil {
+			log.Printf("  ⚠️  Warning: Failed to update metadata: %v", markErr)
+		}
+	}
+	appendFailure(failureLog, job, err)
+}
+
+// appendFailure writes a failed job to failureLog, if there is one
+func appendFailure(failureLog *storage.FailureLog, job *ProcessingJob, jobErr error) {
+	if failureLog == nil {
+		return
+	}
+
+	hash := job.FileHash
+	if strings.HasPrefix(hash, tempHashPrefix) {
+		hash = ""
+	}
+
+	entry, err := failureLog.Append(storage.FailureEntry{
+		FilePath: job.FilePath,
+		FileHash: hash,
+		Category: failureCategory(jobErr),
+		Error:    jobErr.Error(),
+	})
+	if err != nil {
+		log.Printf("  ⚠️  Warning: Failed to record failure: %v", err)
+		return
+	}
+	log.Printf("  📕 Recorded %s failure (attempt %d) in %s", entry.Category, entry.Attempts, failureLog.Path())
+}
@@ -0,0 +1,56 @@
+package worker
+
+import (
+	"archivist/internal/storage"
+	"archivist/pkg/fileutil"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureCategory(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("%w (600s)", ErrPaperTimeout), FailureTimeout},
+		{fmt.Errorf("%w: file is empty", fileutil.ErrInvalidPDF), FailureInvalidPDF},
+		{errors.New("analysis failed: failed after 5 attempts: googleapi: Error 429: RESOURCE_EXHAUSTED"), FailureRateLimit},
+		{errors.New("analysis failed: openai: status 401: invalid key"), FailureAuth},
+		{errors.New("failed to create analyzer: GEMINI_API_KEY not set"), FailureAnalysis},
+		{errors.New("analysis failed: INVALID_ARGUMENT"), FailureAnalysis},
+		{errors.New("LaTeX generation failed: permission denied"), FailureGeneration},
+		{errors.New("Markdown generation failed: disk full"), FailureGeneration},
+		{errors.New("PDF compilation failed: latexmk compilation failed: exit status 12"), FailureCompilation},
+		{errors.New("something else"), FailureOther},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, failureCategory(tt.err), tt.err.Error())
+	}
+}
+
+func TestRejectInvalidPDF(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewMetadataStore(dir)
+	require.NoError(t, err)
+	failureLog := storage.NewFailureLog(dir)
+
+	job := &ProcessingJob{FilePath: "papers/broken.pdf", FileHash: "abc123"}
+	rejectInvalidPDF(store, failureLog, job, fmt.Errorf("%w: file is empty", fileutil.ErrInvalidPDF))
+
+	record, ok := store.GetRecord("abc123")
+	require.True(t, ok)
+	assert.Equal(t, storage.StatusFailed, record.Status)
+	assert.Equal(t, "papers/broken.pdf", record.FilePath)
+	assert.Contains(t, record.Error, "file is empty")
+
+	entries, err := failureLog.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, FailureInvalidPDF, entries[0].Category)
+	assert.Equal(t, "abc123", entries[0].FileHash)
+}
@@ -1,25 +1,25 @@
 package worker
 
 import (
+	"archivist/internal/analyzer"
 	"archivist/internal/app"
+	"archivist/internal/parser"
 	"archivist/internal/rag"
 	"context"
 	"log"
-	"path/filepath"
 )
 
 // IndexPaperAfterProcessing indexes a paper after successful processing
 func IndexPaperAfterProcessing(ctx context.Context, config *app.Config, paperTitle, latexContent, pdfPath string) error {
 	// Initialize FAISS vector store
-	indexDir := filepath.Join(".metadata", "vector_index")
-	vectorStore, err := rag.NewFAISSVectorStore(indexDir)
+	vectorStore, err := rag.NewFAISSVectorStore(config.FAISS.IndexDir, config.RAG.EmbeddingDim)
 	if err != nil {
 		log.Printf("  ⚠️  Warning: Failed to create FAISS vector store, skipping indexing: %v", err)
 		return nil // Don't fail the whole process if indexing fails
 	}
 
 	// Initialize embedding client
-	embedClient, err := rag.NewEmbeddingClient(config.Gemini.APIKey)
+	embedClient, err := rag.NewEmbeddingClient(config.Gemini.APIKey, config.RAG.EmbeddingModel, config.RAG.EmbeddingDim)
 	if err != nil {
 		log.Printf("  ⚠️  Warning: Failed to create embedding client, skipping indexing: %v", err)
 		return nil
@@ -27,16 +27,40 @@ func IndexPaperAfterProcessing(ctx context.Context, config *app.Config, paperTit
 	defer embedClient.Close()
 
 	// Create indexer
-	chunker := rag.NewChunker(rag.DefaultChunkSize, rag.DefaultChunkOverlap)
+	chunker := rag.NewChunker(config.RAG.ChunkSize, config.RAG.ChunkOverlap)
 	indexer := rag.NewIndexer(chunker, embedClient, vectorStore)
+	if config.RAG.IndexMetadata {
+		paperAnalyzer, err := analyzer.NewAnalyzer(config)
+		if err != nil {
+			log.Printf("  ⚠️  Warning: Failed to create analyzer, indexing without paper metadata: %v", err)
+		} else {
+			defer paperAnalyzer.Close()
+			indexer.SetMetadataExtractor(parser.NewPDFParser(paperAnalyzer))
+		}
+	}
 
 	// Index the paper
 	log.Printf("  📇 Indexing paper for chat feature...")
-	if err := indexer.IndexPaper(ctx, paperTitle, latexContent, pdfPath); err != nil {
+	indexed, err := indexer.IndexPaperIfChanged(ctx, paperTitle, latexContent, pdfPath)
+	if err != nil {
 		log.Printf("  ⚠️  Warning: Failed to index paper: %v", err)
 		return nil // Don't fail the whole process
 	}
 
-	log.Printf("  ✅ Paper indexed successfully for chat")
+	if indexed {
+		log.Printf("  ✅ Paper indexed successfully for chat")
+	}
 	return nil
 }
+
+// RemovePaperVectors drops a paper's chunks from the local FAISS index so a
+// deleted or reprocessed paper doesn't leave stale context for chat. dim is
+// the configured embedding size the index must have been built with.
+func RemovePaperVectors(indexDir string, dim int, paperTitle string) (int, error) {
+	vectorStore, err := rag.NewFAISSVectorStore(indexDir, dim)
+	if err != nil {
+		return 0, err
+	}
+
+	return vectorStore.DeletePaper(paperTitle)
+}
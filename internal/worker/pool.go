@@ -7,68 +7,128 @@ import (
 	"archivist/internal/compiler"
 	"archivist/internal/generator"
 	"archivist/internal/graph"
+	"archivist/internal/metrics"
+	"archivist/internal/parser"
+	"archivist/internal/storage"
 	"archivist/internal/ui"
 	"archivist/pkg/fileutil"
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
+
+	"github.com/schollz/progressbar/v3"
+)
+
+// OutputFormat selects what the pipeline produces for each paper
+type OutputFormat string
+
+const (
+	FormatLatex    OutputFormat = "latex"    // .tex file compiled to a PDF report (default)
+	FormatMarkdown OutputFormat = "markdown" // .md report written to the report dir, nothing to compile
 )
 
 type ProcessingJob struct {
 	FilePath string
 	FileHash string
 	Priority int
+	Size     int64 // Bytes, for processing.max_inflight_bytes; filled in on dispatch if zero
 }
 
 type ProcessingResult struct {
 	Job        *ProcessingJob
 	PaperTitle string
-	TexFile    string
-	ReportFile string
+	TexFile    string // Empty for FormatMarkdown
+	ReportFile string // The compiled PDF, or the .md report for FormatMarkdown
 	Duration   time.Duration
+	ModelUsed  string
 	Error      error
+
+	// Gemini tokens spent on this paper (zero on cache hits)
+	PromptTokens int
+	OutputTokens int
+
+	// Normalized title, authors and year; set with processing.dedup_by_metadata
+	Fingerprint string
+
+	// Identifiers printed on the paper; set when its metadata was extracted
+	DOI     string
+	ArxivID string
 }
 
 type WorkerPool struct {
 	numWorkers     int
-	jobs           chan *ProcessingJob
+	incoming       chan *ProcessingJob // Jobs submitted but not yet prioritized
+	jobs           chan *ProcessingJob // Highest-priority job handed to workers
+	released       chan int64          // Sizes of finished jobs, returned to the byte budget
 	results        chan *ProcessingResult
 	wg             sync.WaitGroup
 	config         *app.Config
-	cache          *cache.RedisCache
+	cache          cache.AnalysisCache
 	kafkaProducer  *graph.KafkaProducer
+	metadataStore  storage.Store
 	enableRAG      bool // Enable RAG indexing during processing
+	force          bool // Reprocessing on purpose; the graph service re-ingests unchanged papers too
+	progress       chan<- BatchProgress // Optional; told when a worker starts a paper
+	format         OutputFormat
+	rateLimiter    *analyzer.RateLimiter // Shared by every worker's analyzer (gemini.rate_limit)
+	failureLog     *storage.FailureLog   // Optional dead-letter log of failed papers
+	budget         byteBudget            // Owned by dispatch
+	metrics        *metrics.Recorder     // Optional; nil records nothing
+	cacheHits      atomic.Int64          // Analysis cache lookups, for the batch summary
+	cacheMisses    atomic.Int64
+	fingerprints   map[string]string // Paper fingerprints claimed by files of this batch (processing.dedup_by_metadata)
+	fingerprintsMu sync.Mutex
 }
 
+// tempHashPrefix marks placeholder hashes used when a file could not be hashed
+const tempHashPrefix = "temp_"
+
+// ErrInterrupted is reported for jobs cut short by a shutdown signal
+var ErrInterrupted = errors.New("interrupted")
+
+// ErrPaperTimeout is reported for jobs that ran past timeout_per_paper
+var ErrPaperTimeout = errors.New("exceeded per-paper timeout")
+
 // NewWorkerPool creates a new worker pool
-func NewWorkerPool(numWorkers int, config *app.Config, redisCache *cache.RedisCache, enableGraphBuilding bool) *WorkerPool {
+func NewWorkerPool(numWorkers int, config *app.Config, analysisCache cache.AnalysisCache, enableGraphBuilding bool) *WorkerPool {
 	// Initialize Kafka producer if graph is enabled AND user opted in
 	var kafkaProducer *graph.KafkaProducer
 	if config.Graph.Enabled && enableGraphBuilding {
-		kafkaProducer = graph.NewKafkaProducer(
-			[]string{"localhost:9094"}, // Kafka broker (external listener)
-			"paper.processed",           // Topic
-			true,                        // Enabled
-		)
+		brokers := kafkaBrokers(config)
+		topic := config.Graph.Kafka.Topic
+		if topic == "" {
+			topic = app.DefaultKafkaTopic
+		}
+		kafkaProducer = graph.NewKafkaProducer(brokers, topic, config.Graph.Kafka.Enabled)
 	}
 
 	return &WorkerPool{
 		numWorkers:    numWorkers,
-		jobs:          make(chan *ProcessingJob, numWorkers*2),
+		incoming:      make(chan *ProcessingJob, numWorkers*2),
+		jobs:          make(chan *ProcessingJob),
+		released:      make(chan int64, numWorkers), // A worker holds at most one job
 		results:       make(chan *ProcessingResult, numWorkers*2),
 		config:        config,
-		cache:         redisCache,
+		cache:         analysisCache,
 		kafkaProducer: kafkaProducer,
 		enableRAG:     false, // Default off
+		rateLimiter:   analyzer.NewRateLimiter(config.Gemini.RateLimit),
+		budget:        byteBudget{limit: config.Processing.MaxInflightBytes},
 	}
 }
 
@@ -77,14 +137,121 @@ func (wp *WorkerPool) SetEnableRAG(enable bool) {
 	wp.enableRAG = enable
 }
 
+// SetForce marks the batch as a forced reprocess
+func (wp *WorkerPool) SetForce(force bool) {
+	wp.force = force
+}
+
+// SetMetadataStore sets the store used to persist per-paper processing records
+func (wp *WorkerPool) SetMetadataStore(store storage.Store) {
+	wp.metadataStore = store
+}
+
+// SetFailureLog sets the dead-letter log that papers failing for good are appended to
+func (wp *WorkerPool) SetFailureLog(failureLog *storage.FailureLog) {
+	wp.failureLog = failureLog
+}
+
+// SetProgress sets a channel that is told whenever a worker starts a paper
+func (wp *WorkerPool) SetProgress(progress chan<- BatchProgress) {
+	wp.progress = progress
+}
+
+// SetMetrics sets the recorder that the Prometheus endpoint reads from
+func (wp *WorkerPool) SetMetrics(recorder *metrics.Recorder) {
+	wp.metrics = recorder
+}
+
+// CacheStats returns the analysis cache hits and misses so far
+func (wp *WorkerPool) CacheStats() ui.CacheStats {
+	return ui.CacheStats{
+		Hits:   int(wp.cacheHits.Load()),
+		Misses: int(wp.cacheMisses.Load()),
+	}
+}
+
+// SetOutputFormat sets what each paper is turned into; the default is FormatLatex
+func (wp *WorkerPool) SetOutputFormat(format OutputFormat) {
+	wp.format = format
+}
+
+// analysisCacheKey keys cached analyses by format, since a Markdown report
+// can't stand in for a LaTeX one or the other way round
+func analysisCacheKey(fileHash string, format OutputFormat) string {
+	if format == FormatMarkdown {
+		return fileHash + ":" + string(FormatMarkdown)
+	}
+	return fileHash
+}
+
 // Start starts the worker pool
 func (wp *WorkerPool) Start(ctx context.Context) {
+	go wp.dispatch(ctx)
 	for i := 0; i < wp.numWorkers; i++ {
 		wp.wg.Add(1)
 		go wp.worker(ctx, i)
 	}
 }
 
+// dispatch feeds workers from a priority queue so higher-priority jobs run first.
+// The jobs channel is unbuffered, so jobs stay in the queue until a worker is free.
+// With processing.max_inflight_bytes set, the next job also waits until the
+// PDFs already being processed leave room for it in the byte budget.
+func (wp *WorkerPool) dispatch(ctx context.Context) {
+	defer close(wp.jobs)
+
+	var queue jobQueue
+	var waiting *ProcessingJob // Job last logged as waiting on the byte budget
+	incoming := wp.incoming
+
+	for incoming != nil || queue.len() > 0 {
+		// Drain everything already submitted before picking the next job
+		for drained := false; incoming != nil && !drained; {
+			select {
+			case job, ok := <-incoming:
+				if !ok {
+					incoming = nil
+				} else {
+					queue.push(sizeJob(job))
+				}
+			default:
+				drained = true
+			}
+		}
+
+		var out chan *ProcessingJob
+		var next *ProcessingJob
+		if queue.len() > 0 {
+			next = queue.peek()
+			if wp.budget.admits(next.Size) {
+				out = wp.jobs
+			} else if next != waiting {
+				waiting = next
+				log.Printf("⏳ %s (%s) waiting for in-flight PDFs to finish (%s of %s budget in use)",
+					filepath.Base(next.FilePath), humanBytes(next.Size), humanBytes(wp.budget.inflight), humanBytes(wp.budget.limit))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case size := <-wp.released:
+			wp.budget.release(size)
+		case job, ok := <-incoming:
+			if !ok {
+				incoming = nil
+				continue
+			}
+			queue.push(sizeJob(job))
+		case out <- next:
+			queue.pop()
+			if wp.budget.enabled() {
+				wp.budget.acquire(next.Size)
+			}
+		}
+	}
+}
+
 // worker processes jobs
 func (wp *WorkerPool) worker(ctx context.Context, id int) {
 	defer wp.wg.Done()
@@ -97,29 +264,68 @@ func (wp *WorkerPool) worker(ctx context.Context, id int) {
 			if !ok {
 				return
 			}
+			// Don't start new work once shutdown has begun
+			if ctx.Err() != nil {
+				return
+			}
 			log.Printf("[Worker %d] Processing: %s", id, job.FilePath)
+			if wp.progress != nil {
+				wp.progress <- BatchProgress{Started: job.FilePath}
+			}
+			wp.metrics.WorkerBusy()
+			started := time.Now()
 			result := wp.processJob(ctx, job)
+			wp.metrics.WorkerIdle()
+			wp.recordMetrics(result, time.Since(started))
+			if wp.budget.enabled() {
+				wp.released <- job.Size
+			}
+			wp.recordResult(result)
+			wp.recordFailure(result)
 			wp.results <- result
 		}
 	}
 }
 
-// processJob processes a single PDF file
+// processJob processes a single PDF file. ctx is cancelled when the batch
+// shuts down; the job itself gets timeout_per_paper to finish analysis, LaTeX
+// generation and compilation, after which it is failed with ErrPaperTimeout.
 func (wp *WorkerPool) processJob(ctx context.Context, job *ProcessingJob) *ProcessingResult {
 	startTime := time.Now()
 	result := &ProcessingResult{Job: job}
 
+	jobCtx, cancel := context.WithTimeout(ctx, time.Duration(wp.config.Processing.TimeoutPerPaper)*time.Second)
+	defer cancel()
+
 	log.Printf("  ⏱️  Starting processing pipeline for: %s", job.FilePath)
 
-	// Compute hash for cache lookup
-	fileHash, err := fileutil.ComputeFileHash(job.FilePath)
-	if err != nil {
-		log.Printf("  ⚠️  Warning: Could not compute hash: %v", err)
-		fileHash = fmt.Sprintf("temp_%d", time.Now().UnixNano()) // Temporary hash
+	// Compute hash for cache lookup (ProcessBatch may already have done this)
+	fileHash := job.FileHash
+	if fileHash == "" {
+		var err error
+		fileHash, err = fileutil.ComputeFileHash(job.FilePath)
+		if err != nil {
+			log.Printf("  ⚠️  Warning: Could not compute hash: %v", err)
+			fileHash = fmt.Sprintf("%s%d", tempHashPrefix, time.Now().UnixNano()) // Temporary hash
+		}
+		job.FileHash = fileHash
+	}
+
+	// Remember the title of an earlier run so its vectors can be replaced
+	var previousTitle string
+	if wp.metadataStore != nil && !strings.HasPrefix(fileHash, tempHashPrefix) {
+		if record, ok := wp.metadataStore.GetRecord(fileHash); ok {
+			previousTitle = record.PaperTitle
+		}
+		if err := wp.metadataStore.MarkProcessing(fileHash, job.FilePath); err != nil {
+			log.Printf("  ⚠️  Warning: Failed to update metadata: %v", err)
+		}
 	}
-	job.FileHash = fileHash
 
 	// Step 1: Create analyzer
+	if wp.interrupted(ctx, result) || wp.timedOut(jobCtx, result) {
+		return result
+	}
 	stepStart := time.Now()
 	log.Printf("  🔧 Step 1/4: Initializing Gemini analyzer...")
 	analyzer, err := analyzer.NewAnalyzer(wp.config)
@@ -128,49 +334,79 @@ func (wp *WorkerPool) processJob(ctx context.Context, job *ProcessingJob) *Proce
 		return result
 	}
 	defer analyzer.Close()
-	log.Printf("  ✓ Analyzer initialized (%.2fs)", time.Since(stepStart).Seconds())
+	analyzer.SetRateLimiter(wp.rateLimiter)
+	defer func() {
+		usage := analyzer.TokenUsage()
+		result.PromptTokens = usage.PromptTokens
+		result.OutputTokens = usage.OutputTokens
+	}()
+	app.Debugf("  ✓ Analyzer initialized (%.2fs)", time.Since(stepStart).Seconds())
+
+	// Skip the paper if another file of it was already processed
+	var metadata *parser.PaperMetadata
+	if wp.config.Processing.DedupByMetadata && !wp.force && wp.metadataStore != nil {
+		var duplicate bool
+		metadata, duplicate = wp.checkDuplicate(jobCtx, analyzer, job, result)
+		if wp.interrupted(ctx, result) || wp.timedOut(jobCtx, result) {
+			return result
+		}
+		if duplicate {
+			return result
+		}
+	}
 
 	// Step 2: Check cache first, then analyze if needed
 	stepStart = time.Now()
-	var latexContent string
+	var content string
 	var paperTitle string
 
 	// Try to get from cache if enabled
+	cacheKey := analysisCacheKey(fileHash, wp.format)
 	if wp.cache != nil {
 		log.Printf("  🔍 Step 2/4: Checking cache for existing analysis...")
-		cached, err := wp.cache.Get(ctx, fileHash)
+		cached, err := wp.cache.Get(jobCtx, cacheKey)
 		if err != nil {
 			log.Printf("  ⚠️  Cache error (continuing with analysis): %v", err)
 		} else if cached != nil {
 			// Cache hit! Use cached result
-			latexContent = cached.LatexContent
+			content = cached.LatexContent
 			paperTitle = cached.PaperTitle
-			log.Printf("  ✓ Cache hit! Skipping Gemini API call (%.2fs)", time.Since(stepStart).Seconds())
+			result.ModelUsed = cached.ModelUsed
+			app.Debugf("  ✓ Cache hit! Skipping Gemini API call (%.2fs)", time.Since(stepStart).Seconds())
+			wp.cacheHits.Add(1)
+			wp.metrics.CacheHit()
+		} else {
+			wp.cacheMisses.Add(1)
+			wp.metrics.CacheMiss()
 		}
 	}
 
 	// If not in cache, analyze with Gemini
-	if latexContent == "" {
+	if content == "" {
 		log.Printf("  🤖 Step 2/4: Analyzing paper with Gemini (cache miss)...")
 		log.Printf("     → Sending PDF to Gemini API for analysis and LaTeX generation...")
 
-		// Enforce timeout for API call
-		apiCtx, apiCancel := context.WithTimeout(ctx, time.Duration(wp.config.Processing.TimeoutPerPaper)*time.Second)
-		defer apiCancel()
-
-		latexContent, err = analyzer.AnalyzePaper(apiCtx, job.FilePath)
+		if wp.format == FormatMarkdown {
+			content, err = analyzer.AnalyzePaperMarkdown(jobCtx, job.FilePath)
+		} else {
+			content, err = analyzer.AnalyzePaper(jobCtx, job.FilePath)
+		}
 		if err != nil {
-			if apiCtx.Err() == context.DeadlineExceeded {
-				result.Error = fmt.Errorf("analysis timed out after %d seconds (increase timeout_per_paper in config)", wp.config.Processing.TimeoutPerPaper)
-			} else {
-				result.Error = fmt.Errorf("analysis failed: %w", err)
+			if wp.interrupted(ctx, result) || wp.timedOut(jobCtx, result) {
+				return result
 			}
+			result.Error = fmt.Errorf("analysis failed: %w", err)
 			return result
 		}
-		log.Printf("  ✓ Analysis complete (%.2fs)", time.Since(stepStart).Seconds())
+		app.Debugf("  ✓ Analysis complete (%.2fs)", time.Since(stepStart).Seconds())
+		result.ModelUsed = activeModel(wp.config)
 
 		// Extract title (but DON'T cache yet - wait for successful PDF compilation)
-		paperTitle = extractTitleFromLatex(latexContent)
+		if wp.format == FormatMarkdown {
+			paperTitle = extractTitleFromMarkdown(content)
+		} else {
+			paperTitle = extractTitleFromLatex(content)
+		}
 		if paperTitle == "" {
 			paperTitle = "Unknown Paper"
 		}
@@ -179,51 +415,46 @@ func (wp *WorkerPool) processJob(ctx context.Context, job *ProcessingJob) *Proce
 	// Set result paper title
 	result.PaperTitle = paperTitle
 
-	// Step 3: Write LaTeX file
-	stepStart = time.Now()
-	log.Printf("  📝 Step 3/4: Generating LaTeX file...")
-	latexGen := generator.NewLatexGenerator(wp.config.TexOutputDir)
-	texPath, err := latexGen.GenerateLatexFile(paperTitle, latexContent)
-	if err != nil {
-		result.Error = fmt.Errorf("LaTeX generation failed: %w", err)
+	// Steps 3-4: Write the report, compiling it to PDF unless it is Markdown
+	var ok bool
+	if wp.format == FormatMarkdown {
+		ok = wp.writeMarkdownReport(ctx, jobCtx, result, content, fileHash)
+	} else {
+		// Figures go into the written report only; the cache keeps the plain analysis
+		texContent := content
+		if wp.config.Processing.ExtractFigures {
+			texContent = wp.addKeyFigures(jobCtx, analyzer, job.FilePath, fileHash, content)
+		}
+		ok = wp.writeLatexReport(ctx, jobCtx, result, texContent, fileHash)
+	}
+	if !ok {
 		return result
 	}
-	result.TexFile = texPath
-	log.Printf("  ✓ LaTeX file created: %s (%.2fs)", texPath, time.Since(stepStart).Seconds())
-
-	// Step 4: Compile to PDF
-	stepStart = time.Now()
-	log.Printf("  🔨 Step 4/4: Compiling LaTeX to PDF (running pdflatex)...")
-	compiler := compiler.NewLatexCompiler(
-		wp.config.Latex.Compiler,
-		wp.config.Latex.Engine == "latexmk",
-		wp.config.Latex.CleanAux,
-		wp.config.ReportOutputDir,
-	)
 
-	reportPath, err := compiler.Compile(texPath)
-	if err != nil {
-		result.Error = fmt.Errorf("PDF compilation failed: %w", err)
-		return result
+	// Drop chat vectors left over from an earlier run; the paper is reindexed on demand
+	if previousTitle != "" {
+		if removed, err := RemovePaperVectors(wp.config.FAISS.IndexDir, wp.config.RAG.EmbeddingDim, previousTitle); err != nil {
+			log.Printf("  ⚠️  Failed to remove stale vectors: %v", err)
+		} else if removed > 0 {
+			log.Printf("  🗑️  Removed %d stale vectors for %s", removed, previousTitle)
+		}
 	}
-	result.ReportFile = reportPath
-	log.Printf("  ✓ PDF compiled: %s (%.2fs)", reportPath, time.Since(stepStart).Seconds())
 
 	// Step 5: NOW cache the result after successful PDF compilation
 	// Only cache if we generated new content (not from cache)
-	if wp.cache != nil && latexContent != "" {
+	if wp.cache != nil && content != "" {
 		// Check if this was a cache hit by seeing if we have the cache marker
-		cached, _ := wp.cache.Get(ctx, fileHash)
+		cached, _ := wp.cache.Get(ctx, cacheKey)
 		if cached == nil {
 			// This was NOT from cache, so cache it now
 			log.Printf("  💾 Caching successful analysis result...")
 			cacheEntry := &cache.CachedAnalysis{
 				ContentHash:  fileHash,
 				PaperTitle:   paperTitle,
-				LatexContent: latexContent,
-				ModelUsed:    wp.config.Gemini.Model,
+				LatexContent: content,
+				ModelUsed:    result.ModelUsed,
 			}
-			if err := wp.cache.Set(ctx, fileHash, cacheEntry); err != nil {
+			if err := wp.cache.Set(ctx, cacheKey, cacheEntry); err != nil {
 				log.Printf("  ⚠️  Failed to cache result: %v", err)
 			} else {
 				log.Printf("  ✓ Analysis cached for future use")
@@ -237,24 +468,289 @@ func (wp *WorkerPool) processJob(ctx context.Context, job *ProcessingJob) *Proce
 	// - Graph Service: Building Neo4j knowledge graph
 	if wp.kafkaProducer != nil {
 		log.Printf("  📡 Publishing to Kafka for microservices...")
-		if err := wp.kafkaProducer.PublishPaperProcessed(ctx, paperTitle, latexContent, job.FilePath); err != nil {
+		contentHash := job.FileHash
+		if strings.HasPrefix(contentHash, tempHashPrefix) {
+			contentHash = ""
+		}
+		event := graph.PaperProcessedEvent{
+			PaperTitle:   paperTitle,
+			LatexContent: content,
+			PDFPath:      job.FilePath,
+			ContentHash:  contentHash,
+			Force:        wp.force,
+		}
+		if wp.kafkaProducer.Enabled() {
+			metadata = wp.addPaperMetadata(jobCtx, analyzer, &event, metadata)
+		}
+		if err := wp.kafkaProducer.PublishPaperProcessed(ctx, event); err != nil {
 			log.Printf("  ⚠️  Kafka publish warning: %v", err)
 		}
 	}
 
+	if metadata != nil {
+		result.DOI, result.ArxivID = metadata.DOI, metadata.ArxivID
+	}
+
 	result.Duration = time.Since(startTime)
 	log.Printf("  🎉 Processing complete! Total time: %.2fs", result.Duration.Seconds())
 	return result
 }
 
+// addPaperMetadata fills in the authors, year, abstract and identifiers of
+// event from the source PDF so the graph gets Author nodes and WRITTEN_BY
+// edges. metadata is reused if the duplicate check already extracted it, and
+// the metadata used is returned. On failure the event goes out with the title
+// only, the graph service falls back to the report, and nil is returned.
+func (wp *WorkerPool) addPaperMetadata(ctx context.Context, paperAnalyzer *analyzer.Analyzer, event *graph.PaperProcessedEvent, metadata *parser.PaperMetadata) *parser.PaperMetadata {
+	stepStart := time.Now()
+	if metadata == nil {
+		var err error
+		metadata, err = parser.NewPDFParser(paperAnalyzer).ExtractMetadata(ctx, event.PDFPath)
+		if err != nil {
+			log.Printf("  ⚠️  Failed to extract paper metadata for the graph: %v", err)
+			return nil
+		}
+	}
+
+	event.Authors = metadata.Authors
+	event.Year = publicationYear(metadata.Year)
+	event.Abstract = metadata.Abstract
+	event.DOI = metadata.DOI
+	event.ArxivID = metadata.ArxivID
+	app.Debugf("  ✓ Paper metadata extracted: %d authors (%.2fs)", len(event.Authors), time.Since(stepStart).Seconds())
+	return metadata
+}
+
+// yearRegex matches a plausible publication year
+var yearRegex = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+
+// publicationYear returns the first year in s, or 0 if there is none
+func publicationYear(s string) int {
+	year, err := strconv.Atoi(yearRegex.FindString(s))
+	if err != nil {
+		return 0
+	}
+	return year
+}
+
+// writeLatexReport writes content to a .tex file and compiles it into the
+// report dir, recording both paths on result. It returns false if the job failed
+// or was interrupted along the way.
+func (wp *WorkerPool) writeLatexReport(ctx, jobCtx context.Context, result *ProcessingResult, content, fileHash string) bool {
+	// Step 3: Write LaTeX file
+	if wp.interrupted(ctx, result) || wp.timedOut(jobCtx, result) {
+		return false
+	}
+	stepStart := time.Now()
+	log.Printf("  📝 Step 3/4: Generating LaTeX file...")
+	latexGen, err := generator.NewLatexGenerator(wp.config.TexOutputDir, wp.config.Latex.Template)
+	if err != nil {
+		result.Error = fmt.Errorf("LaTeX generation failed: %w", err)
+		return false
+	}
+	texPath, err := latexGen.GenerateLatexFile(result.PaperTitle, content, fileHash)
+	if err != nil {
+		result.Error = fmt.Errorf("LaTeX generation failed: %w", err)
+		return false
+	}
+	result.TexFile = texPath
+	app.Debugf("  ✓ LaTeX file created: %s (%.2fs)", texPath, time.Since(stepStart).Seconds())
+
+	// Step 4: Compile to PDF
+	if wp.interrupted(ctx, result) {
+		removeTexFile(texPath)
+		return false
+	}
+	if wp.timedOut(jobCtx, result) {
+		return false
+	}
+	stepStart = time.Now()
+	log.Printf("  🔨 Step 4/4: Compiling LaTeX to PDF (running pdflatex)...")
+	compiler := compiler.NewLatexCompiler(
+		wp.config.Latex.Compiler,
+		wp.config.Latex.Engine == "latexmk",
+		wp.config.Latex.CleanAux,
+		wp.config.ReportOutputDir,
+	)
+	compiler.SetBibEngine(wp.config.Latex.BibEngine)
+
+	reportPath, err := compiler.CompileContext(jobCtx, texPath)
+	if err != nil {
+		if wp.interrupted(ctx, result) {
+			removeTexFile(texPath)
+			return false
+		}
+		if wp.timedOut(jobCtx, result) {
+			return false
+		}
+		result.Error = fmt.Errorf("PDF compilation failed: %w", err)
+		return false
+	}
+	result.ReportFile = reportPath
+	app.Debugf("  ✓ PDF compiled: %s (%.2fs)", reportPath, time.Since(stepStart).Seconds())
+	return true
+}
+
+// addKeyFigures extracts the paper's key figures next to the tex files and
+// references them from latexContent. Failures only cost the figures, so
+// they are logged and the report is written without them.
+func (wp *WorkerPool) addKeyFigures(ctx context.Context, a *analyzer.Analyzer, pdfPath, fileHash, latexContent string) string {
+	figuresDir := FiguresDir(wp.config, fileHash)
+	os.RemoveAll(figuresDir) // Don't mix in figures from an earlier run
+
+	figures, err := a.ExtractKeyFigures(ctx, pdfPath, figuresDir)
+	if err != nil {
+		log.Printf("  ⚠️  Figure extraction skipped: %v", err)
+		return latexContent
+	}
+
+	var refs []generator.Figure
+	for _, figure := range figures {
+		rel, err := filepath.Rel(wp.config.TexOutputDir, figure.Path)
+		if err != nil {
+			continue
+		}
+		refs = append(refs, generator.Figure{Path: filepath.ToSlash(rel), Caption: figure.Caption})
+	}
+	return generator.InsertFigures(latexContent, refs)
+}
+
+// FiguresDir is where the key figures extracted from the paper with fileHash are kept
+func FiguresDir(config *app.Config, fileHash string) string {
+	return filepath.Join(config.TexOutputDir, "figures", shortHash(fileHash))
+}
+
+// shortHash trims a file hash for use in directory names
+func shortHash(fileHash string) string {
+	if len(fileHash) > 12 {
+		return fileHash[:12]
+	}
+	return fileHash
+}
+
+// writeMarkdownReport writes content straight to a .md file in the report
+// dir; there is no compile step. It returns false if the job failed or was interrupted.
+func (wp *WorkerPool) writeMarkdownReport(ctx, jobCtx context.Context, result *ProcessingResult, content, fileHash string) bool {
+	if wp.interrupted(ctx, result) || wp.timedOut(jobCtx, result) {
+		return false
+	}
+	stepStart := time.Now()
+	log.Printf("  📝 Step 3/3: Writing Markdown report...")
+	mdGen := generator.NewMarkdownGenerator(wp.config.ReportOutputDir)
+	reportPath, err := mdGen.GenerateMarkdownFile(result.PaperTitle, content, fileHash)
+	if err != nil {
+		result.Error = fmt.Errorf("Markdown generation failed: %w", err)
+		return false
+	}
+	result.ReportFile = reportPath
+	app.Debugf("  ✓ Markdown report created: %s (%.2fs)", reportPath, time.Since(stepStart).Seconds())
+	return true
+}
+
+// interrupted reports whether ctx has been cancelled, marking the result as interrupted if so
+func (wp *WorkerPool) interrupted(ctx context.Context, result *ProcessingResult) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+	result.Error = ErrInterrupted
+	return true
+}
+
+// timedOut reports whether the job's deadline has passed, failing the result with ErrPaperTimeout if so
+func (wp *WorkerPool) timedOut(jobCtx context.Context, result *ProcessingResult) bool {
+	if jobCtx.Err() != context.DeadlineExceeded {
+		return false
+	}
+	result.Error = fmt.Errorf("%w of %d seconds (increase timeout_per_paper in config)",
+		ErrPaperTimeout, wp.config.Processing.TimeoutPerPaper)
+	return true
+}
+
+// activeModel returns the model that analyses papers under the configured LLM provider
+func activeModel(config *app.Config) string {
+	if config.LLM.Provider == app.ProviderOpenAI {
+		return config.LLM.OpenAI.Model
+	}
+	return config.Gemini.Model
+}
+
+// removeTexFile deletes a tex file left behind by an interrupted job
+func removeTexFile(texPath string) {
+	if err := os.Remove(texPath); err != nil && !os.IsNotExist(err) {
+		log.Printf("  ⚠️  Warning: Failed to remove partial LaTeX file %s: %v", texPath, err)
+		return
+	}
+	log.Printf("  🧹 Removed partial LaTeX file: %s", texPath)
+}
+
+// recordResult persists the outcome of a job to the metadata store
+func (wp *WorkerPool) recordResult(result *ProcessingResult) {
+	if wp.metadataStore == nil || strings.HasPrefix(result.Job.FileHash, tempHashPrefix) {
+		return
+	}
+
+	// The file wasn't processed, so it goes back to having no record
+	if errors.Is(result.Error, ErrDuplicate) {
+		if err := wp.metadataStore.DeleteRecord(result.Job.FileHash); err != nil {
+			log.Printf("  ⚠️  Warning: Failed to update metadata: %v", err)
+		}
+		return
+	}
+
+	var err error
+	if result.Error != nil {
+		err = wp.metadataStore.MarkFailed(result.Job.FileHash, result.Error.Error())
+	} else {
+		err = wp.metadataStore.MarkCompleted(result.Job.FileHash, result.PaperTitle, result.TexFile, result.ReportFile)
+	}
+	if err != nil {
+		log.Printf("  ⚠️  Warning: Failed to update metadata: %v", err)
+	}
+
+	if result.Error == nil {
+		if err := wp.metadataStore.RecordRunInfo(result.Job.FileHash, result.Duration, wp.config.Processing.Mode, result.ModelUsed); err != nil {
+			log.Printf("  ⚠️  Warning: Failed to record run info: %v", err)
+		}
+		if result.Fingerprint != "" {
+			if err := wp.metadataStore.SetFingerprint(result.Job.FileHash, result.Fingerprint); err != nil {
+				log.Printf("  ⚠️  Warning: Failed to record paper fingerprint: %v", err)
+			}
+		}
+		if result.DOI != "" || result.ArxivID != "" {
+			if err := wp.metadataStore.SetIdentifiers(result.Job.FileHash, result.DOI, result.ArxivID); err != nil {
+				log.Printf("  ⚠️  Warning: Failed to record paper identifiers: %v", err)
+			}
+		}
+	}
+
+	if result.PromptTokens > 0 || result.OutputTokens > 0 {
+		if err := wp.metadataStore.AddTokenUsage(result.Job.FileHash, result.PromptTokens, result.OutputTokens); err != nil {
+			log.Printf("  ⚠️  Warning: Failed to record token usage: %v", err)
+		}
+	}
+}
+
+// recordMetrics counts a finished job for the Prometheus endpoint
+func (wp *WorkerPool) recordMetrics(result *ProcessingResult, duration time.Duration) {
+	status := metrics.StatusCompleted
+	switch {
+	case errors.Is(result.Error, ErrInterrupted):
+		status = metrics.StatusInterrupted
+	case result.Error != nil:
+		status = metrics.StatusFailed
+	}
+	wp.metrics.PaperProcessed(status, duration)
+	wp.metrics.AddTokens(result.PromptTokens, result.OutputTokens)
+}
+
 // SubmitJob submits a job to the pool
 func (wp *WorkerPool) SubmitJob(job *ProcessingJob) {
-	wp.jobs <- job
+	wp.incoming <- job
 }
 
-// Close closes the job channel
+// Close signals that no more jobs will be submitted
 func (wp *WorkerPool) Close() {
-	close(wp.jobs)
+	close(wp.incoming)
 }
 
 // Wait waits for all workers to finish
@@ -268,46 +764,124 @@ func (wp *WorkerPool) Results() <-chan *ProcessingResult {
 	return wp.results
 }
 
+// BatchOptions controls how ProcessBatchWithOptions runs a batch
+type BatchOptions struct {
+	Force               bool // Reprocess even if already processed/cached
+	EnableRAG           bool // Index papers for chat
+	EnableGraphBuilding bool // Publish papers to the knowledge graph
+	NonInteractive      bool // Return immediately instead of waiting for 'q'
+
+	// Format selects the report format; empty means FormatLatex
+	Format OutputFormat
+
+	// Priorities maps file paths to job priorities; higher runs first.
+	// Files not in the map default to priority 0.
+	Priorities map[string]int
+
+	// Resume continues an interrupted batch: files it records as completed
+	// are skipped (even with Force) and it is updated instead of a new
+	// checkpoint being started
+	Resume *storage.BatchCheckpoint
+
+	// Progress, if set, receives updates as the batch runs and replaces the
+	// terminal progress bar, per-paper output, summary and background service
+	// notices, so a caller such as the TUI can render them itself. Implies
+	// NonInteractive. The channel is not closed.
+	Progress chan<- BatchProgress
+}
+
+// BatchProgress is sent on BatchOptions.Progress once the batch is queued,
+// whenever a worker starts a paper and whenever a paper finishes
+type BatchProgress struct {
+	Started string            // File a worker just picked up; counts are not set on these updates
+	Result  *ProcessingResult // Paper that just finished, if any
+
+	Total     int // Papers queued (already-processed ones are skipped)
+	Completed int // Papers finished so far, successfully or not
+	Succeeded int
+	Failed    int
+}
+
 // ProcessBatch processes a batch of PDF files
 func ProcessBatch(ctx context.Context, files []string, config *app.Config, force bool, enableRAG bool, enableGraphBuilding bool) error {
-	// Initialize Redis cache if enabled
-	var redisCache *cache.RedisCache
+	return ProcessBatchWithOptions(ctx, files, config, BatchOptions{
+		Force:               force,
+		EnableRAG:           enableRAG,
+		EnableGraphBuilding: enableGraphBuilding,
+	})
+}
+
+// ProcessBatchWithOptions processes a batch of PDF files using the given options
+func ProcessBatchWithOptions(ctx context.Context, files []string, config *app.Config, opts BatchOptions) error {
+	force := opts.Force
+	enableRAG := opts.EnableRAG
+	enableGraphBuilding := opts.EnableGraphBuilding
+	quiet := opts.Progress != nil
+	if quiet {
+		opts.NonInteractive = true
+	}
+
+	// Cancel in-flight work on Ctrl-C / SIGTERM
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Initialize analysis cache if enabled
+	var analysisCache cache.AnalysisCache
 	var err error
-	if config.Cache.Enabled && config.Cache.Type == "redis" {
-		log.Println("🔌 Initializing Redis cache...")
-		ttl := time.Duration(config.Cache.TTL) * time.Hour
-		redisCache, err = cache.NewRedisCache(
-			config.Cache.Redis.Addr,
-			config.Cache.Redis.Password,
-			config.Cache.Redis.DB,
-			ttl,
-		)
+	if config.Cache.Enabled {
+		analysisCache, err = NewAnalysisCache(config)
 		if err != nil {
-			log.Printf("⚠️  Warning: Failed to connect to Redis: %v", err)
+			log.Printf("⚠️  Warning: %v", err)
 			log.Println("   Continuing without cache...")
-			redisCache = nil
+			analysisCache = nil
 		} else {
-			defer redisCache.Close()
-			stats, _ := redisCache.GetStats(ctx)
-			log.Printf("✓ Cache ready (%d entries, TTL: %d hours)", stats, config.Cache.TTL)
+			defer analysisCache.Close()
+			stats, _ := analysisCache.GetStats(ctx)
+			log.Printf("✓ Cache ready (%s, %d entries, TTL: %d hours)", config.Cache.Type, stats, config.Cache.TTL)
 		}
 	}
 
+	// Open metadata store so runs show up in `list` and `status`
+	metadataStore, err := storage.Open(config.Metadata.Backend, storage.DefaultMetadataDir)
+	if err != nil {
+		log.Printf("⚠️  Warning: Failed to open metadata store: %v", err)
+		log.Println("   Continuing without processing history...")
+		metadataStore = nil
+	} else {
+		defer metadataStore.Close()
+	}
+
 	// Show graph integration status
 	if config.Graph.Enabled {
 		log.Println("📊 Knowledge graph integration enabled")
 		log.Println("   → Papers will be added to Neo4j graph via Kafka")
 	}
 
+	failureLog := storage.NewFailureLog(storage.DefaultMetadataDir)
+
 	// Queue files for processing
 	log.Println("🔍 Queuing files for processing...")
 	var jobsToProcess []*ProcessingJob
+	var invalid int
 	for _, file := range files {
-		// If not force mode and cache is enabled, check cache to skip already processed files
-		if !force && redisCache != nil {
-			hash, err := fileutil.ComputeFileHash(file)
-			if err == nil {
-				cached, _ := redisCache.Get(ctx, hash)
+		hash, err := fileutil.ComputeFileHash(file)
+		if err != nil {
+			hash = "" // Worker will retry hashing and report the error
+		}
+
+		if opts.Resume != nil && hash != "" && opts.Resume.IsCompleted(hash) {
+			log.Printf("  ⏭️  Skipping (completed before the batch was interrupted): %s", file)
+			continue
+		}
+
+		// If not force mode, skip files already completed or cached
+		if !force && hash != "" {
+			if metadataStore != nil && metadataStore.IsProcessed(hash) {
+				log.Printf("  ⏭️  Skipping (already processed): %s", file)
+				continue
+			}
+			if analysisCache != nil {
+				cached, _ := analysisCache.Get(ctx, analysisCacheKey(hash, opts.Format))
 				if cached != nil {
 					log.Printf("  ⏭️  Skipping (already in cache): %s", file)
 					continue
@@ -315,19 +889,46 @@ func ProcessBatch(ctx context.Context, files []string, config *app.Config, force
 			}
 		}
 
-		log.Printf("  ✅ Queued for processing: %s", file)
-		jobsToProcess = append(jobsToProcess, &ProcessingJob{
+		job := &ProcessingJob{
 			FilePath: file,
-			FileHash: "",
-		})
+			FileHash: hash,
+			Priority: opts.Priorities[file],
+		}
+
+		// Don't spend an API call on an empty, truncated or non-PDF file
+		if hash != "" {
+			if err := fileutil.ValidatePDF(file); err != nil {
+				log.Printf("  ❌ Not queued (%v): %s", err, file)
+				rejectInvalidPDF(metadataStore, failureLog, job, err)
+				invalid++
+				continue
+			}
+		}
+
+		log.Printf("  ✅ Queued for processing: %s", file)
+		jobsToProcess = append(jobsToProcess, job)
+	}
+
+	if invalid > 0 && !quiet {
+		ui.PrintWarning(fmt.Sprintf("%d file(s) are not valid PDFs and were marked failed (see 'rph list --failed')", invalid))
 	}
 
 	if len(jobsToProcess) == 0 {
 		log.Println("No files to process")
+		removeCheckpoint(opts.Resume)
 		return nil
 	}
 
+	// Make sure the services behind RAG and graph building are up before relying on them
+	enableRAG, enableGraphBuilding, err = confirmBackgroundServices(config, enableRAG, enableGraphBuilding, opts.NonInteractive, quiet)
+	if err != nil {
+		return err
+	}
+
 	log.Printf("Processing %d files with %d workers", len(jobsToProcess), config.Processing.MaxWorkers)
+	if config.Gemini.RateLimit > 0 {
+		log.Printf("⏳ API calls limited to %d per minute across all workers", config.Gemini.RateLimit)
+	}
 
 	if enableRAG {
 		log.Println("💬 RAG indexing enabled - papers will be ready for chat after processing")
@@ -337,27 +938,63 @@ func ProcessBatch(ctx context.Context, files []string, config *app.Config, force
 		log.Println("🕸️  Knowledge graph building enabled - papers will be added concurrently")
 	}
 
+	// Serve Prometheus metrics for the lifetime of the batch
+	var recorder *metrics.Recorder
+	if config.Metrics.Enabled {
+		recorder = metrics.NewRecorder()
+		server, err := metrics.Serve(config.Metrics.Port, recorder)
+		if err != nil {
+			log.Printf("⚠️  Metrics disabled: %v", err)
+			recorder = nil
+		} else {
+			log.Printf("📈 Serving metrics at http://localhost:%d/metrics", config.Metrics.Port)
+			defer func() {
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				server.Shutdown(shutdownCtx)
+			}()
+		}
+	}
+
+	checkpoint := startCheckpoint(opts.Resume, files, len(jobsToProcess))
+
 	// Create and start worker pool
-	pool := NewWorkerPool(config.Processing.MaxWorkers, config, redisCache, enableGraphBuilding)
+	pool := NewWorkerPool(config.Processing.MaxWorkers, config, analysisCache, enableGraphBuilding)
 	pool.SetEnableRAG(enableRAG) // Set RAG flag
+	pool.SetForce(force)
+	pool.SetMetadataStore(metadataStore)
+	pool.SetFailureLog(failureLog)
+	pool.SetProgress(opts.Progress)
+	pool.SetOutputFormat(opts.Format)
+	pool.SetMetrics(recorder)
 	pool.Start(ctx)
 
-	// Submit jobs
+	// Submit jobs, stopping early if the batch is interrupted
 	go func() {
+		defer pool.Close()
 		for _, job := range jobsToProcess {
-			pool.SubmitJob(job)
+			select {
+			case <-ctx.Done():
+				return
+			case pool.incoming <- job:
+			}
 		}
-		pool.Close()
 	}()
 
 	// Collect results
-	var successful, failed, skipped int
+	var successful, failed, skipped, interrupted, duplicates int
+	var tokenUsage []ui.PaperTokenUsage
 	totalFiles := len(files)
 	processedCount := 0
 	startTime := time.Now()
 
-	// Create progress bar with better description
-	bar := ui.CreateProgressBar(len(jobsToProcess), fmt.Sprintf("📚 Processing %d papers", len(jobsToProcess)))
+	// Create progress bar with better description, unless the caller renders progress
+	var bar *progressbar.ProgressBar
+	if quiet {
+		opts.Progress <- BatchProgress{Total: len(jobsToProcess)}
+	} else {
+		bar = ui.CreateProgressBar(len(jobsToProcess), fmt.Sprintf("📚 Processing %d papers", len(jobsToProcess)))
+	}
 
 	// Wait for workers to finish in background and close results channel
 	go func() {
@@ -369,35 +1006,111 @@ func ProcessBatch(ctx context.Context, files []string, config *app.Config, force
 		processedCount++
 
 		// Update progress bar description with current status
-		bar.Describe(fmt.Sprintf("📚 [%d/%d] Processing papers (✅ %d | ❌ %d)",
-			processedCount, len(jobsToProcess), successful, failed))
-		bar.Add(1)
+		if bar != nil {
+			bar.Describe(fmt.Sprintf("📚 [%d/%d] Processing papers (✅ %d | ❌ %d)",
+				processedCount, len(jobsToProcess), successful, failed))
+			bar.Add(1)
+		}
+
+		if result.PromptTokens > 0 || result.OutputTokens > 0 {
+			paper := result.PaperTitle
+			if paper == "" {
+				paper = filepath.Base(result.Job.FilePath)
+			}
+			tokenUsage = append(tokenUsage, ui.PaperTokenUsage{
+				Paper:        paper,
+				PromptTokens: result.PromptTokens,
+				OutputTokens: result.OutputTokens,
+			})
+		}
 
-		if result.Error != nil {
+		if errors.Is(result.Error, ErrInterrupted) {
+			interrupted++
+		} else if result.Error != nil && !errors.Is(result.Error, ErrDuplicate) {
 			failed++
+		} else {
+			if result.Error != nil {
+				duplicates++
+			} else {
+				successful++
+			}
+			if checkpoint != nil {
+				if err := checkpoint.MarkCompleted(result.Job.FileHash); err != nil {
+					log.Printf("⚠️  Warning: Failed to update batch checkpoint: %v", err)
+				}
+			}
+		}
+
+		if quiet {
+			opts.Progress <- BatchProgress{
+				Result:    result,
+				Total:     len(jobsToProcess),
+				Completed: processedCount,
+				Succeeded: successful,
+				Failed:    failed + interrupted,
+			}
+			continue
+		}
+
+		if errors.Is(result.Error, ErrInterrupted) {
+			fmt.Println() // New line after progress bar
+			ui.PrintWarning(fmt.Sprintf("[%d/%d] %s - interrupted", processedCount, len(jobsToProcess), result.Job.FilePath))
+		} else if errors.Is(result.Error, ErrDuplicate) {
+			fmt.Println() // New line after progress bar
+			ui.PrintWarning(fmt.Sprintf("[%d/%d] %s - skipped, %v", processedCount, len(jobsToProcess), result.Job.FilePath, result.Error))
+		} else if result.Error != nil {
 			fmt.Println() // New line after progress bar
 			ui.PrintError(fmt.Sprintf("[%d/%d] %s - %v", processedCount, len(jobsToProcess), result.Job.FilePath, result.Error))
 		} else {
-			successful++
 			fmt.Println() // New line after progress bar
 			ui.PrintSuccess(fmt.Sprintf("[%d/%d] %s -> %s (%.1fs)",
 				processedCount, len(jobsToProcess), result.PaperTitle, result.ReportFile, result.Duration.Seconds()))
 		}
 	}
 
-	// Finish the progress bar properly
-	bar.Finish()
-	fmt.Println() // Add extra newline for spacing
+	// Calculate skipped files; invalid PDFs count as failed, duplicates as skipped
+	failed += invalid
+	skipped = totalFiles - len(jobsToProcess) - invalid + duplicates
+
+	if !quiet {
+		// Finish the progress bar properly
+		bar.Finish()
+		fmt.Println() // Add extra newline for spacing
+
+		// Show summary
+		totalTime := time.Since(startTime)
+		var cacheStats *ui.CacheStats
+		if analysisCache != nil {
+			stats := pool.CacheStats()
+			cacheStats = &stats
+		}
+		ui.PrintSummary(successful, failed, skipped, totalTime, tokenUsage, cacheStats)
+	}
+
+	if ctx.Err() != nil {
+		notStarted := len(jobsToProcess) - processedCount
+		if !quiet {
+			fmt.Println()
+			ui.PrintWarning("Processing interrupted")
+			ui.PrintInfo(fmt.Sprintf("   Completed: %d | Interrupted: %d | Not started: %d", successful, interrupted, notStarted))
+			if checkpoint != nil {
+				ui.PrintInfo(fmt.Sprintf("   Resume with: rph process --resume %s", checkpoint.ID))
+			}
+		}
 
-	// Calculate skipped files
-	skipped = totalFiles - len(jobsToProcess)
+		if pool.kafkaProducer != nil {
+			if err := pool.kafkaProducer.Close(); err != nil {
+				log.Printf("⚠️  Warning: Failed to close Kafka producer: %v", err)
+			}
+		}
+		return fmt.Errorf("processing interrupted: %d completed, %d cancelled", successful, interrupted+notStarted)
+	}
 
-	// Show summary
-	totalTime := time.Since(startTime)
-	ui.PrintSummary(successful, failed, skipped, totalTime)
+	// Every file has had its turn; failures are retried with reprocess-failed
+	removeCheckpoint(checkpoint)
 
 	// Notify user that microservices are processing in background
-	if enableRAG || enableGraphBuilding {
+	if !quiet && (enableRAG || enableGraphBuilding) {
 		fmt.Println()
 		ui.PrintInfo("📡 Background services are processing:")
 		if enableRAG {
@@ -409,18 +1122,20 @@ func ProcessBatch(ctx context.Context, files []string, config *app.Config, force
 		fmt.Println()
 
 		// Start monitoring microservices in background
-		go monitorMicroservices(successful, enableRAG, enableGraphBuilding)
+		go monitorMicroservices(config.Microservices, successful, enableRAG, enableGraphBuilding)
 	}
 
 	// Wait for user input to continue
-	fmt.Println()
-	ui.PrintInfo("Press 'q' and Enter to return to homepage...")
-	reader := bufio.NewReader(os.Stdin)
-	for {
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(strings.ToLower(input))
-		if input == "q" {
-			break
+	if !opts.NonInteractive {
+		fmt.Println()
+		ui.PrintInfo("Press 'q' and Enter to return to homepage...")
+		reader := bufio.NewReader(os.Stdin)
+		for {
+			input, _ := reader.ReadString('\n')
+			input = strings.TrimSpace(strings.ToLower(input))
+			if input == "q" {
+				break
+			}
 		}
 	}
 
@@ -440,39 +1155,206 @@ func ProcessBatch(ctx context.Context, files []string, config *app.Config, force
 	return nil
 }
 
+// startCheckpoint returns the checkpoint tracking this batch: resume if it is
+// being resumed, otherwise a new one for files. Single-paper batches aren't
+// worth resuming and get none, as does a batch whose checkpoint can't be written.
+func startCheckpoint(resume *storage.BatchCheckpoint, files []string, queued int) *storage.BatchCheckpoint {
+	if resume != nil {
+		log.Printf("↩️  Resuming batch %s (%d of %d files completed)", resume.ID, len(resume.Completed), len(resume.Files))
+		return resume
+	}
+	if queued < 2 {
+		return nil
+	}
+
+	checkpoint := storage.NewBatchCheckpoint(storage.DefaultMetadataDir, files)
+	if err := checkpoint.Save(); err != nil {
+		log.Printf("⚠️  Warning: Failed to write batch checkpoint, this batch can't be resumed: %v", err)
+		return nil
+	}
+	log.Printf("📍 Batch %s checkpointed to %s", checkpoint.ID, checkpoint.Path())
+	return checkpoint
+}
+
+// removeCheckpoint deletes the checkpoint of a batch that has finished
+func removeCheckpoint(checkpoint *storage.BatchCheckpoint) {
+	if checkpoint == nil {
+		return
+	}
+	if err := checkpoint.Remove(); err != nil {
+		log.Printf("⚠️  Warning: %v", err)
+	}
+}
+
+// NewAnalysisCache builds the cache backend selected by cache.type
+func NewAnalysisCache(config *app.Config) (cache.AnalysisCache, error) {
+	ttl := time.Duration(config.Cache.TTL) * time.Hour
+
+	switch config.Cache.Type {
+	case "memory":
+		log.Println("🧠 Initializing in-memory cache...")
+		memoryCache, err := cache.NewMemoryCache(ttl, config.Cache.Memory.SnapshotFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize memory cache: %w", err)
+		}
+		return memoryCache, nil
+	default:
+		log.Println("🔌 Initializing Redis cache...")
+		redisCache, err := cache.NewRedisCache(
+			config.Cache.Redis.Addr,
+			config.Cache.Redis.Password,
+			config.Cache.Redis.DB,
+			ttl,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+		}
+		redisCache.SetRefreshOnHit(config.Cache.RefreshOnHit)
+		return redisCache, nil
+	}
+}
+
+// titleCommandRegex matches the opening of \title{...}, allowing a short
+// title in brackets before the argument
+var titleCommandRegex = regexp.MustCompile(`\\title\s*(?:\[[^\]]*\])?\s*\{`)
+
+// sectionCommandRegex matches the opening of the first (sub)section heading
+var sectionCommandRegex = regexp.MustCompile(`\\(?:section|subsection)\*?\s*\{`)
+
+// droppedTitleCommands are commands whose argument is not part of the title
+var droppedTitleCommands = map[string]bool{
+	"thanks":   true,
+	"footnote": true,
+	"label":    true,
+}
+
 // extractTitleFromLatex extracts the paper title from LaTeX content
 func extractTitleFromLatex(latexContent string) string {
-	// Look for \title{...} command
-	titleRegex := regexp.MustCompile(`\\title\{([^}]+)\}`)
-	matches := titleRegex.FindStringSubmatch(latexContent)
-	if len(matches) > 1 {
-		title := strings.TrimSpace(matches[1])
-		// Remove any LaTeX commands from the title
-		title = strings.ReplaceAll(title, "\\", "")
-		return title
-	}
-
-	// Fallback: look for the first section or subsection title
-	sectionRegex := regexp.MustCompile(`\\(?:section|subsection)\*?\{([^}]+)\}`)
-	matches = sectionRegex.FindStringSubmatch(latexContent)
-	if len(matches) > 1 {
-		title := strings.TrimSpace(matches[1])
-		// Remove any LaTeX commands from the title
-		title = strings.ReplaceAll(title, "\\", "")
-		return title
+	// Prefer \title{...}, falling back to the first section or subsection title
+	for _, commandRegex := range []*regexp.Regexp{titleCommandRegex, sectionCommandRegex} {
+		loc := commandRegex.FindStringIndex(latexContent)
+		if loc == nil {
+			continue
+		}
+		if argument, _, ok := bracedArgument(latexContent, loc[1]-1); ok {
+			return cleanLatexText(argument)
+		}
+	}
+
+	return ""
+}
+
+// extractTitleFromMarkdown returns the first level-1 heading of a Markdown
+// report, without emphasis markers
+func extractTitleFromMarkdown(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "# ") {
+			continue
+		}
+		title := strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		title = strings.NewReplacer("**", "", "__", "", "`", "").Replace(title)
+		return strings.TrimSpace(title)
 	}
 
 	return ""
 }
 
+// bracedArgument returns the text between the '{' at open and its matching
+// '}', along with the index of that closing brace. Escaped braces are skipped.
+func bracedArgument(content string, open int) (string, int, bool) {
+	if open >= len(content) || content[open] != '{' {
+		return "", 0, false
+	}
+
+	depth := 0
+	for i := open; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++ // skip the escaped character
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return content[open+1 : i], i, true
+			}
+		}
+	}
+
+	return "", 0, false
+}
+
+// cleanLatexText turns a LaTeX fragment into plain text: formatting commands
+// such as \textbf{...} keep only their argument, math delimiters and braces
+// are removed, and line breaks become spaces
+func cleanLatexText(text string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text) && isASCIILetter(text[i+1]):
+			start := i + 1
+			end := start
+			for end < len(text) && isASCIILetter(text[end]) {
+				end++
+			}
+			i = end - 1
+
+			if droppedTitleCommands[text[start:end]] {
+				next := end
+				for next < len(text) && text[next] == ' ' {
+					next++
+				}
+				if _, closing, ok := bracedArgument(text, next); ok {
+					i = closing
+				}
+			}
+
+		case c == '\\' && i+1 < len(text):
+			i++
+			switch text[i] {
+			case '&', '%', '$', '#', '_', '{', '}':
+				b.WriteByte(text[i])
+			case '\\':
+				// Line break, possibly followed by spacing such as \\[2pt]
+				if i+1 < len(text) && text[i+1] == '[' {
+					if closing := strings.IndexByte(text[i:], ']'); closing >= 0 {
+						i += closing
+					}
+				}
+				b.WriteByte(' ')
+			case ',', ' ', ';':
+				b.WriteByte(' ')
+			}
+			// Anything else is an accent or spacing command and is dropped
+
+		case c == '{' || c == '}' || c == '$' || c == '\\':
+			// Grouping and math delimiters carry no text
+
+		case c == '~' || c == '\n' || c == '\t':
+			b.WriteByte(' ')
+
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// isASCIILetter reports whether c can be part of a LaTeX command name
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
 // monitorMicroservices monitors the microservices and shows notifications when they complete
-func monitorMicroservices(expectedPapers int, checkRAG bool, checkGraph bool) {
-	const (
-		graphServiceURL = "http://localhost:8081/api/graph/queue-stats"
-		ragServiceURL   = "http://localhost:8082/status" // Python RAG service
-		pollInterval    = 3 * time.Second
-		maxWaitTime     = 5 * time.Minute
-	)
+func monitorMicroservices(services app.MicroservicesConfig, expectedPapers int, checkRAG bool, checkGraph bool) {
+	graphServiceURL := strings.TrimSuffix(services.GraphURL, "/") + "/api/graph/queue-stats"
+	ragHealthURL := strings.TrimSuffix(services.RAGURL, "/") + "/health"
+	pollInterval := time.Duration(services.PollInterval) * time.Second
+	maxWaitTime := time.Duration(services.MaxWait) * time.Second
 
 	startTime := time.Now()
 	ragCompleted := !checkRAG  // If not checking, mark as completed
@@ -508,11 +1390,11 @@ func monitorMicroservices(expectedPapers int, checkRAG bool, checkGraph bool) {
 			}
 		}
 
-		// Check RAG Service (if you have status endpoint)
+		// Check RAG Service
 		if checkRAG && !ragCompleted {
-			// For now, assume RAG completes quickly after graph
-			// You can add actual RAG service status check here
-			if graphCompleted {
+			// The RAG service has no progress endpoint, so assume it finishes
+			// quickly after the graph, or once it is up when no graph is built
+			if graphCompleted && (checkGraph || serviceHealthy(ragHealthURL)) {
 				ragCompleted = true
 				fmt.Println()
 				ui.PrintSuccess("✅ RAG indexing complete!")
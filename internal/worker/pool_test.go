@@ -0,0 +1,159 @@
+package worker
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/cache"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractTitleFromLatex(t *testing.T) {
+	tests := []struct {
+		name  string
+		latex string
+		want  string
+	}{
+		{
+			name:  "plain title",
+			latex: `\title{Attention Is All You Need}`,
+			want:  "Attention Is All You Need",
+		},
+		{
+			name:  "nested braces",
+			latex: `\title{Attention with \textbf{Flash} Kernels}`,
+			want:  "Attention with Flash Kernels",
+		},
+		{
+			name:  "deeply nested formatting",
+			latex: `\title{\emph{Scaling \texttt{{GPT}} Models}: A Study}`,
+			want:  "Scaling GPT Models: A Study",
+		},
+		{
+			name:  "inline math",
+			latex: `\title{$\mathcal{O}(n)$ Attention via $k$-Means}`,
+			want:  "O(n) Attention via k-Means",
+		},
+		{
+			name:  "multi-line title with line break",
+			latex: "\\title{Deep Residual Learning\\\\[2pt]\n  for Image Recognition}",
+			want:  "Deep Residual Learning for Image Recognition",
+		},
+		{
+			name:  "short title and thanks",
+			latex: `\title[BERT]{BERT: Pre-training\thanks{Work done at {Google}.} of Deep Transformers}`,
+			want:  "BERT: Pre-training of Deep Transformers",
+		},
+		{
+			name:  "escaped characters",
+			latex: `\title{Q\&A over 100\% of \{Tables\}}`,
+			want:  "Q&A over 100% of {Tables}",
+		},
+		{
+			name:  "falls back to first section",
+			latex: "\\begin{document}\n\\section*{Summary of \\emph{ResNet}}\n\\section{Methods}",
+			want:  "Summary of ResNet",
+		},
+		{
+			name:  "unbalanced title falls back to section",
+			latex: "\\title{Broken \\textbf{Title}\n\\section{Overview}",
+			want:  "Overview",
+		},
+		{
+			name:  "no title",
+			latex: `\begin{document}Hello\end{document}`,
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, extractTitleFromLatex(tt.latex))
+		})
+	}
+}
+
+func TestExtractTitleFromMarkdown(t *testing.T) {
+	assert.Equal(t, "Attention Is All You Need", extractTitleFromMarkdown("# Attention Is All You Need\n\n## Summary"))
+	assert.Equal(t, "ResNet: A Guide", extractTitleFromMarkdown("Intro text\n## Overview\n#  **ResNet**: A `Guide`\n"))
+	assert.Equal(t, "", extractTitleFromMarkdown("## Only a section\n#hashtag"))
+}
+
+func TestPublicationYear(t *testing.T) {
+	assert.Equal(t, 2017, publicationYear("2017"))
+	assert.Equal(t, 2019, publicationYear("June 2019 (NeurIPS)"))
+	assert.Equal(t, 0, publicationYear("Unknown"))
+	assert.Equal(t, 0, publicationYear(""))
+}
+
+func TestAnalysisCacheKeySeparatesFormats(t *testing.T) {
+	assert.Equal(t, "abc123", analysisCacheKey("abc123", FormatLatex))
+	assert.Equal(t, "abc123", analysisCacheKey("abc123", ""))
+	assert.Equal(t, "abc123:markdown", analysisCacheKey("abc123", FormatMarkdown))
+}
+
+// slowCompilerScript stands in for pdflatex: it hangs on tex files whose name
+// contains "Slow" and otherwise writes an empty PDF
+const slowCompilerScript = `#!/bin/sh
+case "$3" in
+  *Slow*) exec sleep 30 ;;
+esac
+: > "${3%.tex}.pdf"
+`
+
+func TestPerPaperTimeoutFailsOnlyTheSlowPaper(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	engine := filepath.Join(dir, "fakelatex")
+	require.NoError(t, os.WriteFile(engine, []byte(slowCompilerScript), 0755))
+
+	config := &app.Config{
+		TexOutputDir:    filepath.Join(dir, "tex"),
+		ReportOutputDir: filepath.Join(dir, "reports"),
+		Processing:      app.ProcessingConfig{MaxWorkers: 1, TimeoutPerPaper: 1},
+		LLM: app.LLMConfig{
+			Provider: app.ProviderOpenAI,
+			OpenAI:   app.OpenAIConfig{APIKey: "test", Model: "test"},
+		},
+		Latex: app.LatexConfig{Compiler: engine, Engine: "pdflatex"},
+	}
+
+	// Cached analyses let the jobs skip the LLM and go straight to compiling
+	analysisCache, err := cache.NewMemoryCache(time.Hour, "")
+	require.NoError(t, err)
+	for hash, title := range map[string]string{"slow": "Slow Paper", "fast": "Fast Paper"} {
+		require.NoError(t, analysisCache.Set(ctx, hash, &cache.CachedAnalysis{
+			PaperTitle:   title,
+			LatexContent: `\section{Intro}`,
+		}))
+	}
+
+	wp := NewWorkerPool(1, config, analysisCache, false)
+	wp.Start(ctx)
+	wp.SubmitJob(&ProcessingJob{FilePath: "slow.pdf", FileHash: "slow", Priority: 1})
+	wp.SubmitJob(&ProcessingJob{FilePath: "fast.pdf", FileHash: "fast"})
+	wp.Close()
+
+	start := time.Now()
+	go wp.Wait()
+
+	results := make(map[string]*ProcessingResult)
+	for result := range wp.Results() {
+		results[result.Job.FileHash] = result
+	}
+
+	assert.Less(t, time.Since(start), 15*time.Second, "the slow compile should be killed at the deadline")
+	require.Len(t, results, 2)
+
+	assert.ErrorIs(t, results["slow"].Error, ErrPaperTimeout)
+	assert.ErrorContains(t, results["slow"].Error, "exceeded per-paper timeout")
+
+	require.NoError(t, results["fast"].Error)
+	assert.FileExists(t, results["fast"].ReportFile)
+}
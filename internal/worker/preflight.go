@@ -0,0 +1,154 @@
+package worker
+
+import (
+	"archivist/internal/app"
+	"archivist/internal/ui"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// preflightTimeout bounds each connectivity probe
+const preflightTimeout = 2 * time.Second
+
+// serviceProblem describes a background service that failed its pre-flight check
+type serviceProblem struct {
+	name string
+	err  error
+}
+
+// checkBackgroundServices probes the services behind RAG indexing and graph
+// building and returns the features whose services are unreachable
+func checkBackgroundServices(config *app.Config, enableRAG, enableGraphBuilding bool) (ragProblems, graphProblems []serviceProblem) {
+	if enableGraphBuilding && config.Graph.Enabled {
+		if config.Graph.Kafka.Enabled {
+			if err := checkKafkaBrokers(kafkaBrokers(config)); err != nil {
+				graphProblems = append(graphProblems, serviceProblem{"Kafka", err})
+			}
+		}
+		graphHealthURL := strings.TrimSuffix(config.Microservices.GraphURL, "/") + "/health"
+		if err := checkServiceHealth(graphHealthURL); err != nil {
+			graphProblems = append(graphProblems, serviceProblem{"Graph service", err})
+		}
+	}
+
+	if enableRAG {
+		ragHealthURL := strings.TrimSuffix(config.Microservices.RAGURL, "/") + "/health"
+		if err := checkServiceHealth(ragHealthURL); err != nil {
+			ragProblems = append(ragProblems, serviceProblem{"RAG service", err})
+		}
+	}
+
+	return ragProblems, graphProblems
+}
+
+// confirmBackgroundServices runs the pre-flight checks and, if a service is
+// down, asks whether to continue without the affected features. It returns
+// the features to keep enabled, or an error if the user declines. With quiet
+// set, warnings go to the log instead of the terminal.
+func confirmBackgroundServices(config *app.Config, enableRAG, enableGraphBuilding, nonInteractive, quiet bool) (bool, bool, error) {
+	if !enableRAG && !enableGraphBuilding {
+		return false, false, nil
+	}
+
+	ragProblems, graphProblems := checkBackgroundServices(config, enableRAG, enableGraphBuilding)
+	if len(ragProblems) == 0 && len(graphProblems) == 0 {
+		return enableRAG, enableGraphBuilding, nil
+	}
+
+	printWarning, printInfo := ui.PrintWarning, ui.PrintInfo
+	if quiet {
+		printWarning = func(msg string) { log.Printf("⚠️  %s", msg) }
+		printInfo = func(msg string) { log.Println(msg) }
+	} else {
+		fmt.Println()
+	}
+
+	for _, problem := range append(ragProblems, graphProblems...) {
+		printWarning(fmt.Sprintf("%s unavailable: %v", problem.name, problem.err))
+	}
+
+	var disabled []string
+	if len(ragProblems) > 0 {
+		disabled = append(disabled, "RAG indexing")
+	}
+	if len(graphProblems) > 0 {
+		disabled = append(disabled, "knowledge graph building")
+	}
+	features := strings.Join(disabled, " and ")
+
+	if !nonInteractive {
+		fmt.Printf("Continue without %s? [y/N]: ", features)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			return false, false, fmt.Errorf("aborted: %s services are unavailable", features)
+		}
+	}
+
+	printInfo(fmt.Sprintf("Continuing without %s", features))
+	return enableRAG && len(ragProblems) == 0, enableGraphBuilding && len(graphProblems) == 0, nil
+}
+
+// kafkaBrokers returns the configured Kafka brokers, falling back to the default
+func kafkaBrokers(config *app.Config) []string {
+	if len(config.Graph.Kafka.Brokers) == 0 {
+		return []string{app.DefaultKafkaBroker}
+	}
+	return config.Graph.Kafka.Brokers
+}
+
+// checkKafkaBrokers succeeds if at least one broker accepts a TCP connection
+func checkKafkaBrokers(brokers []string) error {
+	var lastErr error
+	for _, broker := range brokers {
+		conn, err := net.DialTimeout("tcp", broker, preflightTimeout)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("no broker reachable (%s): %w", strings.Join(brokers, ", "), lastErr)
+}
+
+// checkServiceHealth requires url to answer 200 OK and, if it reports a
+// status field, for that status to be "healthy"
+func checkServiceHealth(url string) error {
+	client := &http.Client{Timeout: preflightTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", url, err)
+	}
+
+	var health struct {
+		Status string `json:"status"`
+	}
+	if json.Unmarshal(body, &health) == nil && health.Status != "" && health.Status != "healthy" {
+		return fmt.Errorf("service reports status %q", health.Status)
+	}
+
+	return nil
+}
+
+// serviceHealthy reports whether url passes checkServiceHealth
+func serviceHealthy(url string) bool {
+	return checkServiceHealth(url) == nil
+}
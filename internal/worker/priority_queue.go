@@ -0,0 +1,62 @@
+package worker
+
+import "container/heap"
+
+// queuedJob wraps a job with its submission order so equal priorities stay FIFO
+type queuedJob struct {
+	job *ProcessingJob
+	seq uint64
+}
+
+// jobHeap is a max-heap of jobs ordered by Priority, then submission order
+type jobHeap []queuedJob
+
+func (h jobHeap) Len() int { return len(h) }
+
+func (h jobHeap) Less(i, j int) bool {
+	if h[i].job.Priority != h[j].job.Priority {
+		return h[i].job.Priority > h[j].job.Priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h jobHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *jobHeap) Push(x any) { *h = append(*h, x.(queuedJob)) }
+
+func (h *jobHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = queuedJob{}
+	*h = old[:n-1]
+	return item
+}
+
+// jobQueue is a priority queue of pending jobs. It is not safe for concurrent use;
+// the pool's dispatcher goroutine owns it.
+type jobQueue struct {
+	items jobHeap
+	seq   uint64
+}
+
+// push adds a job to the queue
+func (q *jobQueue) push(job *ProcessingJob) {
+	heap.Push(&q.items, queuedJob{job: job, seq: q.seq})
+	q.seq++
+}
+
+// peek returns the highest-priority job without removing it
+func (q *jobQueue) peek() *ProcessingJob {
+	return q.items[0].job
+}
+
+// pop removes and returns the highest-priority job
+func (q *jobQueue) pop() *ProcessingJob {
+	return heap.Pop(&q.items).(queuedJob).job
+}
+
+// len returns the number of queued jobs
+func (q *jobQueue) len() int {
+	return q.items.Len()
+}
@@ -0,0 +1,37 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobQueue_PriorityOrder(t *testing.T) {
+	var q jobQueue
+	q.push(&ProcessingJob{FilePath: "low.pdf", Priority: 0})
+	q.push(&ProcessingJob{FilePath: "high.pdf", Priority: 10})
+	q.push(&ProcessingJob{FilePath: "mid-a.pdf", Priority: 5})
+	q.push(&ProcessingJob{FilePath: "mid-b.pdf", Priority: 5})
+	q.push(&ProcessingJob{FilePath: "negative.pdf", Priority: -1})
+
+	var order []string
+	for q.len() > 0 {
+		next := q.peek()
+		job := q.pop()
+		assert.Same(t, next, job)
+		order = append(order, job.FilePath)
+	}
+
+	assert.Equal(t, []string{"high.pdf", "mid-a.pdf", "mid-b.pdf", "low.pdf", "negative.pdf"}, order)
+}
+
+func TestJobQueue_DefaultPriorityIsFIFO(t *testing.T) {
+	var q jobQueue
+	for _, name := range []string{"a.pdf", "b.pdf", "c.pdf"} {
+		q.push(&ProcessingJob{FilePath: name})
+	}
+
+	assert.Equal(t, "a.pdf", q.pop().FilePath)
+	assert.Equal(t, "b.pdf", q.pop().FilePath)
+	assert.Equal(t, "c.pdf", q.pop().FilePath)
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
@@ -77,6 +78,37 @@ func GetPDFFiles(dir string) ([]string, error) {
 	return pdfFiles, nil
 }
 
+// FilterByName keeps the files whose base name matches pattern, either as a
+// glob (filepath.Match syntax) or, if useRegex is set, as a regular expression
+func FilterByName(files []string, pattern string, useRegex bool) ([]string, error) {
+	var match func(name string) (bool, error)
+	if useRegex {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex %q: %w", pattern, err)
+		}
+		match = func(name string) (bool, error) { return re.MatchString(name), nil }
+	} else {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+		match = func(name string) (bool, error) { return filepath.Match(pattern, name) }
+	}
+
+	var matched []string
+	for _, file := range files {
+		ok, err := match(filepath.Base(file))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, file)
+		}
+	}
+
+	return matched, nil
+}
+
 // FileExists checks if a file exists
 func FileExists(path string) bool {
 	_, err := os.Stat(path)
@@ -171,6 +171,33 @@ func TestGetPDFFiles_NonExistentDirectory(t *testing.T) {
 	assert.Error(t, err, "Should return error for non-existent directory")
 }
 
+// Filter discovered files by base name
+func TestFilterByName(t *testing.T) {
+	files := []string{
+		"lib/iclr/2023_iclr_diffusion.pdf",
+		"lib/2023_neurips_agents.pdf",
+		"lib/2022_iclr_vit.pdf",
+	}
+
+	matched, err := FilterByName(files, "2023_iclr_*", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lib/iclr/2023_iclr_diffusion.pdf"}, matched, "Glob should match the base name only")
+
+	matched, err = FilterByName(files, `^\d{4}_iclr_`, true)
+	require.NoError(t, err)
+	assert.Len(t, matched, 2)
+
+	matched, err = FilterByName(files, "*.docx", false)
+	require.NoError(t, err)
+	assert.Empty(t, matched)
+
+	_, err = FilterByName(files, "[", false)
+	assert.Error(t, err, "Should reject malformed glob")
+
+	_, err = FilterByName(files, "(", true)
+	assert.Error(t, err, "Should reject malformed regex")
+}
+
 // Benchmark hash computation
 func BenchmarkComputeFileHash(b *testing.B) {
 	tmpDir := b.TempDir()
@@ -0,0 +1,35 @@
+package fileutil
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// OpenFile opens path with the platform's default application: xdg-open on
+// Linux and the BSDs, open on macOS and the URL handler of rundll32 on
+// Windows. It returns once the viewer has been launched.
+func OpenFile(path string) error {
+	name, args := openCommand(runtime.GOOS, path)
+
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open %s with %s: %w", path, name, err)
+	}
+	// Reap the launcher so it doesn't linger as a zombie
+	go cmd.Wait()
+	return nil
+}
+
+// openCommand returns the command that opens path on goos
+func openCommand(goos, path string) (string, []string) {
+	switch goos {
+	case "darwin":
+		return "open", []string{path}
+	case "windows":
+		// Unlike "cmd /c start", rundll32 needs no quoting for paths with spaces
+		return "rundll32", []string{"url.dll,FileProtocolHandler", path}
+	default:
+		return "xdg-open", []string{path}
+	}
+}
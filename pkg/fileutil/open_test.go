@@ -0,0 +1,28 @@
+package fileutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenCommand(t *testing.T) {
+	tests := []struct {
+		goos string
+		name string
+		args []string
+	}{
+		{"linux", "xdg-open", []string{"reports/my paper.pdf"}},
+		{"freebsd", "xdg-open", []string{"reports/my paper.pdf"}},
+		{"darwin", "open", []string{"reports/my paper.pdf"}},
+		{"windows", "rundll32", []string{"url.dll,FileProtocolHandler", "reports/my paper.pdf"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			name, args := openCommand(tt.goos, "reports/my paper.pdf")
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
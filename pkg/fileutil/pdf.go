@@ -0,0 +1,70 @@
+package fileutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// pdfTrailerWindow is how far from the end of the file to look for %%EOF
+const pdfTrailerWindow = 1024
+
+// ErrInvalidPDF is wrapped by ValidatePDF errors that describe what is wrong
+// with the file itself, as opposed to errors reading it
+var ErrInvalidPDF = errors.New("invalid PDF")
+
+// ValidatePDF checks that a file starts with the PDF header and ends with an
+// %%EOF marker. It catches failed or truncated downloads (empty files, HTML
+// error pages) before they are sent to the model.
+func ValidatePDF(filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.Size() == 0 {
+		return fmt.Errorf("%w: file is empty", ErrInvalidPDF)
+	}
+
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(f, header); err != nil {
+		return fmt.Errorf("%w: file is only %d bytes", ErrInvalidPDF, info.Size())
+	}
+	if !bytes.Equal(header, []byte("%PDF-")) {
+		if bytes.HasPrefix(bytes.TrimSpace(header), []byte("<")) {
+			return fmt.Errorf("%w: file is HTML/XML, not a PDF (failed download?)", ErrInvalidPDF)
+		}
+		return fmt.Errorf("%w: missing %%PDF- header", ErrInvalidPDF)
+	}
+
+	offset := info.Size() - pdfTrailerWindow
+	if offset < 0 {
+		offset = 0
+	}
+	trailer := make([]byte, info.Size()-offset)
+	if _, err := f.ReadAt(trailer, offset); err != nil && err != io.EOF {
+		return fmt.Errorf("failed to read file trailer: %w", err)
+	}
+	if !bytes.Contains(trailer, []byte("%%EOF")) {
+		return fmt.Errorf("%w: no %%%%EOF marker, file is truncated", ErrInvalidPDF)
+	}
+
+	return nil
+}
+
+// IsCompletePDF reports whether a file passes ValidatePDF, i.e. that it isn't
+// still being downloaded or written. Only read failures are returned as errors.
+func IsCompletePDF(filePath string) (bool, error) {
+	err := ValidatePDF(filePath)
+	if errors.Is(err, ErrInvalidPDF) {
+		return false, nil
+	}
+	return err == nil, err
+}
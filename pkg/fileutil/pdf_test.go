@@ -0,0 +1,71 @@
+package fileutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCompletePDF(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+	}{
+		{"complete", "%PDF-1.7\n1 0 obj\n<<>>\nendobj\ntrailer\n%%EOF\n", true},
+		{"truncated download", "%PDF-1.7\n1 0 obj\n<<>>\n", false},
+		{"not a pdf", "<html>not a pdf</html>%%EOF", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "paper.pdf")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			complete, err := IsCompletePDF(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, complete)
+		})
+	}
+}
+
+func TestValidatePDF(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"complete", "%PDF-1.7\n1 0 obj\n<<>>\nendobj\ntrailer\n%%EOF\n", ""},
+		{"empty", "", "file is empty"},
+		{"too short", "%PD", "only 3 bytes"},
+		{"html error page", "<!DOCTYPE html><html>404 Not Found</html>", "file is HTML/XML"},
+		{"not a pdf", "PK\x03\x04zipdata", "missing %PDF- header"},
+		{"truncated download", "%PDF-1.7\n1 0 obj\n<<>>\n", "no %%EOF marker"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "paper.pdf")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			err := ValidatePDF(path)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidPDF)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidatePDF_MissingFile(t *testing.T) {
+	err := ValidatePDF(filepath.Join(t.TempDir(), "missing.pdf"))
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidPDF)
+}
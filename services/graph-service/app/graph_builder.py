@@ -5,6 +5,7 @@ Graph Builder - Neo4j operations for knowledge graph construction
 import logging
 from typing import List, Dict, Any, Optional
 from neo4j import AsyncGraphDatabase, AsyncDriver
+from neo4j.exceptions import ConstraintError
 from dataclasses import dataclass
 
 logger = logging.getLogger(__name__)
@@ -68,6 +69,7 @@ class GraphBuilder:
         queries = [
             # Constraints (ensure uniqueness)
             "CREATE CONSTRAINT paper_title_unique IF NOT EXISTS FOR (p:Paper) REQUIRE p.title IS UNIQUE",
+            "CREATE CONSTRAINT paper_doi_unique IF NOT EXISTS FOR (p:Paper) REQUIRE p.doi IS UNIQUE",
             "CREATE CONSTRAINT author_name_unique IF NOT EXISTS FOR (a:Author) REQUIRE a.name IS UNIQUE",
             "CREATE CONSTRAINT institution_name_unique IF NOT EXISTS FOR (i:Institution) REQUIRE i.name IS UNIQUE",
             "CREATE CONSTRAINT method_name_unique IF NOT EXISTS FOR (m:Method) REQUIRE m.name IS UNIQUE",
@@ -89,11 +91,17 @@ class GraphBuilder:
 
         logger.info("✅ Neo4j schema initialized")
 
-    async def add_paper_node(self, title: str, pdf_path: str, metadata: PaperMetadata):
-        """Add a paper node to the graph"""
+    async def add_paper_node(self, title: str, pdf_path: str, metadata: PaperMetadata,
+                             content_hash: Optional[str] = None, doi: str = "", arxiv_id: str = ""):
+        """Add a paper node to the graph, recording content_hash, doi and arxiv_id if given.
+
+        A DOI already held by another paper (paper_doi_unique) is left off this
+        one with a warning rather than failing the write.
+        """
         query = """
         MERGE (p:Paper {title: $title})
         SET p.pdf_path = $pdf_path,
+            p.content_hash = coalesce($content_hash, p.content_hash),
             p.year = $year,
             p.abstract = $abstract,
             p.authors = $authors,
@@ -101,22 +109,46 @@ class GraphBuilder:
             p.datasets = $datasets,
             p.metrics = $metrics,
             p.processed_at = datetime()
-        RETURN p.title
+        REMOVE p.stub
+        WITH p
+        OPTIONAL MATCH (owner:Paper {doi: $doi}) WHERE owner <> p
+        WITH p, head(collect(owner.title)) AS doi_owner
+        SET p.doi = CASE WHEN $doi = '' OR doi_owner IS NOT NULL THEN p.doi ELSE $doi END,
+            p.arxiv_id = CASE WHEN $arxiv_id = '' THEN p.arxiv_id ELSE $arxiv_id END
+        RETURN p.title, doi_owner
         """
 
-        async with self.driver.session(database=self.database) as session:
-            result = await session.run(query, {
-                "title": title,
-                "pdf_path": pdf_path,
-                "year": metadata.year,
-                "abstract": metadata.abstract[:500],  # Truncate long abstracts
-                "authors": metadata.authors,
-                "methods": metadata.methods,
-                "datasets": metadata.datasets,
-                "metrics": metadata.metrics
-            })
-            await result.consume()
+        params = {
+            "title": title,
+            "pdf_path": pdf_path,
+            "content_hash": content_hash or None,
+            "year": metadata.year,
+            "abstract": metadata.abstract[:500],  # Truncate long abstracts
+            "authors": metadata.authors,
+            "methods": metadata.methods,
+            "datasets": metadata.datasets,
+            "metrics": metadata.metrics,
+            "doi": doi or "",
+            "arxiv_id": arxiv_id or ""
+        }
 
+        async with self.driver.session(database=self.database) as session:
+            try:
+                result = await session.run(query, params)
+                record = await result.single()
+                doi_owner = record["doi_owner"] if record else None
+            except ConstraintError:
+                if not params["doi"]:
+                    raise
+                # Another paper claimed the DOI between the check and the write
+                params["doi"] = ""
+                result = await session.run(query, params)
+                await result.consume()
+                doi_owner = "another paper"
+
+        if doi_owner:
+            logger.warning(f"  ⚠️  DOI {doi} of '{title}' already belongs to '{doi_owner}'; "
+                           f"stored the paper without it")
         logger.info(f"  ✅ Added paper node: {title}")
 
     async def add_authors(self, paper_title: str, authors: List[str], affiliations: List[str]):
@@ -285,14 +317,32 @@ class GraphBuilder:
         logger.info(f"  ✅ Added {added} citations")
 
     async def paper_exists(self, title: str) -> bool:
-        """Check if a paper exists in the graph"""
-        query = "MATCH (p:Paper {title: $title}) RETURN count(p) as count"
+        """Check if a paper exists in the graph (stubs for cited papers don't count)"""
+        query = "MATCH (p:Paper {title: $title}) WHERE coalesce(p.stub, false) = false RETURN count(p) as count"
 
         async with self.driver.session(database=self.database) as session:
             result = await session.run(query, {"title": title})
             record = await result.single()
             return record["count"] > 0 if record else False
 
+    async def needs_ingestion(self, title: str, content_hash: Optional[str] = None,
+                              force: bool = False) -> bool:
+        """
+        Check whether a paper has to be (re)written to the graph: it isn't there
+        yet, force is set, or content_hash differs from the stored hash
+        """
+        if force or not await self.paper_exists(title):
+            return True
+        if not content_hash:
+            return False  # Nothing to compare against
+
+        query = "MATCH (p:Paper {title: $title}) RETURN p.content_hash as content_hash"
+
+        async with self.driver.session(database=self.database) as session:
+            result = await session.run(query, {"title": title})
+            record = await result.single()
+            return not record or record["content_hash"] != content_hash
+
     async def get_stats(self) -> Dict[str, int]:
         """Get graph statistics"""
         queries = {
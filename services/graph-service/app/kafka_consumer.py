@@ -89,7 +89,14 @@ class GraphKafkaConsumer:
                         latex_content=paper_data['latex_content'],
                         pdf_path=paper_data['pdf_path'],
                         processed_at=paper_data.get('processed_at'),
-                        priority=paper_data.get('priority', 0)
+                        priority=paper_data.get('priority', 0),
+                        content_hash=paper_data.get('content_hash'),
+                        force=paper_data.get('force', False),
+                        authors=paper_data.get('authors'),
+                        year=paper_data.get('year', 0),
+                        abstract=paper_data.get('abstract', ''),
+                        doi=paper_data.get('doi', ''),
+                        arxiv_id=paper_data.get('arxiv_id', '')
                     )
 
                     logger.info(f"✅ Queued for graph building: {paper_data['paper_title']}")
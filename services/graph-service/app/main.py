@@ -60,6 +60,11 @@ class PaperRequest(BaseModel):
     pdf_path: str
     processed_at: Optional[str] = None
     priority: int = 0
+    content_hash: Optional[str] = None  # Skip the paper if it is already in the graph with this hash
+    force: bool = False  # Re-ingest even if unchanged
+    authors: Optional[List[str]] = None  # Parsed from the PDF; preferred over the report's
+    year: int = 0
+    abstract: str = ""
 
 class PaperResponse(BaseModel):
     """Response after submitting paper"""
@@ -242,7 +247,12 @@ async def add_paper(request: PaperRequest):
             latex_content=request.latex_content,
             pdf_path=request.pdf_path,
             processed_at=request.processed_at,
-            priority=request.priority
+            priority=request.priority,
+            content_hash=request.content_hash,
+            force=request.force,
+            authors=request.authors,
+            year=request.year,
+            abstract=request.abstract
         )
 
         queue_position = worker_queue.queue_size()
@@ -275,7 +285,12 @@ async def batch_process(request: BatchProcessRequest):
                 latex_content=paper.latex_content,
                 pdf_path=paper.pdf_path,
                 processed_at=paper.processed_at,
-                priority=paper.priority
+                priority=paper.priority,
+                content_hash=paper.content_hash,
+                force=paper.force,
+                authors=paper.authors,
+                year=paper.year,
+                abstract=paper.abstract
             )
             job_ids.append(job_id)
         except Exception as e:
@@ -43,11 +43,21 @@ class MetadataExtractor:
 
         logger.info(f"✅ Metadata extractor initialized with model: {model}")
 
-    async def extract_metadata(self, latex_content: str, paper_title: str) -> PaperMetadata:
-        """Extract metadata from LaTeX content"""
+    async def extract_metadata(self, latex_content: str, paper_title: str,
+                               authors: Optional[List[str]] = None, year: int = 0,
+                               abstract: str = "") -> PaperMetadata:
+        """Extract metadata from LaTeX content
+
+        authors, year and abstract are what the processor parsed from the source
+        PDF; when given they take precedence over what is found in the report.
+        """
 
         # Try simple extraction first (faster)
         metadata = self._simple_extraction(latex_content, paper_title)
+        if authors:
+            metadata.authors = authors
+        if year:
+            metadata.year = year
 
         # If simple extraction fails or is incomplete, use LLM
         if not metadata.authors or not metadata.year:
@@ -57,6 +67,14 @@ class MetadataExtractor:
             # Merge results (prefer LLM results)
             metadata = self._merge_metadata(metadata, llm_metadata)
 
+        # The PDF is the better source for the paper's own metadata
+        if authors:
+            metadata.authors = authors
+        if year:
+            metadata.year = year
+        if abstract:
+            metadata.abstract = abstract[:500]
+
         logger.info(f"  ✅ Extracted metadata: {len(metadata.authors)} authors, {len(metadata.methods)} methods")
 
         return metadata
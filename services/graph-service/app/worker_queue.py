@@ -5,7 +5,7 @@ Worker Queue - Manages concurrent background processing of graph building jobs
 import asyncio
 import uuid
 import logging
-from typing import Optional, Dict, Any
+from typing import Optional, Dict, Any, List
 from datetime import datetime
 from dataclasses import dataclass, asdict
 from enum import Enum
@@ -35,6 +35,15 @@ class GraphJob:
     completed_at: Optional[str] = None
     error: Optional[str] = None
     progress: float = 0.0
+    content_hash: Optional[str] = None
+    force: bool = False
+    skipped: bool = False  # Already in the graph and unchanged
+    # Parsed from the source PDF by the processor, if it did
+    authors: Optional[List[str]] = None
+    year: int = 0
+    abstract: str = ""
+    doi: str = ""
+    arxiv_id: str = ""
 
 
 class WorkerQueue:
@@ -119,11 +128,20 @@ class WorkerQueue:
     async def _process_job(self, job: GraphJob, worker_id: int):
         """Process a single graph building job"""
 
+        # Skip papers already in the graph unless they changed or force is set
+        if not await self.graph_builder.needs_ingestion(job.paper_title, job.content_hash, job.force):
+            logger.info(f"[Worker {worker_id}]   ⏭️  Already in graph and unchanged, skipping: {job.paper_title}")
+            job.skipped = True
+            return
+
         # Step 1: Extract metadata (20% progress)
         logger.info(f"[Worker {worker_id}]   📊 Extracting metadata...")
         metadata = await self.metadata_extractor.extract_metadata(
             job.latex_content,
-            job.paper_title
+            job.paper_title,
+            authors=job.authors,
+            year=job.year,
+            abstract=job.abstract
         )
         job.progress = 20.0
 
@@ -132,7 +150,10 @@ class WorkerQueue:
         await self.graph_builder.add_paper_node(
             title=job.paper_title,
             pdf_path=job.pdf_path,
-            metadata=metadata
+            metadata=metadata,
+            content_hash=job.content_hash,
+            doi=job.doi,
+            arxiv_id=job.arxiv_id
         )
         job.progress = 40.0
 
@@ -175,7 +196,14 @@ class WorkerQueue:
         latex_content: str,
         pdf_path: str,
         processed_at: Optional[str] = None,
-        priority: int = 0
+        priority: int = 0,
+        content_hash: Optional[str] = None,
+        force: bool = False,
+        authors: Optional[List[str]] = None,
+        year: int = 0,
+        abstract: str = "",
+        doi: str = "",
+        arxiv_id: str = ""
     ) -> str:
         """Submit a job to the queue (higher priority = processed first)"""
 
@@ -189,7 +217,14 @@ class WorkerQueue:
             processed_at=processed_at or datetime.now().isoformat(),
             priority=priority,
             status=JobStatus.PENDING,
-            created_at=datetime.now().isoformat()
+            created_at=datetime.now().isoformat(),
+            content_hash=content_hash,
+            force=force,
+            authors=authors,
+            year=year,
+            abstract=abstract,
+            doi=doi,
+            arxiv_id=arxiv_id
         )
 
         # Store job